@@ -0,0 +1,3888 @@
+// Package account provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for AccountRole.
+const (
+	Admin     AccountRole = "admin"
+	Moderator AccountRole = "moderator"
+	User      AccountRole = "user"
+)
+
+// Defines values for AccountStatus.
+const (
+	Active      AccountStatus = "active"
+	Deactivated AccountStatus = "deactivated"
+)
+
+// Defines values for CommentPermission.
+const (
+	Everyone  CommentPermission = "everyone"
+	Followers CommentPermission = "followers"
+	Nobody    CommentPermission = "nobody"
+)
+
+// Defines values for CreateAPIKeyRequestScopes.
+const (
+	Read  CreateAPIKeyRequestScopes = "read"
+	Write CreateAPIKeyRequestScopes = "write"
+)
+
+// Defines values for SensitiveContentPreference.
+const (
+	Blur SensitiveContentPreference = "blur"
+	Hide SensitiveContentPreference = "hide"
+	Show SensitiveContentPreference = "show"
+)
+
+// Defines values for StandardResponseCode.
+const (
+	BADREQUEST          StandardResponseCode = "BAD_REQUEST"
+	CONFLICT            StandardResponseCode = "CONFLICT"
+	FAILED              StandardResponseCode = "FAILED"
+	FORBIDDEN           StandardResponseCode = "FORBIDDEN"
+	INTERNALSERVERERROR StandardResponseCode = "INTERNAL_SERVER_ERROR"
+	NOTFOUND            StandardResponseCode = "NOT_FOUND"
+	SUCCESS             StandardResponseCode = "SUCCESS"
+	UNAUTHORIZED        StandardResponseCode = "UNAUTHORIZED"
+)
+
+// Defines values for GetApiAuthProviderCallbackParamsProvider.
+const (
+	GetApiAuthProviderCallbackParamsProviderGithub GetApiAuthProviderCallbackParamsProvider = "github"
+	GetApiAuthProviderCallbackParamsProviderGoogle GetApiAuthProviderCallbackParamsProvider = "google"
+)
+
+// Defines values for GetApiAuthProviderLoginParamsProvider.
+const (
+	GetApiAuthProviderLoginParamsProviderGithub GetApiAuthProviderLoginParamsProvider = "github"
+	GetApiAuthProviderLoginParamsProviderGoogle GetApiAuthProviderLoginParamsProvider = "google"
+)
+
+// Account defines model for Account.
+type Account struct {
+	AvatarUrl  *string              `json:"avatar_url,omitempty"`
+	CreatedAt  *time.Time           `json:"created_at,omitempty"`
+	DeletedAt  *time.Time           `json:"deleted_at"`
+	Email      *openapi_types.Email `json:"email,omitempty"`
+	Id         *int64               `json:"id,omitempty"`
+	IsVerified *bool                `json:"is_verified,omitempty"`
+
+	// LastLoginAt Time of the last sign-in
+	LastLoginAt *time.Time `json:"last_login_at"`
+
+	// LastSeenAt Time of the last authenticated request, updated at most once a minute
+	LastSeenAt   *time.Time   `json:"last_seen_at"`
+	Name         *string      `json:"name,omitempty"`
+	PinnedPostId *int64       `json:"pinned_post_id"`
+	Role         *AccountRole `json:"role,omitempty"`
+
+	// SensitiveContent How posts flagged as sensitive appear in lists: shown as is, flagged as blurred, or left out
+	SensitiveContent *SensitiveContentPreference `json:"sensitive_content,omitempty"`
+	Status           *AccountStatus              `json:"status,omitempty"`
+	UpdatedAt        *time.Time                  `json:"updated_at,omitempty"`
+}
+
+// AccountRole defines model for Account.Role.
+type AccountRole string
+
+// AccountStatus defines model for Account.Status.
+type AccountStatus string
+
+// ChangeEmailRequest defines model for ChangeEmailRequest.
+type ChangeEmailRequest struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+// ChangePasswordRequest defines model for ChangePasswordRequest.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+
+	// NewPassword Must satisfy the password policy
+	NewPassword string `json:"new_password"`
+}
+
+// CommentPermission Who can comment on the account's posts
+type CommentPermission string
+
+// ConfirmEmailRequest defines model for ConfirmEmailRequest.
+type ConfirmEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// CreateAPIKeyRequest defines model for CreateAPIKeyRequest.
+type CreateAPIKeyRequest struct {
+	// ExpiresInDays Days until the key expires; omit for a key that does not expire
+	ExpiresInDays *int   `json:"expires_in_days,omitempty"`
+	Name          string `json:"name"`
+
+	// RateLimit Requests per minute; defaults to API_KEY_RATE_LIMIT
+	RateLimit *int `json:"rate_limit,omitempty"`
+
+	// Scopes Defaults to read
+	Scopes *[]CreateAPIKeyRequestScopes `json:"scopes,omitempty"`
+}
+
+// CreateAPIKeyRequestScopes defines model for CreateAPIKeyRequest.Scopes.
+type CreateAPIKeyRequestScopes string
+
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
+	Email    openapi_types.Email `json:"email"`
+	Password string              `json:"password"`
+
+	// RememberMe Keep the session signed in for JWT_REFRESH_EXPIRATION instead of JWT_SHORT_REFRESH_EXPIRATION
+	RememberMe *bool `json:"remember_me,omitempty"`
+}
+
+// LoginResponse defines model for LoginResponse.
+type LoginResponse struct {
+	AccessToken *string  `json:"access_token,omitempty"`
+	Account     *Account `json:"account,omitempty"`
+	ExpiresIn   *int64   `json:"expires_in,omitempty"`
+
+	// Reactivated Set when signing in reactivated a deactivated account
+	Reactivated      *bool   `json:"reactivated,omitempty"`
+	RefreshExpiresIn *int64  `json:"refresh_expires_in,omitempty"`
+	RefreshToken     *string `json:"refresh_token,omitempty"`
+	TokenType        *string `json:"token_type,omitempty"`
+}
+
+// LogoutRequest defines model for LogoutRequest.
+type LogoutRequest struct {
+	// RefreshToken Optional refresh token to revoke together with the access token
+	RefreshToken *string `json:"refresh_token,omitempty"`
+}
+
+// RefreshTokenRequest defines model for RefreshTokenRequest.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RegisterRequest defines model for RegisterRequest.
+type RegisterRequest struct {
+	Email openapi_types.Email `json:"email"`
+	Name  string              `json:"name"`
+
+	// Password Must satisfy the password policy (PASSWORD_* settings); by default at least 8 characters and not found in known data breaches
+	Password string `json:"password"`
+}
+
+// SensitiveContentPreference How posts flagged as sensitive appear in lists: shown as is, flagged as blurred, or left out
+type SensitiveContentPreference string
+
+// StandardResponse defines model for StandardResponse.
+type StandardResponse struct {
+	Code *StandardResponseCode `json:"code,omitempty"`
+
+	// Data Response data (varies by endpoint)
+	Data       *map[string]interface{} `json:"data,omitempty"`
+	Errors     *[]string               `json:"errors,omitempty"`
+	Message    *string                 `json:"message,omitempty"`
+	RequestId  *string                 `json:"requestId,omitempty"`
+	ServerTime *time.Time              `json:"serverTime,omitempty"`
+}
+
+// StandardResponseCode defines model for StandardResponse.Code.
+type StandardResponseCode string
+
+// UpdateSettingsRequest defines model for UpdateSettingsRequest.
+type UpdateSettingsRequest struct {
+	// CommentPermission Who can comment on the account's posts
+	CommentPermission *CommentPermission `json:"comment_permission,omitempty"`
+
+	// Discoverable Whether the account appears in search and follow suggestions
+	Discoverable *bool `json:"discoverable,omitempty"`
+
+	// IsPrivate Private accounts show posts only to followers
+	IsPrivate *bool `json:"is_private,omitempty"`
+
+	// SensitiveContent How posts flagged as sensitive appear in lists: shown as is, flagged as blurred, or left out
+	SensitiveContent *SensitiveContentPreference `json:"sensitive_content,omitempty"`
+}
+
+// PutApiAccountAvatarMultipartBody defines parameters for PutApiAccountAvatar.
+type PutApiAccountAvatarMultipartBody struct {
+	// Avatar Avatar image file (PNG, JPG, JPEG, BMP)
+	Avatar openapi_types.File `json:"avatar"`
+}
+
+// GetApiAccountSecurityLogParams defines parameters for GetApiAccountSecurityLog.
+type GetApiAccountSecurityLogParams struct {
+	// Cursor Cursor for pagination
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Number of items to return (max 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetApiAuthProviderCallbackParams defines parameters for GetApiAuthProviderCallback.
+type GetApiAuthProviderCallbackParams struct {
+	// Code Authorization code issued by the provider
+	Code *string `form:"code,omitempty" json:"code,omitempty"`
+
+	// State State echoed back by the provider
+	State *string `form:"state,omitempty" json:"state,omitempty"`
+
+	// Error Error reported by the provider, e.g. access_denied
+	Error *string `form:"error,omitempty" json:"error,omitempty"`
+}
+
+// GetApiAuthProviderCallbackParamsProvider defines parameters for GetApiAuthProviderCallback.
+type GetApiAuthProviderCallbackParamsProvider string
+
+// GetApiAuthProviderLoginParamsProvider defines parameters for GetApiAuthProviderLogin.
+type GetApiAuthProviderLoginParamsProvider string
+
+// PostApiAccountApiKeysJSONRequestBody defines body for PostApiAccountApiKeys for application/json ContentType.
+type PostApiAccountApiKeysJSONRequestBody = CreateAPIKeyRequest
+
+// PutApiAccountAvatarMultipartRequestBody defines body for PutApiAccountAvatar for multipart/form-data ContentType.
+type PutApiAccountAvatarMultipartRequestBody PutApiAccountAvatarMultipartBody
+
+// PutApiAccountEmailJSONRequestBody defines body for PutApiAccountEmail for application/json ContentType.
+type PutApiAccountEmailJSONRequestBody = ChangeEmailRequest
+
+// PostApiAccountEmailConfirmJSONRequestBody defines body for PostApiAccountEmailConfirm for application/json ContentType.
+type PostApiAccountEmailConfirmJSONRequestBody = ConfirmEmailRequest
+
+// PostApiAccountLoginJSONRequestBody defines body for PostApiAccountLogin for application/json ContentType.
+type PostApiAccountLoginJSONRequestBody = LoginRequest
+
+// PostApiAccountLogoutJSONRequestBody defines body for PostApiAccountLogout for application/json ContentType.
+type PostApiAccountLogoutJSONRequestBody = LogoutRequest
+
+// PutApiAccountPasswordJSONRequestBody defines body for PutApiAccountPassword for application/json ContentType.
+type PutApiAccountPasswordJSONRequestBody = ChangePasswordRequest
+
+// PostApiAccountRegisterJSONRequestBody defines body for PostApiAccountRegister for application/json ContentType.
+type PostApiAccountRegisterJSONRequestBody = RegisterRequest
+
+// PutApiAccountSettingsJSONRequestBody defines body for PutApiAccountSettings for application/json ContentType.
+type PutApiAccountSettingsJSONRequestBody = UpdateSettingsRequest
+
+// PostApiAccountTokenRefreshJSONRequestBody defines body for PostApiAccountTokenRefresh for application/json ContentType.
+type PostApiAccountTokenRefreshJSONRequestBody = RefreshTokenRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// DeleteApiAccount request
+	DeleteApiAccount(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiAccountApiKeys request
+	GetApiAccountApiKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiAccountApiKeysWithBody request with any body
+	PostApiAccountApiKeysWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiAccountApiKeys(ctx context.Context, body PostApiAccountApiKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteApiAccountApiKeysId request
+	DeleteApiAccountApiKeysId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutApiAccountAvatarWithBody request with any body
+	PutApiAccountAvatarWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiAccountDeactivate request
+	PostApiAccountDeactivate(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutApiAccountEmailWithBody request with any body
+	PutApiAccountEmailWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutApiAccountEmail(ctx context.Context, body PutApiAccountEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiAccountEmailConfirmWithBody request with any body
+	PostApiAccountEmailConfirmWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiAccountEmailConfirm(ctx context.Context, body PostApiAccountEmailConfirmJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiAccountLoginWithBody request with any body
+	PostApiAccountLoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiAccountLogin(ctx context.Context, body PostApiAccountLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiAccountLogoutWithBody request with any body
+	PostApiAccountLogoutWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiAccountLogout(ctx context.Context, body PostApiAccountLogoutJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiAccountLogoutAll request
+	PostApiAccountLogoutAll(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutApiAccountPasswordWithBody request with any body
+	PutApiAccountPasswordWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutApiAccountPassword(ctx context.Context, body PutApiAccountPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiAccountProfile request
+	GetApiAccountProfile(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiAccountRegisterWithBody request with any body
+	PostApiAccountRegisterWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiAccountRegister(ctx context.Context, body PostApiAccountRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiAccountSecurityLog request
+	GetApiAccountSecurityLog(ctx context.Context, params *GetApiAccountSecurityLogParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiAccountSessions request
+	GetApiAccountSessions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteApiAccountSessionsId request
+	DeleteApiAccountSessionsId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiAccountSettings request
+	GetApiAccountSettings(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutApiAccountSettingsWithBody request with any body
+	PutApiAccountSettingsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutApiAccountSettings(ctx context.Context, body PutApiAccountSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiAccountTokenRefreshWithBody request with any body
+	PostApiAccountTokenRefreshWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiAccountTokenRefresh(ctx context.Context, body PostApiAccountTokenRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteApiAdminAccountsIdVerification request
+	DeleteApiAdminAccountsIdVerification(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiAdminAccountsIdVerification request
+	PostApiAdminAccountsIdVerification(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiAuthProviderCallback request
+	GetApiAuthProviderCallback(ctx context.Context, provider GetApiAuthProviderCallbackParamsProvider, params *GetApiAuthProviderCallbackParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiAuthProviderLogin request
+	GetApiAuthProviderLogin(ctx context.Context, provider GetApiAuthProviderLoginParamsProvider, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) DeleteApiAccount(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteApiAccountRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetApiAccountApiKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiAccountApiKeysRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiAccountApiKeysWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiAccountApiKeysRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiAccountApiKeys(ctx context.Context, body PostApiAccountApiKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiAccountApiKeysRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteApiAccountApiKeysId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteApiAccountApiKeysIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutApiAccountAvatarWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutApiAccountAvatarRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiAccountDeactivate(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiAccountDeactivateRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutApiAccountEmailWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutApiAccountEmailRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutApiAccountEmail(ctx context.Context, body PutApiAccountEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutApiAccountEmailRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiAccountEmailConfirmWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiAccountEmailConfirmRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiAccountEmailConfirm(ctx context.Context, body PostApiAccountEmailConfirmJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiAccountEmailConfirmRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiAccountLoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiAccountLoginRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiAccountLogin(ctx context.Context, body PostApiAccountLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiAccountLoginRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiAccountLogoutWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiAccountLogoutRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiAccountLogout(ctx context.Context, body PostApiAccountLogoutJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiAccountLogoutRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiAccountLogoutAll(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiAccountLogoutAllRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutApiAccountPasswordWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutApiAccountPasswordRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutApiAccountPassword(ctx context.Context, body PutApiAccountPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutApiAccountPasswordRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetApiAccountProfile(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiAccountProfileRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiAccountRegisterWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiAccountRegisterRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiAccountRegister(ctx context.Context, body PostApiAccountRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiAccountRegisterRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetApiAccountSecurityLog(ctx context.Context, params *GetApiAccountSecurityLogParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiAccountSecurityLogRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetApiAccountSessions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiAccountSessionsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteApiAccountSessionsId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteApiAccountSessionsIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetApiAccountSettings(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiAccountSettingsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutApiAccountSettingsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutApiAccountSettingsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutApiAccountSettings(ctx context.Context, body PutApiAccountSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutApiAccountSettingsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiAccountTokenRefreshWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiAccountTokenRefreshRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiAccountTokenRefresh(ctx context.Context, body PostApiAccountTokenRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiAccountTokenRefreshRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteApiAdminAccountsIdVerification(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteApiAdminAccountsIdVerificationRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiAdminAccountsIdVerification(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiAdminAccountsIdVerificationRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetApiAuthProviderCallback(ctx context.Context, provider GetApiAuthProviderCallbackParamsProvider, params *GetApiAuthProviderCallbackParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiAuthProviderCallbackRequest(c.Server, provider, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetApiAuthProviderLogin(ctx context.Context, provider GetApiAuthProviderLoginParamsProvider, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiAuthProviderLoginRequest(c.Server, provider)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewDeleteApiAccountRequest generates requests for DeleteApiAccount
+func NewDeleteApiAccountRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiAccountApiKeysRequest generates requests for GetApiAccountApiKeys
+func NewGetApiAccountApiKeysRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/api-keys")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostApiAccountApiKeysRequest calls the generic PostApiAccountApiKeys builder with application/json body
+func NewPostApiAccountApiKeysRequest(server string, body PostApiAccountApiKeysJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiAccountApiKeysRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostApiAccountApiKeysRequestWithBody generates requests for PostApiAccountApiKeys with any type of body
+func NewPostApiAccountApiKeysRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/api-keys")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteApiAccountApiKeysIdRequest generates requests for DeleteApiAccountApiKeysId
+func NewDeleteApiAccountApiKeysIdRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/api-keys/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutApiAccountAvatarRequestWithBody generates requests for PutApiAccountAvatar with any type of body
+func NewPutApiAccountAvatarRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/avatar")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostApiAccountDeactivateRequest generates requests for PostApiAccountDeactivate
+func NewPostApiAccountDeactivateRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/deactivate")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutApiAccountEmailRequest calls the generic PutApiAccountEmail builder with application/json body
+func NewPutApiAccountEmailRequest(server string, body PutApiAccountEmailJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutApiAccountEmailRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPutApiAccountEmailRequestWithBody generates requests for PutApiAccountEmail with any type of body
+func NewPutApiAccountEmailRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/email")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostApiAccountEmailConfirmRequest calls the generic PostApiAccountEmailConfirm builder with application/json body
+func NewPostApiAccountEmailConfirmRequest(server string, body PostApiAccountEmailConfirmJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiAccountEmailConfirmRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostApiAccountEmailConfirmRequestWithBody generates requests for PostApiAccountEmailConfirm with any type of body
+func NewPostApiAccountEmailConfirmRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/email/confirm")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostApiAccountLoginRequest calls the generic PostApiAccountLogin builder with application/json body
+func NewPostApiAccountLoginRequest(server string, body PostApiAccountLoginJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiAccountLoginRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostApiAccountLoginRequestWithBody generates requests for PostApiAccountLogin with any type of body
+func NewPostApiAccountLoginRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/login")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostApiAccountLogoutRequest calls the generic PostApiAccountLogout builder with application/json body
+func NewPostApiAccountLogoutRequest(server string, body PostApiAccountLogoutJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiAccountLogoutRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostApiAccountLogoutRequestWithBody generates requests for PostApiAccountLogout with any type of body
+func NewPostApiAccountLogoutRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/logout")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostApiAccountLogoutAllRequest generates requests for PostApiAccountLogoutAll
+func NewPostApiAccountLogoutAllRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/logout-all")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutApiAccountPasswordRequest calls the generic PutApiAccountPassword builder with application/json body
+func NewPutApiAccountPasswordRequest(server string, body PutApiAccountPasswordJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutApiAccountPasswordRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPutApiAccountPasswordRequestWithBody generates requests for PutApiAccountPassword with any type of body
+func NewPutApiAccountPasswordRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/password")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetApiAccountProfileRequest generates requests for GetApiAccountProfile
+func NewGetApiAccountProfileRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/profile")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostApiAccountRegisterRequest calls the generic PostApiAccountRegister builder with application/json body
+func NewPostApiAccountRegisterRequest(server string, body PostApiAccountRegisterJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiAccountRegisterRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostApiAccountRegisterRequestWithBody generates requests for PostApiAccountRegister with any type of body
+func NewPostApiAccountRegisterRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/register")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetApiAccountSecurityLogRequest generates requests for GetApiAccountSecurityLog
+func NewGetApiAccountSecurityLogRequest(server string, params *GetApiAccountSecurityLogParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/security-log")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiAccountSessionsRequest generates requests for GetApiAccountSessions
+func NewGetApiAccountSessionsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/sessions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteApiAccountSessionsIdRequest generates requests for DeleteApiAccountSessionsId
+func NewDeleteApiAccountSessionsIdRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/sessions/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiAccountSettingsRequest generates requests for GetApiAccountSettings
+func NewGetApiAccountSettingsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/settings")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutApiAccountSettingsRequest calls the generic PutApiAccountSettings builder with application/json body
+func NewPutApiAccountSettingsRequest(server string, body PutApiAccountSettingsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutApiAccountSettingsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPutApiAccountSettingsRequestWithBody generates requests for PutApiAccountSettings with any type of body
+func NewPutApiAccountSettingsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/settings")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostApiAccountTokenRefreshRequest calls the generic PostApiAccountTokenRefresh builder with application/json body
+func NewPostApiAccountTokenRefreshRequest(server string, body PostApiAccountTokenRefreshJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiAccountTokenRefreshRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostApiAccountTokenRefreshRequestWithBody generates requests for PostApiAccountTokenRefresh with any type of body
+func NewPostApiAccountTokenRefreshRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/token/refresh")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteApiAdminAccountsIdVerificationRequest generates requests for DeleteApiAdminAccountsIdVerification
+func NewDeleteApiAdminAccountsIdVerificationRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/accounts/%s/verification", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostApiAdminAccountsIdVerificationRequest generates requests for PostApiAdminAccountsIdVerification
+func NewPostApiAdminAccountsIdVerificationRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/accounts/%s/verification", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiAuthProviderCallbackRequest generates requests for GetApiAuthProviderCallback
+func NewGetApiAuthProviderCallbackRequest(server string, provider GetApiAuthProviderCallbackParamsProvider, params *GetApiAuthProviderCallbackParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "provider", runtime.ParamLocationPath, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/auth/%s/callback", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Code != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "code", runtime.ParamLocationQuery, *params.Code); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.State != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "state", runtime.ParamLocationQuery, *params.State); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Error != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "error", runtime.ParamLocationQuery, *params.Error); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiAuthProviderLoginRequest generates requests for GetApiAuthProviderLogin
+func NewGetApiAuthProviderLoginRequest(server string, provider GetApiAuthProviderLoginParamsProvider) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "provider", runtime.ParamLocationPath, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/auth/%s/login", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// DeleteApiAccountWithResponse request
+	DeleteApiAccountWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteApiAccountResponse, error)
+
+	// GetApiAccountApiKeysWithResponse request
+	GetApiAccountApiKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiAccountApiKeysResponse, error)
+
+	// PostApiAccountApiKeysWithBodyWithResponse request with any body
+	PostApiAccountApiKeysWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiAccountApiKeysResponse, error)
+
+	PostApiAccountApiKeysWithResponse(ctx context.Context, body PostApiAccountApiKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiAccountApiKeysResponse, error)
+
+	// DeleteApiAccountApiKeysIdWithResponse request
+	DeleteApiAccountApiKeysIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteApiAccountApiKeysIdResponse, error)
+
+	// PutApiAccountAvatarWithBodyWithResponse request with any body
+	PutApiAccountAvatarWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutApiAccountAvatarResponse, error)
+
+	// PostApiAccountDeactivateWithResponse request
+	PostApiAccountDeactivateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostApiAccountDeactivateResponse, error)
+
+	// PutApiAccountEmailWithBodyWithResponse request with any body
+	PutApiAccountEmailWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutApiAccountEmailResponse, error)
+
+	PutApiAccountEmailWithResponse(ctx context.Context, body PutApiAccountEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*PutApiAccountEmailResponse, error)
+
+	// PostApiAccountEmailConfirmWithBodyWithResponse request with any body
+	PostApiAccountEmailConfirmWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiAccountEmailConfirmResponse, error)
+
+	PostApiAccountEmailConfirmWithResponse(ctx context.Context, body PostApiAccountEmailConfirmJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiAccountEmailConfirmResponse, error)
+
+	// PostApiAccountLoginWithBodyWithResponse request with any body
+	PostApiAccountLoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiAccountLoginResponse, error)
+
+	PostApiAccountLoginWithResponse(ctx context.Context, body PostApiAccountLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiAccountLoginResponse, error)
+
+	// PostApiAccountLogoutWithBodyWithResponse request with any body
+	PostApiAccountLogoutWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiAccountLogoutResponse, error)
+
+	PostApiAccountLogoutWithResponse(ctx context.Context, body PostApiAccountLogoutJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiAccountLogoutResponse, error)
+
+	// PostApiAccountLogoutAllWithResponse request
+	PostApiAccountLogoutAllWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostApiAccountLogoutAllResponse, error)
+
+	// PutApiAccountPasswordWithBodyWithResponse request with any body
+	PutApiAccountPasswordWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutApiAccountPasswordResponse, error)
+
+	PutApiAccountPasswordWithResponse(ctx context.Context, body PutApiAccountPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*PutApiAccountPasswordResponse, error)
+
+	// GetApiAccountProfileWithResponse request
+	GetApiAccountProfileWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiAccountProfileResponse, error)
+
+	// PostApiAccountRegisterWithBodyWithResponse request with any body
+	PostApiAccountRegisterWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiAccountRegisterResponse, error)
+
+	PostApiAccountRegisterWithResponse(ctx context.Context, body PostApiAccountRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiAccountRegisterResponse, error)
+
+	// GetApiAccountSecurityLogWithResponse request
+	GetApiAccountSecurityLogWithResponse(ctx context.Context, params *GetApiAccountSecurityLogParams, reqEditors ...RequestEditorFn) (*GetApiAccountSecurityLogResponse, error)
+
+	// GetApiAccountSessionsWithResponse request
+	GetApiAccountSessionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiAccountSessionsResponse, error)
+
+	// DeleteApiAccountSessionsIdWithResponse request
+	DeleteApiAccountSessionsIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteApiAccountSessionsIdResponse, error)
+
+	// GetApiAccountSettingsWithResponse request
+	GetApiAccountSettingsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiAccountSettingsResponse, error)
+
+	// PutApiAccountSettingsWithBodyWithResponse request with any body
+	PutApiAccountSettingsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutApiAccountSettingsResponse, error)
+
+	PutApiAccountSettingsWithResponse(ctx context.Context, body PutApiAccountSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*PutApiAccountSettingsResponse, error)
+
+	// PostApiAccountTokenRefreshWithBodyWithResponse request with any body
+	PostApiAccountTokenRefreshWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiAccountTokenRefreshResponse, error)
+
+	PostApiAccountTokenRefreshWithResponse(ctx context.Context, body PostApiAccountTokenRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiAccountTokenRefreshResponse, error)
+
+	// DeleteApiAdminAccountsIdVerificationWithResponse request
+	DeleteApiAdminAccountsIdVerificationWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteApiAdminAccountsIdVerificationResponse, error)
+
+	// PostApiAdminAccountsIdVerificationWithResponse request
+	PostApiAdminAccountsIdVerificationWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*PostApiAdminAccountsIdVerificationResponse, error)
+
+	// GetApiAuthProviderCallbackWithResponse request
+	GetApiAuthProviderCallbackWithResponse(ctx context.Context, provider GetApiAuthProviderCallbackParamsProvider, params *GetApiAuthProviderCallbackParams, reqEditors ...RequestEditorFn) (*GetApiAuthProviderCallbackResponse, error)
+
+	// GetApiAuthProviderLoginWithResponse request
+	GetApiAuthProviderLoginWithResponse(ctx context.Context, provider GetApiAuthProviderLoginParamsProvider, reqEditors ...RequestEditorFn) (*GetApiAuthProviderLoginResponse, error)
+}
+
+type DeleteApiAccountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteApiAccountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteApiAccountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiAccountApiKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON403      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiAccountApiKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiAccountApiKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiAccountApiKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *StandardResponse
+	JSON400      *StandardResponse
+	JSON401      *StandardResponse
+	JSON403      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiAccountApiKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiAccountApiKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteApiAccountApiKeysIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON403      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteApiAccountApiKeysIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteApiAccountApiKeysIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutApiAccountAvatarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON400      *StandardResponse
+	JSON401      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutApiAccountAvatarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutApiAccountAvatarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiAccountDeactivateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiAccountDeactivateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiAccountDeactivateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutApiAccountEmailResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *StandardResponse
+	JSON400      *StandardResponse
+	JSON401      *StandardResponse
+	JSON409      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutApiAccountEmailResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutApiAccountEmailResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiAccountEmailConfirmResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON400      *StandardResponse
+	JSON409      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiAccountEmailConfirmResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiAccountEmailConfirmResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiAccountLoginResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON400      *StandardResponse
+	JSON401      *StandardResponse
+	JSON423      *StandardResponse
+	JSON429      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiAccountLoginResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiAccountLoginResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiAccountLogoutResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON400      *StandardResponse
+	JSON401      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiAccountLogoutResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiAccountLogoutResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiAccountLogoutAllResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiAccountLogoutAllResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiAccountLogoutAllResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutApiAccountPasswordResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON400      *StandardResponse
+	JSON401      *StandardResponse
+	JSON403      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutApiAccountPasswordResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutApiAccountPasswordResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiAccountProfileResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiAccountProfileResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiAccountProfileResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiAccountRegisterResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *StandardResponse
+	JSON400      *StandardResponse
+	JSON409      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiAccountRegisterResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiAccountRegisterResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiAccountSecurityLogResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiAccountSecurityLogResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiAccountSecurityLogResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiAccountSessionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiAccountSessionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiAccountSessionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteApiAccountSessionsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteApiAccountSessionsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteApiAccountSessionsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiAccountSettingsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiAccountSettingsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiAccountSettingsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutApiAccountSettingsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON400      *StandardResponse
+	JSON401      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutApiAccountSettingsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutApiAccountSettingsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiAccountTokenRefreshResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON400      *StandardResponse
+	JSON401      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiAccountTokenRefreshResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiAccountTokenRefreshResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteApiAdminAccountsIdVerificationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON403      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteApiAdminAccountsIdVerificationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteApiAdminAccountsIdVerificationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiAdminAccountsIdVerificationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON403      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiAdminAccountsIdVerificationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiAdminAccountsIdVerificationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiAuthProviderCallbackResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON400      *StandardResponse
+	JSON401      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiAuthProviderCallbackResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiAuthProviderCallbackResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiAuthProviderLoginResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiAuthProviderLoginResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiAuthProviderLoginResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// DeleteApiAccountWithResponse request returning *DeleteApiAccountResponse
+func (c *ClientWithResponses) DeleteApiAccountWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteApiAccountResponse, error) {
+	rsp, err := c.DeleteApiAccount(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteApiAccountResponse(rsp)
+}
+
+// GetApiAccountApiKeysWithResponse request returning *GetApiAccountApiKeysResponse
+func (c *ClientWithResponses) GetApiAccountApiKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiAccountApiKeysResponse, error) {
+	rsp, err := c.GetApiAccountApiKeys(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiAccountApiKeysResponse(rsp)
+}
+
+// PostApiAccountApiKeysWithBodyWithResponse request with arbitrary body returning *PostApiAccountApiKeysResponse
+func (c *ClientWithResponses) PostApiAccountApiKeysWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiAccountApiKeysResponse, error) {
+	rsp, err := c.PostApiAccountApiKeysWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiAccountApiKeysResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiAccountApiKeysWithResponse(ctx context.Context, body PostApiAccountApiKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiAccountApiKeysResponse, error) {
+	rsp, err := c.PostApiAccountApiKeys(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiAccountApiKeysResponse(rsp)
+}
+
+// DeleteApiAccountApiKeysIdWithResponse request returning *DeleteApiAccountApiKeysIdResponse
+func (c *ClientWithResponses) DeleteApiAccountApiKeysIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteApiAccountApiKeysIdResponse, error) {
+	rsp, err := c.DeleteApiAccountApiKeysId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteApiAccountApiKeysIdResponse(rsp)
+}
+
+// PutApiAccountAvatarWithBodyWithResponse request with arbitrary body returning *PutApiAccountAvatarResponse
+func (c *ClientWithResponses) PutApiAccountAvatarWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutApiAccountAvatarResponse, error) {
+	rsp, err := c.PutApiAccountAvatarWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutApiAccountAvatarResponse(rsp)
+}
+
+// PostApiAccountDeactivateWithResponse request returning *PostApiAccountDeactivateResponse
+func (c *ClientWithResponses) PostApiAccountDeactivateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostApiAccountDeactivateResponse, error) {
+	rsp, err := c.PostApiAccountDeactivate(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiAccountDeactivateResponse(rsp)
+}
+
+// PutApiAccountEmailWithBodyWithResponse request with arbitrary body returning *PutApiAccountEmailResponse
+func (c *ClientWithResponses) PutApiAccountEmailWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutApiAccountEmailResponse, error) {
+	rsp, err := c.PutApiAccountEmailWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutApiAccountEmailResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutApiAccountEmailWithResponse(ctx context.Context, body PutApiAccountEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*PutApiAccountEmailResponse, error) {
+	rsp, err := c.PutApiAccountEmail(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutApiAccountEmailResponse(rsp)
+}
+
+// PostApiAccountEmailConfirmWithBodyWithResponse request with arbitrary body returning *PostApiAccountEmailConfirmResponse
+func (c *ClientWithResponses) PostApiAccountEmailConfirmWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiAccountEmailConfirmResponse, error) {
+	rsp, err := c.PostApiAccountEmailConfirmWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiAccountEmailConfirmResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiAccountEmailConfirmWithResponse(ctx context.Context, body PostApiAccountEmailConfirmJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiAccountEmailConfirmResponse, error) {
+	rsp, err := c.PostApiAccountEmailConfirm(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiAccountEmailConfirmResponse(rsp)
+}
+
+// PostApiAccountLoginWithBodyWithResponse request with arbitrary body returning *PostApiAccountLoginResponse
+func (c *ClientWithResponses) PostApiAccountLoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiAccountLoginResponse, error) {
+	rsp, err := c.PostApiAccountLoginWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiAccountLoginResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiAccountLoginWithResponse(ctx context.Context, body PostApiAccountLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiAccountLoginResponse, error) {
+	rsp, err := c.PostApiAccountLogin(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiAccountLoginResponse(rsp)
+}
+
+// PostApiAccountLogoutWithBodyWithResponse request with arbitrary body returning *PostApiAccountLogoutResponse
+func (c *ClientWithResponses) PostApiAccountLogoutWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiAccountLogoutResponse, error) {
+	rsp, err := c.PostApiAccountLogoutWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiAccountLogoutResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiAccountLogoutWithResponse(ctx context.Context, body PostApiAccountLogoutJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiAccountLogoutResponse, error) {
+	rsp, err := c.PostApiAccountLogout(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiAccountLogoutResponse(rsp)
+}
+
+// PostApiAccountLogoutAllWithResponse request returning *PostApiAccountLogoutAllResponse
+func (c *ClientWithResponses) PostApiAccountLogoutAllWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostApiAccountLogoutAllResponse, error) {
+	rsp, err := c.PostApiAccountLogoutAll(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiAccountLogoutAllResponse(rsp)
+}
+
+// PutApiAccountPasswordWithBodyWithResponse request with arbitrary body returning *PutApiAccountPasswordResponse
+func (c *ClientWithResponses) PutApiAccountPasswordWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutApiAccountPasswordResponse, error) {
+	rsp, err := c.PutApiAccountPasswordWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutApiAccountPasswordResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutApiAccountPasswordWithResponse(ctx context.Context, body PutApiAccountPasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*PutApiAccountPasswordResponse, error) {
+	rsp, err := c.PutApiAccountPassword(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutApiAccountPasswordResponse(rsp)
+}
+
+// GetApiAccountProfileWithResponse request returning *GetApiAccountProfileResponse
+func (c *ClientWithResponses) GetApiAccountProfileWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiAccountProfileResponse, error) {
+	rsp, err := c.GetApiAccountProfile(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiAccountProfileResponse(rsp)
+}
+
+// PostApiAccountRegisterWithBodyWithResponse request with arbitrary body returning *PostApiAccountRegisterResponse
+func (c *ClientWithResponses) PostApiAccountRegisterWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiAccountRegisterResponse, error) {
+	rsp, err := c.PostApiAccountRegisterWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiAccountRegisterResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiAccountRegisterWithResponse(ctx context.Context, body PostApiAccountRegisterJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiAccountRegisterResponse, error) {
+	rsp, err := c.PostApiAccountRegister(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiAccountRegisterResponse(rsp)
+}
+
+// GetApiAccountSecurityLogWithResponse request returning *GetApiAccountSecurityLogResponse
+func (c *ClientWithResponses) GetApiAccountSecurityLogWithResponse(ctx context.Context, params *GetApiAccountSecurityLogParams, reqEditors ...RequestEditorFn) (*GetApiAccountSecurityLogResponse, error) {
+	rsp, err := c.GetApiAccountSecurityLog(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiAccountSecurityLogResponse(rsp)
+}
+
+// GetApiAccountSessionsWithResponse request returning *GetApiAccountSessionsResponse
+func (c *ClientWithResponses) GetApiAccountSessionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiAccountSessionsResponse, error) {
+	rsp, err := c.GetApiAccountSessions(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiAccountSessionsResponse(rsp)
+}
+
+// DeleteApiAccountSessionsIdWithResponse request returning *DeleteApiAccountSessionsIdResponse
+func (c *ClientWithResponses) DeleteApiAccountSessionsIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteApiAccountSessionsIdResponse, error) {
+	rsp, err := c.DeleteApiAccountSessionsId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteApiAccountSessionsIdResponse(rsp)
+}
+
+// GetApiAccountSettingsWithResponse request returning *GetApiAccountSettingsResponse
+func (c *ClientWithResponses) GetApiAccountSettingsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiAccountSettingsResponse, error) {
+	rsp, err := c.GetApiAccountSettings(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiAccountSettingsResponse(rsp)
+}
+
+// PutApiAccountSettingsWithBodyWithResponse request with arbitrary body returning *PutApiAccountSettingsResponse
+func (c *ClientWithResponses) PutApiAccountSettingsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutApiAccountSettingsResponse, error) {
+	rsp, err := c.PutApiAccountSettingsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutApiAccountSettingsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutApiAccountSettingsWithResponse(ctx context.Context, body PutApiAccountSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*PutApiAccountSettingsResponse, error) {
+	rsp, err := c.PutApiAccountSettings(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutApiAccountSettingsResponse(rsp)
+}
+
+// PostApiAccountTokenRefreshWithBodyWithResponse request with arbitrary body returning *PostApiAccountTokenRefreshResponse
+func (c *ClientWithResponses) PostApiAccountTokenRefreshWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiAccountTokenRefreshResponse, error) {
+	rsp, err := c.PostApiAccountTokenRefreshWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiAccountTokenRefreshResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiAccountTokenRefreshWithResponse(ctx context.Context, body PostApiAccountTokenRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiAccountTokenRefreshResponse, error) {
+	rsp, err := c.PostApiAccountTokenRefresh(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiAccountTokenRefreshResponse(rsp)
+}
+
+// DeleteApiAdminAccountsIdVerificationWithResponse request returning *DeleteApiAdminAccountsIdVerificationResponse
+func (c *ClientWithResponses) DeleteApiAdminAccountsIdVerificationWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteApiAdminAccountsIdVerificationResponse, error) {
+	rsp, err := c.DeleteApiAdminAccountsIdVerification(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteApiAdminAccountsIdVerificationResponse(rsp)
+}
+
+// PostApiAdminAccountsIdVerificationWithResponse request returning *PostApiAdminAccountsIdVerificationResponse
+func (c *ClientWithResponses) PostApiAdminAccountsIdVerificationWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*PostApiAdminAccountsIdVerificationResponse, error) {
+	rsp, err := c.PostApiAdminAccountsIdVerification(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiAdminAccountsIdVerificationResponse(rsp)
+}
+
+// GetApiAuthProviderCallbackWithResponse request returning *GetApiAuthProviderCallbackResponse
+func (c *ClientWithResponses) GetApiAuthProviderCallbackWithResponse(ctx context.Context, provider GetApiAuthProviderCallbackParamsProvider, params *GetApiAuthProviderCallbackParams, reqEditors ...RequestEditorFn) (*GetApiAuthProviderCallbackResponse, error) {
+	rsp, err := c.GetApiAuthProviderCallback(ctx, provider, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiAuthProviderCallbackResponse(rsp)
+}
+
+// GetApiAuthProviderLoginWithResponse request returning *GetApiAuthProviderLoginResponse
+func (c *ClientWithResponses) GetApiAuthProviderLoginWithResponse(ctx context.Context, provider GetApiAuthProviderLoginParamsProvider, reqEditors ...RequestEditorFn) (*GetApiAuthProviderLoginResponse, error) {
+	rsp, err := c.GetApiAuthProviderLogin(ctx, provider, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiAuthProviderLoginResponse(rsp)
+}
+
+// ParseDeleteApiAccountResponse parses an HTTP response from a DeleteApiAccountWithResponse call
+func ParseDeleteApiAccountResponse(rsp *http.Response) (*DeleteApiAccountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteApiAccountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiAccountApiKeysResponse parses an HTTP response from a GetApiAccountApiKeysWithResponse call
+func ParseGetApiAccountApiKeysResponse(rsp *http.Response) (*GetApiAccountApiKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiAccountApiKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiAccountApiKeysResponse parses an HTTP response from a PostApiAccountApiKeysWithResponse call
+func ParsePostApiAccountApiKeysResponse(rsp *http.Response) (*PostApiAccountApiKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiAccountApiKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteApiAccountApiKeysIdResponse parses an HTTP response from a DeleteApiAccountApiKeysIdWithResponse call
+func ParseDeleteApiAccountApiKeysIdResponse(rsp *http.Response) (*DeleteApiAccountApiKeysIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteApiAccountApiKeysIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutApiAccountAvatarResponse parses an HTTP response from a PutApiAccountAvatarWithResponse call
+func ParsePutApiAccountAvatarResponse(rsp *http.Response) (*PutApiAccountAvatarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutApiAccountAvatarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiAccountDeactivateResponse parses an HTTP response from a PostApiAccountDeactivateWithResponse call
+func ParsePostApiAccountDeactivateResponse(rsp *http.Response) (*PostApiAccountDeactivateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiAccountDeactivateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutApiAccountEmailResponse parses an HTTP response from a PutApiAccountEmailWithResponse call
+func ParsePutApiAccountEmailResponse(rsp *http.Response) (*PutApiAccountEmailResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutApiAccountEmailResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiAccountEmailConfirmResponse parses an HTTP response from a PostApiAccountEmailConfirmWithResponse call
+func ParsePostApiAccountEmailConfirmResponse(rsp *http.Response) (*PostApiAccountEmailConfirmResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiAccountEmailConfirmResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiAccountLoginResponse parses an HTTP response from a PostApiAccountLoginWithResponse call
+func ParsePostApiAccountLoginResponse(rsp *http.Response) (*PostApiAccountLoginResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiAccountLoginResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 423:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON423 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiAccountLogoutResponse parses an HTTP response from a PostApiAccountLogoutWithResponse call
+func ParsePostApiAccountLogoutResponse(rsp *http.Response) (*PostApiAccountLogoutResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiAccountLogoutResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiAccountLogoutAllResponse parses an HTTP response from a PostApiAccountLogoutAllWithResponse call
+func ParsePostApiAccountLogoutAllResponse(rsp *http.Response) (*PostApiAccountLogoutAllResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiAccountLogoutAllResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutApiAccountPasswordResponse parses an HTTP response from a PutApiAccountPasswordWithResponse call
+func ParsePutApiAccountPasswordResponse(rsp *http.Response) (*PutApiAccountPasswordResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutApiAccountPasswordResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiAccountProfileResponse parses an HTTP response from a GetApiAccountProfileWithResponse call
+func ParseGetApiAccountProfileResponse(rsp *http.Response) (*GetApiAccountProfileResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiAccountProfileResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiAccountRegisterResponse parses an HTTP response from a PostApiAccountRegisterWithResponse call
+func ParsePostApiAccountRegisterResponse(rsp *http.Response) (*PostApiAccountRegisterResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiAccountRegisterResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiAccountSecurityLogResponse parses an HTTP response from a GetApiAccountSecurityLogWithResponse call
+func ParseGetApiAccountSecurityLogResponse(rsp *http.Response) (*GetApiAccountSecurityLogResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiAccountSecurityLogResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiAccountSessionsResponse parses an HTTP response from a GetApiAccountSessionsWithResponse call
+func ParseGetApiAccountSessionsResponse(rsp *http.Response) (*GetApiAccountSessionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiAccountSessionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteApiAccountSessionsIdResponse parses an HTTP response from a DeleteApiAccountSessionsIdWithResponse call
+func ParseDeleteApiAccountSessionsIdResponse(rsp *http.Response) (*DeleteApiAccountSessionsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteApiAccountSessionsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiAccountSettingsResponse parses an HTTP response from a GetApiAccountSettingsWithResponse call
+func ParseGetApiAccountSettingsResponse(rsp *http.Response) (*GetApiAccountSettingsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiAccountSettingsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutApiAccountSettingsResponse parses an HTTP response from a PutApiAccountSettingsWithResponse call
+func ParsePutApiAccountSettingsResponse(rsp *http.Response) (*PutApiAccountSettingsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutApiAccountSettingsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiAccountTokenRefreshResponse parses an HTTP response from a PostApiAccountTokenRefreshWithResponse call
+func ParsePostApiAccountTokenRefreshResponse(rsp *http.Response) (*PostApiAccountTokenRefreshResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiAccountTokenRefreshResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteApiAdminAccountsIdVerificationResponse parses an HTTP response from a DeleteApiAdminAccountsIdVerificationWithResponse call
+func ParseDeleteApiAdminAccountsIdVerificationResponse(rsp *http.Response) (*DeleteApiAdminAccountsIdVerificationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteApiAdminAccountsIdVerificationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiAdminAccountsIdVerificationResponse parses an HTTP response from a PostApiAdminAccountsIdVerificationWithResponse call
+func ParsePostApiAdminAccountsIdVerificationResponse(rsp *http.Response) (*PostApiAdminAccountsIdVerificationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiAdminAccountsIdVerificationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiAuthProviderCallbackResponse parses an HTTP response from a GetApiAuthProviderCallbackWithResponse call
+func ParseGetApiAuthProviderCallbackResponse(rsp *http.Response) (*GetApiAuthProviderCallbackResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiAuthProviderCallbackResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiAuthProviderLoginResponse parses an HTTP response from a GetApiAuthProviderLoginWithResponse call
+func ParseGetApiAuthProviderLoginResponse(rsp *http.Response) (*GetApiAuthProviderLoginResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiAuthProviderLoginResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
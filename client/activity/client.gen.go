@@ -0,0 +1,362 @@
+// Package activity provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for ActivityType.
+const (
+	Comment     ActivityType = "comment"
+	CommentLike ActivityType = "comment_like"
+	Follow      ActivityType = "follow"
+	Post        ActivityType = "post"
+)
+
+// Defines values for StandardResponseCode.
+const (
+	BADREQUEST          StandardResponseCode = "BAD_REQUEST"
+	CONFLICT            StandardResponseCode = "CONFLICT"
+	FAILED              StandardResponseCode = "FAILED"
+	FORBIDDEN           StandardResponseCode = "FORBIDDEN"
+	INTERNALSERVERERROR StandardResponseCode = "INTERNAL_SERVER_ERROR"
+	NOTFOUND            StandardResponseCode = "NOT_FOUND"
+	SUCCESS             StandardResponseCode = "SUCCESS"
+	UNAUTHORIZED        StandardResponseCode = "UNAUTHORIZED"
+)
+
+// Activity defines model for Activity.
+type Activity struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// ObjectId ID of the created post, created comment, liked comment or followed account
+	ObjectId *int64 `json:"object_id,omitempty"`
+
+	// PostId Post the activity belongs to; not set for follows
+	PostId *int64        `json:"post_id"`
+	Type   *ActivityType `json:"type,omitempty"`
+}
+
+// ActivityType defines model for Activity.Type.
+type ActivityType string
+
+// ActivityListResponse defines model for ActivityListResponse.
+type ActivityListResponse struct {
+	// Cursor Cursor for the next page
+	Cursor  *string     `json:"cursor,omitempty"`
+	HasMore *bool       `json:"has_more,omitempty"`
+	Items   *[]Activity `json:"items,omitempty"`
+}
+
+// StandardResponse defines model for StandardResponse.
+type StandardResponse struct {
+	Code *StandardResponseCode `json:"code,omitempty"`
+
+	// Data Response data (varies by endpoint)
+	Data       *map[string]interface{} `json:"data,omitempty"`
+	Errors     *[]string               `json:"errors,omitempty"`
+	Message    *string                 `json:"message,omitempty"`
+	RequestId  *string                 `json:"requestId,omitempty"`
+	ServerTime *time.Time              `json:"serverTime,omitempty"`
+}
+
+// StandardResponseCode defines model for StandardResponse.Code.
+type StandardResponseCode string
+
+// GetApiAccountActivityParams defines parameters for GetApiAccountActivity.
+type GetApiAccountActivityParams struct {
+	// Cursor Cursor for pagination
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Number of activities to return (max 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetApiAccountActivity request
+	GetApiAccountActivity(ctx context.Context, params *GetApiAccountActivityParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetApiAccountActivity(ctx context.Context, params *GetApiAccountActivityParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiAccountActivityRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetApiAccountActivityRequest generates requests for GetApiAccountActivity
+func NewGetApiAccountActivityRequest(server string, params *GetApiAccountActivityParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/account/activity")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetApiAccountActivityWithResponse request
+	GetApiAccountActivityWithResponse(ctx context.Context, params *GetApiAccountActivityParams, reqEditors ...RequestEditorFn) (*GetApiAccountActivityResponse, error)
+}
+
+type GetApiAccountActivityResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiAccountActivityResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiAccountActivityResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetApiAccountActivityWithResponse request returning *GetApiAccountActivityResponse
+func (c *ClientWithResponses) GetApiAccountActivityWithResponse(ctx context.Context, params *GetApiAccountActivityParams, reqEditors ...RequestEditorFn) (*GetApiAccountActivityResponse, error) {
+	rsp, err := c.GetApiAccountActivity(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiAccountActivityResponse(rsp)
+}
+
+// ParseGetApiAccountActivityResponse parses an HTTP response from a GetApiAccountActivityWithResponse call
+func ParseGetApiAccountActivityResponse(rsp *http.Response) (*GetApiAccountActivityResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiAccountActivityResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
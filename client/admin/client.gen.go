@@ -0,0 +1,1458 @@
+// Package admin provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for CreateReportRequestTargetType.
+const (
+	CreateReportRequestTargetTypeAccount CreateReportRequestTargetType = "account"
+	CreateReportRequestTargetTypeComment CreateReportRequestTargetType = "comment"
+	CreateReportRequestTargetTypePost    CreateReportRequestTargetType = "post"
+)
+
+// Defines values for StandardResponseCode.
+const (
+	BADREQUEST          StandardResponseCode = "BAD_REQUEST"
+	CONFLICT            StandardResponseCode = "CONFLICT"
+	FAILED              StandardResponseCode = "FAILED"
+	FORBIDDEN           StandardResponseCode = "FORBIDDEN"
+	INTERNALSERVERERROR StandardResponseCode = "INTERNAL_SERVER_ERROR"
+	NOTFOUND            StandardResponseCode = "NOT_FOUND"
+	SUCCESS             StandardResponseCode = "SUCCESS"
+	UNAUTHORIZED        StandardResponseCode = "UNAUTHORIZED"
+)
+
+// Defines values for UpdateReportRequestStatus.
+const (
+	UpdateReportRequestStatusDismissed UpdateReportRequestStatus = "dismissed"
+	UpdateReportRequestStatusResolved  UpdateReportRequestStatus = "resolved"
+)
+
+// Defines values for GetApiAdminAccountsParamsRole.
+const (
+	Admin     GetApiAdminAccountsParamsRole = "admin"
+	Moderator GetApiAdminAccountsParamsRole = "moderator"
+	User      GetApiAdminAccountsParamsRole = "user"
+)
+
+// Defines values for GetApiAdminAccountsParamsStatus.
+const (
+	Active      GetApiAdminAccountsParamsStatus = "active"
+	Deactivated GetApiAdminAccountsParamsStatus = "deactivated"
+)
+
+// Defines values for GetApiAdminReportsParamsStatus.
+const (
+	GetApiAdminReportsParamsStatusDismissed GetApiAdminReportsParamsStatus = "dismissed"
+	GetApiAdminReportsParamsStatusOpen      GetApiAdminReportsParamsStatus = "open"
+	GetApiAdminReportsParamsStatusResolved  GetApiAdminReportsParamsStatus = "resolved"
+)
+
+// Defines values for GetApiAdminReportsParamsTargetType.
+const (
+	GetApiAdminReportsParamsTargetTypeAccount GetApiAdminReportsParamsTargetType = "account"
+	GetApiAdminReportsParamsTargetTypeComment GetApiAdminReportsParamsTargetType = "comment"
+	GetApiAdminReportsParamsTargetTypePost    GetApiAdminReportsParamsTargetType = "post"
+)
+
+// CreateReportRequest defines model for CreateReportRequest.
+type CreateReportRequest struct {
+	Reason     string                        `json:"reason"`
+	TargetId   int64                         `json:"target_id"`
+	TargetType CreateReportRequestTargetType `json:"target_type"`
+}
+
+// CreateReportRequestTargetType defines model for CreateReportRequest.TargetType.
+type CreateReportRequestTargetType string
+
+// StandardResponse defines model for StandardResponse.
+type StandardResponse struct {
+	Code *StandardResponseCode `json:"code,omitempty"`
+
+	// Data Response data (varies by endpoint)
+	Data       *map[string]interface{} `json:"data,omitempty"`
+	Errors     *[]string               `json:"errors,omitempty"`
+	Message    *string                 `json:"message,omitempty"`
+	RequestId  *string                 `json:"requestId,omitempty"`
+	ServerTime *time.Time              `json:"serverTime,omitempty"`
+}
+
+// StandardResponseCode defines model for StandardResponse.Code.
+type StandardResponseCode string
+
+// UpdateReportRequest defines model for UpdateReportRequest.
+type UpdateReportRequest struct {
+	Status UpdateReportRequestStatus `json:"status"`
+}
+
+// UpdateReportRequestStatus defines model for UpdateReportRequest.Status.
+type UpdateReportRequestStatus string
+
+// GetApiAdminAccountsParams defines parameters for GetApiAdminAccounts.
+type GetApiAdminAccountsParams struct {
+	// Q Case-insensitive match on name or email
+	Q      *string                          `form:"q,omitempty" json:"q,omitempty"`
+	Role   *GetApiAdminAccountsParamsRole   `form:"role,omitempty" json:"role,omitempty"`
+	Status *GetApiAdminAccountsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Cursor Cursor for pagination
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Number of items to return (max 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetApiAdminAccountsParamsRole defines parameters for GetApiAdminAccounts.
+type GetApiAdminAccountsParamsRole string
+
+// GetApiAdminAccountsParamsStatus defines parameters for GetApiAdminAccounts.
+type GetApiAdminAccountsParamsStatus string
+
+// GetApiAdminReportsParams defines parameters for GetApiAdminReports.
+type GetApiAdminReportsParams struct {
+	Status     *GetApiAdminReportsParamsStatus     `form:"status,omitempty" json:"status,omitempty"`
+	TargetType *GetApiAdminReportsParamsTargetType `form:"target_type,omitempty" json:"target_type,omitempty"`
+
+	// Cursor Cursor for pagination
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Number of items to return (max 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetApiAdminReportsParamsStatus defines parameters for GetApiAdminReports.
+type GetApiAdminReportsParamsStatus string
+
+// GetApiAdminReportsParamsTargetType defines parameters for GetApiAdminReports.
+type GetApiAdminReportsParamsTargetType string
+
+// PutApiAdminReportsIdJSONRequestBody defines body for PutApiAdminReportsId for application/json ContentType.
+type PutApiAdminReportsIdJSONRequestBody = UpdateReportRequest
+
+// PostApiReportsJSONRequestBody defines body for PostApiReports for application/json ContentType.
+type PostApiReportsJSONRequestBody = CreateReportRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetApiAdminAccounts request
+	GetApiAdminAccounts(ctx context.Context, params *GetApiAdminAccountsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteApiAdminCommentsId request
+	DeleteApiAdminCommentsId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteApiAdminPostsId request
+	DeleteApiAdminPostsId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiAdminReports request
+	GetApiAdminReports(ctx context.Context, params *GetApiAdminReportsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutApiAdminReportsIdWithBody request with any body
+	PutApiAdminReportsIdWithBody(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutApiAdminReportsId(ctx context.Context, id int64, body PutApiAdminReportsIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiAdminStats request
+	GetApiAdminStats(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiReportsWithBody request with any body
+	PostApiReportsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiReports(ctx context.Context, body PostApiReportsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetApiAdminAccounts(ctx context.Context, params *GetApiAdminAccountsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiAdminAccountsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteApiAdminCommentsId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteApiAdminCommentsIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteApiAdminPostsId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteApiAdminPostsIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetApiAdminReports(ctx context.Context, params *GetApiAdminReportsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiAdminReportsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutApiAdminReportsIdWithBody(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutApiAdminReportsIdRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutApiAdminReportsId(ctx context.Context, id int64, body PutApiAdminReportsIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutApiAdminReportsIdRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetApiAdminStats(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiAdminStatsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiReportsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiReportsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiReports(ctx context.Context, body PostApiReportsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiReportsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetApiAdminAccountsRequest generates requests for GetApiAdminAccounts
+func NewGetApiAdminAccountsRequest(server string, params *GetApiAdminAccountsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/accounts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Q != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "q", runtime.ParamLocationQuery, *params.Q); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Role != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "role", runtime.ParamLocationQuery, *params.Role); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteApiAdminCommentsIdRequest generates requests for DeleteApiAdminCommentsId
+func NewDeleteApiAdminCommentsIdRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/comments/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteApiAdminPostsIdRequest generates requests for DeleteApiAdminPostsId
+func NewDeleteApiAdminPostsIdRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/posts/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiAdminReportsRequest generates requests for GetApiAdminReports
+func NewGetApiAdminReportsRequest(server string, params *GetApiAdminReportsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/reports")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TargetType != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "target_type", runtime.ParamLocationQuery, *params.TargetType); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutApiAdminReportsIdRequest calls the generic PutApiAdminReportsId builder with application/json body
+func NewPutApiAdminReportsIdRequest(server string, id int64, body PutApiAdminReportsIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutApiAdminReportsIdRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPutApiAdminReportsIdRequestWithBody generates requests for PutApiAdminReportsId with any type of body
+func NewPutApiAdminReportsIdRequestWithBody(server string, id int64, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/reports/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetApiAdminStatsRequest generates requests for GetApiAdminStats
+func NewGetApiAdminStatsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/stats")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostApiReportsRequest calls the generic PostApiReports builder with application/json body
+func NewPostApiReportsRequest(server string, body PostApiReportsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiReportsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostApiReportsRequestWithBody generates requests for PostApiReports with any type of body
+func NewPostApiReportsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/reports")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetApiAdminAccountsWithResponse request
+	GetApiAdminAccountsWithResponse(ctx context.Context, params *GetApiAdminAccountsParams, reqEditors ...RequestEditorFn) (*GetApiAdminAccountsResponse, error)
+
+	// DeleteApiAdminCommentsIdWithResponse request
+	DeleteApiAdminCommentsIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteApiAdminCommentsIdResponse, error)
+
+	// DeleteApiAdminPostsIdWithResponse request
+	DeleteApiAdminPostsIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteApiAdminPostsIdResponse, error)
+
+	// GetApiAdminReportsWithResponse request
+	GetApiAdminReportsWithResponse(ctx context.Context, params *GetApiAdminReportsParams, reqEditors ...RequestEditorFn) (*GetApiAdminReportsResponse, error)
+
+	// PutApiAdminReportsIdWithBodyWithResponse request with any body
+	PutApiAdminReportsIdWithBodyWithResponse(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutApiAdminReportsIdResponse, error)
+
+	PutApiAdminReportsIdWithResponse(ctx context.Context, id int64, body PutApiAdminReportsIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutApiAdminReportsIdResponse, error)
+
+	// GetApiAdminStatsWithResponse request
+	GetApiAdminStatsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiAdminStatsResponse, error)
+
+	// PostApiReportsWithBodyWithResponse request with any body
+	PostApiReportsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiReportsResponse, error)
+
+	PostApiReportsWithResponse(ctx context.Context, body PostApiReportsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiReportsResponse, error)
+}
+
+type GetApiAdminAccountsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON403      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiAdminAccountsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiAdminAccountsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteApiAdminCommentsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON403      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteApiAdminCommentsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteApiAdminCommentsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteApiAdminPostsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON403      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteApiAdminPostsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteApiAdminPostsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiAdminReportsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON400      *StandardResponse
+	JSON401      *StandardResponse
+	JSON403      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiAdminReportsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiAdminReportsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutApiAdminReportsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON400      *StandardResponse
+	JSON401      *StandardResponse
+	JSON403      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutApiAdminReportsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutApiAdminReportsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiAdminStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON403      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiAdminStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiAdminStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiReportsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *StandardResponse
+	JSON400      *StandardResponse
+	JSON401      *StandardResponse
+	JSON404      *StandardResponse
+	JSON409      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiReportsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiReportsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetApiAdminAccountsWithResponse request returning *GetApiAdminAccountsResponse
+func (c *ClientWithResponses) GetApiAdminAccountsWithResponse(ctx context.Context, params *GetApiAdminAccountsParams, reqEditors ...RequestEditorFn) (*GetApiAdminAccountsResponse, error) {
+	rsp, err := c.GetApiAdminAccounts(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiAdminAccountsResponse(rsp)
+}
+
+// DeleteApiAdminCommentsIdWithResponse request returning *DeleteApiAdminCommentsIdResponse
+func (c *ClientWithResponses) DeleteApiAdminCommentsIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteApiAdminCommentsIdResponse, error) {
+	rsp, err := c.DeleteApiAdminCommentsId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteApiAdminCommentsIdResponse(rsp)
+}
+
+// DeleteApiAdminPostsIdWithResponse request returning *DeleteApiAdminPostsIdResponse
+func (c *ClientWithResponses) DeleteApiAdminPostsIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteApiAdminPostsIdResponse, error) {
+	rsp, err := c.DeleteApiAdminPostsId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteApiAdminPostsIdResponse(rsp)
+}
+
+// GetApiAdminReportsWithResponse request returning *GetApiAdminReportsResponse
+func (c *ClientWithResponses) GetApiAdminReportsWithResponse(ctx context.Context, params *GetApiAdminReportsParams, reqEditors ...RequestEditorFn) (*GetApiAdminReportsResponse, error) {
+	rsp, err := c.GetApiAdminReports(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiAdminReportsResponse(rsp)
+}
+
+// PutApiAdminReportsIdWithBodyWithResponse request with arbitrary body returning *PutApiAdminReportsIdResponse
+func (c *ClientWithResponses) PutApiAdminReportsIdWithBodyWithResponse(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutApiAdminReportsIdResponse, error) {
+	rsp, err := c.PutApiAdminReportsIdWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutApiAdminReportsIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutApiAdminReportsIdWithResponse(ctx context.Context, id int64, body PutApiAdminReportsIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutApiAdminReportsIdResponse, error) {
+	rsp, err := c.PutApiAdminReportsId(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutApiAdminReportsIdResponse(rsp)
+}
+
+// GetApiAdminStatsWithResponse request returning *GetApiAdminStatsResponse
+func (c *ClientWithResponses) GetApiAdminStatsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetApiAdminStatsResponse, error) {
+	rsp, err := c.GetApiAdminStats(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiAdminStatsResponse(rsp)
+}
+
+// PostApiReportsWithBodyWithResponse request with arbitrary body returning *PostApiReportsResponse
+func (c *ClientWithResponses) PostApiReportsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiReportsResponse, error) {
+	rsp, err := c.PostApiReportsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiReportsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiReportsWithResponse(ctx context.Context, body PostApiReportsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiReportsResponse, error) {
+	rsp, err := c.PostApiReports(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiReportsResponse(rsp)
+}
+
+// ParseGetApiAdminAccountsResponse parses an HTTP response from a GetApiAdminAccountsWithResponse call
+func ParseGetApiAdminAccountsResponse(rsp *http.Response) (*GetApiAdminAccountsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiAdminAccountsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteApiAdminCommentsIdResponse parses an HTTP response from a DeleteApiAdminCommentsIdWithResponse call
+func ParseDeleteApiAdminCommentsIdResponse(rsp *http.Response) (*DeleteApiAdminCommentsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteApiAdminCommentsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteApiAdminPostsIdResponse parses an HTTP response from a DeleteApiAdminPostsIdWithResponse call
+func ParseDeleteApiAdminPostsIdResponse(rsp *http.Response) (*DeleteApiAdminPostsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteApiAdminPostsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiAdminReportsResponse parses an HTTP response from a GetApiAdminReportsWithResponse call
+func ParseGetApiAdminReportsResponse(rsp *http.Response) (*GetApiAdminReportsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiAdminReportsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutApiAdminReportsIdResponse parses an HTTP response from a PutApiAdminReportsIdWithResponse call
+func ParsePutApiAdminReportsIdResponse(rsp *http.Response) (*PutApiAdminReportsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutApiAdminReportsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiAdminStatsResponse parses an HTTP response from a GetApiAdminStatsWithResponse call
+func ParseGetApiAdminStatsResponse(rsp *http.Response) (*GetApiAdminStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiAdminStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiReportsResponse parses an HTTP response from a PostApiReportsWithResponse call
+func ParsePostApiReportsResponse(rsp *http.Response) (*PostApiReportsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiReportsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/client/account"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Tokens are the credentials of a signed-in session
+type Tokens struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	Expiry       time.Time `json:"expiry"` // When the access token expires
+}
+
+// auth signs requests in and refreshes the access token when the API rejects it. It is
+// the HTTP doer of the generated clients.
+type auth struct {
+	next      *http.Client
+	refresher *account.ClientWithResponses
+	apiKey    string
+	onRefresh func(Tokens)
+
+	mu        sync.Mutex // Guards tokens
+	tokens    Tokens
+	refreshMu sync.Mutex
+}
+
+// Do sends the request with the current credentials. A 401 is retried once with a
+// refreshed access token when there is a refresh token and the body can be replayed.
+func (a *auth) Do(req *http.Request) (*http.Response, error) {
+	used := a.sign(req)
+	resp, err := a.next.Do(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || used == "" {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+	if strings.HasSuffix(req.URL.Path, "/api/account/token/refresh") {
+		return resp, nil
+	}
+
+	if refreshErr := a.refresh(req.Context(), used); refreshErr != nil {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	retry.Header.Del("Authorization")
+	a.sign(retry)
+	return a.next.Do(retry)
+}
+
+// sign sets the API key or access token header and returns the access token it used. An
+// Authorization header set by a request editor is left alone.
+func (a *auth) sign(req *http.Request) string {
+	if a.apiKey != "" {
+		req.Header.Set("X-API-Key", a.apiKey)
+		return ""
+	}
+
+	a.mu.Lock()
+	token := a.tokens.AccessToken
+	a.mu.Unlock()
+	if token == "" || req.Header.Get("Authorization") != "" {
+		return ""
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return token
+}
+
+// refresh exchanges the refresh token for new tokens, unless a concurrent request already
+// replaced the rejected access token. Refreshes run one at a time since each refresh
+// token can only be used once.
+func (a *auth) refresh(ctx context.Context, rejected string) error {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+
+	a.mu.Lock()
+	current := a.tokens
+	a.mu.Unlock()
+	if current.AccessToken != rejected {
+		return nil
+	}
+	if current.RefreshToken == "" {
+		return errors.New("no refresh token")
+	}
+
+	resp, err := a.refresher.PostApiAccountTokenRefreshWithResponse(ctx, account.RefreshTokenRequest{RefreshToken: current.RefreshToken})
+	if err != nil {
+		return err
+	}
+	login, err := Decode[account.LoginResponse](resp.StatusCode(), resp.Body)
+	if err != nil {
+		return err
+	}
+	a.set(login)
+	return nil
+}
+
+// set stores the tokens of a login or refresh response and passes them to the hook
+func (a *auth) set(login account.LoginResponse) {
+	tokens := Tokens{}
+	if login.AccessToken != nil {
+		tokens.AccessToken = *login.AccessToken
+	}
+	if login.RefreshToken != nil {
+		tokens.RefreshToken = *login.RefreshToken
+	}
+	if login.ExpiresIn != nil {
+		tokens.Expiry = time.Now().Add(time.Duration(*login.ExpiresIn) * time.Second)
+	}
+
+	a.mu.Lock()
+	a.tokens = tokens
+	a.mu.Unlock()
+
+	if a.onRefresh != nil {
+		a.onRefresh(tokens)
+	}
+}
+
+// Login signs in with an email and password; later requests use the returned tokens and
+// refresh them when they expire
+func (c *Client) Login(ctx context.Context, email string, password string, rememberMe bool) (*account.LoginResponse, error) {
+	resp, err := c.Account.PostApiAccountLoginWithResponse(ctx, account.LoginRequest{
+		Email:      openapi_types.Email(email),
+		Password:   password,
+		RememberMe: &rememberMe,
+	})
+	if err != nil {
+		return nil, err
+	}
+	login, err := Decode[account.LoginResponse](resp.StatusCode(), resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.auth.set(login)
+	return &login, nil
+}
+
+// Logout revokes the session's tokens and forgets them
+func (c *Client) Logout(ctx context.Context) error {
+	tokens := c.Tokens()
+	body := account.LogoutRequest{}
+	if tokens.RefreshToken != "" {
+		body.RefreshToken = &tokens.RefreshToken
+	}
+
+	resp, err := c.Account.PostApiAccountLogoutWithResponse(ctx, body)
+	if err != nil {
+		return err
+	}
+	if _, err := Decode[any](resp.StatusCode(), resp.Body); err != nil {
+		return err
+	}
+	c.SetTokens(Tokens{})
+	return nil
+}
+
+// Tokens returns the current session tokens, e.g. to save them for WithTokens
+func (c *Client) Tokens() Tokens {
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+	return c.auth.tokens
+}
+
+// SetTokens replaces the session tokens
+func (c *Client) SetTokens(tokens Tokens) {
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+	c.auth.tokens = tokens
+}
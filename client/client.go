@@ -0,0 +1,154 @@
+// Package client calls the social media service API from other Go services. The clients
+// of each API in the subpackages (client/post, client/comment, ...) are generated from the
+// specs in api/http by scripts/client.sh; this package ties them together with access
+// token handling, response decoding and iteration over paginated lists.
+//
+//	c, err := client.New("https://api.example.com")
+//	if _, err := c.Login(ctx, "me@example.com", "secret", true); err != nil { ... }
+//	for p, err := range c.Posts(ctx, nil) { ... }
+package client
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/client/account"
+	"github.com/fanzru/social-media-service-go/client/activity"
+	"github.com/fanzru/social-media-service-go/client/admin"
+	"github.com/fanzru/social-media-service-go/client/audit"
+	"github.com/fanzru/social-media-service-go/client/comment"
+	"github.com/fanzru/social-media-service-go/client/export"
+	"github.com/fanzru/social-media-service-go/client/health"
+	"github.com/fanzru/social-media-service-go/client/message"
+	"github.com/fanzru/social-media-service-go/client/post"
+	"github.com/fanzru/social-media-service-go/client/search"
+	"github.com/fanzru/social-media-service-go/client/shortlink"
+	"github.com/fanzru/social-media-service-go/client/story"
+	"github.com/fanzru/social-media-service-go/client/suggestion"
+)
+
+// defaultTimeout bounds requests made with the default HTTP client
+const defaultTimeout = 30 * time.Second
+
+// Client holds a generated client per API, all sharing the same credentials
+type Client struct {
+	Account    *account.ClientWithResponses
+	Activity   *activity.ClientWithResponses
+	Admin      *admin.ClientWithResponses
+	Audit      *audit.ClientWithResponses
+	Comment    *comment.ClientWithResponses
+	Export     *export.ClientWithResponses
+	Health     *health.ClientWithResponses
+	Message    *message.ClientWithResponses
+	Post       *post.ClientWithResponses
+	Search     *search.ClientWithResponses
+	ShortLink  *shortlink.ClientWithResponses
+	Story      *story.ClientWithResponses
+	Suggestion *suggestion.ClientWithResponses
+
+	auth *auth
+}
+
+// Option configures a Client
+type Option func(*options)
+
+type options struct {
+	httpClient *http.Client
+	tokens     Tokens
+	apiKey     string
+	onRefresh  func(Tokens)
+}
+
+// WithHTTPClient sends requests with c instead of a client with a 30 second timeout
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = c
+	}
+}
+
+// WithTokens signs requests in with tokens saved from an earlier Login
+func WithTokens(tokens Tokens) Option {
+	return func(o *options) {
+		o.tokens = tokens
+	}
+}
+
+// WithAPIKey signs requests in with an API key instead of access tokens
+func WithAPIKey(key string) Option {
+	return func(o *options) {
+		o.apiKey = key
+	}
+}
+
+// WithTokenRefreshHook calls fn with the new tokens after every login and refresh, e.g. to
+// save them; refresh tokens can only be used once
+func WithTokenRefreshHook(fn func(Tokens)) Option {
+	return func(o *options) {
+		o.onRefresh = fn
+	}
+}
+
+// New creates a client of the API served at server, e.g. "http://localhost:8080"
+func New(server string, opts ...Option) (*Client, error) {
+	o := options{httpClient: &http.Client{Timeout: defaultTimeout}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	server = strings.TrimRight(server, "/")
+
+	// Refreshing goes around the auth doer so a failed refresh is not retried
+	refresher, err := account.NewClientWithResponses(server, account.WithHTTPClient(o.httpClient))
+	if err != nil {
+		return nil, err
+	}
+	a := &auth{
+		next:      o.httpClient,
+		refresher: refresher,
+		tokens:    o.tokens,
+		apiKey:    o.apiKey,
+		onRefresh: o.onRefresh,
+	}
+
+	c := &Client{auth: a}
+	if c.Account, err = account.NewClientWithResponses(server, account.WithHTTPClient(a)); err != nil {
+		return nil, err
+	}
+	if c.Activity, err = activity.NewClientWithResponses(server, activity.WithHTTPClient(a)); err != nil {
+		return nil, err
+	}
+	if c.Admin, err = admin.NewClientWithResponses(server, admin.WithHTTPClient(a)); err != nil {
+		return nil, err
+	}
+	if c.Audit, err = audit.NewClientWithResponses(server, audit.WithHTTPClient(a)); err != nil {
+		return nil, err
+	}
+	if c.Comment, err = comment.NewClientWithResponses(server, comment.WithHTTPClient(a)); err != nil {
+		return nil, err
+	}
+	if c.Export, err = export.NewClientWithResponses(server, export.WithHTTPClient(a)); err != nil {
+		return nil, err
+	}
+	if c.Health, err = health.NewClientWithResponses(server, health.WithHTTPClient(a)); err != nil {
+		return nil, err
+	}
+	if c.Message, err = message.NewClientWithResponses(server, message.WithHTTPClient(a)); err != nil {
+		return nil, err
+	}
+	if c.Post, err = post.NewClientWithResponses(server, post.WithHTTPClient(a)); err != nil {
+		return nil, err
+	}
+	if c.Search, err = search.NewClientWithResponses(server, search.WithHTTPClient(a)); err != nil {
+		return nil, err
+	}
+	if c.ShortLink, err = shortlink.NewClientWithResponses(server, shortlink.WithHTTPClient(a)); err != nil {
+		return nil, err
+	}
+	if c.Story, err = story.NewClientWithResponses(server, story.WithHTTPClient(a)); err != nil {
+		return nil, err
+	}
+	if c.Suggestion, err = suggestion.NewClientWithResponses(server, suggestion.WithHTTPClient(a)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,1663 @@
+// Package comment provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package comment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for StandardResponseCode.
+const (
+	BADREQUEST          StandardResponseCode = "BAD_REQUEST"
+	CONFLICT            StandardResponseCode = "CONFLICT"
+	FAILED              StandardResponseCode = "FAILED"
+	FORBIDDEN           StandardResponseCode = "FORBIDDEN"
+	INTERNALSERVERERROR StandardResponseCode = "INTERNAL_SERVER_ERROR"
+	NOTFOUND            StandardResponseCode = "NOT_FOUND"
+	SUCCESS             StandardResponseCode = "SUCCESS"
+	UNAUTHORIZED        StandardResponseCode = "UNAUTHORIZED"
+)
+
+// Defines values for GetApiCommentsByPostPostIdParamsSort.
+const (
+	Newest GetApiCommentsByPostPostIdParamsSort = "newest"
+	Oldest GetApiCommentsByPostPostIdParamsSort = "oldest"
+	Top    GetApiCommentsByPostPostIdParamsSort = "top"
+)
+
+// Comment defines model for Comment.
+type Comment struct {
+	Content   *string    `json:"content,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	CreatorId *int64     `json:"creator_id,omitempty"`
+
+	// CreatorIsVerified Whether the creator has a verified badge
+	CreatorIsVerified *bool      `json:"creator_is_verified,omitempty"`
+	CreatorName       *string    `json:"creator_name,omitempty"`
+	DeletedAt         *time.Time `json:"deleted_at"`
+	Id                *int64     `json:"id,omitempty"`
+	LikeCount         *int64     `json:"like_count,omitempty"`
+	PostId            *int64     `json:"post_id,omitempty"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+// CommentListResponse defines model for CommentListResponse.
+type CommentListResponse struct {
+	Comments *[]Comment `json:"comments,omitempty"`
+
+	// Cursor Cursor for next page
+	Cursor *string `json:"cursor"`
+
+	// HasMore Whether there are more comments
+	HasMore    *bool             `json:"has_more,omitempty"`
+	Pagination *CursorPagination `json:"pagination,omitempty"`
+}
+
+// CreateCommentRequest defines model for CreateCommentRequest.
+type CreateCommentRequest struct {
+	Content string `json:"content"`
+}
+
+// CursorPagination defines model for CursorPagination.
+type CursorPagination struct {
+	// HasMore Whether there are more comments
+	HasMore *bool `json:"has_more,omitempty"`
+
+	// Limit Most comments returned per page
+	Limit *int `json:"limit,omitempty"`
+
+	// NextCursor Cursor for next page, same as cursor
+	NextCursor *string `json:"next_cursor"`
+
+	// TotalCount Number of comments in the whole listing, only with include_total=true
+	TotalCount *int64 `json:"total_count"`
+}
+
+// StandardResponse defines model for StandardResponse.
+type StandardResponse struct {
+	Code *StandardResponseCode `json:"code,omitempty"`
+
+	// Data Response data (varies by endpoint)
+	Data       *map[string]interface{} `json:"data,omitempty"`
+	Errors     *[]string               `json:"errors,omitempty"`
+	Message    *string                 `json:"message,omitempty"`
+	RequestId  *string                 `json:"requestId,omitempty"`
+	ServerTime *time.Time              `json:"serverTime,omitempty"`
+}
+
+// StandardResponseCode defines model for StandardResponse.Code.
+type StandardResponseCode string
+
+// UpdateCommentRequest defines model for UpdateCommentRequest.
+type UpdateCommentRequest struct {
+	Content string `json:"content"`
+}
+
+// GetApiCommentsByPostPostIdParams defines parameters for GetApiCommentsByPostPostId.
+type GetApiCommentsByPostPostIdParams struct {
+	// Sort Sort order: newest first (default), oldest first, or top (most liked first)
+	Sort *GetApiCommentsByPostPostIdParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Fields Comma-separated comment fields to return, e.g. content,creator_name; id is always included
+	Fields *[]string `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Page Page number for offset pagination; with page or per_page the response has pagination totals instead of a cursor
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// PerPage Items per page for offset pagination (max 100)
+	PerPage *int `form:"per_page,omitempty" json:"per_page,omitempty"`
+
+	// Cursor Opaque signed cursor from the previous page's next_cursor. Must come from a previous response with the same sort mode.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Number of comments to return (max 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// IncludeTotal Also count all comments in the listing as pagination.total_count, at the cost of an extra query
+	IncludeTotal *bool `form:"include_total,omitempty" json:"include_total,omitempty"`
+}
+
+// GetApiCommentsByPostPostIdParamsSort defines parameters for GetApiCommentsByPostPostId.
+type GetApiCommentsByPostPostIdParamsSort string
+
+// GetApiCommentsUserUserIdParams defines parameters for GetApiCommentsUserUserId.
+type GetApiCommentsUserUserIdParams struct {
+	// Fields Comma-separated comment fields to return, e.g. content,creator_name; id is always included
+	Fields *[]string `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Page Page number for offset pagination; with page or per_page the response has pagination totals instead of a cursor
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// PerPage Items per page for offset pagination (max 100)
+	PerPage *int `form:"per_page,omitempty" json:"per_page,omitempty"`
+
+	// Cursor Opaque signed cursor from the previous page's next_cursor
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Number of comments to return (max 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// IncludeTotal Also count all comments in the listing as pagination.total_count, at the cost of an extra query
+	IncludeTotal *bool `form:"include_total,omitempty" json:"include_total,omitempty"`
+}
+
+// PostApiCommentsByPostPostIdJSONRequestBody defines body for PostApiCommentsByPostPostId for application/json ContentType.
+type PostApiCommentsByPostPostIdJSONRequestBody = CreateCommentRequest
+
+// PutApiCommentsIdJSONRequestBody defines body for PutApiCommentsId for application/json ContentType.
+type PutApiCommentsIdJSONRequestBody = UpdateCommentRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetApiCommentsByPostPostId request
+	GetApiCommentsByPostPostId(ctx context.Context, postId int64, params *GetApiCommentsByPostPostIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiCommentsByPostPostIdWithBody request with any body
+	PostApiCommentsByPostPostIdWithBody(ctx context.Context, postId int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostApiCommentsByPostPostId(ctx context.Context, postId int64, body PostApiCommentsByPostPostIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiCommentsUserUserId request
+	GetApiCommentsUserUserId(ctx context.Context, userId int64, params *GetApiCommentsUserUserIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteApiCommentsId request
+	DeleteApiCommentsId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetApiCommentsId request
+	GetApiCommentsId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutApiCommentsIdWithBody request with any body
+	PutApiCommentsIdWithBody(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutApiCommentsId(ctx context.Context, id int64, body PutApiCommentsIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteApiCommentsIdLike request
+	DeleteApiCommentsIdLike(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostApiCommentsIdLike request
+	PostApiCommentsIdLike(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetApiCommentsByPostPostId(ctx context.Context, postId int64, params *GetApiCommentsByPostPostIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiCommentsByPostPostIdRequest(c.Server, postId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiCommentsByPostPostIdWithBody(ctx context.Context, postId int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiCommentsByPostPostIdRequestWithBody(c.Server, postId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiCommentsByPostPostId(ctx context.Context, postId int64, body PostApiCommentsByPostPostIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiCommentsByPostPostIdRequest(c.Server, postId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetApiCommentsUserUserId(ctx context.Context, userId int64, params *GetApiCommentsUserUserIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiCommentsUserUserIdRequest(c.Server, userId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteApiCommentsId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteApiCommentsIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetApiCommentsId(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetApiCommentsIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutApiCommentsIdWithBody(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutApiCommentsIdRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutApiCommentsId(ctx context.Context, id int64, body PutApiCommentsIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutApiCommentsIdRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteApiCommentsIdLike(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteApiCommentsIdLikeRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostApiCommentsIdLike(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostApiCommentsIdLikeRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetApiCommentsByPostPostIdRequest generates requests for GetApiCommentsByPostPostId
+func NewGetApiCommentsByPostPostIdRequest(server string, postId int64, params *GetApiCommentsByPostPostIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "postId", runtime.ParamLocationPath, postId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/comments/by-post/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Fields != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", false, "fields", runtime.ParamLocationQuery, *params.Fields); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Page != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page", runtime.ParamLocationQuery, *params.Page); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PerPage != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "per_page", runtime.ParamLocationQuery, *params.PerPage); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.IncludeTotal != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "include_total", runtime.ParamLocationQuery, *params.IncludeTotal); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostApiCommentsByPostPostIdRequest calls the generic PostApiCommentsByPostPostId builder with application/json body
+func NewPostApiCommentsByPostPostIdRequest(server string, postId int64, body PostApiCommentsByPostPostIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostApiCommentsByPostPostIdRequestWithBody(server, postId, "application/json", bodyReader)
+}
+
+// NewPostApiCommentsByPostPostIdRequestWithBody generates requests for PostApiCommentsByPostPostId with any type of body
+func NewPostApiCommentsByPostPostIdRequestWithBody(server string, postId int64, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "postId", runtime.ParamLocationPath, postId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/comments/by-post/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetApiCommentsUserUserIdRequest generates requests for GetApiCommentsUserUserId
+func NewGetApiCommentsUserUserIdRequest(server string, userId int64, params *GetApiCommentsUserUserIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/comments/user/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Fields != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", false, "fields", runtime.ParamLocationQuery, *params.Fields); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Page != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page", runtime.ParamLocationQuery, *params.Page); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PerPage != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "per_page", runtime.ParamLocationQuery, *params.PerPage); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.IncludeTotal != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "include_total", runtime.ParamLocationQuery, *params.IncludeTotal); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteApiCommentsIdRequest generates requests for DeleteApiCommentsId
+func NewDeleteApiCommentsIdRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/comments/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetApiCommentsIdRequest generates requests for GetApiCommentsId
+func NewGetApiCommentsIdRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/comments/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutApiCommentsIdRequest calls the generic PutApiCommentsId builder with application/json body
+func NewPutApiCommentsIdRequest(server string, id int64, body PutApiCommentsIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutApiCommentsIdRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPutApiCommentsIdRequestWithBody generates requests for PutApiCommentsId with any type of body
+func NewPutApiCommentsIdRequestWithBody(server string, id int64, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/comments/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteApiCommentsIdLikeRequest generates requests for DeleteApiCommentsIdLike
+func NewDeleteApiCommentsIdLikeRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/comments/%s/like", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostApiCommentsIdLikeRequest generates requests for PostApiCommentsIdLike
+func NewPostApiCommentsIdLikeRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/comments/%s/like", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetApiCommentsByPostPostIdWithResponse request
+	GetApiCommentsByPostPostIdWithResponse(ctx context.Context, postId int64, params *GetApiCommentsByPostPostIdParams, reqEditors ...RequestEditorFn) (*GetApiCommentsByPostPostIdResponse, error)
+
+	// PostApiCommentsByPostPostIdWithBodyWithResponse request with any body
+	PostApiCommentsByPostPostIdWithBodyWithResponse(ctx context.Context, postId int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiCommentsByPostPostIdResponse, error)
+
+	PostApiCommentsByPostPostIdWithResponse(ctx context.Context, postId int64, body PostApiCommentsByPostPostIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiCommentsByPostPostIdResponse, error)
+
+	// GetApiCommentsUserUserIdWithResponse request
+	GetApiCommentsUserUserIdWithResponse(ctx context.Context, userId int64, params *GetApiCommentsUserUserIdParams, reqEditors ...RequestEditorFn) (*GetApiCommentsUserUserIdResponse, error)
+
+	// DeleteApiCommentsIdWithResponse request
+	DeleteApiCommentsIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteApiCommentsIdResponse, error)
+
+	// GetApiCommentsIdWithResponse request
+	GetApiCommentsIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetApiCommentsIdResponse, error)
+
+	// PutApiCommentsIdWithBodyWithResponse request with any body
+	PutApiCommentsIdWithBodyWithResponse(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutApiCommentsIdResponse, error)
+
+	PutApiCommentsIdWithResponse(ctx context.Context, id int64, body PutApiCommentsIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutApiCommentsIdResponse, error)
+
+	// DeleteApiCommentsIdLikeWithResponse request
+	DeleteApiCommentsIdLikeWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteApiCommentsIdLikeResponse, error)
+
+	// PostApiCommentsIdLikeWithResponse request
+	PostApiCommentsIdLikeWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*PostApiCommentsIdLikeResponse, error)
+}
+
+type GetApiCommentsByPostPostIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON400      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiCommentsByPostPostIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiCommentsByPostPostIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiCommentsByPostPostIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *StandardResponse
+	JSON400      *StandardResponse
+	JSON401      *StandardResponse
+	JSON403      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiCommentsByPostPostIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiCommentsByPostPostIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiCommentsUserUserIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiCommentsUserUserIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiCommentsUserUserIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteApiCommentsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON403      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteApiCommentsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteApiCommentsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetApiCommentsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetApiCommentsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetApiCommentsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutApiCommentsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON400      *StandardResponse
+	JSON401      *StandardResponse
+	JSON403      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PutApiCommentsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutApiCommentsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteApiCommentsIdLikeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteApiCommentsIdLikeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteApiCommentsIdLikeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostApiCommentsIdLikeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StandardResponse
+	JSON401      *StandardResponse
+	JSON404      *StandardResponse
+	JSON500      *StandardResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostApiCommentsIdLikeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostApiCommentsIdLikeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetApiCommentsByPostPostIdWithResponse request returning *GetApiCommentsByPostPostIdResponse
+func (c *ClientWithResponses) GetApiCommentsByPostPostIdWithResponse(ctx context.Context, postId int64, params *GetApiCommentsByPostPostIdParams, reqEditors ...RequestEditorFn) (*GetApiCommentsByPostPostIdResponse, error) {
+	rsp, err := c.GetApiCommentsByPostPostId(ctx, postId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiCommentsByPostPostIdResponse(rsp)
+}
+
+// PostApiCommentsByPostPostIdWithBodyWithResponse request with arbitrary body returning *PostApiCommentsByPostPostIdResponse
+func (c *ClientWithResponses) PostApiCommentsByPostPostIdWithBodyWithResponse(ctx context.Context, postId int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostApiCommentsByPostPostIdResponse, error) {
+	rsp, err := c.PostApiCommentsByPostPostIdWithBody(ctx, postId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiCommentsByPostPostIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostApiCommentsByPostPostIdWithResponse(ctx context.Context, postId int64, body PostApiCommentsByPostPostIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PostApiCommentsByPostPostIdResponse, error) {
+	rsp, err := c.PostApiCommentsByPostPostId(ctx, postId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiCommentsByPostPostIdResponse(rsp)
+}
+
+// GetApiCommentsUserUserIdWithResponse request returning *GetApiCommentsUserUserIdResponse
+func (c *ClientWithResponses) GetApiCommentsUserUserIdWithResponse(ctx context.Context, userId int64, params *GetApiCommentsUserUserIdParams, reqEditors ...RequestEditorFn) (*GetApiCommentsUserUserIdResponse, error) {
+	rsp, err := c.GetApiCommentsUserUserId(ctx, userId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiCommentsUserUserIdResponse(rsp)
+}
+
+// DeleteApiCommentsIdWithResponse request returning *DeleteApiCommentsIdResponse
+func (c *ClientWithResponses) DeleteApiCommentsIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteApiCommentsIdResponse, error) {
+	rsp, err := c.DeleteApiCommentsId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteApiCommentsIdResponse(rsp)
+}
+
+// GetApiCommentsIdWithResponse request returning *GetApiCommentsIdResponse
+func (c *ClientWithResponses) GetApiCommentsIdWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetApiCommentsIdResponse, error) {
+	rsp, err := c.GetApiCommentsId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetApiCommentsIdResponse(rsp)
+}
+
+// PutApiCommentsIdWithBodyWithResponse request with arbitrary body returning *PutApiCommentsIdResponse
+func (c *ClientWithResponses) PutApiCommentsIdWithBodyWithResponse(ctx context.Context, id int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutApiCommentsIdResponse, error) {
+	rsp, err := c.PutApiCommentsIdWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutApiCommentsIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutApiCommentsIdWithResponse(ctx context.Context, id int64, body PutApiCommentsIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutApiCommentsIdResponse, error) {
+	rsp, err := c.PutApiCommentsId(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutApiCommentsIdResponse(rsp)
+}
+
+// DeleteApiCommentsIdLikeWithResponse request returning *DeleteApiCommentsIdLikeResponse
+func (c *ClientWithResponses) DeleteApiCommentsIdLikeWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*DeleteApiCommentsIdLikeResponse, error) {
+	rsp, err := c.DeleteApiCommentsIdLike(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteApiCommentsIdLikeResponse(rsp)
+}
+
+// PostApiCommentsIdLikeWithResponse request returning *PostApiCommentsIdLikeResponse
+func (c *ClientWithResponses) PostApiCommentsIdLikeWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*PostApiCommentsIdLikeResponse, error) {
+	rsp, err := c.PostApiCommentsIdLike(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostApiCommentsIdLikeResponse(rsp)
+}
+
+// ParseGetApiCommentsByPostPostIdResponse parses an HTTP response from a GetApiCommentsByPostPostIdWithResponse call
+func ParseGetApiCommentsByPostPostIdResponse(rsp *http.Response) (*GetApiCommentsByPostPostIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiCommentsByPostPostIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiCommentsByPostPostIdResponse parses an HTTP response from a PostApiCommentsByPostPostIdWithResponse call
+func ParsePostApiCommentsByPostPostIdResponse(rsp *http.Response) (*PostApiCommentsByPostPostIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiCommentsByPostPostIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiCommentsUserUserIdResponse parses an HTTP response from a GetApiCommentsUserUserIdWithResponse call
+func ParseGetApiCommentsUserUserIdResponse(rsp *http.Response) (*GetApiCommentsUserUserIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiCommentsUserUserIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteApiCommentsIdResponse parses an HTTP response from a DeleteApiCommentsIdWithResponse call
+func ParseDeleteApiCommentsIdResponse(rsp *http.Response) (*DeleteApiCommentsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteApiCommentsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetApiCommentsIdResponse parses an HTTP response from a GetApiCommentsIdWithResponse call
+func ParseGetApiCommentsIdResponse(rsp *http.Response) (*GetApiCommentsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetApiCommentsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutApiCommentsIdResponse parses an HTTP response from a PutApiCommentsIdWithResponse call
+func ParsePutApiCommentsIdResponse(rsp *http.Response) (*PutApiCommentsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutApiCommentsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteApiCommentsIdLikeResponse parses an HTTP response from a DeleteApiCommentsIdLikeWithResponse call
+func ParseDeleteApiCommentsIdLikeResponse(rsp *http.Response) (*DeleteApiCommentsIdLikeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteApiCommentsIdLikeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostApiCommentsIdLikeResponse parses an HTTP response from a PostApiCommentsIdLikeWithResponse call
+func ParsePostApiCommentsIdLikeResponse(rsp *http.Response) (*PostApiCommentsIdLikeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostApiCommentsIdLikeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest StandardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"iter"
+
+	"github.com/fanzru/social-media-service-go/client/comment"
+	"github.com/fanzru/social-media-service-go/client/post"
+)
+
+// page fetches one page of a list; a nil cursor asks for the first page
+type page[T any] func(ctx context.Context, cursor *string) (items []T, next *string, hasMore bool, err error)
+
+// paginate yields the items of every page in turn, fetching the next page when the
+// previous one is used up. Iteration stops at the first error, which is yielded.
+func paginate[T any](ctx context.Context, fetch page[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var cursor *string
+		for {
+			items, next, hasMore, err := fetch(ctx, cursor)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !hasMore || next == nil || *next == "" {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
+// listPage reads the items, cursor and has_more of a decoded list response
+func listPage[T any](items *[]T, cursor *string, hasMore *bool) ([]T, *string, bool) {
+	var list []T
+	if items != nil {
+		list = *items
+	}
+	return list, cursor, hasMore != nil && *hasMore
+}
+
+// Posts iterates over the post list in the order and with the filters of params, which
+// may be nil. Page, PerPage and Cursor are ignored; pages are followed by cursor.
+func (c *Client) Posts(ctx context.Context, params *post.GetApiPostsParams) iter.Seq2[post.Post, error] {
+	p := post.GetApiPostsParams{}
+	if params != nil {
+		p = *params
+	}
+	p.Page, p.PerPage = nil, nil
+
+	return paginate(ctx, func(ctx context.Context, cursor *string) ([]post.Post, *string, bool, error) {
+		p.Cursor = cursor
+		resp, err := c.Post.GetApiPostsWithResponse(ctx, &p)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		list, err := Decode[post.PostListResponse](resp.StatusCode(), resp.Body)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		items, next, hasMore := listPage(list.Posts, list.Cursor, list.HasMore)
+		return items, next, hasMore, nil
+	})
+}
+
+// UserPosts iterates over the posts of an account, the pinned post first
+func (c *Client) UserPosts(ctx context.Context, userID int64, params *post.GetApiPostsByUserUserIdParams) iter.Seq2[post.Post, error] {
+	p := post.GetApiPostsByUserUserIdParams{}
+	if params != nil {
+		p = *params
+	}
+	p.Page, p.PerPage = nil, nil
+
+	return paginate(ctx, func(ctx context.Context, cursor *string) ([]post.Post, *string, bool, error) {
+		p.Cursor = cursor
+		resp, err := c.Post.GetApiPostsByUserUserIdWithResponse(ctx, userID, &p)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		list, err := Decode[post.PostListResponse](resp.StatusCode(), resp.Body)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		items, next, hasMore := listPage(list.Posts, list.Cursor, list.HasMore)
+		return items, next, hasMore, nil
+	})
+}
+
+// PostComments iterates over the comments of a post in the sort order of params
+func (c *Client) PostComments(ctx context.Context, postID int64, params *comment.GetApiCommentsByPostPostIdParams) iter.Seq2[comment.Comment, error] {
+	p := comment.GetApiCommentsByPostPostIdParams{}
+	if params != nil {
+		p = *params
+	}
+	p.Page, p.PerPage = nil, nil
+
+	return paginate(ctx, func(ctx context.Context, cursor *string) ([]comment.Comment, *string, bool, error) {
+		p.Cursor = cursor
+		resp, err := c.Comment.GetApiCommentsByPostPostIdWithResponse(ctx, postID, &p)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		list, err := Decode[comment.CommentListResponse](resp.StatusCode(), resp.Body)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		items, next, hasMore := listPage(list.Comments, list.Cursor, list.HasMore)
+		return items, next, hasMore, nil
+	})
+}
+
+// UserComments iterates over the comments an account wrote
+func (c *Client) UserComments(ctx context.Context, userID int64, params *comment.GetApiCommentsUserUserIdParams) iter.Seq2[comment.Comment, error] {
+	p := comment.GetApiCommentsUserUserIdParams{}
+	if params != nil {
+		p = *params
+	}
+	p.Page, p.PerPage = nil, nil
+
+	return paginate(ctx, func(ctx context.Context, cursor *string) ([]comment.Comment, *string, bool, error) {
+		p.Cursor = cursor
+		resp, err := c.Comment.GetApiCommentsUserUserIdWithResponse(ctx, userID, &p)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		list, err := Decode[comment.CommentListResponse](resp.StatusCode(), resp.Body)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		items, next, hasMore := listPage(list.Comments, list.Cursor, list.HasMore)
+		return items, next, hasMore, nil
+	})
+}
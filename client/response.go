@@ -0,0 +1,79 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// envelope is the standard response every endpoint wraps its data in. Detail is set
+// instead of Message when the server sends errors as RFC 7807 problem details.
+type envelope struct {
+	Code      string          `json:"code"`
+	Message   string          `json:"message"`
+	Detail    string          `json:"detail"`
+	Data      json.RawMessage `json:"data"`
+	Errors    []string        `json:"errors"`
+	RequestID string          `json:"requestId"`
+}
+
+// Error is a response with a status code other than 2xx
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Errors     []string
+	RequestID  string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("api error %d", e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if len(e.Errors) > 0 {
+		msg += " (" + strings.Join(e.Errors, "; ") + ")"
+	}
+	if e.RequestID != "" {
+		msg += " [request " + e.RequestID + "]"
+	}
+	return msg
+}
+
+// Decode returns the data of a response as T, e.g. post.PostListResponse. Responses
+// with a status code other than 2xx give an *Error.
+//
+//	resp, err := c.Post.GetApiPostsIdWithResponse(ctx, id)
+//	p, err := client.Decode[post.Post](resp.StatusCode(), resp.Body)
+func Decode[T any](statusCode int, body []byte) (T, error) {
+	var data T
+	var env envelope
+	if err := json.Unmarshal(body, &env); err != nil && statusCode/100 == 2 {
+		return data, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if statusCode/100 != 2 {
+		e := &Error{
+			StatusCode: statusCode,
+			Code:       env.Code,
+			Message:    env.Message,
+			Errors:     env.Errors,
+			RequestID:  env.RequestID,
+		}
+		if e.Message == "" {
+			e.Message = env.Detail
+		}
+		if e.Message == "" {
+			e.Message = http.StatusText(statusCode)
+		}
+		return data, e
+	}
+
+	if len(env.Data) > 0 && string(env.Data) != "null" {
+		if err := json.Unmarshal(env.Data, &data); err != nil {
+			return data, fmt.Errorf("failed to decode response data: %w", err)
+		}
+	}
+	return data, nil
+}
@@ -0,0 +1,213 @@
+// Command import bulk loads posts and comments migrated from another platform. It reads
+// newline-delimited JSON, one post with its comments per line, and inserts them in
+// batches with COPY:
+//
+//	{"id": "src-1", "caption": "Hello", "image_url": "https://...", "creator_id": 7,
+//	 "visibility": "public", "created_at": "2023-05-01T10:00:00Z",
+//	 "comments": [{"content": "Nice", "creator_id": 9, "created_at": "2023-05-01T11:00:00Z"}]}
+//
+// Creators are accounts of this service; posts and comments of unknown accounts are
+// skipped and counted.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/infrastructure/config"
+	"github.com/fanzru/social-media-service-go/internal/app/post"
+	postRepo "github.com/fanzru/social-media-service-go/internal/app/post/repo"
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
+)
+
+// maxLineBytes is the longest input line accepted
+const maxLineBytes = 16 << 20
+
+// importedPost is one line of the input
+type importedPost struct {
+	ID         string            `json:"id"`
+	Caption    string            `json:"caption"`
+	ImageURL   string            `json:"image_url"`
+	CreatorID  int64             `json:"creator_id"`
+	Visibility string            `json:"visibility"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Comments   []importedComment `json:"comments"`
+}
+
+// importedComment is a comment of an input post
+type importedComment struct {
+	Content   string    `json:"content"`
+	CreatorID int64     `json:"creator_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func main() {
+	file := flag.String("file", "-", "newline-delimited JSON file to import, - for standard input")
+	batchSize := flag.Int("batch", 5000, "posts inserted per transaction")
+	flag.Parse()
+
+	logger.InitFromEnv()
+	log := logger.GetGlobal()
+	cfg := config.Load()
+
+	var input io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			log.Error("Failed to open import file", "error", err.Error())
+			os.Exit(1)
+		}
+		defer f.Close()
+		input = f
+	}
+
+	dbConnStr := os.Getenv("DATABASE_URL")
+	if dbConnStr == "" {
+		dbConnStr = fmt.Sprintf("postgresql://%s:%s@%s:%d/%s?sslmode=%s",
+			cfg.Database.User,
+			cfg.Database.Password,
+			cfg.Database.Host,
+			cfg.Database.Port,
+			cfg.Database.DBName,
+			cfg.Database.SSLMode,
+		)
+	}
+	db, err := sqlwrap.Open(dbConnStr)
+	if err != nil {
+		log.Error("Failed to open database", "error", err.Error())
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	repository := postRepo.NewRepository(db)
+	start := time.Now()
+	total, err := importAll(context.Background(), repository, input, *batchSize, log)
+	if err != nil {
+		log.Error("Import failed", "error", err.Error(), "posts", total.Posts, "comments", total.Comments)
+		os.Exit(1)
+	}
+
+	log.Info("Import completed",
+		"posts", total.Posts,
+		"comments", total.Comments,
+		"skippedPosts", total.SkippedPosts,
+		"skippedComments", total.SkippedComments,
+		"duration", time.Since(start).String(),
+	)
+}
+
+// importAll reads the input and imports it in batches of batchSize posts, returning what
+// the batches committed so far, also when one fails
+func importAll(ctx context.Context, repository post.PostRepository, input io.Reader, batchSize int, log *logger.Logger) (post.ImportResult, error) {
+	if batchSize <= 0 {
+		batchSize = 5000
+	}
+
+	var total post.ImportResult
+	var posts []post.ImportPost
+	var comments []post.ImportComment
+	flush := func() error {
+		if len(posts) == 0 {
+			return nil
+		}
+		result, err := repository.Import(ctx, posts, comments)
+		if err != nil {
+			return err
+		}
+		total.Posts += result.Posts
+		total.Comments += result.Comments
+		total.SkippedPosts += result.SkippedPosts
+		total.SkippedComments += result.SkippedComments
+		log.Info("Batch imported", "posts", result.Posts, "comments", result.Comments)
+
+		posts, comments = posts[:0], comments[:0]
+		return nil
+	}
+
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var p importedPost
+		if err := json.Unmarshal(scanner.Bytes(), &p); err != nil {
+			return total, fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := validate(&p); err != nil {
+			return total, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		posts = append(posts, post.ImportPost{
+			ExternalID: p.ID,
+			Caption:    p.Caption,
+			ImageURL:   p.ImageURL,
+			CreatorID:  p.CreatorID,
+			Visibility: p.Visibility,
+			CreatedAt:  p.CreatedAt,
+		})
+		for _, c := range p.Comments {
+			comments = append(comments, post.ImportComment{
+				PostExternalID: p.ID,
+				Content:        c.Content,
+				CreatorID:      c.CreatorID,
+				CreatedAt:      c.CreatedAt,
+			})
+		}
+
+		if len(posts) >= batchSize {
+			if err := flush(); err != nil {
+				return total, fmt.Errorf("batch ending at line %d: %w", line, err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return total, err
+	}
+
+	if err := flush(); err != nil {
+		return total, fmt.Errorf("last batch: %w", err)
+	}
+	return total, nil
+}
+
+// validate checks an input post and fills in defaults: public visibility, and the post's
+// time for comments without one
+func validate(p *importedPost) error {
+	if p.ID == "" {
+		return fmt.Errorf("post id is required")
+	}
+	if p.Caption == "" {
+		return fmt.Errorf("post %s: caption is required", p.ID)
+	}
+	if p.CreatedAt.IsZero() {
+		return fmt.Errorf("post %s: created_at is required", p.ID)
+	}
+	if p.Visibility == "" {
+		p.Visibility = post.VisibilityPublic
+	}
+	if !post.IsValidVisibility(p.Visibility) {
+		return fmt.Errorf("post %s: invalid visibility %q", p.ID, p.Visibility)
+	}
+
+	for i := range p.Comments {
+		c := &p.Comments[i]
+		if c.Content == "" {
+			return fmt.Errorf("post %s: comment %d: content is required", p.ID, i+1)
+		}
+		if c.CreatedAt.IsZero() {
+			c.CreatedAt = p.CreatedAt
+		}
+	}
+	return nil
+}
@@ -1,37 +1,106 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"expvar"
 	"fmt"
+	"net"
 	"net/http"
+	"net/http/pprof"
 	"os"
+	"runtime"
 	"time"
 
+	"github.com/fanzru/social-media-service-go/docs"
 	"github.com/fanzru/social-media-service-go/infrastructure/config"
 	accountApp "github.com/fanzru/social-media-service-go/internal/app/account/app"
 	accountHTTP "github.com/fanzru/social-media-service-go/internal/app/account/port"
+	accountGenGRPC "github.com/fanzru/social-media-service-go/internal/app/account/port/gengrpc"
 	"github.com/fanzru/social-media-service-go/internal/app/account/port/genhttp"
 	"github.com/fanzru/social-media-service-go/internal/app/account/repo"
+	activityApp "github.com/fanzru/social-media-service-go/internal/app/activity/app"
+	activityHTTP "github.com/fanzru/social-media-service-go/internal/app/activity/port"
+	activityGenHTTP "github.com/fanzru/social-media-service-go/internal/app/activity/port/genhttp"
+	activityRepo "github.com/fanzru/social-media-service-go/internal/app/activity/repo"
+	adminApp "github.com/fanzru/social-media-service-go/internal/app/admin/app"
+	adminHTTP "github.com/fanzru/social-media-service-go/internal/app/admin/port"
+	adminGenHTTP "github.com/fanzru/social-media-service-go/internal/app/admin/port/genhttp"
+	adminRepo "github.com/fanzru/social-media-service-go/internal/app/admin/repo"
+	auditApp "github.com/fanzru/social-media-service-go/internal/app/audit/app"
+	auditHTTP "github.com/fanzru/social-media-service-go/internal/app/audit/port"
+	auditGenHTTP "github.com/fanzru/social-media-service-go/internal/app/audit/port/genhttp"
+	auditRepo "github.com/fanzru/social-media-service-go/internal/app/audit/repo"
 	commentApp "github.com/fanzru/social-media-service-go/internal/app/comment/app"
 	commentHTTP "github.com/fanzru/social-media-service-go/internal/app/comment/port"
+	commentGenGRPC "github.com/fanzru/social-media-service-go/internal/app/comment/port/gengrpc"
 	commentGenHTTP "github.com/fanzru/social-media-service-go/internal/app/comment/port/genhttp"
 	commentRepo "github.com/fanzru/social-media-service-go/internal/app/comment/repo"
+	exportApp "github.com/fanzru/social-media-service-go/internal/app/export/app"
+	exportHTTP "github.com/fanzru/social-media-service-go/internal/app/export/port"
+	exportGenHTTP "github.com/fanzru/social-media-service-go/internal/app/export/port/genhttp"
+	exportRepo "github.com/fanzru/social-media-service-go/internal/app/export/repo"
+	feedApp "github.com/fanzru/social-media-service-go/internal/app/feed/app"
+	feedHTTP "github.com/fanzru/social-media-service-go/internal/app/feed/port"
+	graphqlApp "github.com/fanzru/social-media-service-go/internal/app/graphql/app"
+	graphqlHTTP "github.com/fanzru/social-media-service-go/internal/app/graphql/port"
 	healthApp "github.com/fanzru/social-media-service-go/internal/app/health/app"
 	healthHTTP "github.com/fanzru/social-media-service-go/internal/app/health/port"
 	healthGenHTTP "github.com/fanzru/social-media-service-go/internal/app/health/port/genhttp"
 	healthRepo "github.com/fanzru/social-media-service-go/internal/app/health/repo"
+	idempotencyApp "github.com/fanzru/social-media-service-go/internal/app/idempotency/app"
+	idempotencyRepo "github.com/fanzru/social-media-service-go/internal/app/idempotency/repo"
+	messageApp "github.com/fanzru/social-media-service-go/internal/app/message/app"
+	messageHTTP "github.com/fanzru/social-media-service-go/internal/app/message/port"
+	messageGenHTTP "github.com/fanzru/social-media-service-go/internal/app/message/port/genhttp"
+	messageRepo "github.com/fanzru/social-media-service-go/internal/app/message/repo"
 	postApp "github.com/fanzru/social-media-service-go/internal/app/post/app"
 	postHTTP "github.com/fanzru/social-media-service-go/internal/app/post/port"
+	postGenGRPC "github.com/fanzru/social-media-service-go/internal/app/post/port/gengrpc"
 	postGenHTTP "github.com/fanzru/social-media-service-go/internal/app/post/port/genhttp"
 	postRepo "github.com/fanzru/social-media-service-go/internal/app/post/repo"
+	relationshipApp "github.com/fanzru/social-media-service-go/internal/app/relationship/app"
+	relationshipHTTP "github.com/fanzru/social-media-service-go/internal/app/relationship/port"
+	relationshipGenHTTP "github.com/fanzru/social-media-service-go/internal/app/relationship/port/genhttp"
+	relationshipRepo "github.com/fanzru/social-media-service-go/internal/app/relationship/repo"
+	searchApp "github.com/fanzru/social-media-service-go/internal/app/search/app"
+	searchHTTP "github.com/fanzru/social-media-service-go/internal/app/search/port"
+	searchGenHTTP "github.com/fanzru/social-media-service-go/internal/app/search/port/genhttp"
+	searchRepo "github.com/fanzru/social-media-service-go/internal/app/search/repo"
+	shortlinkApp "github.com/fanzru/social-media-service-go/internal/app/shortlink/app"
+	shortlinkHTTP "github.com/fanzru/social-media-service-go/internal/app/shortlink/port"
+	shortlinkGenHTTP "github.com/fanzru/social-media-service-go/internal/app/shortlink/port/genhttp"
+	shortlinkRepo "github.com/fanzru/social-media-service-go/internal/app/shortlink/repo"
+	storyApp "github.com/fanzru/social-media-service-go/internal/app/story/app"
+	storyHTTP "github.com/fanzru/social-media-service-go/internal/app/story/port"
+	storyGenHTTP "github.com/fanzru/social-media-service-go/internal/app/story/port/genhttp"
+	storyRepo "github.com/fanzru/social-media-service-go/internal/app/story/repo"
+	suggestionApp "github.com/fanzru/social-media-service-go/internal/app/suggestion/app"
+	suggestionHTTP "github.com/fanzru/social-media-service-go/internal/app/suggestion/port"
+	suggestionGenHTTP "github.com/fanzru/social-media-service-go/internal/app/suggestion/port/genhttp"
+	suggestionRepo "github.com/fanzru/social-media-service-go/internal/app/suggestion/repo"
+	"github.com/fanzru/social-media-service-go/pkg/cache"
+	"github.com/fanzru/social-media-service-go/pkg/eventbus"
 	"github.com/fanzru/social-media-service-go/pkg/influxdb"
 	"github.com/fanzru/social-media-service-go/pkg/jwt"
 	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/mailer"
+	"github.com/fanzru/social-media-service-go/pkg/metrics"
 	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"github.com/fanzru/social-media-service-go/pkg/oauth"
+	"github.com/fanzru/social-media-service-go/pkg/pagination"
+	"github.com/fanzru/social-media-service-go/pkg/password"
+	"github.com/fanzru/social-media-service-go/pkg/prometheus"
+	"github.com/fanzru/social-media-service-go/pkg/realtime"
 	"github.com/fanzru/social-media-service-go/pkg/reqctx"
+	"github.com/fanzru/social-media-service-go/pkg/response"
 	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
+	"github.com/fanzru/social-media-service-go/pkg/statsd"
 	"github.com/fanzru/social-media-service-go/pkg/storage"
-	_ "github.com/lib/pq"
+	"golang.org/x/net/netutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
@@ -43,6 +112,20 @@ func main() {
 	cfg := config.Load()
 	log.Info("Configuration loaded", "serverPort", cfg.Server.Port, "dbHost", cfg.Database.Host)
 
+	// Choose how error responses are rendered
+	if err := response.SetErrorFormat(cfg.Response.ErrorFormat, cfg.Response.ProblemTypeBaseURL); err != nil {
+		log.Error("Invalid response configuration", "error", err.Error())
+		os.Exit(1)
+	}
+	if err := response.SetJSONEncoding(cfg.Response.JSONEncoding); err != nil {
+		log.Error("Invalid response configuration", "error", err.Error())
+		os.Exit(1)
+	}
+	if cfg.Response.CursorSecret == "" {
+		log.Warn("CURSOR_SECRET is not set; pagination cursors are signed with a random key, so they stop working on restart and are rejected by other instances")
+	}
+	pagination.SetCursorSecret(cfg.Response.CursorSecret)
+
 	// Build database connection string
 	dbConnStr := os.Getenv("DATABASE_URL")
 	if dbConnStr == "" {
@@ -58,11 +141,16 @@ func main() {
 	}
 
 	// Initialize database
-	db, err := sql.Open("postgres", dbConnStr)
+	db, err := sqlwrap.Open(dbConnStr)
 	if err != nil {
 		log.Error("Failed to open database", "error", err.Error())
 		os.Exit(1)
 	}
+	db.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.Database.ConnMaxIdleTime)
+	log.Info("Database connection pool configured", "maxOpenConns", cfg.Database.MaxOpenConns, "maxIdleConns", cfg.Database.MaxIdleConns, "connMaxLifetime", cfg.Database.ConnMaxLifetime.String(), "connMaxIdleTime", cfg.Database.ConnMaxIdleTime.String())
 
 	// Test the connection (skip for now)
 	// if err := db.Ping(); err != nil {
@@ -73,29 +161,83 @@ func main() {
 	log.Info("PostgreSQL database connected successfully (ping skipped)", "host", cfg.Database.Host, "port", cfg.Database.Port, "database", cfg.Database.DBName)
 	defer db.Close()
 
-	// Initialize InfluxDB client
-	influxHost := os.Getenv("INFLUXDB_HOST")
-	if influxHost == "" {
-		influxHost = "http://localhost:8086" // Default for local development
+	// Initialize metrics sinks
+	var metricsSinks []metrics.Sink
+	var metricsBackend healthRepo.MetricsBackend
+	if cfg.InfluxDB.Enabled {
+		// Metrics are not needed to serve requests, so the server starts without them
+		influxClient, err := influxdb.NewClient(cfg.InfluxDB.Host, cfg.InfluxDB.Token, cfg.InfluxDB.Org, cfg.InfluxDB.Bucket, influxdb.Options{
+			BatchSize:     uint(cfg.InfluxDB.BatchSize),
+			FlushInterval: cfg.InfluxDB.FlushInterval,
+			BufferSize:    cfg.InfluxDB.BufferSize,
+			RetryInterval: cfg.InfluxDB.RetryInterval,
+		})
+		if err != nil {
+			log.Warn("Failed to initialize InfluxDB client, metrics are not sent to InfluxDB", "error", err.Error())
+		} else {
+			defer influxClient.Close()
+			metricsSinks = append(metricsSinks, influxClient)
+			metricsBackend = influxClient
+			log.Info("InfluxDB client initialized", "host", cfg.InfluxDB.Host, "org", cfg.InfluxDB.Org, "bucket", cfg.InfluxDB.Bucket, "batchSize", cfg.InfluxDB.BatchSize, "flushInterval", cfg.InfluxDB.FlushInterval.String(), "bufferSize", cfg.InfluxDB.BufferSize)
+		}
 	}
-	influxClient, err := influxdb.NewClient(influxHost, "my-super-secret-auth-token", "social-media", "metrics")
-	if err != nil {
-		log.Error("Failed to initialize InfluxDB client", "error", err.Error())
-		os.Exit(1)
+	if cfg.StatsD.Enabled {
+		statsdClient, err := statsd.NewClient(fmt.Sprintf("%s:%d", cfg.StatsD.Host, cfg.StatsD.Port), cfg.StatsD.Prefix, cfg.StatsD.Sampling)
+		if err != nil {
+			log.Error("Failed to initialize StatsD client", "error", err.Error())
+			os.Exit(1)
+		}
+		defer statsdClient.Close()
+		metricsSinks = append(metricsSinks, statsdClient)
+		log.Info("StatsD client initialized", "host", cfg.StatsD.Host, "port", cfg.StatsD.Port, "sampling", cfg.StatsD.Sampling)
 	}
-	defer influxClient.Close()
-	log.Info("InfluxDB client initialized")
+
+	// Prometheus metrics are pulled from an endpoint, registered only when it is served
+	var promMetrics *prometheus.Metrics
+	if cfg.Prometheus.Enabled {
+		promMetrics = prometheus.NewMetrics()
+		metricsSinks = append(metricsSinks, prometheus.NewSink(cfg.Prometheus.Namespace))
+		log.Info("Prometheus metrics initialized", "path", cfg.Prometheus.Path, "namespace", cfg.Prometheus.Namespace, "basicAuth", cfg.Prometheus.Username != "")
+	}
+	metricsSink := metrics.Multi(metricsSinks...)
 
 	// Wrap database with metrics and logging
-	var dbInterface interface{} = db
+	var wrappedDB *sqlwrap.DB
 	if cfg.Database.LogQueries {
-		dbInterface = sqlwrap.NewDBWithInfluxDB(db, influxClient)
+		wrappedDB = sqlwrap.NewDBWithMetrics(db, metricsSink)
 		log.Info("Database query logging enabled", "slowQueryThreshold", cfg.Database.SlowQueryThreshold)
+	} else {
+		// Still log the queries of requests traced with X-Debug-Queries
+		wrappedDB = sqlwrap.NewQuietDB(db)
+	}
+	if promMetrics != nil {
+		wrappedDB.Observe(promMetrics.RecordDBQuery)
+	}
+	if cfg.Database.LogSlowQueries {
+		wrappedDB.SlowQueries(time.Duration(cfg.Database.SlowQueryThreshold) * time.Millisecond)
 	}
+	wrappedDB.TagRequestID(cfg.Database.MetricsRequestID)
+	go wrappedDB.ReportPoolStats(context.Background(), metricsSink, cfg.Database.PoolMetricsInterval)
+	var dbInterface interface{} = wrappedDB
 
 	// Initialize JWT service
-	jwtService := jwt.NewService(cfg.JWT.Secret, time.Duration(cfg.JWT.Expiration)*time.Hour)
-	log.Info("JWT service initialized")
+	signingKey, previousKeys, err := jwt.KeysFromConfig(&cfg.JWT)
+	if err != nil {
+		log.Error("Invalid JWT key configuration", "error", err.Error())
+		os.Exit(1)
+	}
+	jwtService, err := jwt.NewService(signingKey, previousKeys, jwt.Options{
+		ExpiresIn:   time.Duration(cfg.JWT.Expiration) * time.Hour,
+		GracePeriod: cfg.JWT.KeyGracePeriod,
+		Issuer:      cfg.JWT.Issuer,
+		Audience:    cfg.JWT.Audience,
+		Leeway:      cfg.JWT.Leeway,
+	})
+	if err != nil {
+		log.Error("Failed to initialize JWT service", "error", err.Error())
+		os.Exit(1)
+	}
+	log.Info("JWT service initialized", "algorithm", cfg.JWT.Algorithm, "keyId", cfg.JWT.KeyID, "previousKeys", len(previousKeys))
 
 	// Initialize account repository and service
 	accountRepository := repo.NewRepository(dbInterface)
@@ -105,7 +247,52 @@ func main() {
 	imageStorage := storage.NewImageStorageService(&cfg.Storage)
 	log.Info("Image storage service initialized")
 
-	accountService := accountApp.NewService(accountRepository, jwtService, imageStorage)
+	// Initialize mailer
+	mailService := mailer.NewMailer(&cfg.Mail)
+	log.Info("Mailer initialized", "smtpHost", cfg.Mail.SMTPHost)
+
+	// Initialize OAuth providers
+	oauthProviders := oauth.NewRegistry(&cfg.OAuth)
+	log.Info("OAuth providers initialized", "count", len(oauthProviders))
+
+	passwordPolicy := password.NewPolicy(&cfg.Password)
+	log.Info("Password policy initialized", "minLength", cfg.Password.MinLength, "checkBreached", cfg.Password.CheckBreached)
+
+	passwordHasher, err := password.NewHasher(&cfg.Password)
+	if err != nil {
+		log.Error("Failed to initialize password hasher", "error", err.Error())
+		os.Exit(1)
+	}
+	log.Info("Password hasher initialized", "algorithm", cfg.Password.HashAlgorithm)
+
+	// Services publish their changes on the bus; the cache invalidator drops cached data a
+	// change makes stale before the write returns
+	changeBus := eventbus.New()
+	cacheStore := cache.NewMemory()
+	changeBus.Subscribe(cache.Invalidator(cacheStore, feedApp.CacheKeys))
+	accountCache := cache.NewLRU(cfg.Account.CacheSize)
+	changeBus.Subscribe(cache.Invalidator(accountCache, accountApp.CacheKeys))
+	responseCache := cache.NewLRU(cfg.Response.CacheSize)
+	changeBus.Subscribe(cache.Invalidator(responseCache, middleware.ResponseCacheKeys))
+	log.Info("Event bus initialized")
+
+	accountService := accountApp.NewService(accountRepository, jwtService, imageStorage, mailService, oauthProviders, passwordPolicy, passwordHasher, changeBus, accountCache, metricsSink, accountApp.Config{
+		RefreshTTL:      cfg.JWT.RefreshExpiration,
+		ShortRefreshTTL: cfg.JWT.ShortRefreshExpiration,
+		EmailChangeTTL:  cfg.Mail.EmailChangeTTL,
+		EmailConfirmURL: cfg.Mail.EmailConfirmURL,
+		APIKeyRateLimit: cfg.APIKey.DefaultRateLimit,
+		APIKeyMaxLimit:  cfg.APIKey.MaxRateLimit,
+
+		MaxLoginAttempts:      cfg.Login.MaxAttempts,
+		MaxLoginAttemptsPerIP: cfg.Login.MaxAttemptsPerIP,
+		LoginAttemptWindow:    cfg.Login.AttemptWindow,
+		LockoutBase:           cfg.Login.LockoutBase,
+		LockoutMax:            cfg.Login.LockoutMax,
+		NewDeviceAlerts:       cfg.Login.NewDeviceAlerts,
+
+		CacheTTL: cfg.Account.CacheTTL,
+	})
 	log.Info("Account service initialized")
 
 	accountHandler := accountHTTP.NewHandler(accountService)
@@ -119,21 +306,161 @@ func main() {
 	commentRepository := commentRepo.NewRepository(dbInterface)
 	log.Info("Comment repository initialized")
 
-	postService := postApp.NewService(postRepository, commentRepository, imageStorage)
+	// Initialize the real-time hub; clients may only follow posts they are allowed to see
+	realtimeHub := realtime.NewHub(func(ctx context.Context, postID, viewerID int64) (bool, error) {
+		_, err := postRepository.GetByID(ctx, postID, viewerID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+	log.Info("Real-time hub initialized")
+
+	postService := postApp.NewService(postRepository, commentRepository, imageStorage, cfg.Moderation.ModeratorIDs, cfg.Timeline.FanoutMaxFollowers, realtimeHub, changeBus, metricsSink)
 	log.Info("Post service initialized")
 
 	postHandler := postHTTP.NewHandler(postService)
 	log.Info("Post HTTP handler initialized")
 
+	// Start background workers that resize the images of new posts
+	for i := 0; i < cfg.Storage.ImageWorkers; i++ {
+		go postService.RunImageWorker(context.Background(), cfg.Storage.ImagePollInterval)
+	}
+	log.Info("Post image workers started", "workers", cfg.Storage.ImageWorkers, "interval", cfg.Storage.ImagePollInterval.String())
+
+	// Start background workers that write new posts to followers' home timelines
+	for i := 0; i < cfg.Timeline.FanoutWorkers; i++ {
+		go postService.RunFanoutWorker(context.Background(), cfg.Timeline.PollInterval)
+	}
+	log.Info("Timeline fan-out workers started", "workers", cfg.Timeline.FanoutWorkers, "interval", cfg.Timeline.PollInterval.String())
+
 	// Initialize comment service
-	commentService := commentApp.NewService(commentRepository, postRepository)
+	commentService := commentApp.NewService(commentRepository, postRepository, realtimeHub, changeBus, metricsSink)
 	log.Info("Comment service initialized")
 
 	commentHandler := commentHTTP.NewHandler(commentService)
 	log.Info("Comment HTTP handler initialized")
 
+	// Initialize message repository and service
+	messageRepository := messageRepo.NewRepository(dbInterface)
+	log.Info("Message repository initialized")
+
+	messageService := messageApp.NewService(messageRepository, realtimeHub)
+	log.Info("Message service initialized")
+
+	messageHandler := messageHTTP.NewHandler(messageService)
+	log.Info("Message HTTP handler initialized")
+
+	// Initialize story repository and service
+	storyRepository := storyRepo.NewRepository(dbInterface)
+	log.Info("Story repository initialized")
+
+	storyService := storyApp.NewService(storyRepository, imageStorage, cfg.Story.TTL)
+	log.Info("Story service initialized")
+
+	storyHandler := storyHTTP.NewHandler(storyService)
+	log.Info("Story HTTP handler initialized")
+
+	// Start background sweep that expires stories and removes their images
+	go storyService.RunExpirySweeper(context.Background(), cfg.Story.SweepInterval)
+	log.Info("Story expiry sweeper started", "interval", cfg.Story.SweepInterval.String())
+
+	// Initialize search repository and service
+	searchRepository := searchRepo.NewRepository(dbInterface)
+	log.Info("Search repository initialized")
+
+	searchService := searchApp.NewService(searchRepository)
+	log.Info("Search service initialized")
+
+	searchHandler := searchHTTP.NewHandler(searchService)
+	log.Info("Search HTTP handler initialized")
+
+	// Initialize suggestion repository and service
+	suggestionRepository := suggestionRepo.NewRepository(dbInterface)
+	log.Info("Suggestion repository initialized")
+
+	suggestionService := suggestionApp.NewService(suggestionRepository)
+	log.Info("Suggestion service initialized")
+
+	suggestionHandler := suggestionHTTP.NewHandler(suggestionService)
+	log.Info("Suggestion HTTP handler initialized")
+
+	// Initialize relationship repository and service
+	relationshipRepository := relationshipRepo.NewRepository(dbInterface)
+	log.Info("Relationship repository initialized")
+
+	relationshipService := relationshipApp.NewService(relationshipRepository)
+	log.Info("Relationship service initialized")
+
+	relationshipHandler := relationshipHTTP.NewHandler(relationshipService)
+	log.Info("Relationship HTTP handler initialized")
+
+	// Initialize short link repository and service
+	shortlinkRepository := shortlinkRepo.NewRepository(dbInterface)
+	log.Info("Short link repository initialized")
+
+	shortlinkService := shortlinkApp.NewService(shortlinkRepository, postRepository, cfg.ShortLink.BaseURL, cfg.ShortLink.PostTarget)
+	log.Info("Short link service initialized")
+
+	shortlinkHandler := shortlinkHTTP.NewHandler(shortlinkService)
+	log.Info("Short link HTTP handler initialized")
+
+	// Initialize activity repository and service
+	activityRepository := activityRepo.NewRepository(dbInterface)
+	log.Info("Activity repository initialized")
+
+	activityService := activityApp.NewService(activityRepository)
+	log.Info("Activity service initialized")
+
+	activityHandler := activityHTTP.NewHandler(activityService)
+	log.Info("Activity HTTP handler initialized")
+
+	// Initialize admin repository and service
+	adminRepository := adminRepo.NewRepository(dbInterface)
+	log.Info("Admin repository initialized")
+
+	adminService := adminApp.NewService(adminRepository, imageStorage)
+	log.Info("Admin service initialized")
+
+	adminHandler := adminHTTP.NewHandler(adminService)
+	log.Info("Admin HTTP handler initialized")
+
+	// Initialize export repository and service
+	exportRepository := exportRepo.NewRepository(dbInterface)
+	log.Info("Export repository initialized")
+
+	exportService := exportApp.NewService(exportRepository, cfg.Export.TTL)
+	log.Info("Export service initialized")
+
+	exportHandler := exportHTTP.NewHandler(exportService)
+	log.Info("Export HTTP handler initialized")
+
+	// Start background worker that builds queued data export archives
+	go exportService.RunWorker(context.Background(), cfg.Export.PollInterval)
+	log.Info("Export worker started", "interval", cfg.Export.PollInterval.String())
+
+	// Initialize GraphQL service on top of the account, post and comment services
+	graphqlService := graphqlApp.NewService(accountService, postService, commentService)
+	log.Info("GraphQL service initialized")
+
+	graphqlHandler := graphqlHTTP.NewHandler(graphqlService)
+	log.Info("GraphQL HTTP handler initialized")
+
+	// Initialize RSS/Atom feeds of recent public posts
+	feedService := feedApp.NewService(postService, accountService, cacheStore, feedApp.Config{
+		BaseURL:  cfg.Feed.BaseURL,
+		Title:    cfg.Feed.Title,
+		PostLink: cfg.Feed.PostLink,
+		Size:     cfg.Feed.Size,
+		CacheTTL: cfg.Feed.CacheTTL,
+	})
+	log.Info("Feed service initialized")
+
+	feedHandler := feedHTTP.NewHandler(feedService)
+	log.Info("Feed HTTP handler initialized")
+
 	// Initialize health repository and service
-	healthRepository := healthRepo.NewRepository(dbInterface)
+	healthRepository := healthRepo.NewRepository(dbInterface, imageStorage, metricsBackend)
 	log.Info("Health repository initialized")
 
 	healthService := healthApp.NewService(healthRepository)
@@ -142,29 +469,155 @@ func main() {
 	healthHandler := healthHTTP.NewHandler(healthService)
 	log.Info("Health HTTP handler initialized")
 
+	// Initialize idempotency key storage for retried requests
+	idempotencyRepository := idempotencyRepo.NewRepository(dbInterface)
+	log.Info("Idempotency repository initialized")
+
+	idempotencyService := idempotencyApp.NewService(idempotencyRepository, cfg.Idempotency.TTL)
+	log.Info("Idempotency service initialized")
+
+	// Start background sweep that deletes expired idempotency keys
+	go idempotencyService.RunExpirySweeper(context.Background(), cfg.Idempotency.SweepInterval)
+	log.Info("Idempotency key sweeper started", "interval", cfg.Idempotency.SweepInterval.String())
+
+	// Initialize the audit trail of mutating API calls
+	auditRepository := auditRepo.NewRepository(dbInterface)
+	log.Info("Audit repository initialized")
+
+	auditService := auditApp.NewService(auditRepository, cfg.Audit.BufferSize)
+	log.Info("Audit service initialized")
+
+	auditHandler := auditHTTP.NewHandler(auditService)
+	log.Info("Audit HTTP handler initialized")
+
+	// Start background writer of audit entries
+	auditMiddleware := func(next http.Handler) http.Handler { return next }
+	if cfg.Audit.Enabled {
+		go auditService.Run(context.Background(), cfg.Audit.FlushInterval)
+		auditMiddleware = middleware.Audit(auditService)
+		log.Info("Audit writer started", "bufferSize", cfg.Audit.BufferSize, "flushInterval", cfg.Audit.FlushInterval.String())
+	}
+
 	// Initialize middleware
 	loggingMiddleware := middleware.LoggingMiddleware()
-	authMiddleware := middleware.NewAuthMiddleware(jwtService)
+	authMiddleware := middleware.NewAuthMiddleware(jwtService, accountRepository, accountService, accountService)
+	roleMiddleware := middleware.NewRoleMiddleware()
+	idempotencyMiddleware := middleware.NewIdempotencyMiddleware(idempotencyService)
+	routeMetadata := middleware.NewRouteMetadata()
+	responseCacheMiddleware := middleware.NewResponseCache(responseCache, cfg.Response.CacheTTL)
 
 	// Initialize metrics middleware
-	metricsMiddleware := middleware.InfluxDBMiddleware(influxClient)
+	metricsMiddleware := middleware.MetricsMiddleware(metricsSink)
+	if promMetrics != nil {
+		sinkMiddleware := metricsMiddleware
+		metricsMiddleware = func(next http.Handler) http.Handler {
+			return promMetrics.HTTPMiddleware(sinkMiddleware(next))
+		}
+	}
 	log.Info("Metrics middleware initialized")
 
 	// Add security requirements manually for now
 	authMiddleware.AddSecurityRequirement("GET", "/api/account/profile", true)
+	authMiddleware.AddSecurityRequirement("POST", "/api/account/logout", true)
+	authMiddleware.AddSecurityRequirement("POST", "/api/account/logout-all", true)
+	authMiddleware.AddSecurityRequirement("PUT", "/api/account/email", true)
+	authMiddleware.AddSecurityRequirement("PUT", "/api/account/password", true)
+	authMiddleware.AddSecurityRequirement("POST", "/api/account/deactivate", true)
+	authMiddleware.AddSecurityRequirement("POST", "/api/account/export", true)
+	authMiddleware.AddSecurityRequirement("POST", "/api/account/api-keys", true)
+	authMiddleware.AddSecurityRequirement("GET", "/api/account/api-keys", true)
+	authMiddleware.AddSecurityRequirement("DELETE", "/api/account/api-keys", true)
+	authMiddleware.AddSecurityRequirement("GET", "/api/account/export", true)
+	authMiddleware.AddSecurityRequirement("GET", "/api/account/sessions", true)
+	authMiddleware.AddSecurityRequirement("GET", "/api/account/security-log", true)
+	authMiddleware.AddSecurityRequirement("DELETE", "/api/account/sessions", true)
 	authMiddleware.AddSecurityRequirement("DELETE", "/api/account", true)
+	authMiddleware.AddSecurityRequirement("PUT", "/api/account/avatar", true)
 	authMiddleware.AddSecurityRequirement("GET", "/api/posts", false)
 	authMiddleware.AddSecurityRequirement("POST", "/api/posts", true)
 	authMiddleware.AddSecurityRequirement("PUT", "/api/posts", true)
 	authMiddleware.AddSecurityRequirement("DELETE", "/api/posts", true)
 	// New explicit paths
 	authMiddleware.AddSecurityRequirement("GET", "/api/posts/by-user", false)
+	authMiddleware.AddSecurityRequirement("GET", "/api/posts/timeline", true)
 	authMiddleware.AddSecurityRequirement("GET", "/api/comments/by-post", false)
 	authMiddleware.AddSecurityRequirement("POST", "/api/comments/by-post", true)
+	authMiddleware.AddSecurityRequirement("POST", "/api/comments", true)
 	authMiddleware.AddSecurityRequirement("PUT", "/api/comments", true)
 	authMiddleware.AddSecurityRequirement("DELETE", "/api/comments", true)
+	authMiddleware.AddSecurityRequirement("GET", "/api/conversations", true)
+	authMiddleware.AddSecurityRequirement("POST", "/api/conversations", true)
+	authMiddleware.AddSecurityRequirement("GET", "/api/stories", true)
+	authMiddleware.AddSecurityRequirement("POST", "/api/stories", true)
+	authMiddleware.AddSecurityRequirement("GET", "/api/search", false)
+	authMiddleware.AddSecurityRequirement("GET", "/api/accounts/suggestions", true)
+	authMiddleware.AddSecurityRequirement("POST", "/api/accounts", true)
+	authMiddleware.AddSecurityRequirement("DELETE", "/api/accounts", true)
+	authMiddleware.AddSecurityRequirement("GET", "/api/account/settings", true)
+	authMiddleware.AddSecurityRequirement("PUT", "/api/account/settings", true)
+	authMiddleware.AddSecurityRequirement("PUT", "/api/moderation", true)
+	authMiddleware.AddSecurityRequirement("GET", "/api/account/activity", true)
+	authMiddleware.AddSecurityRequirement("GET", "/api/admin", true)
+	authMiddleware.AddSecurityRequirement("POST", "/api/admin", true)
+	authMiddleware.AddSecurityRequirement("PUT", "/api/admin", true)
+	authMiddleware.AddSecurityRequirement("DELETE", "/api/admin", true)
+	authMiddleware.AddSecurityRequirement("POST", "/api/reports", true)
+	authMiddleware.AddSecurityRequirement("GET", "/api/notifications", true)
+	authMiddleware.AddSecurityRequirement("GET", "/debug", true)
+	authMiddleware.AddSecurityRequirement("POST", "/debug", true)
 	log.Info("Security requirements loaded manually")
 
+	// Role requirements; services still check the stored role for sensitive actions
+	roleMiddleware.AddRoleRequirement("GET", "/api/admin", "admin")
+	roleMiddleware.AddRoleRequirement("POST", "/api/admin", "admin")
+	roleMiddleware.AddRoleRequirement("PUT", "/api/admin", "admin")
+	roleMiddleware.AddRoleRequirement("DELETE", "/api/admin", "admin")
+	roleMiddleware.AddRoleRequirement("GET", "/debug", "admin")
+	roleMiddleware.AddRoleRequirement("POST", "/debug", "admin")
+	log.Info("Role requirements loaded")
+
+	// Routes API keys are refused on; keys act as plain users and cannot reach admin tools.
+	authMiddleware.DenyAPIKey("GET", "/api/admin")
+	authMiddleware.DenyAPIKey("POST", "/api/admin")
+	authMiddleware.DenyAPIKey("PUT", "/api/admin")
+	authMiddleware.DenyAPIKey("DELETE", "/api/admin")
+	authMiddleware.DenyAPIKey("PUT", "/api/moderation")
+	authMiddleware.DenyAPIKey("GET", "/debug")
+	authMiddleware.DenyAPIKey("POST", "/debug")
+	// Nor can keys change credentials, sign sessions out or end the account; DELETE
+	// /api/account covers revoking sessions and API keys too
+	authMiddleware.DenyAPIKey("POST", "/api/account/logout")
+	authMiddleware.DenyAPIKey("POST", "/api/account/logout-all")
+	authMiddleware.DenyAPIKey("PUT", "/api/account/email")
+	authMiddleware.DenyAPIKey("PUT", "/api/account/password")
+	authMiddleware.DenyAPIKey("POST", "/api/account/deactivate")
+	authMiddleware.DenyAPIKey("DELETE", "/api/account")
+	authMiddleware.DenyAPIKey("GET", "/api/account/api-keys")
+	authMiddleware.DenyAPIKey("POST", "/api/account/api-keys")
+	log.Info("API key restrictions loaded")
+
+	// Mutations that replay their response when retried with the same Idempotency-Key
+	idempotencyMiddleware.AddRoute("POST", "/api/posts")
+	idempotencyMiddleware.AddRoute("POST", "/api/comments")
+	log.Info("Idempotent routes loaded")
+
+	// Public listings whose anonymous responses are cached
+	responseCacheMiddleware.AddRoute("/api/posts")
+	responseCacheMiddleware.AddRoute("/api/posts/by-user")
+	responseCacheMiddleware.AddRoute("/api/comments/by-post")
+	log.Info("Cached routes loaded", "ttl", cfg.Response.CacheTTL.String())
+
+	// Deprecated endpoints announce their removal with Deprecation and Sunset headers
+	deprecatedRoutes, err := middleware.ParseDeprecatedRoutes(cfg.Response.DeprecatedRoutes, cfg.Response.DeprecationLink)
+	if err != nil {
+		log.Error("Invalid deprecated routes", "error", err.Error())
+		os.Exit(1)
+	}
+	for _, route := range deprecatedRoutes {
+		routeMetadata.Deprecate(route.Method, route.Path, route.Deprecation)
+	}
+	log.Info("Deprecated routes loaded", "count", len(deprecatedRoutes))
+
 	// Create combined API handler
 	apiHandler := http.NewServeMux()
 
@@ -172,18 +625,38 @@ func main() {
 	genhttp.HandlerFromMux(accountHandler, apiHandler)
 	postGenHTTP.HandlerFromMux(postHandler, apiHandler)
 	commentGenHTTP.HandlerFromMux(commentHandler, apiHandler)
+	messageGenHTTP.HandlerFromMux(messageHandler, apiHandler)
+	storyGenHTTP.HandlerFromMux(storyHandler, apiHandler)
+	searchGenHTTP.HandlerFromMux(searchHandler, apiHandler)
+	suggestionGenHTTP.HandlerFromMux(suggestionHandler, apiHandler)
+	relationshipGenHTTP.HandlerFromMux(relationshipHandler, apiHandler)
+	shortlinkGenHTTP.HandlerFromMux(shortlinkHandler, apiHandler)
+	activityGenHTTP.HandlerFromMux(activityHandler, apiHandler)
+	exportGenHTTP.HandlerFromMux(exportHandler, apiHandler)
+	adminGenHTTP.HandlerFromMux(adminHandler, apiHandler)
+	auditGenHTTP.HandlerFromMux(auditHandler, apiHandler)
+
+	// Stream account notifications as Server-Sent Events for browsers that skip WebSockets
+	apiHandler.Handle("GET /api/notifications/stream", realtimeHub.StreamHandler())
 
 	// Setup routes using combined API handler with comprehensive middleware
 	var apiHandlerWithMiddleware http.Handler = apiHandler
 
-	// Apply middleware in order: metrics -> auth -> logging -> request context
+	// Apply middleware in order: response cache -> conditional GET -> audit -> idempotency -> route metadata -> debug queries -> metrics -> roles -> auth -> logging -> request context
+	apiHandlerWithMiddleware = responseCacheMiddleware.Middleware()(apiHandlerWithMiddleware)
+	apiHandlerWithMiddleware = middleware.ConditionalGET(apiHandlerWithMiddleware)
+	apiHandlerWithMiddleware = auditMiddleware(apiHandlerWithMiddleware)
+	apiHandlerWithMiddleware = idempotencyMiddleware.Middleware()(apiHandlerWithMiddleware)
+	apiHandlerWithMiddleware = routeMetadata.Middleware()(apiHandlerWithMiddleware)
+	apiHandlerWithMiddleware = middleware.DebugQueries(apiHandlerWithMiddleware)
 	apiHandlerWithMiddleware = metricsMiddleware(apiHandlerWithMiddleware)
+	apiHandlerWithMiddleware = roleMiddleware.Middleware()(apiHandlerWithMiddleware)
 	apiHandlerWithMiddleware = authMiddleware.Middleware()(apiHandlerWithMiddleware)
 	apiHandlerWithMiddleware = loggingMiddleware(apiHandlerWithMiddleware)
 	apiHandlerWithMiddleware = reqctx.Middleware(apiHandlerWithMiddleware)
 
-	// InfluxDB metrics are sent directly via HTTP, no endpoint needed
-	log.Info("InfluxDB metrics enabled")
+	// InfluxDB and StatsD metrics are pushed, Prometheus scrapes its own endpoint
+	log.Info("Metrics enabled", "influxdb", cfg.InfluxDB.Enabled, "statsd", cfg.StatsD.Enabled, "prometheus", cfg.Prometheus.Enabled)
 
 	// Create health OpenAPI server with middleware
 	healthApiHandler := healthGenHTTP.Handler(healthHandler)
@@ -206,6 +679,74 @@ func main() {
 		),
 	)
 
+	// Add short link redirects with logging middleware only (public, no auth required)
+	mainMux.Handle("/p/",
+		reqctx.Middleware(
+			loggingMiddleware(apiHandler),
+		),
+	)
+
+	// Add GraphQL endpoint; the token is optional, resolvers show what the caller may see
+	graphqlMux := http.NewServeMux()
+	graphqlMux.Handle("/graphql", graphqlHandler)
+	graphqlMux.HandleFunc("GET /graphql/schema", graphqlHandler.ServeSchema)
+
+	var graphqlHandlerWithMiddleware http.Handler = graphqlMux
+	graphqlHandlerWithMiddleware = metricsMiddleware(graphqlHandlerWithMiddleware)
+	graphqlHandlerWithMiddleware = authMiddleware.Middleware()(graphqlHandlerWithMiddleware)
+	graphqlHandlerWithMiddleware = loggingMiddleware(graphqlHandlerWithMiddleware)
+	graphqlHandlerWithMiddleware = reqctx.Middleware(graphqlHandlerWithMiddleware)
+
+	mainMux.Handle("/graphql", graphqlHandlerWithMiddleware)
+	mainMux.Handle("/graphql/", graphqlHandlerWithMiddleware)
+
+	// Add public RSS/Atom feeds (no auth required)
+	feedMux := http.NewServeMux()
+	feedMux.HandleFunc("GET /feeds/posts.xml", feedHandler.ServePosts)
+	feedMux.HandleFunc("GET /feeds/users/{file}", feedHandler.ServeUser)
+
+	mainMux.Handle("/feeds/",
+		reqctx.Middleware(
+			loggingMiddleware(metricsMiddleware(feedMux)),
+		),
+	)
+
+	// Add WebSocket endpoint for real-time updates. The token is optional; logging and
+	// metrics are left out because their response wrappers cannot hijack the connection.
+	mainMux.Handle("/ws",
+		reqctx.Middleware(
+			authMiddleware.Middleware()(realtimeHub.Handler()),
+		),
+	)
+
+	// Publish the token verification keys (public, no auth required)
+	mainMux.Handle("/.well-known/jwks.json",
+		reqctx.Middleware(
+			loggingMiddleware(jwtService.JWKSHandler()),
+		),
+	)
+
+	// Add Prometheus scrape endpoint, behind basic auth when credentials are set
+	if promMetrics != nil {
+		mainMux.Handle(cfg.Prometheus.Path,
+			prometheus.BasicAuth(promMetrics.Handler(), cfg.Prometheus.Username, cfg.Prometheus.Password),
+		)
+	}
+
+	// Add profiling and runtime diagnostics for admins, when enabled
+	if cfg.Server.DebugEndpoints {
+		mainMux.Handle("/debug/",
+			reqctx.Middleware(
+				loggingMiddleware(
+					authMiddleware.Middleware()(
+						roleMiddleware.Middleware()(debugHandler()),
+					),
+				),
+			),
+		)
+		log.Info("Debug endpoints enabled", "pprof", "/debug/pprof/", "expvar", "/debug/vars")
+	}
+
 	// Add Swagger UI endpoint
 	mainMux.HandleFunc("/swagger/", serveSwaggerUI)
 
@@ -218,8 +759,35 @@ func main() {
 	log.Info("Routes configured",
 		"apiPrefix", "/api/",
 		"healthPrefix", "/health",
+		"shortLinkPrefix", "/p/",
+		"graphqlEndpoint", "/graphql",
+		"websocketEndpoint", "/ws",
 		"swaggerEndpoint", "/swagger/")
 
+	// Serve the account, post and comment services over gRPC for internal consumers
+	if cfg.Server.GRPCPort != 0 {
+		grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+			middleware.GRPCLoggingInterceptor(),
+			authMiddleware.GRPCInterceptor(),
+		))
+		accountGenGRPC.RegisterAccountServiceServer(grpcServer, accountHTTP.NewGRPCServer(accountService))
+		postGenGRPC.RegisterPostServiceServer(grpcServer, postHTTP.NewGRPCServer(postService))
+		commentGenGRPC.RegisterCommentServiceServer(grpcServer, commentHTTP.NewGRPCServer(commentService))
+		reflection.Register(grpcServer)
+
+		grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Server.GRPCPort))
+		if err != nil {
+			log.Error("Failed to listen for gRPC", "port", cfg.Server.GRPCPort, "error", err.Error())
+			os.Exit(1)
+		}
+		go func() {
+			if err := grpcServer.Serve(grpcListener); err != nil {
+				log.Error("gRPC server stopped", "error", err.Error())
+			}
+		}()
+		log.Info("gRPC server started", "port", cfg.Server.GRPCPort)
+	}
+
 	// Start server
 	port := fmt.Sprintf("%d", cfg.Server.Port)
 	if envPort := os.Getenv("PORT"); envPort != "" {
@@ -229,7 +797,53 @@ func main() {
 	// Show cool banner
 	showBanner(cfg.Server.Host, port)
 
-	if err := http.ListenAndServe(":"+port, mainMux); err != nil {
+	// Serve HTTP/2 without TLS next to HTTP/1.1, for clients and proxies multiplexing
+	// requests over one connection; WebSocket upgrades stay on HTTP/1.1
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(cfg.Server.HTTP2)
+
+	connections := metrics.NewConnections()
+	go connections.Report(context.Background(), metricsSink, cfg.Server.ConnectionMetricsInterval)
+	go metrics.ReportRuntime(context.Background(), metricsSink, cfg.Server.RuntimeMetricsInterval)
+	connState := connections.ConnState
+	if promMetrics != nil {
+		connState = func(conn net.Conn, state http.ConnState) {
+			connections.ConnState(conn, state)
+			promMetrics.ActiveConnections.Set(float64(connections.Open()))
+		}
+	}
+
+	server := &http.Server{
+		Addr:              ":" + port,
+		Handler:           mainMux,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+		Protocols:         protocols,
+		HTTP2:             &http.HTTP2Config{MaxConcurrentStreams: cfg.Server.HTTP2MaxConcurrentStreams},
+		ConnState:         connState,
+	}
+	server.SetKeepAlivesEnabled(cfg.Server.KeepAlive)
+
+	listenConfig := net.ListenConfig{KeepAlive: cfg.Server.TCPKeepAlive}
+	listener, err := listenConfig.Listen(context.Background(), "tcp", server.Addr)
+	if err != nil {
+		log.Error("❌ Server failed to start", "error", err.Error())
+		os.Exit(1)
+	}
+	if cfg.Server.MaxConnections > 0 {
+		listener = netutil.LimitListener(listener, cfg.Server.MaxConnections)
+	}
+	log.Info("HTTP connections configured",
+		"http2", cfg.Server.HTTP2,
+		"keepAlive", cfg.Server.KeepAlive,
+		"maxConnections", cfg.Server.MaxConnections,
+	)
+
+	if err := server.Serve(listener); err != nil {
 		log.Error("❌ Server failed to start", "error", err.Error())
 		os.Exit(1)
 	}
@@ -260,6 +874,21 @@ func showBanner(host, port string) {
 	fmt.Println()
 }
 
+// debugHandler serves the pprof profiles under /debug/pprof/ and the expvar variables,
+// runtime memory statistics and goroutine count included, at /debug/vars
+func debugHandler() http.Handler {
+	expvar.Publish("goroutines", expvar.Func(func() interface{} { return runtime.NumGoroutine() }))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	return mux
+}
+
 // serveSwaggerUI serves the Swagger UI HTML page
 func serveSwaggerUI(w http.ResponseWriter, r *http.Request) {
 	// Redirect to index.html if accessing /swagger/ without trailing slash
@@ -268,8 +897,8 @@ func serveSwaggerUI(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Serve the index.html file
-	http.ServeFile(w, r, "docs/index.html")
+	// Serve the embedded index.html file
+	http.ServeFileFS(w, r, docs.Assets, "index.html")
 }
 
 // serveSwaggerJSON serves the Swagger JSON specification
@@ -277,7 +906,7 @@ func serveSwaggerJSON(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 	// Handle preflight requests
 	if r.Method == "OPTIONS" {
@@ -285,13 +914,13 @@ func serveSwaggerJSON(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Serve the swagger JSON file
-	http.ServeFile(w, r, "docs/swagger/docs.json")
+	// Serve the embedded swagger JSON file
+	http.ServeFileFS(w, r, docs.Assets, "swagger/docs.json")
 }
 
 // serveFavicon serves the favicon.ico file
 func serveFavicon(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "image/x-icon")
 	w.Header().Set("Cache-Control", "public, max-age=31536000") // Cache for 1 year
-	http.ServeFile(w, r, "docs/favicon.ico")
+	http.ServeFileFS(w, r, docs.Assets, "favicon.ico")
 }
@@ -0,0 +1,13 @@
+// Package docs embeds the Swagger UI page, its favicon and the merged Swagger spec so the
+// server binary serves them without the repository's docs directory on disk. Regenerate
+// swagger/docs.json with `make swagger-gen` after changing the specs in api/http.
+package docs
+
+import (
+	"embed"
+)
+
+// Assets holds index.html, favicon.ico and swagger/docs.json
+//
+//go:embed index.html favicon.ico swagger/docs.json
+var Assets embed.FS
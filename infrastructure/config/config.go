@@ -1,22 +1,74 @@
 package config
 
 import (
+	"runtime"
+	"time"
+
 	"github.com/fanzru/social-media-service-go/pkg/env"
 )
 
 // Config holds all configuration for our application
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Storage  StorageConfig
-	StatsD   StatsDConfig
+	Server      ServerConfig
+	Response    ResponseConfig
+	Database    DatabaseConfig
+	JWT         JWTConfig
+	Storage     StorageConfig
+	Story       StoryConfig
+	Idempotency IdempotencyConfig
+	Audit       AuditConfig
+	ShortLink   ShortLinkConfig
+	Feed        FeedConfig
+	Timeline    TimelineConfig
+	Moderation  ModerationConfig
+	Mail        MailConfig
+	OAuth       OAuthConfig
+	Export      ExportConfig
+	APIKey      APIKeyConfig
+	Login       LoginConfig
+	Account     AccountConfig
+	Password    PasswordConfig
+	StatsD      StatsDConfig
+	InfluxDB    InfluxDBConfig
+	Prometheus  PrometheusConfig
 }
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
-	Port int
-	Host string
+	Port     int
+	Host     string
+	GRPCPort int // 0 disables the gRPC server
+
+	// HTTP Server Limits
+	ReadHeaderTimeout time.Duration // longest a client may take to send request headers
+	ReadTimeout       time.Duration // longest a client may take to send a whole request, body included
+	WriteTimeout      time.Duration // longest from the end of the headers until the response is written
+	IdleTimeout       time.Duration // how long a keep-alive connection may wait for the next request
+	MaxHeaderBytes    int           // largest request headers accepted, request line included
+
+	// Connections
+	HTTP2                     bool          // serve unencrypted HTTP/2 (h2c with prior knowledge) next to HTTP/1.1
+	HTTP2MaxConcurrentStreams int           // most concurrent requests on one HTTP/2 connection
+	KeepAlive                 bool          // reuse HTTP/1.1 connections for further requests
+	TCPKeepAlive              time.Duration // interval of TCP keep-alive probes on accepted connections; negative disables them
+	MaxConnections            int           // most connections open at once, further ones wait to be accepted; 0 is unlimited
+	ConnectionMetricsInterval time.Duration // how often connection counts are sent to the metrics sinks; 0 disables them
+
+	// Diagnostics
+	DebugEndpoints         bool          // serve pprof profiles and expvar variables to admins under /debug/
+	RuntimeMetricsInterval time.Duration // how often goroutine, heap and GC statistics are sent to the metrics sinks; 0 disables them
+}
+
+// ResponseConfig holds API response configuration
+type ResponseConfig struct {
+	ErrorFormat        string        // envelope or problem (RFC 7807 problem details for every error)
+	ProblemTypeBaseURL string        // base of problem type URIs; about:blank is used when empty
+	DeprecatedRoutes   []string      // deprecated endpoints, as "METHOD /path@deprecated-at[@sunset-at]"
+	DeprecationLink    string        // documentation linked from deprecated endpoints
+	CursorSecret       string        // signs pagination cursors; a random per-process key when empty
+	JSONEncoding       string        // JSON library bodies are marshalled with: std, or one compiled in with a build tag
+	CacheTTL           time.Duration // how long anonymous GET responses of public listings are reused; 0 disables caching
+	CacheSize          int           // most responses cached at once; the least recently used are evicted
 }
 
 // DatabaseConfig holds database configuration
@@ -29,13 +81,35 @@ type DatabaseConfig struct {
 	SSLMode            string
 	LogQueries         bool
 	LogSlowQueries     bool
-	SlowQueryThreshold int // in milliseconds
+	SlowQueryThreshold int  // in milliseconds
+	MetricsRequestID   bool // Tag query metrics with the request ID; adds series for every request
+
+	// Connection pool; 0 leaves open connections, lifetime and idle time unlimited and
+	// keeps no idle connections
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	PoolMetricsInterval time.Duration // how often pool statistics are sent to the metrics sinks; 0 disables them
 }
 
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	Secret     string
-	Expiration int // in hours
+	Algorithm              string        // HS256, RS256 or EdDSA
+	Secret                 string        // HMAC key used with HS256
+	PrivateKey             string        // PEM private key used with RS256 and EdDSA
+	PrivateKeyFile         string        // file holding the PEM private key; takes precedence over PrivateKey
+	KeyID                  string        // kid of the signing key; tokens without a kid are checked against it
+	PreviousKeys           []string      // retired HMAC keys still accepted, as "kid@retired-at=secret"
+	PreviousPublicKeys     []string      // retired public keys still accepted, as "kid@retired-at=path/to/key.pem"
+	KeyGracePeriod         time.Duration // how long after retirement a previous key is still accepted
+	Issuer                 string        // iss claim set on and required of access tokens
+	Audience               string        // aud claim set on and required of access tokens
+	Leeway                 time.Duration // clock skew tolerated when checking token times
+	Expiration             int           // in hours
+	RefreshExpiration      time.Duration // lifetime of refresh tokens of remember me sessions
+	ShortRefreshExpiration time.Duration // lifetime of refresh tokens of sessions without remember me
 }
 
 // StorageConfig holds file storage configuration
@@ -55,6 +129,132 @@ type StorageConfig struct {
 	ImageResizeWidth  int
 	ImageResizeHeight int
 	ImageQuality      int
+
+	// Avatar Processing Configuration
+	AvatarSize    int // width and height of the square avatar
+	AvatarQuality int
+
+	// Image Processing Worker Pool
+	ImageWorkers    int           // images processed at once
+	ImageQueueSize  int           // images waiting for a worker; further uploads are rejected
+	ImageJobTimeout time.Duration // longest an upload waits for its image, queueing included
+
+	// Background Post Image Processing
+	ImagePollInterval time.Duration // how often image workers look for post images awaiting processing
+}
+
+// StoryConfig holds story configuration
+type StoryConfig struct {
+	TTL           time.Duration // how long a story stays visible
+	SweepInterval time.Duration // how often expired stories are cleaned up
+}
+
+// IdempotencyConfig holds idempotency key configuration
+type IdempotencyConfig struct {
+	TTL           time.Duration // how long a response is replayed for a retried idempotency key
+	SweepInterval time.Duration // how often expired keys are cleaned up
+}
+
+// AuditConfig holds audit trail configuration
+type AuditConfig struct {
+	Enabled       bool          // record successful mutating API calls
+	BufferSize    int           // entries waiting to be written; further entries are dropped
+	FlushInterval time.Duration // longest an entry waits before it is written
+}
+
+// ShortLinkConfig holds post short link configuration
+type ShortLinkConfig struct {
+	BaseURL    string // public base URL short links are served from
+	PostTarget string // redirect target for a post, "{id}" is replaced with the post ID
+}
+
+// FeedConfig holds public RSS/Atom feed configuration
+type FeedConfig struct {
+	BaseURL  string        // public base URL feeds and post links are served from
+	Title    string        // title of the site-wide feed
+	PostLink string        // link of a feed item, "{id}" is replaced with the post ID; relative links are resolved against BaseURL
+	Size     int           // how many recent posts a feed lists
+	CacheTTL time.Duration // how long a built feed is served from cache; 0 disables caching
+}
+
+// TimelineConfig holds home timeline configuration
+type TimelineConfig struct {
+	FanoutMaxFollowers int           // followers beyond which an account's posts are read by timelines instead of written to each
+	FanoutWorkers      int           // workers writing new posts to timelines
+	PollInterval       time.Duration // how often the workers look for new posts
+}
+
+// AccountConfig holds account lookup cache configuration
+type AccountConfig struct {
+	CacheTTL  time.Duration // how long an account looked up by ID is reused; 0 disables caching
+	CacheSize int           // most accounts cached at once; the least recently used are evicted
+}
+
+// ModerationConfig holds content moderation configuration
+type ModerationConfig struct {
+	ModeratorIDs []int64 // accounts allowed to enforce the sensitive flag on any post
+}
+
+// MailConfig holds outgoing email configuration
+type MailConfig struct {
+	SMTPHost     string // emails are only logged when empty
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+
+	EmailChangeTTL  time.Duration // how long an email change confirmation token is valid
+	EmailConfirmURL string        // link sent to the new address, the token is appended as ?token=
+}
+
+// OAuthConfig holds social login configuration; a provider is enabled when its client ID is set
+type OAuthConfig struct {
+	RedirectBaseURL    string // public base URL the provider redirects back to
+	GoogleClientID     string
+	GoogleClientSecret string
+	GitHubClientID     string
+	GitHubClientSecret string
+}
+
+// ExportConfig holds GDPR data export configuration
+type ExportConfig struct {
+	TTL          time.Duration // how long a completed archive can be downloaded
+	PollInterval time.Duration // how often the worker checks for queued exports
+}
+
+// APIKeyConfig holds API key configuration
+type APIKeyConfig struct {
+	DefaultRateLimit int // requests per minute when a key is created without a limit
+	MaxRateLimit     int // highest requests per minute a key may be given
+}
+
+// LoginConfig holds login throttling and lockout configuration
+type LoginConfig struct {
+	MaxAttempts      int           // failures per email before it is locked
+	MaxAttemptsPerIP int           // failures per client IP before it is locked
+	AttemptWindow    time.Duration // failures older than this are forgotten
+	LockoutBase      time.Duration // first lockout, doubled on every further failure
+	LockoutMax       time.Duration // longest lockout
+	NewDeviceAlerts  bool          // email the owner on sign-in from an unseen device
+}
+
+// PasswordConfig holds the password policy applied on registration and password change
+type PasswordConfig struct {
+	MinLength     int
+	MaxLength     int // in bytes; bcrypt ignores anything past 72
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	CheckBreached bool   // reject passwords found by the breach API
+	BreachAPIURL  string // k-anonymity range endpoint, the hash prefix is appended
+	BreachTimeout time.Duration
+	// Hashing of stored passwords; hashes made with other settings are upgraded on login
+	HashAlgorithm     string // bcrypt or argon2id
+	BcryptCost        int
+	Argon2Memory      uint32 // KiB
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
 }
 
 // StatsDConfig holds StatsD configuration
@@ -62,16 +262,66 @@ type StatsDConfig struct {
 	Host     string
 	Port     int
 	Prefix   string
-	Sampling float64
-	Enabled  bool
+	Sampling float64 // Share of counters and timings sent, in (0, 1]
+	Enabled  bool    // Send HTTP and database metrics to StatsD
+}
+
+// InfluxDBConfig holds InfluxDB metrics configuration
+type InfluxDBConfig struct {
+	Enabled       bool // Send HTTP and database metrics to InfluxDB
+	Host          string
+	Token         string // API token with write access to Bucket
+	Org           string
+	Bucket        string
+	BatchSize     int           // Points sent in one write request
+	FlushInterval time.Duration // Longest a point waits before it is sent
+	BufferSize    int           // Points waiting to be sent; further points are dropped
+	RetryInterval time.Duration // How often the connection is checked while InfluxDB is down
+}
+
+// PrometheusConfig holds the configuration of the Prometheus scrape endpoint
+type PrometheusConfig struct {
+	Enabled   bool // Serve HTTP and database metrics at Path
+	Path      string
+	Namespace string // Prefix of the metrics also sent to InfluxDB and StatsD
+	Username  string // Basic auth required to scrape; empty leaves the endpoint open
+	Password  string
 }
 
 // Load loads configuration from environment variables
 func Load() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Port: env.GetInt("SERVER_PORT", 8080),
-			Host: env.GetString("SERVER_HOST", "localhost"),
+			Port:     env.GetInt("SERVER_PORT", 8080),
+			Host:     env.GetString("SERVER_HOST", "localhost"),
+			GRPCPort: env.GetInt("GRPC_PORT", 9090),
+
+			// HTTP Server Limits
+			ReadHeaderTimeout: env.GetDuration("SERVER_READ_HEADER_TIMEOUT", 5*time.Second),
+			ReadTimeout:       env.GetDuration("SERVER_READ_TIMEOUT", time.Minute),
+			WriteTimeout:      env.GetDuration("SERVER_WRITE_TIMEOUT", 2*time.Minute),
+			IdleTimeout:       env.GetDuration("SERVER_IDLE_TIMEOUT", 2*time.Minute),
+			MaxHeaderBytes:    env.GetInt("SERVER_MAX_HEADER_BYTES", 1<<20),
+
+			HTTP2:                     env.GetBool("SERVER_HTTP2", true),
+			HTTP2MaxConcurrentStreams: env.GetInt("SERVER_HTTP2_MAX_CONCURRENT_STREAMS", 250),
+			KeepAlive:                 env.GetBool("SERVER_KEEP_ALIVE", true),
+			TCPKeepAlive:              env.GetDuration("SERVER_TCP_KEEP_ALIVE", 30*time.Second),
+			MaxConnections:            env.GetInt("SERVER_MAX_CONNECTIONS", 0),
+			ConnectionMetricsInterval: env.GetDuration("SERVER_CONNECTION_METRICS_INTERVAL", 10*time.Second),
+
+			DebugEndpoints:         env.GetBool("SERVER_DEBUG_ENDPOINTS", false),
+			RuntimeMetricsInterval: env.GetDuration("SERVER_RUNTIME_METRICS_INTERVAL", 10*time.Second),
+		},
+		Response: ResponseConfig{
+			ErrorFormat:        env.GetString("ERROR_FORMAT", "envelope"),
+			ProblemTypeBaseURL: env.GetString("PROBLEM_TYPE_BASE_URL", ""),
+			DeprecatedRoutes:   env.GetStringSlice("DEPRECATED_ROUTES", nil),
+			DeprecationLink:    env.GetString("DEPRECATION_LINK", ""),
+			CursorSecret:       env.GetString("CURSOR_SECRET", ""),
+			JSONEncoding:       env.GetString("JSON_ENCODING", "std"),
+			CacheTTL:           env.GetDuration("RESPONSE_CACHE_TTL", 10*time.Second),
+			CacheSize:          env.GetInt("RESPONSE_CACHE_SIZE", 10000),
 		},
 		Database: DatabaseConfig{
 			Host:               env.GetString("DB_HOST", "localhost"),
@@ -83,10 +333,29 @@ func Load() *Config {
 			LogQueries:         env.GetBool("DB_LOG_QUERIES", true),
 			LogSlowQueries:     env.GetBool("DB_LOG_SLOW_QUERIES", true),
 			SlowQueryThreshold: env.GetInt("DB_SLOW_QUERY_THRESHOLD", 100), // 100ms default
+			MetricsRequestID:   env.GetBool("DB_METRICS_REQUEST_ID", false),
+			MaxOpenConns:       env.GetInt("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:       env.GetInt("DB_MAX_IDLE_CONNS", 10),
+			ConnMaxLifetime:    env.GetDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+			ConnMaxIdleTime:    env.GetDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+
+			PoolMetricsInterval: env.GetDuration("DB_POOL_METRICS_INTERVAL", 10*time.Second),
 		},
 		JWT: JWTConfig{
-			Secret:     env.GetString("JWT_SECRET", "your-secret-key"),
-			Expiration: env.GetInt("JWT_EXPIRATION", 24),
+			Algorithm:              env.GetString("JWT_ALGORITHM", "HS256"),
+			Secret:                 env.GetString("JWT_SECRET", "your-secret-key"),
+			PrivateKey:             env.GetString("JWT_PRIVATE_KEY", ""),
+			PrivateKeyFile:         env.GetString("JWT_PRIVATE_KEY_FILE", ""),
+			KeyID:                  env.GetString("JWT_KEY_ID", "default"),
+			PreviousKeys:           env.GetStringSlice("JWT_PREVIOUS_KEYS", nil),
+			PreviousPublicKeys:     env.GetStringSlice("JWT_PREVIOUS_PUBLIC_KEYS", nil),
+			KeyGracePeriod:         env.GetDuration("JWT_KEY_GRACE_PERIOD", 24*time.Hour),
+			Issuer:                 env.GetString("JWT_ISSUER", "social-media-service"),
+			Audience:               env.GetString("JWT_AUDIENCE", "social-media-api"),
+			Leeway:                 env.GetDuration("JWT_LEEWAY", 30*time.Second),
+			Expiration:             env.GetInt("JWT_EXPIRATION", 24),
+			RefreshExpiration:      env.GetDuration("JWT_REFRESH_EXPIRATION", 30*24*time.Hour),
+			ShortRefreshExpiration: env.GetDuration("JWT_SHORT_REFRESH_EXPIRATION", 24*time.Hour),
 		},
 		Storage: StorageConfig{
 			MaxSize:     env.GetInt64("MAX_FILE_SIZE", 104857600), // 100MB
@@ -104,13 +373,129 @@ func Load() *Config {
 			ImageResizeWidth:  env.GetInt("IMAGE_RESIZE_WIDTH", 600),
 			ImageResizeHeight: env.GetInt("IMAGE_RESIZE_HEIGHT", 600),
 			ImageQuality:      env.GetInt("IMAGE_QUALITY", 85),
+
+			// Avatar Processing Configuration
+			AvatarSize:    env.GetInt("AVATAR_SIZE", 256),
+			AvatarQuality: env.GetInt("AVATAR_QUALITY", 85),
+
+			// Image Processing Worker Pool
+			ImageWorkers:    env.GetInt("IMAGE_WORKERS", runtime.NumCPU()),
+			ImageQueueSize:  env.GetInt("IMAGE_QUEUE_SIZE", 32),
+			ImageJobTimeout: env.GetDuration("IMAGE_JOB_TIMEOUT", 30*time.Second),
+
+			// Background Post Image Processing
+			ImagePollInterval: env.GetDuration("IMAGE_POLL_INTERVAL", 30*time.Second),
+		},
+		Story: StoryConfig{
+			TTL:           env.GetDuration("STORY_TTL", 24*time.Hour),
+			SweepInterval: env.GetDuration("STORY_SWEEP_INTERVAL", 5*time.Minute),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:           env.GetDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+			SweepInterval: env.GetDuration("IDEMPOTENCY_SWEEP_INTERVAL", time.Hour),
+		},
+		Audit: AuditConfig{
+			Enabled:       env.GetBool("AUDIT_ENABLED", true),
+			BufferSize:    env.GetInt("AUDIT_BUFFER_SIZE", 1000),
+			FlushInterval: env.GetDuration("AUDIT_FLUSH_INTERVAL", time.Second),
+		},
+		ShortLink: ShortLinkConfig{
+			BaseURL:    env.GetString("SHORT_LINK_BASE_URL", "http://localhost:8080"),
+			PostTarget: env.GetString("SHORT_LINK_POST_TARGET", "/api/posts/{id}"),
+		},
+		Feed: FeedConfig{
+			BaseURL:  env.GetString("FEED_BASE_URL", "http://localhost:8080"),
+			Title:    env.GetString("FEED_TITLE", "Social Media Service"),
+			PostLink: env.GetString("FEED_POST_LINK", "/api/posts/{id}"),
+			Size:     env.GetInt("FEED_SIZE", 20),
+			CacheTTL: env.GetDuration("FEED_CACHE_TTL", 5*time.Minute),
+		},
+		Timeline: TimelineConfig{
+			FanoutMaxFollowers: env.GetInt("TIMELINE_FANOUT_MAX_FOLLOWERS", 10000),
+			FanoutWorkers:      env.GetInt("TIMELINE_FANOUT_WORKERS", 2),
+			PollInterval:       env.GetDuration("TIMELINE_POLL_INTERVAL", 30*time.Second),
+		},
+		Account: AccountConfig{
+			CacheTTL:  env.GetDuration("ACCOUNT_CACHE_TTL", 30*time.Second),
+			CacheSize: env.GetInt("ACCOUNT_CACHE_SIZE", 10000),
+		},
+		Moderation: ModerationConfig{
+			ModeratorIDs: env.GetInt64Slice("MODERATOR_ACCOUNT_IDS", nil),
+		},
+		Mail: MailConfig{
+			SMTPHost:     env.GetString("SMTP_HOST", ""),
+			SMTPPort:     env.GetInt("SMTP_PORT", 587),
+			SMTPUsername: env.GetString("SMTP_USERNAME", ""),
+			SMTPPassword: env.GetString("SMTP_PASSWORD", ""),
+			From:         env.GetString("MAIL_FROM", "no-reply@localhost"),
+
+			EmailChangeTTL:  env.GetDuration("EMAIL_CHANGE_TTL", 24*time.Hour),
+			EmailConfirmURL: env.GetString("EMAIL_CONFIRM_URL", "http://localhost:3000/account/email/confirm"),
+		},
+		OAuth: OAuthConfig{
+			RedirectBaseURL:    env.GetString("OAUTH_REDIRECT_BASE_URL", "http://localhost:8080"),
+			GoogleClientID:     env.GetString("GOOGLE_CLIENT_ID", ""),
+			GoogleClientSecret: env.GetString("GOOGLE_CLIENT_SECRET", ""),
+			GitHubClientID:     env.GetString("GITHUB_CLIENT_ID", ""),
+			GitHubClientSecret: env.GetString("GITHUB_CLIENT_SECRET", ""),
+		},
+		Export: ExportConfig{
+			TTL:          env.GetDuration("EXPORT_TTL", 7*24*time.Hour),
+			PollInterval: env.GetDuration("EXPORT_POLL_INTERVAL", 30*time.Second),
+		},
+		APIKey: APIKeyConfig{
+			DefaultRateLimit: env.GetInt("API_KEY_RATE_LIMIT", 60),
+			MaxRateLimit:     env.GetInt("API_KEY_MAX_RATE_LIMIT", 600),
+		},
+		Login: LoginConfig{
+			MaxAttempts:      env.GetInt("LOGIN_MAX_ATTEMPTS", 5),
+			MaxAttemptsPerIP: env.GetInt("LOGIN_MAX_ATTEMPTS_PER_IP", 20),
+			AttemptWindow:    env.GetDuration("LOGIN_ATTEMPT_WINDOW", 15*time.Minute),
+			LockoutBase:      env.GetDuration("LOGIN_LOCKOUT_BASE", time.Minute),
+			LockoutMax:       env.GetDuration("LOGIN_LOCKOUT_MAX", time.Hour),
+			NewDeviceAlerts:  env.GetBool("LOGIN_NEW_DEVICE_ALERTS", true),
+		},
+		Password: PasswordConfig{
+			MinLength:     env.GetInt("PASSWORD_MIN_LENGTH", 8),
+			MaxLength:     env.GetInt("PASSWORD_MAX_LENGTH", 72),
+			RequireUpper:  env.GetBool("PASSWORD_REQUIRE_UPPER", false),
+			RequireLower:  env.GetBool("PASSWORD_REQUIRE_LOWER", false),
+			RequireDigit:  env.GetBool("PASSWORD_REQUIRE_DIGIT", false),
+			RequireSymbol: env.GetBool("PASSWORD_REQUIRE_SYMBOL", false),
+			CheckBreached: env.GetBool("PASSWORD_CHECK_BREACHED", true),
+			BreachAPIURL:  env.GetString("PASSWORD_BREACH_API_URL", "https://api.pwnedpasswords.com/range"),
+			BreachTimeout: env.GetDuration("PASSWORD_BREACH_TIMEOUT", 3*time.Second),
+
+			HashAlgorithm:     env.GetString("PASSWORD_HASH_ALGORITHM", "bcrypt"),
+			BcryptCost:        env.GetInt("PASSWORD_BCRYPT_COST", 10),
+			Argon2Memory:      uint32(env.GetInt("PASSWORD_ARGON2_MEMORY", 64*1024)),
+			Argon2Iterations:  uint32(env.GetInt("PASSWORD_ARGON2_ITERATIONS", 3)),
+			Argon2Parallelism: uint8(env.GetInt("PASSWORD_ARGON2_PARALLELISM", 2)),
 		},
 		StatsD: StatsDConfig{
 			Host:     env.GetString("STATSD_HOST", "localhost"),
 			Port:     env.GetInt("STATSD_PORT", 8125),
 			Prefix:   env.GetString("STATSD_PREFIX", "social_media"),
 			Sampling: env.GetFloat64("STATSD_SAMPLING", 1.0),
-			Enabled:  env.GetBool("STATSD_ENABLED", true),
+			Enabled:  env.GetBool("STATSD_ENABLED", false),
+		},
+		InfluxDB: InfluxDBConfig{
+			Enabled:       env.GetBool("INFLUXDB_ENABLED", true),
+			Host:          env.GetString("INFLUXDB_HOST", "http://localhost:8086"),
+			Token:         env.GetString("INFLUXDB_TOKEN", ""),
+			Org:           env.GetString("INFLUXDB_ORG", "social-media"),
+			Bucket:        env.GetString("INFLUXDB_BUCKET", "metrics"),
+			BatchSize:     env.GetInt("INFLUXDB_BATCH_SIZE", 500),
+			FlushInterval: env.GetDuration("INFLUXDB_FLUSH_INTERVAL", time.Second),
+			BufferSize:    env.GetInt("INFLUXDB_BUFFER_SIZE", 10000),
+			RetryInterval: env.GetDuration("INFLUXDB_RETRY_INTERVAL", 30*time.Second),
+		},
+		Prometheus: PrometheusConfig{
+			Enabled:   env.GetBool("PROMETHEUS_ENABLED", false),
+			Path:      env.GetString("PROMETHEUS_PATH", "/metrics"),
+			Namespace: env.GetString("PROMETHEUS_NAMESPACE", "social_media"),
+			Username:  env.GetString("PROMETHEUS_USERNAME", ""),
+			Password:  env.GetString("PROMETHEUS_PASSWORD", ""),
 		},
 	}
 }
@@ -2,44 +2,175 @@ package app
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"mime/multipart"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fanzru/social-media-service-go/internal/app/account"
 	"github.com/fanzru/social-media-service-go/internal/app/account/repo"
+	"github.com/fanzru/social-media-service-go/pkg/cache"
+	"github.com/fanzru/social-media-service-go/pkg/eventbus"
 	"github.com/fanzru/social-media-service-go/pkg/jwt"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/metrics"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"github.com/fanzru/social-media-service-go/pkg/oauth"
+	"github.com/fanzru/social-media-service-go/pkg/reqctx"
+	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
 )
 
 // Service interface defines the contract for account business logic
 type Service interface {
 	Register(ctx context.Context, req *account.RegisterRequest) (*account.Account, error)
 	Login(ctx context.Context, req *account.LoginRequest) (*account.LoginResponse, error)
+	// ChangePassword replaces the password after checking the current one; other sessions are signed out
+	ChangePassword(ctx context.Context, accountID, sessionID int64, req *account.ChangePasswordRequest) error
+	// RefreshToken exchanges a refresh token for a new token pair; the used token is revoked
+	RefreshToken(ctx context.Context, req *account.RefreshTokenRequest) (*account.LoginResponse, error)
+	// Logout revokes the presented access token and, when given, the refresh token
+	Logout(ctx context.Context, accountID, sessionID int64, tokenID string, expiresAt time.Time, req *account.LogoutRequest) error
+	// LogoutAll signs out every device, including the caller, by invalidating all tokens of the account
+	LogoutAll(ctx context.Context, accountID int64) error
+	// ListSessions returns the active sessions of the account, flagging the current one
+	ListSessions(ctx context.Context, accountID, currentSessionID int64) ([]account.Session, error)
+	// RevokeSession signs a device out by revoking its session
+	RevokeSession(ctx context.Context, accountID, sessionID int64) error
+	// CreateAPIKey mints an API key; the returned key is not stored and cannot be shown again
+	CreateAPIKey(ctx context.Context, accountID int64, req *account.CreateAPIKeyRequest) (*account.CreateAPIKeyResponse, error)
+	// ListAPIKeys returns the active API keys of the account
+	ListAPIKeys(ctx context.Context, accountID int64) ([]account.APIKey, error)
+	// RevokeAPIKey revokes an API key of the account
+	RevokeAPIKey(ctx context.Context, accountID, keyID int64) error
+	// AuthenticateAPIKey resolves an API key to its account for the auth middleware
+	AuthenticateAPIKey(ctx context.Context, key string) (*middleware.APIKeyPrincipal, error)
+	// RecordSecurityEvent appends an event to the account's security log; failures are only logged
+	RecordSecurityEvent(ctx context.Context, accountID int64, eventType, details string)
+	// ListSecurityEvents returns a page of the account's security log, newest first
+	ListSecurityEvents(ctx context.Context, accountID int64, cursor string, limit int) (*account.SecurityEventListResponse, error)
+	// RequestEmailChange stages a new email and sends a confirmation token to it
+	RequestEmailChange(ctx context.Context, accountID int64, req *account.ChangeEmailRequest) error
+	// ConfirmEmailChange swaps in the staged email of the token's account
+	ConfirmEmailChange(ctx context.Context, req *account.ConfirmEmailRequest) (*account.Account, error)
+	// OAuthLoginURL returns the consent page URL of an OAuth provider
+	OAuthLoginURL(provider, state string) (string, error)
+	// OAuthLogin completes a social login, creating or linking the account, and issues tokens
+	OAuthLogin(ctx context.Context, provider, code string) (*account.LoginResponse, error)
 	GetAccountByID(ctx context.Context, id int64) (*account.Account, error)
+	// GetAccountsByIDs looks up many accounts in one query, keyed by ID; unknown IDs are left out
+	GetAccountsByIDs(ctx context.Context, ids []int64) (map[int64]*account.Account, error)
 	UpdateAccount(ctx context.Context, acc *account.Account) error
 	DeleteAccount(ctx context.Context, id int64) error
+	// DeactivateAccount hides the account and its content until the user signs in again
+	DeactivateAccount(ctx context.Context, id int64) error
 	// GDPRDeleteAccount permanently deletes the account and all associated data
 	GDPRDeleteAccount(ctx context.Context, id int64) error
+	// UpdateAvatar processes and stores a new avatar for the account
+	UpdateAvatar(ctx context.Context, id int64, file multipart.File, header *multipart.FileHeader) (*account.Account, error)
+	// GetSettings returns the preferences and privacy settings of the account
+	GetSettings(ctx context.Context, id int64) (*account.Settings, error)
+	// UpdateSettings updates the preferences and privacy settings of the account
+	UpdateSettings(ctx context.Context, id int64, req *account.UpdateSettingsRequest) (*account.Settings, error)
+	// SetVerified grants or revokes the verified badge of an account; only admins may do so
+	SetVerified(ctx context.Context, adminID int64, accountID int64, isVerified bool) (*account.Account, error)
 }
 
 // service implements the Service interface
 type service struct {
 	repo       repo.Repository
 	jwtService *jwt.Service
-	imageStore ImageDeleter
+	imageStore ImageStore
+	mailer     Mailer
+	providers  oauth.Registry
+	passwords  PasswordPolicy
+	hasher     PasswordHasher
+	changes    eventbus.Publisher
+	cache      cache.Store
+	metrics    metrics.Sink
+	cfg        Config
 }
 
+// Config holds the settings of the account service
+type Config struct {
+	RefreshTTL      time.Duration // lifetime of refresh tokens of remembered sessions
+	ShortRefreshTTL time.Duration // lifetime of refresh tokens of sessions without remember me
+	EmailChangeTTL  time.Duration // lifetime of email change confirmation tokens
+	EmailConfirmURL string        // link sent to confirm an email change
+	APIKeyRateLimit int           // default requests per minute of an API key
+	APIKeyMaxLimit  int           // highest rate limit a user may set on an API key
+	// Login throttling: after MaxLoginAttempts failures for an email, or MaxLoginAttemptsPerIP
+	// from one IP, within LoginAttemptWindow, sign-in is locked for LockoutBase, doubling
+	// with every further failure up to LockoutMax
+	MaxLoginAttempts      int
+	MaxLoginAttemptsPerIP int
+	LoginAttemptWindow    time.Duration
+	LockoutBase           time.Duration
+	LockoutMax            time.Duration
+	NewDeviceAlerts       bool // email the owner when a sign-in comes from an unseen device
+	// How long account lookups by ID are reused; 0 disables caching. Announced changes drop
+	// the cached account at once, while activity timestamps may lag by up to this long.
+	CacheTTL time.Duration
+}
+
+// accountKeyPrefix starts the cache keys of accounts looked up by ID
+const accountKeyPrefix = "account:"
+
+// apiKeyPrefix marks API keys so they are recognisable in configs and secret scanners
+const apiKeyPrefix = "smk_"
+
 // ImageDeleter defines the capability needed to delete images
 type ImageDeleter interface {
 	DeleteImage(imagePath string) error
 }
 
-// NewService creates a new account service
-func NewService(repo repo.Repository, jwtService *jwt.Service, imageStore ImageDeleter) Service {
+// ImageStore defines the image capabilities needed by the account service
+type ImageStore interface {
+	ImageDeleter
+	ProcessAndUploadAvatar(file multipart.File, header *multipart.FileHeader) (string, string, error)
+}
+
+// Mailer defines the capability needed to send account emails
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// PasswordPolicy defines the checks a new password must pass
+type PasswordPolicy interface {
+	Validate(ctx context.Context, password string) error
+}
+
+// PasswordHasher defines how passwords are hashed and verified; Verify also reports
+// whether a matching hash was made with outdated parameters and should be replaced
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password, hash string) (bool, bool, error)
+}
+
+// NewService creates a new account service; changes may be nil when nothing subscribes to
+// account changes, and store may be nil to read every account from the repository. The
+// store must be invalidated with CacheKeys. Registrations and failed sign-ins are counted
+// on sink, which may be nil.
+func NewService(repo repo.Repository, jwtService *jwt.Service, imageStore ImageStore, mailer Mailer, providers oauth.Registry, passwords PasswordPolicy, hasher PasswordHasher, changes eventbus.Publisher, store cache.Store, sink metrics.Sink, cfg Config) Service {
 	return &service{
 		repo:       repo,
 		jwtService: jwtService,
 		imageStore: imageStore,
+		mailer:     mailer,
+		providers:  providers,
+		passwords:  passwords,
+		hasher:     hasher,
+		changes:    changes,
+		cache:      store,
+		metrics:    sink,
+		cfg:        cfg,
 	}
 }
 
@@ -51,74 +182,941 @@ func (s *service) Register(ctx context.Context, req *account.RegisterRequest) (*
 		return nil, fmt.Errorf("failed to check existing email: %w", err)
 	}
 	if existingAccount != nil {
-		return nil, fmt.Errorf("email already exists")
+		return nil, account.ErrEmailExists
+	}
+
+	if err := s.passwords.Validate(ctx, req.Password); err != nil {
+		return nil, err
 	}
 
 	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := s.hasher.Hash(req.Password)
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
 	// Create account
 	acc := &account.Account{
-		Name:     req.Name,
-		Email:    req.Email,
-		Password: string(hashedPassword),
+		Name:             req.Name,
+		Email:            req.Email,
+		Password:         hashedPassword,
+		SensitiveContent: account.SensitiveContentBlur,
+		Role:             account.RoleUser,
 	}
 
 	err = s.repo.Create(ctx, acc)
 	if err != nil {
+		// Another registration may have taken the email since the check above
+		if sqlwrap.IsUniqueViolation(err) {
+			return nil, account.ErrEmailExists
+		}
 		return nil, fmt.Errorf("failed to create account: %w", err)
 	}
+	metrics.CountEvent(s.metrics, metrics.Registrations, map[string]string{"method": "password"})
 
 	return acc, nil
 }
 
-// Login authenticates a user
+// Login authenticates a user. Repeated failures lock sign-in for the email or the client IP.
 func (s *service) Login(ctx context.Context, req *account.LoginRequest) (*account.LoginResponse, error) {
+	emailSubject := "email:" + strings.ToLower(strings.TrimSpace(req.Email))
+	ipSubject := ""
+	if ip := reqctx.GetClientIP(ctx); ip != "" {
+		ipSubject = "ip:" + ip
+	}
+
+	if err := s.checkLoginLock(ctx, emailSubject, ipSubject); err != nil {
+		return nil, s.loginFailed(err)
+	}
+
 	// Get account by email
 	acc, err := s.repo.GetByEmail(ctx, req.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("invalid credentials")
+			return nil, s.loginFailed(s.recordLoginFailure(ctx, emailSubject, ipSubject))
 		}
 		return nil, fmt.Errorf("failed to get account: %w", err)
 	}
 
 	// Verify password
-	err = bcrypt.CompareHashAndPassword([]byte(acc.Password), []byte(req.Password))
+	ok, needsRehash, err := s.hasher.Verify(req.Password, acc.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify password: %w", err)
+	}
+	if !ok {
+		s.RecordSecurityEvent(ctx, acc.ID, account.SecurityEventLoginFailure, "invalid password")
+		return nil, s.loginFailed(s.recordLoginFailure(ctx, emailSubject, ipSubject))
+	}
+
+	// The plaintext is only available here, so hashes made with old parameters are upgraded on login
+	if needsRehash {
+		s.rehashPassword(ctx, acc.ID, req.Password)
+	}
+
+	// Only the email is cleared; clearing the IP would let an attacker reset it with their own account
+	if err := s.repo.ClearLoginFailures(ctx, emailSubject); err != nil {
+		return nil, fmt.Errorf("failed to clear login failures: %w", err)
+	}
+
+	return s.startLoginSession(ctx, acc, "password", req.RememberMe)
+}
+
+// ChangePassword verifies the current password, applies the password policy to the new one
+// and signs out every other session
+func (s *service) ChangePassword(ctx context.Context, accountID, sessionID int64, req *account.ChangePasswordRequest) error {
+	acc, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return account.ErrNotAuthenticated
+		}
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	ok, _, err := s.hasher.Verify(req.CurrentPassword, acc.Password)
+	if err != nil {
+		return fmt.Errorf("failed to verify password: %w", err)
+	}
+	if !ok {
+		return account.ErrWrongPassword
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return account.ErrSamePassword
+	}
+
+	if err := s.passwords.Validate(ctx, req.NewPassword); err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.hasher.Hash(req.NewPassword)
 	if err != nil {
-		return nil, fmt.Errorf("invalid credentials")
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.repo.UpdatePassword(ctx, accountID, hashedPassword); err != nil {
+		if err == sql.ErrNoRows {
+			return account.ErrNotAuthenticated
+		}
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if err := s.repo.RevokeOtherSessions(ctx, accountID, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke other sessions: %w", err)
+	}
+
+	s.RecordSecurityEvent(ctx, accountID, account.SecurityEventPasswordChange, "")
+	return nil
+}
+
+// rehashPassword replaces a password hash made with outdated parameters; a failure only
+// leaves the old hash in place, so it is logged rather than failing the login
+func (s *service) rehashPassword(ctx context.Context, accountID int64, password string) {
+	hashedPassword, err := s.hasher.Hash(password)
+	if err == nil {
+		err = s.repo.UpdatePassword(ctx, accountID, hashedPassword)
+	}
+	if err != nil {
+		logger.GetGlobal().Warn("Failed to rehash password", "account_id", accountID, "error", err.Error())
+	}
+}
+
+// checkLoginLock returns a LoginLockedError while the email or IP is locked
+func (s *service) checkLoginLock(ctx context.Context, emailSubject, ipSubject string) error {
+	subjects := []struct{ subject, scope string }{
+		{emailSubject, account.LockScopeAccount},
+		{ipSubject, account.LockScopeIP},
+	}
+	for _, sub := range subjects {
+		if sub.subject == "" {
+			continue
+		}
+		lockedUntil, err := s.repo.GetLoginLockedUntil(ctx, sub.subject)
+		if err != nil {
+			return fmt.Errorf("failed to check login lock: %w", err)
+		}
+		if lockedUntil != nil {
+			return &account.LoginLockedError{Scope: sub.scope, RetryAfter: time.Until(*lockedUntil)}
+		}
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed sign-in for the email and IP and locks the ones
+// over their limit. It returns the error Login should report.
+func (s *service) recordLoginFailure(ctx context.Context, emailSubject, ipSubject string) error {
+	subjects := []struct {
+		subject, scope string
+		limit          int
+	}{
+		{emailSubject, account.LockScopeAccount, s.cfg.MaxLoginAttempts},
+		{ipSubject, account.LockScopeIP, s.cfg.MaxLoginAttemptsPerIP},
+	}
+
+	var locked *account.LoginLockedError
+	for _, sub := range subjects {
+		if sub.subject == "" || sub.limit <= 0 {
+			continue
+		}
+		failures, err := s.repo.RecordLoginFailure(ctx, sub.subject, time.Now().Add(-s.cfg.LoginAttemptWindow))
+		if err != nil {
+			return fmt.Errorf("failed to record login failure: %w", err)
+		}
+		if failures < sub.limit {
+			continue
+		}
+
+		lockout := lockoutDuration(failures-sub.limit, s.cfg.LockoutBase, s.cfg.LockoutMax)
+		if err := s.repo.LockLogin(ctx, sub.subject, time.Now().Add(lockout)); err != nil {
+			return fmt.Errorf("failed to lock login: %w", err)
+		}
+		if locked == nil {
+			locked = &account.LoginLockedError{Scope: sub.scope, RetryAfter: lockout}
+		}
+	}
+
+	if locked != nil {
+		return locked
+	}
+	return account.ErrInvalidCredentials
+}
+
+// loginFailed counts a sign-in refused with err, for wrong credentials or a lock, and
+// returns err. Other errors are not refusals and are returned uncounted.
+func (s *service) loginFailed(err error) error {
+	var locked *account.LoginLockedError
+	switch {
+	case errors.As(err, &locked):
+		metrics.CountEvent(s.metrics, metrics.LoginsFailed, map[string]string{"reason": "locked"})
+	case errors.Is(err, account.ErrInvalidCredentials):
+		metrics.CountEvent(s.metrics, metrics.LoginsFailed, map[string]string{"reason": "invalid_credentials"})
+	}
+	return err
+}
+
+// lockoutDuration doubles the base lockout for every failure past the limit, up to max
+func lockoutDuration(overLimit int, base, max time.Duration) time.Duration {
+	lockout := base
+	for i := 0; i < overLimit && lockout < max; i++ {
+		lockout *= 2
+	}
+	if lockout > max {
+		lockout = max
+	}
+	return lockout
+}
+
+// RefreshToken rotates a refresh token and issues a new access token
+func (s *service) RefreshToken(ctx context.Context, req *account.RefreshTokenRequest) (*account.LoginResponse, error) {
+	tokenHash := hashToken(req.RefreshToken)
+
+	accountID, sessionID, rememberMe, err := s.repo.ConsumeRefreshToken(ctx, tokenHash)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to consume refresh token: %w", err)
+		}
+
+		// A rotated token being presented again means it may have been stolen,
+		// so every session of the owner is revoked
+		ownerID, rotated, ownerErr := s.repo.GetRefreshTokenOwner(ctx, tokenHash)
+		if ownerErr == nil && rotated {
+			if err := s.repo.RevokeAllSessions(ctx, ownerID); err != nil {
+				return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+			}
+			s.RecordSecurityEvent(ctx, ownerID, account.SecurityEventRefreshTokenReuse, "all sessions revoked")
+		}
+		return nil, account.ErrInvalidRefreshToken
+	}
+
+	acc, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, account.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	// Tokens issued before sessions were tracked start a new session
+	var resp *account.LoginResponse
+	if sessionID == 0 {
+		resp, err = s.startSession(ctx, acc, rememberMe)
+	} else {
+		if err := s.repo.TouchSession(ctx, sessionID, reqctx.GetClientIP(ctx)); err != nil {
+			return nil, fmt.Errorf("failed to update session: %w", err)
+		}
+		resp, err = s.issueTokens(ctx, acc, sessionID, rememberMe)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.RecordSecurityEvent(ctx, acc.ID, account.SecurityEventTokenRefresh, "")
+	return resp, nil
+}
+
+// Logout denylists the access token until it expires, ends its session and
+// revokes the refresh token if supplied
+func (s *service) Logout(ctx context.Context, accountID, sessionID int64, tokenID string, expiresAt time.Time, req *account.LogoutRequest) error {
+	if err := s.repo.RevokeToken(ctx, tokenID, accountID, expiresAt); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	if sessionID != 0 {
+		if err := s.repo.RevokeSession(ctx, accountID, sessionID); err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("failed to revoke session: %w", err)
+		}
+	}
+
+	if req != nil && req.RefreshToken != "" {
+		if err := s.repo.RevokeRefreshToken(ctx, accountID, hashToken(req.RefreshToken)); err != nil {
+			return fmt.Errorf("failed to revoke refresh token: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// LogoutAll bumps the account's token version, so every access token issued so far is rejected,
+// and revokes all sessions and refresh tokens
+func (s *service) LogoutAll(ctx context.Context, accountID int64) error {
+	if err := s.repo.BumpTokenVersion(ctx, accountID); err != nil {
+		if err == sql.ErrNoRows {
+			return account.ErrNotAuthenticated
+		}
+		return fmt.Errorf("failed to bump token version: %w", err)
+	}
+
+	if err := s.repo.RevokeAllSessions(ctx, accountID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	s.RecordSecurityEvent(ctx, accountID, account.SecurityEventLogoutAll, "")
+	return nil
+}
+
+// ListSessions lists the signed-in devices of the account
+func (s *service) ListSessions(ctx context.Context, accountID, currentSessionID int64) ([]account.Session, error) {
+	sessions, err := s.repo.ListSessions(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
+	}
+	return sessions, nil
+}
+
+// RevokeSession revokes one session of the account; its access and refresh tokens stop working
+func (s *service) RevokeSession(ctx context.Context, accountID, sessionID int64) error {
+	if err := s.repo.RevokeSession(ctx, accountID, sessionID); err != nil {
+		if err == sql.ErrNoRows {
+			return account.ErrSessionNotFound
+		}
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+// CreateAPIKey validates the request and mints a new API key for the account
+func (s *service) CreateAPIKey(ctx context.Context, accountID int64, req *account.CreateAPIKeyRequest) (*account.CreateAPIKeyResponse, error) {
+	scopes := req.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{account.ScopeRead}
+	}
+	for _, scope := range scopes {
+		if !account.IsValidScope(scope) {
+			return nil, fmt.Errorf("%w: %s", account.ErrInvalidScope, scope)
+		}
+	}
+
+	rateLimit := req.RateLimit
+	if rateLimit == 0 {
+		rateLimit = s.cfg.APIKeyRateLimit
+	}
+	if rateLimit < 1 || rateLimit > s.cfg.APIKeyMaxLimit {
+		return nil, fmt.Errorf("%w: must be between 1 and %d", account.ErrInvalidRateLimit, s.cfg.APIKeyMaxLimit)
+	}
+
+	if req.ExpiresInDays < 0 {
+		return nil, fmt.Errorf("%w: must not be negative", account.ErrInvalidExpiresIn)
+	}
+
+	secret, err := newToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key := apiKeyPrefix + secret
+
+	apiKey := &account.APIKey{
+		AccountID: accountID,
+		Name:      req.Name,
+		Prefix:    key[:len(apiKeyPrefix)+8],
+		Scopes:    scopes,
+		RateLimit: rateLimit,
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		apiKey.ExpiresAt = &expiresAt
+	}
+
+	if err := s.repo.CreateAPIKey(ctx, apiKey, hashToken(key)); err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return &account.CreateAPIKeyResponse{APIKey: *apiKey, Key: key}, nil
+}
+
+// ListAPIKeys lists the API keys of the account
+func (s *service) ListAPIKeys(ctx context.Context, accountID int64) ([]account.APIKey, error) {
+	keys, err := s.repo.ListAPIKeys(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes an API key of the account; it stops working immediately
+func (s *service) RevokeAPIKey(ctx context.Context, accountID, keyID int64) error {
+	if err := s.repo.RevokeAPIKey(ctx, accountID, keyID); err != nil {
+		if err == sql.ErrNoRows {
+			return account.ErrAPIKeyNotFound
+		}
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return nil
+}
+
+// AuthenticateAPIKey looks up an API key and its account. Unknown, expired and revoked
+// keys, and keys of deactivated accounts, yield a nil principal.
+func (s *service) AuthenticateAPIKey(ctx context.Context, key string) (*middleware.APIKeyPrincipal, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, nil
+	}
+
+	apiKey, err := s.repo.GetAPIKeyByHash(ctx, hashToken(key))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	acc, err := s.cachedAccount(ctx, apiKey.AccountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	if acc.Status == account.StatusDeactivated {
+		return nil, nil
+	}
+
+	// Usage tracking must not block the request
+	if err := s.repo.TouchAPIKey(ctx, apiKey.ID); err != nil {
+		logger.GetGlobal().Warn("Failed to update api key usage", "api_key_id", apiKey.ID, "error", err.Error())
+	}
+
+	// Keys have no role scope, so they act as a plain user whatever the owner's role
+	return &middleware.APIKeyPrincipal{
+		KeyID:      apiKey.ID,
+		AccountID:  acc.ID,
+		Email:      acc.Email,
+		Name:       acc.Name,
+		Role:       account.RoleUser,
+		IsVerified: acc.IsVerified,
+		Scopes:     apiKey.Scopes,
+		RateLimit:  apiKey.RateLimit,
+	}, nil
+}
+
+// RecordSecurityEvent appends an event with the caller's IP and user agent to the security log.
+// The audited action has already happened, so a failed write is logged instead of returned.
+func (s *service) RecordSecurityEvent(ctx context.Context, accountID int64, eventType, details string) {
+	event := &account.SecurityEvent{
+		AccountID: accountID,
+		EventType: eventType,
+		IPAddress: reqctx.GetClientIP(ctx),
+		UserAgent: reqctx.GetUserAgent(ctx),
+		Details:   details,
+	}
+	if err := s.repo.CreateSecurityEvent(ctx, event); err != nil {
+		logger.GetGlobal().Error("Failed to record security event", "account_id", accountID, "event_type", eventType, "error", err.Error())
+	}
+}
+
+// ListSecurityEvents returns a page of the account's security log
+func (s *service) ListSecurityEvents(ctx context.Context, accountID int64, cursor string, limit int) (*account.SecurityEventListResponse, error) {
+	events, err := s.repo.ListSecurityEvents(ctx, accountID, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security events: %w", err)
+	}
+	return events, nil
+}
+
+// startSession records a new session for the requesting device and issues its tokens.
+// Signing in to a deactivated account reactivates it.
+func (s *service) startSession(ctx context.Context, acc *account.Account, rememberMe bool) (*account.LoginResponse, error) {
+	reactivated := false
+	if acc.Status == account.StatusDeactivated {
+		if err := s.repo.SetStatus(ctx, acc.ID, account.StatusActive); err != nil {
+			return nil, fmt.Errorf("failed to reactivate account: %w", err)
+		}
+		acc.Status = account.StatusActive
+		reactivated = true
+	}
+
+	sessionID, err := s.repo.CreateSession(ctx, acc.ID, reqctx.GetClientIP(ctx), reqctx.GetUserAgent(ctx), rememberMe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	resp, err := s.issueTokens(ctx, acc, sessionID, rememberMe)
+	if err != nil {
+		return nil, err
+	}
+	resp.Reactivated = reactivated
+	return resp, nil
+}
+
+// startLoginSession starts a session for a successful sign-in and records it in the security log
+func (s *service) startLoginSession(ctx context.Context, acc *account.Account, method string, rememberMe bool) (*account.LoginResponse, error) {
+	resp, err := s.startSession(ctx, acc, rememberMe)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.RecordLogin(ctx, acc.ID); err != nil {
+		logger.GetGlobal().Warn("Failed to record last login", "account_id", acc.ID, "error", err.Error())
+	}
+	s.RecordSecurityEvent(ctx, acc.ID, account.SecurityEventLoginSuccess, method)
+	s.checkLoginDevice(ctx, acc)
+	return resp, nil
+}
+
+// checkLoginDevice remembers the device of a sign-in and alerts the owner when it has not
+// been seen before. The first device of an account is trusted without an alert. Failures
+// are logged so they never block the sign-in.
+func (s *service) checkLoginDevice(ctx context.Context, acc *account.Account) {
+	ipAddress := reqctx.GetClientIP(ctx)
+	userAgent := reqctx.GetUserAgent(ctx)
+	sum := sha256.Sum256([]byte(ipAddress + "\n" + userAgent))
+
+	isNew, hadDevices, err := s.repo.RecordSeenDevice(ctx, acc.ID, hex.EncodeToString(sum[:]), ipAddress, userAgent)
+	if err != nil {
+		logger.GetGlobal().Error("Failed to record login device", "account_id", acc.ID, "error", err.Error())
+		return
+	}
+	if !isNew || !hadDevices {
+		return
+	}
+
+	s.RecordSecurityEvent(ctx, acc.ID, account.SecurityEventNewDeviceLogin, "")
+	if !s.cfg.NewDeviceAlerts {
+		return
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nYour account was just signed in to from a new device.\n\nTime: %s\nIP address: %s\nDevice: %s\n\nIf this was you, no action is needed. If not, change your password and sign out of all devices.\n",
+		acc.Name, time.Now().UTC().Format(time.RFC1123), ipAddress, userAgent)
+	if err := s.mailer.Send(ctx, acc.Email, "New sign-in to your account", body); err != nil {
+		logger.GetGlobal().Error("Failed to send new device alert", "account_id", acc.ID, "error", err.Error())
+	}
+}
+
+// issueTokens generates an access token and persists a new refresh token for the account session.
+// Sessions without remember me get short-lived refresh tokens, and access tokens never outlive them.
+func (s *service) issueTokens(ctx context.Context, acc *account.Account, sessionID int64, rememberMe bool) (*account.LoginResponse, error) {
+	refreshTTL := s.cfg.RefreshTTL
+	if !rememberMe {
+		refreshTTL = s.cfg.ShortRefreshTTL
 	}
 
 	// Generate JWT token
-	accessToken, err := s.jwtService.GenerateToken(acc.ID, acc.Email, acc.Name)
+	accessToken, err := s.jwtService.GenerateToken(acc.ID, sessionID, acc.TokenVersion, acc.Email, acc.Name, acc.Role, acc.IsVerified, refreshTTL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
+	refreshToken, err := newToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	err = s.repo.CreateRefreshToken(ctx, acc.ID, sessionID, hashToken(refreshToken), time.Now().Add(refreshTTL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
 	return &account.LoginResponse{
-		Account:     *acc,
-		AccessToken: accessToken,
-		TokenType:   "Bearer",
-		ExpiresIn:   s.jwtService.GetExpiresInSeconds(),
+		Account:          *acc,
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(s.jwtService.TokenTTL(refreshTTL).Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresIn: int64(refreshTTL.Seconds()),
 	}, nil
 }
 
-// GetAccountByID retrieves an account by ID
+// RequestEmailChange stages the new email and mails a confirmation link to it
+func (s *service) RequestEmailChange(ctx context.Context, accountID int64, req *account.ChangeEmailRequest) error {
+	acc, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return account.ErrNotAuthenticated
+		}
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	newEmail := strings.TrimSpace(req.Email)
+	if newEmail == acc.Email {
+		return account.ErrSameEmail
+	}
+
+	taken, err := s.repo.IsEmailTaken(ctx, newEmail, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to check existing email: %w", err)
+	}
+	if taken {
+		return account.ErrEmailExists
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+
+	err = s.repo.CreateEmailChange(ctx, accountID, newEmail, hashToken(token), time.Now().Add(s.cfg.EmailChangeTTL))
+	if err != nil {
+		return fmt.Errorf("failed to stage email change: %w", err)
+	}
+
+	link := s.cfg.EmailConfirmURL + "?token=" + url.QueryEscape(token)
+	body := fmt.Sprintf("Hi %s,\n\nConfirm your new email address by opening the link below:\n\n%s\n\nThe link expires in %s. If you did not request this change you can ignore this email.\n",
+		acc.Name, link, s.cfg.EmailChangeTTL)
+	if err := s.mailer.Send(ctx, newEmail, "Confirm your new email address", body); err != nil {
+		return fmt.Errorf("failed to send confirmation email: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange applies a staged email once its token is presented
+func (s *service) ConfirmEmailChange(ctx context.Context, req *account.ConfirmEmailRequest) (*account.Account, error) {
+	tokenHash := hashToken(req.Token)
+
+	accountID, newEmail, err := s.repo.GetEmailChange(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, account.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get email change: %w", err)
+	}
+
+	// The address may have been registered since the change was requested
+	taken, err := s.repo.IsEmailTaken(ctx, newEmail, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing email: %w", err)
+	}
+	if taken {
+		if err := s.repo.DeleteEmailChange(ctx, accountID); err != nil {
+			return nil, fmt.Errorf("failed to delete email change: %w", err)
+		}
+		return nil, account.ErrEmailExists
+	}
+
+	if _, err := s.repo.ConfirmEmailChange(ctx, tokenHash); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, account.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to confirm email change: %w", err)
+	}
+	s.publish(ctx, eventbus.AccountUpdated, accountID)
+
+	return s.repo.GetByID(ctx, accountID)
+}
+
+// OAuthLoginURL builds the provider consent page URL
+func (s *service) OAuthLoginURL(provider, state string) (string, error) {
+	p, ok := s.providers.Get(provider)
+	if !ok {
+		return "", account.ErrProviderUnsupported
+	}
+	return p.AuthCodeURL(state), nil
+}
+
+// OAuthLogin exchanges the authorization code and signs in the linked account.
+// Unknown identities are linked to the account with the same verified email,
+// otherwise a new account without a usable password is created.
+func (s *service) OAuthLogin(ctx context.Context, provider, code string) (*account.LoginResponse, error) {
+	p, ok := s.providers.Get(provider)
+	if !ok {
+		return nil, account.ErrProviderUnsupported
+	}
+
+	accessToken, err := p.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("oauth login failed: %w", err)
+	}
+
+	user, err := p.FetchUser(ctx, accessToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", account.ErrOAuthLoginFailed, err)
+	}
+	if user.ProviderUserID == "" {
+		return nil, fmt.Errorf("%w: provider returned no user id", account.ErrOAuthLoginFailed)
+	}
+
+	accountID, err := s.repo.GetAccountIDByIdentity(ctx, p.Name, user.ProviderUserID)
+	if err == nil {
+		acc, err := s.repo.GetByID(ctx, accountID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, fmt.Errorf("%w: account is deleted", account.ErrOAuthLoginFailed)
+			}
+			return nil, fmt.Errorf("failed to get account: %w", err)
+		}
+		return s.startLoginSession(ctx, acc, "oauth:"+p.Name, true)
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	// Only a verified email may be used to link or create an account
+	if user.Email == "" || !user.EmailVerified {
+		return nil, fmt.Errorf("%w: provider did not return a verified email", account.ErrOAuthLoginFailed)
+	}
+
+	acc, err := s.repo.GetByEmail(ctx, user.Email)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to check existing email: %w", err)
+	}
+	if acc == nil {
+		acc, err = s.createOAuthAccount(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		metrics.CountEvent(s.metrics, metrics.Registrations, map[string]string{"method": "oauth:" + p.Name})
+	}
+
+	if err := s.repo.CreateIdentity(ctx, acc.ID, p.Name, user.ProviderUserID, user.Email); err != nil {
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+
+	return s.startLoginSession(ctx, acc, "oauth:"+p.Name, true)
+}
+
+// createOAuthAccount registers an account for a social login; the random password
+// cannot be used to sign in until the user sets one
+func (s *service) createOAuthAccount(ctx context.Context, user *oauth.UserInfo) (*account.Account, error) {
+	name := strings.TrimSpace(user.Name)
+	if name == "" {
+		name = strings.Split(user.Email, "@")[0]
+	}
+	if len(name) > 100 {
+		name = name[:100]
+	}
+
+	password, err := newToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate password: %w", err)
+	}
+	hashedPassword, err := s.hasher.Hash(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	acc := &account.Account{
+		Name:             name,
+		Email:            user.Email,
+		Password:         hashedPassword,
+		SensitiveContent: account.SensitiveContentBlur,
+		Role:             account.RoleUser,
+	}
+	if err := s.repo.Create(ctx, acc); err != nil {
+		return nil, fmt.Errorf("failed to create account: %w", err)
+	}
+
+	return acc, nil
+}
+
+// newToken returns a random URL-safe token with 256 bits of entropy
+func newToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken returns the hex encoded SHA-256 of a token; only hashes are stored
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetAccountByID retrieves an account by ID, through the account cache
 func (s *service) GetAccountByID(ctx context.Context, id int64) (*account.Account, error) {
-	return s.repo.GetByID(ctx, id)
+	return s.cachedAccount(ctx, id)
+}
+
+// GetAccountsByIDs looks up many accounts in one query, keyed by ID
+func (s *service) GetAccountsByIDs(ctx context.Context, ids []int64) (map[int64]*account.Account, error) {
+	accounts, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	byID := make(map[int64]*account.Account, len(accounts))
+	for i := range accounts {
+		byID[accounts[i].ID] = &accounts[i]
+	}
+	return byID, nil
 }
 
 // UpdateAccount updates an existing account
 func (s *service) UpdateAccount(ctx context.Context, acc *account.Account) error {
-	return s.repo.Update(ctx, acc)
+	if err := s.repo.Update(ctx, acc); err != nil {
+		return err
+	}
+	s.publish(ctx, eventbus.AccountUpdated, acc.ID)
+	return nil
 }
 
 // DeleteAccount soft deletes an account
 func (s *service) DeleteAccount(ctx context.Context, id int64) error {
-	return s.repo.SoftDelete(ctx, id)
+	if err := s.repo.SoftDelete(ctx, id); err != nil {
+		return err
+	}
+	s.publish(ctx, eventbus.AccountDeleted, id)
+	return nil
+}
+
+// DeactivateAccount marks the account deactivated and signs out every session
+func (s *service) DeactivateAccount(ctx context.Context, id int64) error {
+	if err := s.repo.SetStatus(ctx, id, account.StatusDeactivated); err != nil {
+		if err == sql.ErrNoRows {
+			return account.ErrNotAuthenticated
+		}
+		return fmt.Errorf("failed to deactivate account: %w", err)
+	}
+	s.publish(ctx, eventbus.AccountUpdated, id)
+
+	if err := s.repo.RevokeAllSessions(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSettings validates and stores the account preferences
+func (s *service) UpdateSettings(ctx context.Context, id int64, req *account.UpdateSettingsRequest) (*account.Settings, error) {
+	if req.SensitiveContent != nil && !account.IsValidSensitiveContent(*req.SensitiveContent) {
+		return nil, fmt.Errorf("%w: %s", account.ErrInvalidSensitive, *req.SensitiveContent)
+	}
+	if req.CommentPermission != nil && !account.IsValidCommentPermission(*req.CommentPermission) {
+		return nil, fmt.Errorf("%w: %s", account.ErrInvalidCommentPerm, *req.CommentPermission)
+	}
+
+	settings, err := s.GetSettings(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.SensitiveContent != nil {
+		settings.SensitiveContent = *req.SensitiveContent
+	}
+	if req.IsPrivate != nil {
+		settings.IsPrivate = *req.IsPrivate
+	}
+	if req.CommentPermission != nil {
+		settings.CommentPermission = *req.CommentPermission
+	}
+	if req.Discoverable != nil {
+		settings.Discoverable = *req.Discoverable
+	}
+
+	if err := s.repo.UpdateSettings(ctx, id, settings); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, account.ErrNotAuthenticated
+		}
+		return nil, fmt.Errorf("failed to update settings: %w", err)
+	}
+	s.publish(ctx, eventbus.AccountUpdated, id)
+
+	return settings, nil
+}
+
+// GetSettings returns the preferences and privacy settings of the account
+func (s *service) GetSettings(ctx context.Context, id int64) (*account.Settings, error) {
+	acc, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, account.ErrNotAuthenticated
+		}
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	return &account.Settings{
+		SensitiveContent:  acc.SensitiveContent,
+		IsPrivate:         acc.IsPrivate,
+		CommentPermission: acc.CommentPermission,
+		Discoverable:      acc.Discoverable,
+	}, nil
+}
+
+// SetVerified grants or revokes the verified badge of an account. The caller's role is read
+// from the database so a revoked admin cannot keep using a token issued earlier.
+func (s *service) SetVerified(ctx context.Context, adminID int64, accountID int64, isVerified bool) (*account.Account, error) {
+	admin, err := s.repo.GetByID(ctx, adminID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, account.ErrAdminRequired
+		}
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	if admin.Role != account.RoleAdmin {
+		return nil, account.ErrAdminRequired
+	}
+
+	if err := s.repo.UpdateVerified(ctx, accountID, isVerified); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, account.ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to update verification: %w", err)
+	}
+	s.publish(ctx, eventbus.AccountUpdated, accountID)
+
+	return s.repo.GetByID(ctx, accountID)
+}
+
+// UpdateAvatar uploads a new avatar and replaces the previous one
+func (s *service) UpdateAvatar(ctx context.Context, id int64, file multipart.File, header *multipart.FileHeader) (*account.Account, error) {
+	acc, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	avatarPath, avatarURL, err := s.imageStore.ProcessAndUploadAvatar(file, header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process and upload avatar: %w", err)
+	}
+
+	if err := s.repo.UpdateAvatar(ctx, id, avatarPath, avatarURL); err != nil {
+		// If the update fails, try to delete the uploaded avatar
+		s.imageStore.DeleteImage(avatarPath)
+		return nil, fmt.Errorf("failed to update avatar: %w", err)
+	}
+	s.publish(ctx, eventbus.AccountUpdated, id)
+
+	// Remove the previous avatar from storage
+	if acc.AvatarPath != "" {
+		if err := s.imageStore.DeleteImage(acc.AvatarPath); err != nil {
+			logger.GetGlobal().Warn("Failed to delete previous avatar", "avatar_path", acc.AvatarPath, "error", err.Error())
+		}
+	}
+
+	acc.AvatarPath = avatarPath
+	acc.AvatarURL = avatarURL
+	return acc, nil
 }
 
 // GDPRDeleteAccount permanently deletes an account and cleans up user images
@@ -138,6 +1136,11 @@ func (s *service) GDPRDeleteAccount(ctx context.Context, id int64) error {
 		return fmt.Errorf("failed to list user's post images: %w", err)
 	}
 
+	// Include the account avatar, if any
+	if acc, err := s.repo.GetByID(ctx, id); err == nil && acc.AvatarPath != "" {
+		imagePaths = append(imagePaths, acc.AvatarPath)
+	}
+
 	// Try deleting images first; if any fails, rollback to keep DB unchanged
 	for _, path := range imagePaths {
 		if err := s.imageStore.DeleteImage(path); err != nil {
@@ -156,6 +1159,54 @@ func (s *service) GDPRDeleteAccount(ctx context.Context, id int64) error {
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
+	s.publish(ctx, eventbus.AccountDeleted, id)
 
+	s.RecordSecurityEvent(ctx, id, account.SecurityEventAccountDelete, "")
 	return nil
 }
+
+// cachedAccount looks up an account by ID, reusing a copy kept for CacheTTL. It serves
+// lookups for display and API key authentication; account changes read the repository.
+func (s *service) cachedAccount(ctx context.Context, id int64) (*account.Account, error) {
+	if s.cache == nil || s.cfg.CacheTTL <= 0 {
+		return s.repo.GetByID(ctx, id)
+	}
+
+	key := accountKey(id)
+	if cached, ok := s.cache.Get(ctx, key); ok {
+		acc := cached.(account.Account) // Stored by value so callers cannot change the cached copy
+		return &acc, nil
+	}
+
+	acc, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Set(ctx, key, *acc, s.cfg.CacheTTL)
+	return acc, nil
+}
+
+// CacheKeys returns the keys of the cached accounts a change makes stale: the account
+// itself, and the creator of a changed or deleted post, which may have been pinned
+func CacheKeys(e eventbus.Event) []string {
+	switch e.Type {
+	case eventbus.AccountUpdated, eventbus.AccountDeleted, eventbus.PostUpdated, eventbus.PostDeleted:
+		if e.AccountID == 0 {
+			return []string{accountKeyPrefix + "*"}
+		}
+		return []string{accountKey(e.AccountID)}
+	}
+	return nil
+}
+
+// accountKey returns the cache key of an account
+func accountKey(id int64) string {
+	return accountKeyPrefix + strconv.FormatInt(id, 10)
+}
+
+// publish announces a change to an account, if anything subscribes to changes
+func (s *service) publish(ctx context.Context, eventType string, id int64) {
+	if s.changes != nil {
+		s.changes.Publish(ctx, eventbus.Event{Type: eventType, ID: id, AccountID: id})
+	}
+}
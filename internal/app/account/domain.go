@@ -3,17 +3,188 @@ package account
 import (
 	"context"
 	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
+)
+
+// Errors returned by the account service
+var (
+	ErrAccountNotFound     = apperr.NotFound("account not found")
+	ErrNotAuthenticated    = apperr.Unauthorized("user not authenticated") // The signed in account no longer exists
+	ErrEmailExists         = apperr.Conflict("email already exists")
+	ErrInvalidCredentials  = apperr.Unauthorized("invalid credentials")
+	ErrInvalidRefreshToken = apperr.Unauthorized("invalid refresh token")
+	ErrWrongPassword       = apperr.Unauthorized("current password is incorrect")
+	ErrSamePassword        = apperr.Invalid("new password must be different from the current password")
+	ErrSameEmail           = apperr.Invalid("new email must be different from the current email")
+	ErrInvalidToken        = apperr.Invalid("invalid or expired token")
+	ErrSessionNotFound     = apperr.NotFound("session not found")
+	ErrAPIKeyNotFound      = apperr.NotFound("api key not found")
+	ErrInvalidScope        = apperr.Invalid("invalid scope")
+	ErrInvalidRateLimit    = apperr.Invalid("invalid rate_limit")
+	ErrInvalidExpiresIn    = apperr.Invalid("invalid expires_in_days")
+	ErrProviderUnsupported = apperr.NotFound("provider not supported")
+	ErrOAuthLoginFailed    = apperr.Unauthorized("social login failed")
+	ErrInvalidSensitive    = apperr.Invalid("sensitive_content must be one of show, blur, hide")
+	ErrInvalidCommentPerm  = apperr.Invalid("comment_permission must be one of everyone, followers, nobody")
+	ErrAdminRequired       = apperr.Forbidden("admin role required")
+)
+
+// Account roles
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// Account statuses; a deactivated account's content is hidden until the user signs in again
+const (
+	StatusActive      = "active"
+	StatusDeactivated = "deactivated"
 )
 
+// API key scopes; read keys may only issue GET and HEAD requests
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+// IsValidScope reports whether v is a supported API key scope
+func IsValidScope(v string) bool {
+	switch v {
+	case ScopeRead, ScopeWrite:
+		return true
+	}
+	return false
+}
+
+// Login lockout scopes
+const (
+	LockScopeAccount = "account" // Too many failures for the email
+	LockScopeIP      = "ip"      // Too many failures from the client IP
+)
+
+// LoginLockedError is returned by Login while sign-in is blocked after repeated failures
+type LoginLockedError struct {
+	Scope      string
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	if e.Scope == LockScopeIP {
+		return "too many login attempts"
+	}
+	return "account locked"
+}
+
+// Security event types recorded by the account service; the auth middleware records its own
+// (see middleware.SecurityEventRevokedToken and middleware.SecurityEventAPIKeyScopeDenied)
+const (
+	SecurityEventLoginSuccess      = "login_success"
+	SecurityEventNewDeviceLogin    = "new_device_login"
+	SecurityEventLoginFailure      = "login_failure"
+	SecurityEventPasswordChange    = "password_change"
+	SecurityEventTokenRefresh      = "token_refresh"
+	SecurityEventLogoutAll         = "logout_all"
+	SecurityEventRefreshTokenReuse = "refresh_token_reuse"
+	SecurityEventAccountDelete     = "account_delete"
+)
+
+// Sensitive content preferences control how posts flagged as sensitive appear in lists
+const (
+	SensitiveContentShow = "show" // Shown as is
+	SensitiveContentBlur = "blur" // Shown with the blurred flag set
+	SensitiveContentHide = "hide" // Left out of lists
+)
+
+// IsValidSensitiveContent reports whether v is a supported sensitive content preference
+func IsValidSensitiveContent(v string) bool {
+	switch v {
+	case SensitiveContentShow, SensitiveContentBlur, SensitiveContentHide:
+		return true
+	}
+	return false
+}
+
+// Comment permissions control who may comment on an account's posts; the account itself always may
+const (
+	CommentPermissionEveryone  = "everyone"
+	CommentPermissionFollowers = "followers"
+	CommentPermissionNobody    = "nobody"
+)
+
+// IsValidCommentPermission reports whether v is a supported comment permission
+func IsValidCommentPermission(v string) bool {
+	switch v {
+	case CommentPermissionEveryone, CommentPermissionFollowers, CommentPermissionNobody:
+		return true
+	}
+	return false
+}
+
 // Account represents the account domain model
 type Account struct {
-	ID        int64      `json:"id" db:"id"`
-	Name      string     `json:"name" db:"name"`
-	Email     string     `json:"email" db:"email"`
-	Password  string     `json:"-" db:"password"` // Hidden from JSON response
-	CreatedAt time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	ID                int64      `json:"id" db:"id"`
+	Name              string     `json:"name" db:"name"`
+	Email             string     `json:"email" db:"email"`
+	Password          string     `json:"-" db:"password"` // Hidden from JSON response
+	AvatarPath        string     `json:"-" db:"avatar_path"`
+	AvatarURL         string     `json:"avatar_url,omitempty" db:"avatar_url"`
+	PinnedPostID      *int64     `json:"pinned_post_id,omitempty" db:"pinned_post_id"`
+	SensitiveContent  string     `json:"sensitive_content" db:"sensitive_content"`
+	IsPrivate         bool       `json:"is_private" db:"is_private"`                 // Posts are shown to followers only
+	CommentPermission string     `json:"comment_permission" db:"comment_permission"` // Who may comment on the account's posts
+	Discoverable      bool       `json:"discoverable" db:"discoverable"`             // Listed in search and suggestions
+	Role              string     `json:"role" db:"role"`
+	IsVerified        bool       `json:"is_verified" db:"is_verified"`
+	Status            string     `json:"status" db:"status"`
+	TokenVersion      int        `json:"-" db:"token_version"` // Bumped to invalidate every access token
+	LastLoginAt       *time.Time `json:"last_login_at,omitempty" db:"last_login_at"`
+	LastSeenAt        *time.Time `json:"last_seen_at,omitempty" db:"last_seen_at"` // Updated at most once a minute
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+// Session represents a signed-in device of an account
+type Session struct {
+	ID         int64     `json:"id"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
+
+// APIKey represents a key for programmatic access; the secret itself is never stored
+type APIKey struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	RateLimit  int        `json:"rate_limit"` // requests per minute
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	AccountID  int64      `json:"-"`
+}
+
+// SecurityEvent is an entry of the account's append-only security log
+type SecurityEvent struct {
+	ID        int64     `json:"id"`
+	EventType string    `json:"event_type"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	Details   string    `json:"details,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	AccountID int64     `json:"-"`
+}
+
+// SecurityEventListResponse represents the response payload for the security log, newest first
+type SecurityEventListResponse struct {
+	Items   []SecurityEvent `json:"items"`
+	Cursor  string          `json:"cursor,omitempty"`
+	HasMore bool            `json:"has_more"`
 }
 
 // RegisterRequest represents the request payload for account registration
@@ -23,10 +194,28 @@ type RegisterRequest struct {
 	Password string `json:"password" validate:"required,min=8"`
 }
 
+// Settings holds the preferences and privacy settings of an account
+type Settings struct {
+	SensitiveContent  string `json:"sensitive_content"`
+	IsPrivate         bool   `json:"is_private"`
+	CommentPermission string `json:"comment_permission"`
+	Discoverable      bool   `json:"discoverable"`
+}
+
+// UpdateSettingsRequest represents the request payload for updating account settings;
+// omitted fields keep their current value
+type UpdateSettingsRequest struct {
+	SensitiveContent  *string `json:"sensitive_content,omitempty" validate:"omitempty,oneof=show blur hide"`
+	IsPrivate         *bool   `json:"is_private,omitempty"`
+	CommentPermission *string `json:"comment_permission,omitempty" validate:"omitempty,oneof=everyone followers nobody"`
+	Discoverable      *bool   `json:"discoverable,omitempty"`
+}
+
 // LoginRequest represents the request payload for account login
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required"`
+	Email      string `json:"email" validate:"required,email"`
+	Password   string `json:"password" validate:"required"`
+	RememberMe bool   `json:"remember_me"` // Extended session instead of a short one
 }
 
 // LoginResponse represents the response payload for successful login
@@ -35,6 +224,51 @@ type LoginResponse struct {
 	AccessToken string  `json:"access_token"`
 	TokenType   string  `json:"token_type"`
 	ExpiresIn   int64   `json:"expires_in"` // seconds
+	// RefreshToken can be exchanged once for a new token pair
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresIn int64  `json:"refresh_expires_in"` // seconds
+	// Reactivated is set when signing in restored a deactivated account
+	Reactivated bool `json:"reactivated,omitempty"`
+}
+
+// ChangePasswordRequest represents the request payload for changing the account password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+// RefreshTokenRequest represents the request payload for refreshing an access token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// ChangeEmailRequest represents the request payload for changing the account email
+type ChangeEmailRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ConfirmEmailRequest represents the request payload for confirming an email change
+type ConfirmEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// LogoutRequest represents the optional request payload for logging out
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// CreateAPIKeyRequest represents the request payload for creating an API key
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" validate:"required,max=100"`
+	Scopes        []string `json:"scopes,omitempty"`
+	RateLimit     int      `json:"rate_limit,omitempty"`
+	ExpiresInDays int      `json:"expires_in_days,omitempty"`
+}
+
+// CreateAPIKeyResponse carries a new API key; the key is only ever returned here
+type CreateAPIKeyResponse struct {
+	APIKey
+	Key string `json:"key"`
 }
 
 // StandardResponse represents the standard API response format
@@ -54,6 +288,9 @@ type AccountRepository interface {
 	Update(ctx context.Context, acc *Account) error
 	Delete(ctx context.Context, id int64) error
 	SoftDelete(ctx context.Context, id int64) error
+	UpdateAvatar(ctx context.Context, id int64, avatarPath, avatarURL string) error
+	UpdateSettings(ctx context.Context, id int64, sensitiveContent string) error
+	UpdateVerified(ctx context.Context, id int64, isVerified bool) error
 }
 
 // AccountService defines the interface for account business logic
@@ -9,6 +9,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+
+	"github.com/oapi-codegen/runtime"
 )
 
 // ServerInterface represents all server handlers.
@@ -16,15 +18,75 @@ type ServerInterface interface {
 	// Delete own account (GDPR)
 	// (DELETE /api/account)
 	DeleteApiAccount(w http.ResponseWriter, r *http.Request)
+	// List API keys
+	// (GET /api/account/api-keys)
+	GetApiAccountApiKeys(w http.ResponseWriter, r *http.Request)
+	// Create API key
+	// (POST /api/account/api-keys)
+	PostApiAccountApiKeys(w http.ResponseWriter, r *http.Request)
+	// Revoke API key
+	// (DELETE /api/account/api-keys/{id})
+	DeleteApiAccountApiKeysId(w http.ResponseWriter, r *http.Request, id int64)
+	// Upload account avatar
+	// (PUT /api/account/avatar)
+	PutApiAccountAvatar(w http.ResponseWriter, r *http.Request)
+	// Deactivate account
+	// (POST /api/account/deactivate)
+	PostApiAccountDeactivate(w http.ResponseWriter, r *http.Request)
+	// Change email
+	// (PUT /api/account/email)
+	PutApiAccountEmail(w http.ResponseWriter, r *http.Request)
+	// Confirm email change
+	// (POST /api/account/email/confirm)
+	PostApiAccountEmailConfirm(w http.ResponseWriter, r *http.Request)
 	// Login to account
 	// (POST /api/account/login)
 	PostApiAccountLogin(w http.ResponseWriter, r *http.Request)
+	// Logout
+	// (POST /api/account/logout)
+	PostApiAccountLogout(w http.ResponseWriter, r *http.Request)
+	// Logout from all devices
+	// (POST /api/account/logout-all)
+	PostApiAccountLogoutAll(w http.ResponseWriter, r *http.Request)
+	// Change password
+	// (PUT /api/account/password)
+	PutApiAccountPassword(w http.ResponseWriter, r *http.Request)
 	// Get account profile
 	// (GET /api/account/profile)
 	GetApiAccountProfile(w http.ResponseWriter, r *http.Request)
 	// Register a new account
 	// (POST /api/account/register)
 	PostApiAccountRegister(w http.ResponseWriter, r *http.Request)
+	// Get security log
+	// (GET /api/account/security-log)
+	GetApiAccountSecurityLog(w http.ResponseWriter, r *http.Request, params GetApiAccountSecurityLogParams)
+	// List sessions
+	// (GET /api/account/sessions)
+	GetApiAccountSessions(w http.ResponseWriter, r *http.Request)
+	// Revoke session
+	// (DELETE /api/account/sessions/{id})
+	DeleteApiAccountSessionsId(w http.ResponseWriter, r *http.Request, id int64)
+	// Get account settings
+	// (GET /api/account/settings)
+	GetApiAccountSettings(w http.ResponseWriter, r *http.Request)
+	// Update account settings
+	// (PUT /api/account/settings)
+	PutApiAccountSettings(w http.ResponseWriter, r *http.Request)
+	// Refresh access token
+	// (POST /api/account/token/refresh)
+	PostApiAccountTokenRefresh(w http.ResponseWriter, r *http.Request)
+	// Revoke verified badge
+	// (DELETE /api/admin/accounts/{id}/verification)
+	DeleteApiAdminAccountsIdVerification(w http.ResponseWriter, r *http.Request, id int64)
+	// Grant verified badge
+	// (POST /api/admin/accounts/{id}/verification)
+	PostApiAdminAccountsIdVerification(w http.ResponseWriter, r *http.Request, id int64)
+	// Social login callback
+	// (GET /api/auth/{provider}/callback)
+	GetApiAuthProviderCallback(w http.ResponseWriter, r *http.Request, provider GetApiAuthProviderCallbackParamsProvider, params GetApiAuthProviderCallbackParams)
+	// Start social login
+	// (GET /api/auth/{provider}/login)
+	GetApiAuthProviderLogin(w http.ResponseWriter, r *http.Request, provider GetApiAuthProviderLoginParamsProvider)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -56,6 +118,151 @@ func (siw *ServerInterfaceWrapper) DeleteApiAccount(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
+// GetApiAccountApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAccountApiKeys(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAccountApiKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAccountApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAccountApiKeys(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAccountApiKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteApiAccountApiKeysId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiAccountApiKeysId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiAccountApiKeysId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutApiAccountAvatar operation middleware
+func (siw *ServerInterfaceWrapper) PutApiAccountAvatar(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutApiAccountAvatar(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAccountDeactivate operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAccountDeactivate(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAccountDeactivate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutApiAccountEmail operation middleware
+func (siw *ServerInterfaceWrapper) PutApiAccountEmail(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutApiAccountEmail(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAccountEmailConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAccountEmailConfirm(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAccountEmailConfirm(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // PostApiAccountLogin operation middleware
 func (siw *ServerInterfaceWrapper) PostApiAccountLogin(w http.ResponseWriter, r *http.Request) {
 
@@ -70,6 +277,66 @@ func (siw *ServerInterfaceWrapper) PostApiAccountLogin(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
+// PostApiAccountLogout operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAccountLogout(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAccountLogout(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAccountLogoutAll operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAccountLogoutAll(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAccountLogoutAll(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutApiAccountPassword operation middleware
+func (siw *ServerInterfaceWrapper) PutApiAccountPassword(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutApiAccountPassword(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetApiAccountProfile operation middleware
 func (siw *ServerInterfaceWrapper) GetApiAccountProfile(w http.ResponseWriter, r *http.Request) {
 
@@ -104,6 +371,291 @@ func (siw *ServerInterfaceWrapper) PostApiAccountRegister(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
+// GetApiAccountSecurityLog operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAccountSecurityLog(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiAccountSecurityLogParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAccountSecurityLog(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiAccountSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAccountSessions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAccountSessions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteApiAccountSessionsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiAccountSessionsId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiAccountSessionsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiAccountSettings operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAccountSettings(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAccountSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutApiAccountSettings operation middleware
+func (siw *ServerInterfaceWrapper) PutApiAccountSettings(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutApiAccountSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAccountTokenRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAccountTokenRefresh(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAccountTokenRefresh(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteApiAdminAccountsIdVerification operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiAdminAccountsIdVerification(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiAdminAccountsIdVerification(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAdminAccountsIdVerification operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAdminAccountsIdVerification(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAdminAccountsIdVerification(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiAuthProviderCallback operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAuthProviderCallback(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider GetApiAuthProviderCallbackParamsProvider
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", r.PathValue("provider"), &provider, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiAuthProviderCallbackParams
+
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", r.URL.Query(), &params.Code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "error" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "error", r.URL.Query(), &params.Error)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "error", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAuthProviderCallback(w, r, provider, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiAuthProviderLogin operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAuthProviderLogin(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider GetApiAuthProviderLoginParamsProvider
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", r.PathValue("provider"), &provider, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAuthProviderLogin(w, r, provider)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -225,9 +777,29 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 	}
 
 	m.HandleFunc("DELETE "+options.BaseURL+"/api/account", wrapper.DeleteApiAccount)
+	m.HandleFunc("GET "+options.BaseURL+"/api/account/api-keys", wrapper.GetApiAccountApiKeys)
+	m.HandleFunc("POST "+options.BaseURL+"/api/account/api-keys", wrapper.PostApiAccountApiKeys)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/account/api-keys/{id}", wrapper.DeleteApiAccountApiKeysId)
+	m.HandleFunc("PUT "+options.BaseURL+"/api/account/avatar", wrapper.PutApiAccountAvatar)
+	m.HandleFunc("POST "+options.BaseURL+"/api/account/deactivate", wrapper.PostApiAccountDeactivate)
+	m.HandleFunc("PUT "+options.BaseURL+"/api/account/email", wrapper.PutApiAccountEmail)
+	m.HandleFunc("POST "+options.BaseURL+"/api/account/email/confirm", wrapper.PostApiAccountEmailConfirm)
 	m.HandleFunc("POST "+options.BaseURL+"/api/account/login", wrapper.PostApiAccountLogin)
+	m.HandleFunc("POST "+options.BaseURL+"/api/account/logout", wrapper.PostApiAccountLogout)
+	m.HandleFunc("POST "+options.BaseURL+"/api/account/logout-all", wrapper.PostApiAccountLogoutAll)
+	m.HandleFunc("PUT "+options.BaseURL+"/api/account/password", wrapper.PutApiAccountPassword)
 	m.HandleFunc("GET "+options.BaseURL+"/api/account/profile", wrapper.GetApiAccountProfile)
 	m.HandleFunc("POST "+options.BaseURL+"/api/account/register", wrapper.PostApiAccountRegister)
+	m.HandleFunc("GET "+options.BaseURL+"/api/account/security-log", wrapper.GetApiAccountSecurityLog)
+	m.HandleFunc("GET "+options.BaseURL+"/api/account/sessions", wrapper.GetApiAccountSessions)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/account/sessions/{id}", wrapper.DeleteApiAccountSessionsId)
+	m.HandleFunc("GET "+options.BaseURL+"/api/account/settings", wrapper.GetApiAccountSettings)
+	m.HandleFunc("PUT "+options.BaseURL+"/api/account/settings", wrapper.PutApiAccountSettings)
+	m.HandleFunc("POST "+options.BaseURL+"/api/account/token/refresh", wrapper.PostApiAccountTokenRefresh)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/admin/accounts/{id}/verification", wrapper.DeleteApiAdminAccountsIdVerification)
+	m.HandleFunc("POST "+options.BaseURL+"/api/admin/accounts/{id}/verification", wrapper.PostApiAdminAccountsIdVerification)
+	m.HandleFunc("GET "+options.BaseURL+"/api/auth/{provider}/callback", wrapper.GetApiAuthProviderCallback)
+	m.HandleFunc("GET "+options.BaseURL+"/api/auth/{provider}/login", wrapper.GetApiAuthProviderLogin)
 
 	return m
 }
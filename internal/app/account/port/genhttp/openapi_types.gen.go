@@ -13,6 +13,26 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for CommentPermission.
+const (
+	Everyone  CommentPermission = "everyone"
+	Followers CommentPermission = "followers"
+	Nobody    CommentPermission = "nobody"
+)
+
+// Defines values for CreateAPIKeyRequestScopes.
+const (
+	Read  CreateAPIKeyRequestScopes = "read"
+	Write CreateAPIKeyRequestScopes = "write"
+)
+
+// Defines values for SensitiveContentPreference.
+const (
+	Blur SensitiveContentPreference = "blur"
+	Hide SensitiveContentPreference = "hide"
+	Show SensitiveContentPreference = "show"
+)
+
 // Defines values for StandardResponseCode.
 const (
 	BADREQUEST          StandardResponseCode = "BAD_REQUEST"
@@ -25,19 +45,87 @@ const (
 	UNAUTHORIZED        StandardResponseCode = "UNAUTHORIZED"
 )
 
+// Defines values for GetApiAuthProviderCallbackParamsProvider.
+const (
+	GetApiAuthProviderCallbackParamsProviderGithub GetApiAuthProviderCallbackParamsProvider = "github"
+	GetApiAuthProviderCallbackParamsProviderGoogle GetApiAuthProviderCallbackParamsProvider = "google"
+)
+
+// Defines values for GetApiAuthProviderLoginParamsProvider.
+const (
+	GetApiAuthProviderLoginParamsProviderGithub GetApiAuthProviderLoginParamsProvider = "github"
+	GetApiAuthProviderLoginParamsProviderGoogle GetApiAuthProviderLoginParamsProvider = "google"
+)
+
+// ChangeEmailRequest defines model for ChangeEmailRequest.
+type ChangeEmailRequest struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+// ChangePasswordRequest defines model for ChangePasswordRequest.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+
+	// NewPassword Must satisfy the password policy
+	NewPassword string `json:"new_password"`
+}
+
+// CommentPermission Who can comment on the account's posts
+type CommentPermission string
+
+// ConfirmEmailRequest defines model for ConfirmEmailRequest.
+type ConfirmEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// CreateAPIKeyRequest defines model for CreateAPIKeyRequest.
+type CreateAPIKeyRequest struct {
+	// ExpiresInDays Days until the key expires; omit for a key that does not expire
+	ExpiresInDays *int   `json:"expires_in_days,omitempty"`
+	Name          string `json:"name"`
+
+	// RateLimit Requests per minute; defaults to API_KEY_RATE_LIMIT
+	RateLimit *int `json:"rate_limit,omitempty"`
+
+	// Scopes Defaults to read
+	Scopes *[]CreateAPIKeyRequestScopes `json:"scopes,omitempty"`
+}
+
+// CreateAPIKeyRequestScopes defines model for CreateAPIKeyRequest.Scopes.
+type CreateAPIKeyRequestScopes string
+
 // LoginRequest defines model for LoginRequest.
 type LoginRequest struct {
 	Email    openapi_types.Email `json:"email"`
 	Password string              `json:"password"`
+
+	// RememberMe Keep the session signed in for JWT_REFRESH_EXPIRATION instead of JWT_SHORT_REFRESH_EXPIRATION
+	RememberMe *bool `json:"remember_me,omitempty"`
+}
+
+// LogoutRequest defines model for LogoutRequest.
+type LogoutRequest struct {
+	// RefreshToken Optional refresh token to revoke together with the access token
+	RefreshToken *string `json:"refresh_token,omitempty"`
+}
+
+// RefreshTokenRequest defines model for RefreshTokenRequest.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
 // RegisterRequest defines model for RegisterRequest.
 type RegisterRequest struct {
-	Email    openapi_types.Email `json:"email"`
-	Name     string              `json:"name"`
-	Password string              `json:"password"`
+	Email openapi_types.Email `json:"email"`
+	Name  string              `json:"name"`
+
+	// Password Must satisfy the password policy (PASSWORD_* settings); by default at least 8 characters and not found in known data breaches
+	Password string `json:"password"`
 }
 
+// SensitiveContentPreference How posts flagged as sensitive appear in lists: shown as is, flagged as blurred, or left out
+type SensitiveContentPreference string
+
 // StandardResponse defines model for StandardResponse.
 type StandardResponse struct {
 	Code *StandardResponseCode `json:"code,omitempty"`
@@ -53,8 +141,80 @@ type StandardResponse struct {
 // StandardResponseCode defines model for StandardResponse.Code.
 type StandardResponseCode string
 
+// UpdateSettingsRequest defines model for UpdateSettingsRequest.
+type UpdateSettingsRequest struct {
+	// CommentPermission Who can comment on the account's posts
+	CommentPermission *CommentPermission `json:"comment_permission,omitempty"`
+
+	// Discoverable Whether the account appears in search and follow suggestions
+	Discoverable *bool `json:"discoverable,omitempty"`
+
+	// IsPrivate Private accounts show posts only to followers
+	IsPrivate *bool `json:"is_private,omitempty"`
+
+	// SensitiveContent How posts flagged as sensitive appear in lists: shown as is, flagged as blurred, or left out
+	SensitiveContent *SensitiveContentPreference `json:"sensitive_content,omitempty"`
+}
+
+// PutApiAccountAvatarMultipartBody defines parameters for PutApiAccountAvatar.
+type PutApiAccountAvatarMultipartBody struct {
+	// Avatar Avatar image file (PNG, JPG, JPEG, BMP)
+	Avatar openapi_types.File `json:"avatar"`
+}
+
+// GetApiAccountSecurityLogParams defines parameters for GetApiAccountSecurityLog.
+type GetApiAccountSecurityLogParams struct {
+	// Cursor Cursor for pagination
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Number of items to return (max 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetApiAuthProviderCallbackParams defines parameters for GetApiAuthProviderCallback.
+type GetApiAuthProviderCallbackParams struct {
+	// Code Authorization code issued by the provider
+	Code *string `form:"code,omitempty" json:"code,omitempty"`
+
+	// State State echoed back by the provider
+	State *string `form:"state,omitempty" json:"state,omitempty"`
+
+	// Error Error reported by the provider, e.g. access_denied
+	Error *string `form:"error,omitempty" json:"error,omitempty"`
+}
+
+// GetApiAuthProviderCallbackParamsProvider defines parameters for GetApiAuthProviderCallback.
+type GetApiAuthProviderCallbackParamsProvider string
+
+// GetApiAuthProviderLoginParamsProvider defines parameters for GetApiAuthProviderLogin.
+type GetApiAuthProviderLoginParamsProvider string
+
+// PostApiAccountApiKeysJSONRequestBody defines body for PostApiAccountApiKeys for application/json ContentType.
+type PostApiAccountApiKeysJSONRequestBody = CreateAPIKeyRequest
+
+// PutApiAccountAvatarMultipartRequestBody defines body for PutApiAccountAvatar for multipart/form-data ContentType.
+type PutApiAccountAvatarMultipartRequestBody PutApiAccountAvatarMultipartBody
+
+// PutApiAccountEmailJSONRequestBody defines body for PutApiAccountEmail for application/json ContentType.
+type PutApiAccountEmailJSONRequestBody = ChangeEmailRequest
+
+// PostApiAccountEmailConfirmJSONRequestBody defines body for PostApiAccountEmailConfirm for application/json ContentType.
+type PostApiAccountEmailConfirmJSONRequestBody = ConfirmEmailRequest
+
 // PostApiAccountLoginJSONRequestBody defines body for PostApiAccountLogin for application/json ContentType.
 type PostApiAccountLoginJSONRequestBody = LoginRequest
 
+// PostApiAccountLogoutJSONRequestBody defines body for PostApiAccountLogout for application/json ContentType.
+type PostApiAccountLogoutJSONRequestBody = LogoutRequest
+
+// PutApiAccountPasswordJSONRequestBody defines body for PutApiAccountPassword for application/json ContentType.
+type PutApiAccountPasswordJSONRequestBody = ChangePasswordRequest
+
 // PostApiAccountRegisterJSONRequestBody defines body for PostApiAccountRegister for application/json ContentType.
 type PostApiAccountRegisterJSONRequestBody = RegisterRequest
+
+// PutApiAccountSettingsJSONRequestBody defines body for PutApiAccountSettings for application/json ContentType.
+type PutApiAccountSettingsJSONRequestBody = UpdateSettingsRequest
+
+// PostApiAccountTokenRefreshJSONRequestBody defines body for PostApiAccountTokenRefresh for application/json ContentType.
+type PostApiAccountTokenRefreshJSONRequestBody = RefreshTokenRequest
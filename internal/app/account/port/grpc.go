@@ -0,0 +1,101 @@
+package http
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/fanzru/social-media-service-go/internal/app/account"
+	"github.com/fanzru/social-media-service-go/internal/app/account/app"
+	"github.com/fanzru/social-media-service-go/internal/app/account/port/gengrpc"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxBatchGetAccounts caps the IDs of one BatchGetAccounts call
+const maxBatchGetAccounts = 100
+
+// GRPCServer serves the account gRPC API
+// Implements gengrpc.AccountServiceServer
+type GRPCServer struct {
+	gengrpc.UnimplementedAccountServiceServer
+	service app.Service
+}
+
+// NewGRPCServer creates a new account gRPC server
+func NewGRPCServer(service app.Service) *GRPCServer {
+	return &GRPCServer{service: service}
+}
+
+// GetAccount implements gengrpc.AccountServiceServer
+func (s *GRPCServer) GetAccount(ctx context.Context, req *gengrpc.GetAccountRequest) (*gengrpc.Account, error) {
+	acc, err := s.service.GetAccountByID(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "account not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if acc.Status == account.StatusDeactivated {
+		return nil, status.Error(codes.NotFound, "account not found")
+	}
+
+	return accountMessage(acc, false), nil
+}
+
+// BatchGetAccounts implements gengrpc.AccountServiceServer
+func (s *GRPCServer) BatchGetAccounts(ctx context.Context, req *gengrpc.BatchGetAccountsRequest) (*gengrpc.BatchGetAccountsResponse, error) {
+	if len(req.GetIds()) > maxBatchGetAccounts {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d ids can be requested at once", maxBatchGetAccounts)
+	}
+
+	accounts, err := s.service.GetAccountsByIDs(ctx, req.GetIds())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// Keep the requested order, listing repeated IDs once
+	resp := &gengrpc.BatchGetAccountsResponse{}
+	for _, id := range req.GetIds() {
+		acc, ok := accounts[id]
+		if !ok || acc.Status == account.StatusDeactivated {
+			continue
+		}
+		delete(accounts, id)
+		resp.Accounts = append(resp.Accounts, accountMessage(acc, false))
+	}
+	return resp, nil
+}
+
+// GetProfile implements gengrpc.AccountServiceServer
+func (s *GRPCServer) GetProfile(ctx context.Context, req *gengrpc.GetProfileRequest) (*gengrpc.Account, error) {
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	acc, err := s.service.GetAccountByID(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return accountMessage(acc, true), nil
+}
+
+// accountMessage converts an account to its protobuf message; the email is only included for its owner
+func accountMessage(acc *account.Account, withEmail bool) *gengrpc.Account {
+	msg := &gengrpc.Account{
+		Id:         acc.ID,
+		Name:       acc.Name,
+		AvatarUrl:  acc.AvatarURL,
+		IsVerified: acc.IsVerified,
+		IsPrivate:  acc.IsPrivate,
+		CreatedAt:  timestamppb.New(acc.CreatedAt),
+	}
+	if withEmail {
+		msg.Email = acc.Email
+	}
+	return msg
+}
@@ -1,14 +1,25 @@
 package http
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fanzru/social-media-service-go/internal/app/account"
 	"github.com/fanzru/social-media-service-go/internal/app/account/app"
+	"github.com/fanzru/social-media-service-go/internal/app/account/port/genhttp"
 	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"github.com/fanzru/social-media-service-go/pkg/password"
 	"github.com/fanzru/social-media-service-go/pkg/response"
+	"github.com/fanzru/social-media-service-go/pkg/storage"
 )
 
 // Handler handles HTTP requests for account operations
@@ -32,6 +43,409 @@ func (h *Handler) PostApiAccountLogin(w http.ResponseWriter, r *http.Request) {
 	h.Login(w, r)
 }
 
+// PostApiAccountTokenRefresh implements genhttp.ServerInterface for POST /api/account/token/refresh
+func (h *Handler) PostApiAccountTokenRefresh(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req account.RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(ctx, "Invalid request body", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	if req.RefreshToken == "" {
+		response.ValidationError(ctx, "Validation failed", []string{"refresh_token is required"}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	loginResp, err := h.service.RefreshToken(ctx, &req)
+	if err != nil {
+		response.SendError(ctx, w, err, "Failed to refresh token")
+		return
+	}
+
+	response.Success(ctx, "Token refreshed successfully", loginResp).Send(w, http.StatusOK)
+}
+
+// PostApiAccountLogout implements genhttp.ServerInterface for POST /api/account/logout
+func (h *Handler) PostApiAccountLogout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	tokenID, ok := middleware.GetTokenID(ctx)
+	if !ok || tokenID == "" {
+		response.Unauthorized(ctx, "Token cannot be revoked", []string{"token has no jti claim"}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	expiresAt, ok := middleware.GetTokenExpiresAt(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "Token cannot be revoked", []string{"token has no exp claim"}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	// The body is optional; an empty body only revokes the access token
+	var req account.LogoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		response.BadRequest(ctx, "Invalid request body", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	sessionID, _ := middleware.GetSessionID(ctx)
+	if err := h.service.Logout(ctx, userID, sessionID, tokenID, expiresAt, &req); err != nil {
+		response.InternalServerError(ctx, "Failed to logout", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	response.Success(ctx, "Logged out successfully", nil).Send(w, http.StatusOK)
+}
+
+// PostApiAccountLogoutAll implements genhttp.ServerInterface for POST /api/account/logout-all
+func (h *Handler) PostApiAccountLogoutAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.LogoutAll(ctx, userID); err != nil {
+		response.SendError(ctx, w, err, "Failed to logout from all devices")
+		return
+	}
+
+	response.Success(ctx, "Logged out from all devices successfully", nil).Send(w, http.StatusOK)
+}
+
+// PostApiAccountDeactivate implements genhttp.ServerInterface for POST /api/account/deactivate
+func (h *Handler) PostApiAccountDeactivate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.DeactivateAccount(ctx, userID); err != nil {
+		response.SendError(ctx, w, err, "Failed to deactivate account")
+		return
+	}
+
+	response.Success(ctx, "Account deactivated successfully; sign in again to reactivate", nil).Send(w, http.StatusOK)
+}
+
+// GetApiAccountSessions implements genhttp.ServerInterface for GET /api/account/sessions
+func (h *Handler) GetApiAccountSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	sessionID, _ := middleware.GetSessionID(ctx)
+	sessions, err := h.service.ListSessions(ctx, userID, sessionID)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get sessions", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	response.Success(ctx, "Sessions retrieved successfully", sessions).Send(w, http.StatusOK)
+}
+
+// GetApiAccountSecurityLog implements genhttp.ServerInterface for GET /api/account/security-log
+func (h *Handler) GetApiAccountSecurityLog(w http.ResponseWriter, r *http.Request, params genhttp.GetApiAccountSecurityLogParams) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	cursor := ""
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	events, err := h.service.ListSecurityEvents(ctx, userID, cursor, limit)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get security log", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	response.Success(ctx, "Security log retrieved successfully", events).Send(w, http.StatusOK)
+}
+
+// DeleteApiAccountSessionsId implements genhttp.ServerInterface for DELETE /api/account/sessions/{id}
+func (h *Handler) DeleteApiAccountSessionsId(w http.ResponseWriter, r *http.Request, id int64) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RevokeSession(ctx, userID, id); err != nil {
+		response.SendError(ctx, w, err, "Failed to revoke session")
+		return
+	}
+
+	response.Success(ctx, "Session revoked successfully", nil).Send(w, http.StatusOK)
+}
+
+// PostApiAccountApiKeys implements genhttp.ServerInterface for POST /api/account/api-keys
+func (h *Handler) PostApiAccountApiKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	var req account.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(ctx, "Invalid request body", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		response.ValidationError(ctx, "Validation failed", []string{"name is required"}).Send(w, http.StatusBadRequest)
+		return
+	}
+	if len(req.Name) > 100 {
+		response.ValidationError(ctx, "Validation failed", []string{"name must be at most 100 characters"}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.service.CreateAPIKey(ctx, userID, &req)
+	if err != nil {
+		response.SendError(ctx, w, err, "Failed to create API key")
+		return
+	}
+
+	response.Success(ctx, "API key created successfully; store it now, it will not be shown again", created).Send(w, http.StatusCreated)
+}
+
+// GetApiAccountApiKeys implements genhttp.ServerInterface for GET /api/account/api-keys
+func (h *Handler) GetApiAccountApiKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	keys, err := h.service.ListAPIKeys(ctx, userID)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get API keys", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	response.Success(ctx, "API keys retrieved successfully", keys).Send(w, http.StatusOK)
+}
+
+// DeleteApiAccountApiKeysId implements genhttp.ServerInterface for DELETE /api/account/api-keys/{id}
+func (h *Handler) DeleteApiAccountApiKeysId(w http.ResponseWriter, r *http.Request, id int64) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.RevokeAPIKey(ctx, userID, id); err != nil {
+		response.SendError(ctx, w, err, "Failed to revoke API key")
+		return
+	}
+
+	response.Success(ctx, "API key revoked successfully", nil).Send(w, http.StatusOK)
+}
+
+// PutApiAccountPassword implements genhttp.ServerInterface for PUT /api/account/password
+func (h *Handler) PutApiAccountPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	var req account.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(ctx, "Invalid request body", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	if req.CurrentPassword == "" {
+		response.ValidationError(ctx, "Validation failed", []string{"current_password is required"}).Send(w, http.StatusBadRequest)
+		return
+	}
+	if req.NewPassword == "" {
+		response.ValidationError(ctx, "Validation failed", []string{"new_password is required"}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	sessionID, _ := middleware.GetSessionID(ctx)
+	if err := h.service.ChangePassword(ctx, userID, sessionID, &req); err != nil {
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			response.ValidationError(ctx, "Password does not meet the policy", policyErr.Violations).Send(w, http.StatusBadRequest)
+			return
+		}
+		response.SendError(ctx, w, err, "Failed to change password")
+		return
+	}
+
+	response.Success(ctx, "Password changed successfully; other sessions have been signed out", nil).Send(w, http.StatusOK)
+}
+
+// PutApiAccountEmail implements genhttp.ServerInterface for PUT /api/account/email
+func (h *Handler) PutApiAccountEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	var req account.ChangeEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(ctx, "Invalid request body", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	if req.Email == "" {
+		response.ValidationError(ctx, "Validation failed", []string{"email is required"}).Send(w, http.StatusBadRequest)
+		return
+	}
+	if !isValidEmail(req.Email) {
+		response.ValidationError(ctx, "Validation failed", []string{"invalid email format"}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.RequestEmailChange(ctx, userID, &req); err != nil {
+		response.SendError(ctx, w, err, "Failed to change email")
+		return
+	}
+
+	response.Success(ctx, "Confirmation sent to the new email address", nil).Send(w, http.StatusAccepted)
+}
+
+// PostApiAccountEmailConfirm implements genhttp.ServerInterface for POST /api/account/email/confirm
+func (h *Handler) PostApiAccountEmailConfirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req account.ConfirmEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(ctx, "Invalid request body", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	if req.Token == "" {
+		response.ValidationError(ctx, "Validation failed", []string{"token is required"}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	acc, err := h.service.ConfirmEmailChange(ctx, &req)
+	if err != nil {
+		response.SendError(ctx, w, err, "Failed to confirm email change")
+		return
+	}
+
+	response.Success(ctx, "Email changed successfully", acc).Send(w, http.StatusOK)
+}
+
+// oauthStateCookie holds the CSRF state between the login redirect and the callback
+const oauthStateCookie = "oauth_state"
+
+// GetApiAuthProviderLogin implements genhttp.ServerInterface for GET /api/auth/{provider}/login
+func (h *Handler) GetApiAuthProviderLogin(w http.ResponseWriter, r *http.Request, provider genhttp.GetApiAuthProviderLoginParamsProvider) {
+	ctx := r.Context()
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		response.InternalServerError(ctx, "Failed to start login", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+	state := base64.RawURLEncoding.EncodeToString(buf)
+
+	authURL, err := h.service.OAuthLoginURL(string(provider), state)
+	if err != nil {
+		response.SendError(ctx, w, err, "Failed to start login")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oauthStateCookie,
+		Value:    state,
+		Path:     "/api/auth/",
+		MaxAge:   600,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// GetApiAuthProviderCallback implements genhttp.ServerInterface for GET /api/auth/{provider}/callback
+func (h *Handler) GetApiAuthProviderCallback(w http.ResponseWriter, r *http.Request, provider genhttp.GetApiAuthProviderCallbackParamsProvider, params genhttp.GetApiAuthProviderCallbackParams) {
+	ctx := r.Context()
+
+	// The state is single use
+	http.SetCookie(w, &http.Cookie{
+		Name:     oauthStateCookie,
+		Value:    "",
+		Path:     "/api/auth/",
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+
+	if params.Error != nil && *params.Error != "" {
+		response.Unauthorized(ctx, "Login was denied by the provider", []string{*params.Error}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	cookie, err := r.Cookie(oauthStateCookie)
+	if err != nil || params.State == nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(*params.State)) != 1 {
+		response.BadRequest(ctx, "Invalid state", []string{"state does not match"}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	if params.Code == nil || *params.Code == "" {
+		response.BadRequest(ctx, "Authorization code is required", []string{"code is missing"}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	loginResp, err := h.service.OAuthLogin(ctx, string(provider), *params.Code)
+	if err != nil {
+		response.SendError(ctx, w, err, "Failed to login")
+		return
+	}
+
+	response.Success(ctx, "Login successful", loginResp).Send(w, http.StatusOK)
+}
+
 // GetApiAccountProfile implements genhttp.ServerInterface
 func (h *Handler) GetApiAccountProfile(w http.ResponseWriter, r *http.Request) {
 	h.GetProfile(w, r)
@@ -55,6 +469,110 @@ func (h *Handler) DeleteApiAccount(w http.ResponseWriter, r *http.Request) {
     response.Success(ctx, "Account deleted successfully", nil).Send(w, http.StatusOK)
 }
 
+// PutApiAccountAvatar implements genhttp.ServerInterface for PUT /api/account/avatar
+func (h *Handler) PutApiAccountAvatar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseMultipartForm(storage.MaxUploadMemory); err != nil {
+		response.BadRequest(ctx, "Failed to parse multipart form", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("avatar")
+	if err != nil {
+		response.BadRequest(ctx, "Avatar file is required", []string{"avatar field is missing"}).Send(w, http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	acc, err := h.service.UpdateAvatar(ctx, userID, file, header)
+	if err != nil {
+		response.SendError(ctx, w, err, "Failed to update avatar")
+		return
+	}
+
+	response.Success(ctx, "Avatar updated successfully", acc).Send(w, http.StatusOK)
+}
+
+// PutApiAccountSettings implements genhttp.ServerInterface for PUT /api/account/settings
+func (h *Handler) PutApiAccountSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	var req account.UpdateSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(ctx, "Invalid request body", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(ctx, userID, &req)
+	if err != nil {
+		response.SendError(ctx, w, err, "Failed to update settings")
+		return
+	}
+
+	response.Success(ctx, "Settings updated successfully", settings).Send(w, http.StatusOK)
+}
+
+// GetApiAccountSettings implements genhttp.ServerInterface for GET /api/account/settings
+func (h *Handler) GetApiAccountSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	settings, err := h.service.GetSettings(ctx, userID)
+	if err != nil {
+		response.SendError(ctx, w, err, "Failed to get settings")
+		return
+	}
+
+	response.Success(ctx, "Settings retrieved successfully", settings).Send(w, http.StatusOK)
+}
+
+// PostApiAdminAccountsIdVerification implements genhttp.ServerInterface for POST /api/admin/accounts/{id}/verification
+func (h *Handler) PostApiAdminAccountsIdVerification(w http.ResponseWriter, r *http.Request, id int64) {
+	h.setVerified(w, r, id, true)
+}
+
+// DeleteApiAdminAccountsIdVerification implements genhttp.ServerInterface for DELETE /api/admin/accounts/{id}/verification
+func (h *Handler) DeleteApiAdminAccountsIdVerification(w http.ResponseWriter, r *http.Request, id int64) {
+	h.setVerified(w, r, id, false)
+}
+
+// setVerified grants or revokes the verified badge of an account
+func (h *Handler) setVerified(w http.ResponseWriter, r *http.Request, id int64, isVerified bool) {
+	ctx := r.Context()
+
+	userID, ok := middleware.GetUserID(ctx)
+	if !ok || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	acc, err := h.service.SetVerified(ctx, userID, id, isVerified)
+	if err != nil {
+		response.SendError(ctx, w, err, "Failed to update verification")
+		return
+	}
+
+	response.Success(ctx, "Verification updated successfully", acc).Send(w, http.StatusOK)
+}
+
 // Register handles account registration
 func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -75,11 +593,12 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 	// Register account
 	acc, err := h.service.Register(ctx, &req)
 	if err != nil {
-		if err.Error() == "email already exists" {
-			response.Conflict(ctx, "Email already exists", []string{err.Error()}).Send(w, http.StatusConflict)
+		var policyErr *password.PolicyError
+		if errors.As(err, &policyErr) {
+			response.ValidationError(ctx, "Password does not meet the policy", policyErr.Violations).Send(w, http.StatusBadRequest)
 			return
 		}
-		response.InternalServerError(ctx, "Failed to register account", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		response.SendError(ctx, w, err, "Failed to register account")
 		return
 	}
 
@@ -107,11 +626,19 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	// Login account
 	loginResp, err := h.service.Login(ctx, &req)
 	if err != nil {
-		if err.Error() == "invalid credentials" {
-			response.Unauthorized(ctx, "Invalid credentials", []string{err.Error()}).Send(w, http.StatusUnauthorized)
+		var lockedErr *account.LoginLockedError
+		if errors.As(err, &lockedErr) {
+			retryAfter := int64((lockedErr.RetryAfter + time.Second - 1) / time.Second)
+			w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+			data := map[string]interface{}{"scope": lockedErr.Scope, "retry_after": retryAfter}
+			if lockedErr.Scope == account.LockScopeIP {
+				response.TooManyRequests(ctx, "Too many login attempts", []string{err.Error()}).WithData(data).Send(w, http.StatusTooManyRequests)
+				return
+			}
+			response.Locked(ctx, "Account temporarily locked", []string{err.Error()}).WithData(data).Send(w, http.StatusLocked)
 			return
 		}
-		response.InternalServerError(ctx, "Failed to login", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		response.SendError(ctx, w, err, "Failed to login")
 		return
 	}
 
@@ -137,6 +664,9 @@ func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Sign-in and activity times change without bumping updated_at
+	response.SetETag(w, acc.ID, acc.UpdatedAt, acc.LastLoginAt, acc.LastSeenAt)
+
 	// Send success response
 	response.Success(ctx, "Profile retrieved successfully", acc).Send(w, http.StatusOK)
 }
@@ -170,9 +700,6 @@ func validateRegisterRequest(req *account.RegisterRequest) error {
 	if req.Password == "" {
 		return fmt.Errorf("password is required")
 	}
-	if len(req.Password) < 8 {
-		return fmt.Errorf("password must be at least 8 characters")
-	}
 	return nil
 }
 
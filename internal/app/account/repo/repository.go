@@ -3,6 +3,9 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/fanzru/social-media-service-go/internal/app/account"
@@ -24,9 +27,91 @@ type Repository interface {
 	Create(ctx context.Context, acc *account.Account) error
 	GetByID(ctx context.Context, id int64) (*account.Account, error)
 	GetByEmail(ctx context.Context, email string) (*account.Account, error)
+	// GetByIDs retrieves the accounts with the given IDs in one query; unknown and deleted IDs are left out
+	GetByIDs(ctx context.Context, ids []int64) ([]account.Account, error)
 	Update(ctx context.Context, acc *account.Account) error
 	Delete(ctx context.Context, id int64) error
 	SoftDelete(ctx context.Context, id int64) error
+	// UpdateAvatar stores the avatar path and URL for the account
+	UpdateAvatar(ctx context.Context, id int64, avatarPath, avatarURL string) error
+	// UpdateSettings stores the account preferences and privacy settings
+	UpdateSettings(ctx context.Context, id int64, settings *account.Settings) error
+	// UpdatePassword stores a new password hash for the account
+	UpdatePassword(ctx context.Context, id int64, passwordHash string) error
+	// SetStatus marks the account active or deactivated
+	SetStatus(ctx context.Context, id int64, status string) error
+	// UpdateVerified grants or revokes the verified badge of the account
+	UpdateVerified(ctx context.Context, id int64, isVerified bool) error
+	// CreateSession records a new signed-in device and returns its ID; rememberMe selects long-lived refresh tokens
+	CreateSession(ctx context.Context, accountID int64, ipAddress, userAgent string, rememberMe bool) (int64, error)
+	// ListSessions returns the active sessions of the account, most recently seen first
+	ListSessions(ctx context.Context, accountID int64) ([]account.Session, error)
+	// RevokeSession revokes a session of the account and its refresh tokens
+	RevokeSession(ctx context.Context, accountID, sessionID int64) error
+	// RevokeAllSessions revokes every session and refresh token of the account
+	RevokeAllSessions(ctx context.Context, accountID int64) error
+	// RevokeOtherSessions revokes every session and refresh token of the account except one session
+	RevokeOtherSessions(ctx context.Context, accountID, keepSessionID int64) error
+	// RecordLogin sets the last sign-in and last seen time of an account
+	RecordLogin(ctx context.Context, accountID int64) error
+	// TouchSession updates the last seen time and IP address of a session and the last seen time of its account
+	TouchSession(ctx context.Context, sessionID int64, ipAddress string) error
+	// CreateRefreshToken stores the hash of a newly issued refresh token
+	CreateRefreshToken(ctx context.Context, accountID, sessionID int64, tokenHash string, expiresAt time.Time) error
+	// ConsumeRefreshToken revokes an active refresh token and returns its account and session IDs
+	// and whether the session is remembered;
+	// sql.ErrNoRows means the token is unknown, expired, already used or its session was revoked
+	ConsumeRefreshToken(ctx context.Context, tokenHash string) (int64, int64, bool, error)
+	// GetRefreshTokenOwner returns the account of a refresh token and whether it was already rotated
+	GetRefreshTokenOwner(ctx context.Context, tokenHash string) (int64, bool, error)
+	// RevokeRefreshToken revokes a single refresh token owned by the account
+	RevokeRefreshToken(ctx context.Context, accountID int64, tokenHash string) error
+	// RevokeToken adds an access token jti to the denylist until it expires
+	RevokeToken(ctx context.Context, tokenID string, accountID int64, expiresAt time.Time) error
+	// IsTokenRevoked reports whether an access token jti is on the denylist, its session was revoked
+	// or the account's token version has moved past the token's
+	IsTokenRevoked(ctx context.Context, tokenID string, sessionID, accountID int64, tokenVersion int) (bool, error)
+	// BumpTokenVersion increments the account's token version, invalidating every access token issued before
+	BumpTokenVersion(ctx context.Context, accountID int64) error
+	// IsEmailTaken reports whether another account, including soft-deleted ones, uses the email
+	IsEmailTaken(ctx context.Context, email string, excludeID int64) (bool, error)
+	// CreateEmailChange stages a new email for the account, replacing any pending change
+	CreateEmailChange(ctx context.Context, accountID int64, newEmail, tokenHash string, expiresAt time.Time) error
+	// GetEmailChange returns the account and new email of an unexpired pending change
+	GetEmailChange(ctx context.Context, tokenHash string) (int64, string, error)
+	// ConfirmEmailChange swaps in the staged email and removes the pending change
+	ConfirmEmailChange(ctx context.Context, tokenHash string) (int64, error)
+	// DeleteEmailChange removes the pending email change of the account
+	DeleteEmailChange(ctx context.Context, accountID int64) error
+	// GetAccountIDByIdentity returns the account linked to an external identity
+	GetAccountIDByIdentity(ctx context.Context, provider, providerUserID string) (int64, error)
+	// CreateIdentity links an external identity to the account
+	CreateIdentity(ctx context.Context, accountID int64, provider, providerUserID, email string) error
+	// CreateAPIKey stores a new API key and sets its ID and creation time
+	CreateAPIKey(ctx context.Context, key *account.APIKey, keyHash string) error
+	// ListAPIKeys returns the unrevoked API keys of the account, newest first
+	ListAPIKeys(ctx context.Context, accountID int64) ([]account.APIKey, error)
+	// GetAPIKeyByHash returns an unrevoked, unexpired API key; sql.ErrNoRows otherwise
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*account.APIKey, error)
+	// RevokeAPIKey revokes an API key of the account; sql.ErrNoRows means it was not found
+	RevokeAPIKey(ctx context.Context, accountID, keyID int64) error
+	// TouchAPIKey updates the last used time of an API key
+	TouchAPIKey(ctx context.Context, keyID int64) error
+	// GetLoginLockedUntil returns when the sign-in lock of a subject ends, or nil if it is not locked
+	GetLoginLockedUntil(ctx context.Context, subject string) (*time.Time, error)
+	// RecordLoginFailure counts a failed sign-in and returns the failures since windowStart
+	RecordLoginFailure(ctx context.Context, subject string, windowStart time.Time) (int, error)
+	// LockLogin blocks sign-in for a subject until the given time
+	LockLogin(ctx context.Context, subject string, until time.Time) error
+	// ClearLoginFailures forgets the failed sign-ins of a subject
+	ClearLoginFailures(ctx context.Context, subject string) error
+	// CreateSecurityEvent appends an event to the account's security log
+	CreateSecurityEvent(ctx context.Context, event *account.SecurityEvent) error
+	// ListSecurityEvents returns a page of the account's security log, newest first
+	ListSecurityEvents(ctx context.Context, accountID int64, cursor string, limit int) (*account.SecurityEventListResponse, error)
+	// RecordSeenDevice stores a device fingerprint the account signed in from. It reports
+	// whether the fingerprint was new and whether the account had any device recorded before.
+	RecordSeenDevice(ctx context.Context, accountID int64, fingerprint, ipAddress, userAgent string) (bool, bool, error)
 	// ListUserPostImagePaths returns all image_path values for posts created by the user
 	ListUserPostImagePaths(ctx context.Context, userID int64) ([]string, error)
 	// Transactional helpers
@@ -145,7 +230,7 @@ func (r *repository) Create(ctx context.Context, acc *account.Account) error {
 // GetByID retrieves an account by ID
 func (r *repository) GetByID(ctx context.Context, id int64) (*account.Account, error) {
 	query := `
-		SELECT id, name, email, password, created_at, updated_at, deleted_at
+		SELECT id, name, email, password, avatar_path, avatar_url, pinned_post_id, sensitive_content, is_private, comment_permission, discoverable, role, is_verified, status, token_version, last_login_at, last_seen_at, created_at, updated_at, deleted_at
 		FROM accounts
 		WHERE id = $1 AND deleted_at IS NULL`
 
@@ -155,6 +240,19 @@ func (r *repository) GetByID(ctx context.Context, id int64) (*account.Account, e
 		&acc.Name,
 		&acc.Email,
 		&acc.Password,
+		&acc.AvatarPath,
+		&acc.AvatarURL,
+		&acc.PinnedPostID,
+		&acc.SensitiveContent,
+		&acc.IsPrivate,
+		&acc.CommentPermission,
+		&acc.Discoverable,
+		&acc.Role,
+		&acc.IsVerified,
+		&acc.Status,
+		&acc.TokenVersion,
+		&acc.LastLoginAt,
+		&acc.LastSeenAt,
 		&acc.CreatedAt,
 		&acc.UpdatedAt,
 		&acc.DeletedAt,
@@ -167,10 +265,56 @@ func (r *repository) GetByID(ctx context.Context, id int64) (*account.Account, e
 	return acc, nil
 }
 
+// GetByIDs retrieves the accounts with the given IDs
+func (r *repository) GetByIDs(ctx context.Context, ids []int64) ([]account.Account, error) {
+	query := `
+		SELECT id, name, email, password, avatar_path, avatar_url, pinned_post_id, sensitive_content, is_private, comment_permission, discoverable, role, is_verified, status, token_version, last_login_at, last_seen_at, created_at, updated_at, deleted_at
+		FROM accounts
+		WHERE id = ANY($1) AND deleted_at IS NULL`
+
+	rows, err := r.db.QueryContext(ctx, query, sqlwrap.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := []account.Account{}
+	for rows.Next() {
+		var acc account.Account
+		if err := rows.Scan(
+			&acc.ID,
+			&acc.Name,
+			&acc.Email,
+			&acc.Password,
+			&acc.AvatarPath,
+			&acc.AvatarURL,
+			&acc.PinnedPostID,
+			&acc.SensitiveContent,
+			&acc.IsPrivate,
+			&acc.CommentPermission,
+			&acc.Discoverable,
+			&acc.Role,
+			&acc.IsVerified,
+			&acc.Status,
+			&acc.TokenVersion,
+			&acc.LastLoginAt,
+			&acc.LastSeenAt,
+			&acc.CreatedAt,
+			&acc.UpdatedAt,
+			&acc.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, acc)
+	}
+
+	return accounts, rows.Err()
+}
+
 // GetByEmail retrieves an account by email
 func (r *repository) GetByEmail(ctx context.Context, email string) (*account.Account, error) {
 	query := `
-		SELECT id, name, email, password, created_at, updated_at, deleted_at
+		SELECT id, name, email, password, avatar_path, avatar_url, pinned_post_id, sensitive_content, is_private, comment_permission, discoverable, role, is_verified, status, token_version, last_login_at, last_seen_at, created_at, updated_at, deleted_at
 		FROM accounts
 		WHERE email = $1 AND deleted_at IS NULL`
 
@@ -180,6 +324,19 @@ func (r *repository) GetByEmail(ctx context.Context, email string) (*account.Acc
 		&acc.Name,
 		&acc.Email,
 		&acc.Password,
+		&acc.AvatarPath,
+		&acc.AvatarURL,
+		&acc.PinnedPostID,
+		&acc.SensitiveContent,
+		&acc.IsPrivate,
+		&acc.CommentPermission,
+		&acc.Discoverable,
+		&acc.Role,
+		&acc.IsVerified,
+		&acc.Status,
+		&acc.TokenVersion,
+		&acc.LastLoginAt,
+		&acc.LastSeenAt,
 		&acc.CreatedAt,
 		&acc.UpdatedAt,
 		&acc.DeletedAt,
@@ -274,6 +431,664 @@ func (r *repository) SoftDelete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// UpdateAvatar updates the avatar path and URL of an account
+func (r *repository) UpdateAvatar(ctx context.Context, id int64, avatarPath, avatarURL string) error {
+	query := `
+		UPDATE accounts
+		SET avatar_path = $2, avatar_url = $3, updated_at = $4
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, avatarPath, avatarURL, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// UpdateSettings updates the preferences and privacy settings of an account
+func (r *repository) UpdateSettings(ctx context.Context, id int64, settings *account.Settings) error {
+	query := `
+		UPDATE accounts
+		SET sensitive_content = $2, is_private = $3, comment_permission = $4, discoverable = $5, updated_at = $6
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, settings.SensitiveContent, settings.IsPrivate,
+		settings.CommentPermission, settings.Discoverable, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// UpdatePassword replaces the password hash of the account
+func (r *repository) UpdatePassword(ctx context.Context, id int64, passwordHash string) error {
+	query := `
+		UPDATE accounts
+		SET password = $2, updated_at = $3
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, passwordHash, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SetStatus updates the account status, stamping deactivated_at while deactivated
+func (r *repository) SetStatus(ctx context.Context, id int64, status string) error {
+	query := `
+		UPDATE accounts
+		SET status = $2,
+			deactivated_at = CASE WHEN $2 = 'deactivated' THEN $3 ELSE NULL END,
+			updated_at = $3
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, status, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// UpdateVerified sets the verified badge of an account
+func (r *repository) UpdateVerified(ctx context.Context, id int64, isVerified bool) error {
+	query := `
+		UPDATE accounts
+		SET is_verified = $2, updated_at = $3
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, isVerified, time.Now())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CreateSession stores a new session for the account
+func (r *repository) CreateSession(ctx context.Context, accountID int64, ipAddress, userAgent string, rememberMe bool) (int64, error) {
+	query := `
+		INSERT INTO sessions (account_id, ip_address, user_agent, remember_me, created_at, last_seen_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		RETURNING id`
+
+	var id int64
+	err := r.db.QueryRowContext(ctx, query, accountID, ipAddress, userAgent, rememberMe, time.Now()).Scan(&id)
+	return id, err
+}
+
+// ListSessions returns the sessions of an account that have not been revoked
+func (r *repository) ListSessions(ctx context.Context, accountID int64) ([]account.Session, error) {
+	query := `
+		SELECT id, ip_address, user_agent, created_at, last_seen_at
+		FROM sessions
+		WHERE account_id = $1 AND revoked_at IS NULL
+		ORDER BY last_seen_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []account.Session{}
+	for rows.Next() {
+		var s account.Session
+		if err := rows.Scan(&s.ID, &s.IPAddress, &s.UserAgent, &s.CreatedAt, &s.LastSeenAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+// RevokeSession revokes a session and its refresh tokens; sql.ErrNoRows means
+// the session does not exist, belongs to another account or is already revoked
+func (r *repository) RevokeSession(ctx context.Context, accountID, sessionID int64) error {
+	query := `
+		WITH revoked AS (
+			UPDATE sessions
+			SET revoked_at = $3
+			WHERE id = $1 AND account_id = $2 AND revoked_at IS NULL
+			RETURNING id
+		), tokens AS (
+			UPDATE refresh_tokens
+			SET revoked_at = $3
+			WHERE session_id IN (SELECT id FROM revoked) AND revoked_at IS NULL
+		)
+		SELECT id FROM revoked`
+
+	var id int64
+	return r.db.QueryRowContext(ctx, query, sessionID, accountID, time.Now()).Scan(&id)
+}
+
+// RevokeAllSessions revokes every active session and refresh token of an account
+func (r *repository) RevokeAllSessions(ctx context.Context, accountID int64) error {
+	query := `
+		WITH sessions_revoked AS (
+			UPDATE sessions
+			SET revoked_at = $2
+			WHERE account_id = $1 AND revoked_at IS NULL
+		)
+		UPDATE refresh_tokens
+		SET revoked_at = $2
+		WHERE account_id = $1 AND revoked_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, accountID, time.Now())
+	return err
+}
+
+// RevokeOtherSessions revokes the account's sessions and refresh tokens except those of keepSessionID
+func (r *repository) RevokeOtherSessions(ctx context.Context, accountID, keepSessionID int64) error {
+	query := `
+		WITH sessions_revoked AS (
+			UPDATE sessions
+			SET revoked_at = $3
+			WHERE account_id = $1 AND id <> $2 AND revoked_at IS NULL
+		)
+		UPDATE refresh_tokens
+		SET revoked_at = $3
+		WHERE account_id = $1 AND session_id IS DISTINCT FROM $2 AND revoked_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, accountID, keepSessionID, time.Now())
+	return err
+}
+
+// RecordLogin stamps a sign-in; it also counts as activity
+func (r *repository) RecordLogin(ctx context.Context, accountID int64) error {
+	query := `UPDATE accounts SET last_login_at = $2, last_seen_at = $2 WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query, accountID, time.Now())
+	return err
+}
+
+// TouchSession records session activity at most once a minute to keep writes cheap. The
+// account's last_seen_at is only written when the session row was, so it shares the throttle.
+func (r *repository) TouchSession(ctx context.Context, sessionID int64, ipAddress string) error {
+	query := `
+		WITH touched AS (
+			UPDATE sessions
+			SET last_seen_at = $2, ip_address = $3
+			WHERE id = $1 AND revoked_at IS NULL AND last_seen_at < $2 - INTERVAL '1 minute'
+			RETURNING account_id
+		)
+		UPDATE accounts a
+		SET last_seen_at = $2
+		FROM touched
+		WHERE a.id = touched.account_id`
+
+	_, err := r.db.ExecContext(ctx, query, sessionID, time.Now(), ipAddress)
+	return err
+}
+
+// CreateAPIKey stores an API key by its hash
+func (r *repository) CreateAPIKey(ctx context.Context, key *account.APIKey, keyHash string) error {
+	query := `
+		INSERT INTO api_keys (account_id, name, prefix, key_hash, scopes, rate_limit, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		key.AccountID, key.Name, key.Prefix, keyHash, sqlwrap.Array(key.Scopes), key.RateLimit, time.Now(), key.ExpiresAt,
+	).Scan(&key.ID, &key.CreatedAt)
+}
+
+// ListAPIKeys returns the API keys of an account that have not been revoked
+func (r *repository) ListAPIKeys(ctx context.Context, accountID int64) ([]account.APIKey, error) {
+	query := `
+		SELECT id, account_id, name, prefix, scopes, rate_limit, created_at, last_used_at, expires_at
+		FROM api_keys
+		WHERE account_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []account.APIKey{}
+	for rows.Next() {
+		var k account.APIKey
+		if err := rows.Scan(&k.ID, &k.AccountID, &k.Name, &k.Prefix, sqlwrap.Array(&k.Scopes), &k.RateLimit, &k.CreatedAt, &k.LastUsedAt, &k.ExpiresAt); err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+// GetAPIKeyByHash looks up a usable API key by the hash of its secret
+func (r *repository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*account.APIKey, error) {
+	query := `
+		SELECT id, account_id, name, prefix, scopes, rate_limit, created_at, last_used_at, expires_at
+		FROM api_keys
+		WHERE key_hash = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > $2)`
+
+	var k account.APIKey
+	err := r.db.QueryRowContext(ctx, query, keyHash, time.Now()).Scan(
+		&k.ID, &k.AccountID, &k.Name, &k.Prefix, sqlwrap.Array(&k.Scopes), &k.RateLimit, &k.CreatedAt, &k.LastUsedAt, &k.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+// RevokeAPIKey revokes an API key of the account
+func (r *repository) RevokeAPIKey(ctx context.Context, accountID, keyID int64) error {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = $3
+		WHERE id = $1 AND account_id = $2 AND revoked_at IS NULL
+		RETURNING id`
+
+	var id int64
+	return r.db.QueryRowContext(ctx, query, keyID, accountID, time.Now()).Scan(&id)
+}
+
+// TouchAPIKey records API key usage at most once a minute to keep writes cheap
+func (r *repository) TouchAPIKey(ctx context.Context, keyID int64) error {
+	query := `
+		UPDATE api_keys
+		SET last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $2 - INTERVAL '1 minute')`
+
+	_, err := r.db.ExecContext(ctx, query, keyID, time.Now())
+	return err
+}
+
+// GetLoginLockedUntil returns the end of an active sign-in lock
+func (r *repository) GetLoginLockedUntil(ctx context.Context, subject string) (*time.Time, error) {
+	query := `
+		SELECT locked_until
+		FROM login_failures
+		WHERE subject = $1 AND locked_until > $2`
+
+	var lockedUntil time.Time
+	err := r.db.QueryRowContext(ctx, query, subject, time.Now()).Scan(&lockedUntil)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &lockedUntil, nil
+}
+
+// RecordLoginFailure increments the failure count of a subject; failures older than
+// windowStart are forgotten first
+func (r *repository) RecordLoginFailure(ctx context.Context, subject string, windowStart time.Time) (int, error) {
+	query := `
+		INSERT INTO login_failures (subject, failures, last_failed_at)
+		VALUES ($1, 1, $2)
+		ON CONFLICT (subject) DO UPDATE
+		SET failures = CASE
+				WHEN login_failures.last_failed_at < $3 THEN 1
+				ELSE login_failures.failures + 1
+			END,
+			last_failed_at = $2
+		RETURNING failures`
+
+	var failures int
+	err := r.db.QueryRowContext(ctx, query, subject, time.Now(), windowStart).Scan(&failures)
+	return failures, err
+}
+
+// LockLogin sets the sign-in lock of a subject
+func (r *repository) LockLogin(ctx context.Context, subject string, until time.Time) error {
+	query := `UPDATE login_failures SET locked_until = $2 WHERE subject = $1`
+
+	_, err := r.db.ExecContext(ctx, query, subject, until)
+	return err
+}
+
+// ClearLoginFailures removes the failure record of a subject
+func (r *repository) ClearLoginFailures(ctx context.Context, subject string) error {
+	query := `DELETE FROM login_failures WHERE subject = $1`
+
+	_, err := r.db.ExecContext(ctx, query, subject)
+	return err
+}
+
+// CreateSecurityEvent inserts a security log entry and sets its ID and creation time
+func (r *repository) CreateSecurityEvent(ctx context.Context, event *account.SecurityEvent) error {
+	query := `
+		INSERT INTO security_events (account_id, event_type, ip_address, user_agent, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	event.CreatedAt = time.Now()
+	return r.db.QueryRowContext(ctx, query, event.AccountID, event.EventType, event.IPAddress,
+		event.UserAgent, event.Details, event.CreatedAt).Scan(&event.ID)
+}
+
+// RecordSeenDevice upserts a device fingerprint; xmax is zero only for a freshly inserted row
+func (r *repository) RecordSeenDevice(ctx context.Context, accountID int64, fingerprint, ipAddress, userAgent string) (bool, bool, error) {
+	query := `
+		WITH prior AS (
+			SELECT EXISTS (SELECT 1 FROM seen_devices WHERE account_id = $1) AS known
+		), upsert AS (
+			INSERT INTO seen_devices (account_id, fingerprint, ip_address, user_agent, first_seen_at, last_seen_at)
+			VALUES ($1, $2, $3, $4, $5, $5)
+			ON CONFLICT (account_id, fingerprint) DO UPDATE SET last_seen_at = EXCLUDED.last_seen_at
+			RETURNING xmax = 0 AS inserted
+		)
+		SELECT upsert.inserted, prior.known FROM upsert, prior`
+
+	var isNew, hadDevices bool
+	err := r.db.QueryRowContext(ctx, query, accountID, fingerprint, ipAddress, userAgent, time.Now()).Scan(&isNew, &hadDevices)
+	return isNew, hadDevices, err
+}
+
+// ListSecurityEvents returns the security log of an account, newest first, paginated by ID cursor
+func (r *repository) ListSecurityEvents(ctx context.Context, accountID int64, cursor string, limit int) (*account.SecurityEventListResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	query := `
+		SELECT id, event_type, ip_address, user_agent, details, created_at
+		FROM security_events
+		WHERE account_id = $1`
+	args := []interface{}{accountID}
+
+	if cursor != "" {
+		if id, err := decodeIDCursor(cursor); err == nil {
+			args = append(args, id)
+			query += fmt.Sprintf(` AND id < $%d`, len(args))
+		}
+	}
+
+	args = append(args, limit+1) // Get one extra to check if there are more
+	query += fmt.Sprintf(` ORDER BY id DESC LIMIT $%d`, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []account.SecurityEvent{}
+	for rows.Next() {
+		var e account.SecurityEvent
+		if err := rows.Scan(&e.ID, &e.EventType, &e.IPAddress, &e.UserAgent, &e.Details, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.AccountID = accountID
+		items = append(items, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	var nextCursor string
+	if hasMore && len(items) > 0 {
+		nextCursor = encodeIDCursor(items[len(items)-1].ID)
+	}
+
+	return &account.SecurityEventListResponse{
+		Items:   items,
+		Cursor:  nextCursor,
+		HasMore: hasMore,
+	}, nil
+}
+
+// encodeIDCursor creates an opaque cursor from a row ID
+func encodeIDCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+// decodeIDCursor parses a cursor created by encodeIDCursor
+func decodeIDCursor(cursor string) (int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(b), 10, 64)
+}
+
+// CreateRefreshToken stores a refresh token hash for the account session
+func (r *repository) CreateRefreshToken(ctx context.Context, accountID, sessionID int64, tokenHash string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO refresh_tokens (account_id, session_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.db.ExecContext(ctx, query, accountID, sessionID, tokenHash, expiresAt, time.Now())
+	return err
+}
+
+// ConsumeRefreshToken atomically revokes an active refresh token so it can only be used once.
+// Tokens issued before sessions existed have no session and return a session ID of 0.
+func (r *repository) ConsumeRefreshToken(ctx context.Context, tokenHash string) (int64, int64, bool, error) {
+	query := `
+		UPDATE refresh_tokens rt
+		SET revoked_at = $2, rotated_at = $2
+		WHERE rt.token_hash = $1 AND rt.revoked_at IS NULL AND rt.expires_at > $2
+			AND NOT EXISTS (
+				SELECT 1 FROM sessions s
+				WHERE s.id = rt.session_id AND s.revoked_at IS NOT NULL
+			)
+		RETURNING rt.account_id, COALESCE(rt.session_id, 0),
+			COALESCE((SELECT s.remember_me FROM sessions s WHERE s.id = rt.session_id), TRUE)`
+
+	var accountID, sessionID int64
+	var rememberMe bool
+	err := r.db.QueryRowContext(ctx, query, tokenHash, time.Now()).Scan(&accountID, &sessionID, &rememberMe)
+	return accountID, sessionID, rememberMe, err
+}
+
+// GetRefreshTokenOwner returns the account a refresh token belongs to and whether it was rotated
+func (r *repository) GetRefreshTokenOwner(ctx context.Context, tokenHash string) (int64, bool, error) {
+	query := `
+		SELECT account_id, rotated_at IS NOT NULL
+		FROM refresh_tokens
+		WHERE token_hash = $1`
+
+	var accountID int64
+	var rotated bool
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&accountID, &rotated)
+	return accountID, rotated, err
+}
+
+// RevokeRefreshToken revokes one refresh token of an account
+func (r *repository) RevokeRefreshToken(ctx context.Context, accountID int64, tokenHash string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = $3
+		WHERE account_id = $1 AND token_hash = $2 AND revoked_at IS NULL`
+
+	_, err := r.db.ExecContext(ctx, query, accountID, tokenHash, time.Now())
+	return err
+}
+
+// RevokeToken stores an access token jti in the denylist; revoking twice is a no-op
+func (r *repository) RevokeToken(ctx context.Context, tokenID string, accountID int64, expiresAt time.Time) error {
+	query := `
+		INSERT INTO revoked_tokens (jti, account_id, expires_at, revoked_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (jti) DO NOTHING`
+
+	_, err := r.db.ExecContext(ctx, query, tokenID, accountID, expiresAt, time.Now())
+	return err
+}
+
+// IsTokenRevoked checks the denylist for an access token jti and whether its session was revoked
+func (r *repository) IsTokenRevoked(ctx context.Context, tokenID string, sessionID, accountID int64, tokenVersion int) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)
+			OR EXISTS (SELECT 1 FROM sessions WHERE id = $2 AND revoked_at IS NOT NULL)
+			OR EXISTS (SELECT 1 FROM accounts WHERE id = $3 AND token_version > $4)`
+
+	var revoked bool
+	err := r.db.QueryRowContext(ctx, query, tokenID, sessionID, accountID, tokenVersion).Scan(&revoked)
+	return revoked, err
+}
+
+// BumpTokenVersion increments the token version of an account; sql.ErrNoRows means it does not exist
+func (r *repository) BumpTokenVersion(ctx context.Context, accountID int64) error {
+	query := `
+		UPDATE accounts
+		SET token_version = token_version + 1
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, accountID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// IsEmailTaken checks whether an email belongs to any account other than excludeID
+func (r *repository) IsEmailTaken(ctx context.Context, email string, excludeID int64) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM accounts WHERE email = $1 AND id <> $2)`
+
+	var taken bool
+	err := r.db.QueryRowContext(ctx, query, email, excludeID).Scan(&taken)
+	return taken, err
+}
+
+// CreateEmailChange stores a pending email change; a new request replaces the previous one
+func (r *repository) CreateEmailChange(ctx context.Context, accountID int64, newEmail, tokenHash string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO email_changes (account_id, new_email, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (account_id) DO UPDATE
+		SET new_email = EXCLUDED.new_email,
+			token_hash = EXCLUDED.token_hash,
+			expires_at = EXCLUDED.expires_at,
+			created_at = EXCLUDED.created_at`
+
+	_, err := r.db.ExecContext(ctx, query, accountID, newEmail, tokenHash, expiresAt, time.Now())
+	return err
+}
+
+// GetEmailChange looks up an unexpired pending email change by token hash
+func (r *repository) GetEmailChange(ctx context.Context, tokenHash string) (int64, string, error) {
+	query := `
+		SELECT account_id, new_email
+		FROM email_changes
+		WHERE token_hash = $1 AND expires_at > $2`
+
+	var accountID int64
+	var newEmail string
+	err := r.db.QueryRowContext(ctx, query, tokenHash, time.Now()).Scan(&accountID, &newEmail)
+	return accountID, newEmail, err
+}
+
+// ConfirmEmailChange applies a pending email change in a single statement so the
+// pending row is only removed when the account update succeeds
+func (r *repository) ConfirmEmailChange(ctx context.Context, tokenHash string) (int64, error) {
+	query := `
+		WITH pending AS (
+			DELETE FROM email_changes
+			WHERE token_hash = $1 AND expires_at > $2
+			RETURNING account_id, new_email
+		)
+		UPDATE accounts a
+		SET email = pending.new_email, updated_at = $2
+		FROM pending
+		WHERE a.id = pending.account_id AND a.deleted_at IS NULL
+		RETURNING a.id`
+
+	var accountID int64
+	err := r.db.QueryRowContext(ctx, query, tokenHash, time.Now()).Scan(&accountID)
+	return accountID, err
+}
+
+// DeleteEmailChange removes the pending email change of an account
+func (r *repository) DeleteEmailChange(ctx context.Context, accountID int64) error {
+	query := `DELETE FROM email_changes WHERE account_id = $1`
+
+	_, err := r.db.ExecContext(ctx, query, accountID)
+	return err
+}
+
+// GetAccountIDByIdentity looks up the account linked to a provider identity
+func (r *repository) GetAccountIDByIdentity(ctx context.Context, provider, providerUserID string) (int64, error) {
+	query := `
+		SELECT account_id
+		FROM account_identities
+		WHERE provider = $1 AND provider_user_id = $2`
+
+	var accountID int64
+	err := r.db.QueryRowContext(ctx, query, provider, providerUserID).Scan(&accountID)
+	return accountID, err
+}
+
+// CreateIdentity links a provider identity to an account; linking twice is a no-op
+func (r *repository) CreateIdentity(ctx context.Context, accountID int64, provider, providerUserID, email string) error {
+	query := `
+		INSERT INTO account_identities (account_id, provider, provider_user_id, email, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (provider, provider_user_id) DO NOTHING`
+
+	_, err := r.db.ExecContext(ctx, query, accountID, provider, providerUserID, email, time.Now())
+	return err
+}
+
 // ListUserPostImagePaths returns all image paths for posts created by the given user
 func (r *repository) ListUserPostImagePaths(ctx context.Context, userID int64) ([]string, error) {
 	query := `
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fanzru/social-media-service-go/internal/app/activity"
+)
+
+// Service implements activity service interface
+type Service struct {
+	repo activity.ActivityRepository
+}
+
+// NewService creates a new activity service
+func NewService(repo activity.ActivityRepository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// GetAccountActivity retrieves the account's own activity timeline
+func (s *Service) GetAccountActivity(ctx context.Context, accountID int64, cursor string, limit int) (*activity.ActivityListResponse, error) {
+	response, err := s.repo.GetByAccountID(ctx, accountID, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account activity: %w", err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,39 @@
+package activity
+
+import (
+	"context"
+	"time"
+)
+
+// Activity types
+const (
+	TypePost        = "post"         // Created a post
+	TypeComment     = "comment"      // Commented on a post
+	TypeCommentLike = "comment_like" // Liked a comment
+	TypeFollow      = "follow"       // Followed an account
+)
+
+// Activity represents a single action in an account's activity timeline
+type Activity struct {
+	Type      string    `json:"type" db:"type"`
+	ObjectID  int64     `json:"object_id" db:"object_id"` // Post, comment, liked comment or followed account ID
+	PostID    *int64    `json:"post_id,omitempty" db:"post_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// ActivityListResponse represents the response payload for listing activities
+type ActivityListResponse struct {
+	Items   []Activity `json:"items"`
+	Cursor  string     `json:"cursor,omitempty"`
+	HasMore bool       `json:"has_more"`
+}
+
+// ActivityRepository defines the interface for activity data access
+type ActivityRepository interface {
+	GetByAccountID(ctx context.Context, accountID int64, cursor string, limit int) (*ActivityListResponse, error)
+}
+
+// ActivityService defines the interface for activity business logic
+type ActivityService interface {
+	GetAccountActivity(ctx context.Context, accountID int64, cursor string, limit int) (*ActivityListResponse, error)
+}
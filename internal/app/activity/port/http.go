@@ -0,0 +1,52 @@
+package port
+
+import (
+	"net/http"
+
+	"github.com/fanzru/social-media-service-go/internal/app/activity"
+	"github.com/fanzru/social-media-service-go/internal/app/activity/port/genhttp"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"github.com/fanzru/social-media-service-go/pkg/response"
+)
+
+// Handler handles HTTP requests for the activity timeline
+type Handler struct {
+	service activity.ActivityService
+}
+
+// NewHandler creates a new activity handler
+func NewHandler(service activity.ActivityService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// GetApiAccountActivity handles GET /api/account/activity
+func (h *Handler) GetApiAccountActivity(w http.ResponseWriter, r *http.Request, params genhttp.GetApiAccountActivityParams) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	cursor := ""
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	activities, err := h.service.GetAccountActivity(r.Context(), userID, cursor, limit)
+	if err != nil {
+		response.InternalServerError(r.Context(), "Failed to get account activity", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	response.Success(r.Context(), "Account activity retrieved successfully", activities).Send(w, http.StatusOK)
+}
+
+// Implement the generated interface
+var _ genhttp.ServerInterface = (*Handler)(nil)
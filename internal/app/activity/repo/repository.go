@@ -0,0 +1,135 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/activity"
+	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
+)
+
+// Repository implements activity repository interface
+type Repository struct {
+	db interface{} // Can be *sql.DB or *sqlwrap.DB
+}
+
+// NewRepository creates a new activity repository
+func NewRepository(db interface{}) *Repository {
+	return &Repository{db: db}
+}
+
+// GetByAccountID assembles the account's posts, comments, comment likes and follows into
+// a single stream, newest first, with cursor-based pagination
+func (r *Repository) GetByAccountID(ctx context.Context, accountID int64, cursor string, limit int) (*activity.ActivityListResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	query := `
+		SELECT type, object_id, post_id, created_at
+		FROM (
+			SELECT 'post' AS type, id AS object_id, id AS post_id, created_at
+			FROM posts
+			WHERE creator_id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT 'comment', id, post_id, created_at
+			FROM comments
+			WHERE creator_id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT 'comment_like', cl.comment_id, c.post_id, cl.created_at
+			FROM comment_likes cl
+			JOIN comments c ON c.id = cl.comment_id AND c.deleted_at IS NULL
+			WHERE cl.account_id = $1
+			UNION ALL
+			SELECT 'follow', following_id, NULL, created_at
+			FROM follows
+			WHERE follower_id = $1
+		) activities
+	`
+	args := []interface{}{accountID}
+
+	if cursor != "" {
+		ct, typ, id, err := decodeActivityCursor(cursor)
+		if err == nil {
+			query += ` WHERE (created_at, type, object_id) < ($2, $3, $4)`
+			args = append(args, ct, typ, id)
+		}
+	}
+
+	query += ` ORDER BY created_at DESC, type DESC, object_id DESC LIMIT $` + fmt.Sprintf("%d", len(args)+1)
+	args = append(args, limit+1) // Get one extra to check if there are more
+
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, args...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []activity.Activity{}
+	for rows.Next() {
+		var a activity.Activity
+		if err := rows.Scan(&a.Type, &a.ObjectID, &a.PostID, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	var nextCursor string
+	if hasMore && len(items) > 0 {
+		last := items[len(items)-1]
+		nextCursor = encodeActivityCursor(last.CreatedAt, last.Type, last.ObjectID)
+	}
+
+	return &activity.ActivityListResponse{
+		Items:   items,
+		Cursor:  nextCursor,
+		HasMore: hasMore,
+	}, nil
+}
+
+// encodeActivityCursor creates a stable cursor combining created_at, type and object ID
+func encodeActivityCursor(createdAt time.Time, typ string, objectID int64) string {
+	plain := createdAt.Format(time.RFC3339Nano) + "|" + typ + "|" + strconv.FormatInt(objectID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(plain))
+}
+
+// decodeActivityCursor parses the composite cursor back to values
+func decodeActivityCursor(cursor string) (time.Time, string, int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", 0, err
+	}
+	parts := strings.SplitN(string(b), "|", 3)
+	if len(parts) != 3 {
+		return time.Time{}, "", 0, fmt.Errorf("invalid cursor format")
+	}
+	ct, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, "", 0, err
+	}
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return time.Time{}, "", 0, err
+	}
+	return ct, parts[1], id, nil
+}
@@ -0,0 +1,168 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fanzru/social-media-service-go/internal/app/admin"
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+)
+
+// ImageDeleter defines the capability needed to delete images
+type ImageDeleter interface {
+	DeleteImage(imagePath string) error
+}
+
+// Service implements admin service interface
+type Service struct {
+	repo   admin.AdminRepository
+	images ImageDeleter
+}
+
+// NewService creates a new admin service
+func NewService(repo admin.AdminRepository, images ImageDeleter) *Service {
+	return &Service{
+		repo:   repo,
+		images: images,
+	}
+}
+
+// ListAccounts lists and searches accounts
+func (s *Service) ListAccounts(ctx context.Context, filter admin.AccountFilter) (*admin.AccountListResponse, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	accounts, err := s.repo.ListAccounts(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// DeletePost removes any post, deletes its image and resolves the reports about it
+func (s *Service) DeletePost(ctx context.Context, adminID, id int64) error {
+	imagePath, err := s.repo.DeletePost(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return admin.ErrPostNotFound
+		}
+		return fmt.Errorf("failed to delete post: %w", err)
+	}
+
+	// Image cleanup failures must not undo the removal
+	if err := s.images.DeleteImage(imagePath); err != nil {
+		logger.GetGlobal().Warn("Failed to delete post image", "post_id", id, "image_path", imagePath, "error", err.Error())
+	}
+
+	if err := s.repo.ResolveReports(ctx, admin.TargetPost, id, adminID); err != nil {
+		return fmt.Errorf("failed to resolve reports: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteComment removes any comment and resolves the reports about it
+func (s *Service) DeleteComment(ctx context.Context, adminID, id int64) error {
+	if err := s.repo.DeleteComment(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return admin.ErrCommentNotFound
+		}
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	if err := s.repo.ResolveReports(ctx, admin.TargetComment, id, adminID); err != nil {
+		return fmt.Errorf("failed to resolve reports: %w", err)
+	}
+
+	return nil
+}
+
+// CreateReport files a report about a post, comment or account for admin review
+func (s *Service) CreateReport(ctx context.Context, reporterID int64, req *admin.CreateReportRequest) (*admin.Report, error) {
+	if !admin.IsValidTargetType(req.TargetType) {
+		return nil, fmt.Errorf("%w: %s", admin.ErrInvalidTargetType, req.TargetType)
+	}
+	if req.TargetID <= 0 {
+		return nil, fmt.Errorf("%w: %d", admin.ErrInvalidTargetID, req.TargetID)
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" || len(reason) > 1000 {
+		return nil, fmt.Errorf("%w: must be between 1 and 1000 characters", admin.ErrInvalidReason)
+	}
+	if req.TargetType == admin.TargetAccount && req.TargetID == reporterID {
+		return nil, admin.ErrSelfReport
+	}
+
+	exists, err := s.repo.TargetExists(ctx, req.TargetType, req.TargetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check report target: %w", err)
+	}
+	if !exists {
+		return nil, admin.ErrTargetNotFound
+	}
+
+	report := &admin.Report{
+		ReporterID: reporterID,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		Reason:     reason,
+	}
+	if err := s.repo.CreateReport(ctx, report); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, admin.ErrReportExists
+		}
+		return nil, fmt.Errorf("failed to create report: %w", err)
+	}
+
+	return report, nil
+}
+
+// ListReports returns the report queue for a status, oldest first
+func (s *Service) ListReports(ctx context.Context, status, targetType, cursor string, limit int) (*admin.ReportListResponse, error) {
+	if status == "" {
+		status = admin.ReportOpen
+	}
+	if !admin.IsValidReportStatus(status) {
+		return nil, fmt.Errorf("%w: %s", admin.ErrInvalidReportStatus, status)
+	}
+	if targetType != "" && !admin.IsValidTargetType(targetType) {
+		return nil, fmt.Errorf("%w: %s", admin.ErrInvalidTargetType, targetType)
+	}
+
+	reports, err := s.repo.ListReports(ctx, status, targetType, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+// UpdateReport resolves or dismisses an open report
+func (s *Service) UpdateReport(ctx context.Context, adminID, id int64, req *admin.UpdateReportRequest) (*admin.Report, error) {
+	if req.Status != admin.ReportResolved && req.Status != admin.ReportDismissed {
+		return nil, fmt.Errorf("%w: must be one of resolved, dismissed", admin.ErrInvalidReportStatus)
+	}
+
+	report, err := s.repo.UpdateReportStatus(ctx, id, req.Status, adminID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, admin.ErrReportNotFound
+		}
+		return nil, fmt.Errorf("failed to update report: %w", err)
+	}
+
+	return report, nil
+}
+
+// GetStats returns service-wide counters
+func (s *Service) GetStats(ctx context.Context) (*admin.Stats, error) {
+	stats, err := s.repo.GetStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats: %w", err)
+	}
+
+	return stats, nil
+}
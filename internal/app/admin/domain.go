@@ -0,0 +1,161 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
+)
+
+// Errors returned by the admin service
+var (
+	ErrPostNotFound        = apperr.NotFound("post not found")
+	ErrCommentNotFound     = apperr.NotFound("comment not found")
+	ErrReportNotFound      = apperr.NotFound("open report not found")
+	ErrTargetNotFound      = apperr.NotFound("reported target not found")
+	ErrReportExists        = apperr.Conflict("report already submitted")
+	ErrSelfReport          = apperr.Invalid("cannot report your own account")
+	ErrInvalidTargetType   = apperr.Invalid("invalid target_type")
+	ErrInvalidTargetID     = apperr.Invalid("invalid target_id")
+	ErrInvalidReason       = apperr.Invalid("invalid reason")
+	ErrInvalidReportStatus = apperr.Invalid("invalid status")
+)
+
+// Report target types
+const (
+	TargetPost    = "post"
+	TargetComment = "comment"
+	TargetAccount = "account"
+)
+
+// IsValidTargetType reports whether v is a reportable target type
+func IsValidTargetType(v string) bool {
+	switch v {
+	case TargetPost, TargetComment, TargetAccount:
+		return true
+	}
+	return false
+}
+
+// Report statuses
+const (
+	ReportOpen      = "open"      // Waiting for review
+	ReportResolved  = "resolved"  // Reviewed and acted upon
+	ReportDismissed = "dismissed" // Reviewed, no action needed
+)
+
+// IsValidReportStatus reports whether v is a supported report status
+func IsValidReportStatus(v string) bool {
+	switch v {
+	case ReportOpen, ReportResolved, ReportDismissed:
+		return true
+	}
+	return false
+}
+
+// AccountSummary represents an account as seen by admins
+type AccountSummary struct {
+	ID           int64      `json:"id" db:"id"`
+	Name         string     `json:"name" db:"name"`
+	Email        string     `json:"email" db:"email"`
+	Role         string     `json:"role" db:"role"`
+	IsVerified   bool       `json:"is_verified" db:"is_verified"`
+	Status       string     `json:"status" db:"status"`
+	PostCount    int64      `json:"post_count" db:"post_count"`
+	CommentCount int64      `json:"comment_count" db:"comment_count"`
+	LastLoginAt  *time.Time `json:"last_login_at,omitempty" db:"last_login_at"`
+	LastSeenAt   *time.Time `json:"last_seen_at,omitempty" db:"last_seen_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+}
+
+// AccountFilter narrows an admin account listing
+type AccountFilter struct {
+	Query  string // Matches name or email, case-insensitive
+	Role   string
+	Status string
+	Cursor string
+	Limit  int
+}
+
+// AccountListResponse represents the response payload for listing accounts
+type AccountListResponse struct {
+	Items   []AccountSummary `json:"items"`
+	Cursor  string           `json:"cursor,omitempty"`
+	HasMore bool             `json:"has_more"`
+}
+
+// Report represents a user report about a post, comment or account
+type Report struct {
+	ID         int64      `json:"id" db:"id"`
+	ReporterID int64      `json:"reporter_id" db:"reporter_id"`
+	TargetType string     `json:"target_type" db:"target_type"`
+	TargetID   int64      `json:"target_id" db:"target_id"`
+	Reason     string     `json:"reason" db:"reason"`
+	Status     string     `json:"status" db:"status"`
+	ResolvedBy *int64     `json:"resolved_by,omitempty" db:"resolved_by"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+// ReportListResponse represents the response payload for listing reports
+type ReportListResponse struct {
+	Items   []Report `json:"items"`
+	Cursor  string   `json:"cursor,omitempty"`
+	HasMore bool     `json:"has_more"`
+}
+
+// CreateReportRequest represents the request payload for reporting content
+type CreateReportRequest struct {
+	TargetType string `json:"target_type" validate:"required,oneof=post comment account"`
+	TargetID   int64  `json:"target_id" validate:"required"`
+	Reason     string `json:"reason" validate:"required,max=1000"`
+}
+
+// UpdateReportRequest represents the request payload for reviewing a report
+type UpdateReportRequest struct {
+	Status string `json:"status" validate:"required,oneof=resolved dismissed"`
+}
+
+// Stats represents service-wide counters for the admin dashboard
+type Stats struct {
+	Accounts            int64 `json:"accounts"`
+	ActiveAccounts      int64 `json:"active_accounts"`
+	DeactivatedAccounts int64 `json:"deactivated_accounts"`
+	NewAccounts24h      int64 `json:"new_accounts_24h"`
+	Posts               int64 `json:"posts"`
+	NewPosts24h         int64 `json:"new_posts_24h"`
+	Comments            int64 `json:"comments"`
+	Messages            int64 `json:"messages"`
+	ActiveStories       int64 `json:"active_stories"`
+	ActiveSessions      int64 `json:"active_sessions"`
+	OpenReports         int64 `json:"open_reports"`
+}
+
+// AdminRepository defines the interface for admin data access
+type AdminRepository interface {
+	ListAccounts(ctx context.Context, filter AccountFilter) (*AccountListResponse, error)
+	// DeletePost soft deletes any post and returns its image path; sql.ErrNoRows if not found
+	DeletePost(ctx context.Context, id int64) (string, error)
+	// DeleteComment soft deletes any comment; sql.ErrNoRows if not found
+	DeleteComment(ctx context.Context, id int64) error
+	TargetExists(ctx context.Context, targetType string, id int64) (bool, error)
+	// CreateReport stores a report; sql.ErrNoRows if the reporter already has an open report on the target
+	CreateReport(ctx context.Context, report *Report) error
+	ListReports(ctx context.Context, status, targetType, cursor string, limit int) (*ReportListResponse, error)
+	// UpdateReportStatus closes an open report; sql.ErrNoRows if it is not open or does not exist
+	UpdateReportStatus(ctx context.Context, id int64, status string, adminID int64) (*Report, error)
+	// ResolveReports resolves every open report on a target
+	ResolveReports(ctx context.Context, targetType string, targetID int64, adminID int64) error
+	GetStats(ctx context.Context) (*Stats, error)
+}
+
+// AdminService defines the interface for admin business logic
+type AdminService interface {
+	ListAccounts(ctx context.Context, filter AccountFilter) (*AccountListResponse, error)
+	DeletePost(ctx context.Context, adminID, id int64) error
+	DeleteComment(ctx context.Context, adminID, id int64) error
+	CreateReport(ctx context.Context, reporterID int64, req *CreateReportRequest) (*Report, error)
+	ListReports(ctx context.Context, status, targetType, cursor string, limit int) (*ReportListResponse, error)
+	UpdateReport(ctx context.Context, adminID, id int64, req *UpdateReportRequest) (*Report, error)
+	GetStats(ctx context.Context) (*Stats, error)
+}
@@ -0,0 +1,141 @@
+// Package genhttp provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package genhttp
+
+import (
+	"time"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for CreateReportRequestTargetType.
+const (
+	CreateReportRequestTargetTypeAccount CreateReportRequestTargetType = "account"
+	CreateReportRequestTargetTypeComment CreateReportRequestTargetType = "comment"
+	CreateReportRequestTargetTypePost    CreateReportRequestTargetType = "post"
+)
+
+// Defines values for StandardResponseCode.
+const (
+	BADREQUEST          StandardResponseCode = "BAD_REQUEST"
+	CONFLICT            StandardResponseCode = "CONFLICT"
+	FAILED              StandardResponseCode = "FAILED"
+	FORBIDDEN           StandardResponseCode = "FORBIDDEN"
+	INTERNALSERVERERROR StandardResponseCode = "INTERNAL_SERVER_ERROR"
+	NOTFOUND            StandardResponseCode = "NOT_FOUND"
+	SUCCESS             StandardResponseCode = "SUCCESS"
+	UNAUTHORIZED        StandardResponseCode = "UNAUTHORIZED"
+)
+
+// Defines values for UpdateReportRequestStatus.
+const (
+	UpdateReportRequestStatusDismissed UpdateReportRequestStatus = "dismissed"
+	UpdateReportRequestStatusResolved  UpdateReportRequestStatus = "resolved"
+)
+
+// Defines values for GetApiAdminAccountsParamsRole.
+const (
+	Admin     GetApiAdminAccountsParamsRole = "admin"
+	Moderator GetApiAdminAccountsParamsRole = "moderator"
+	User      GetApiAdminAccountsParamsRole = "user"
+)
+
+// Defines values for GetApiAdminAccountsParamsStatus.
+const (
+	Active      GetApiAdminAccountsParamsStatus = "active"
+	Deactivated GetApiAdminAccountsParamsStatus = "deactivated"
+)
+
+// Defines values for GetApiAdminReportsParamsStatus.
+const (
+	GetApiAdminReportsParamsStatusDismissed GetApiAdminReportsParamsStatus = "dismissed"
+	GetApiAdminReportsParamsStatusOpen      GetApiAdminReportsParamsStatus = "open"
+	GetApiAdminReportsParamsStatusResolved  GetApiAdminReportsParamsStatus = "resolved"
+)
+
+// Defines values for GetApiAdminReportsParamsTargetType.
+const (
+	GetApiAdminReportsParamsTargetTypeAccount GetApiAdminReportsParamsTargetType = "account"
+	GetApiAdminReportsParamsTargetTypeComment GetApiAdminReportsParamsTargetType = "comment"
+	GetApiAdminReportsParamsTargetTypePost    GetApiAdminReportsParamsTargetType = "post"
+)
+
+// CreateReportRequest defines model for CreateReportRequest.
+type CreateReportRequest struct {
+	Reason     string                        `json:"reason"`
+	TargetId   int64                         `json:"target_id"`
+	TargetType CreateReportRequestTargetType `json:"target_type"`
+}
+
+// CreateReportRequestTargetType defines model for CreateReportRequest.TargetType.
+type CreateReportRequestTargetType string
+
+// StandardResponse defines model for StandardResponse.
+type StandardResponse struct {
+	Code *StandardResponseCode `json:"code,omitempty"`
+
+	// Data Response data (varies by endpoint)
+	Data       *map[string]interface{} `json:"data,omitempty"`
+	Errors     *[]string               `json:"errors,omitempty"`
+	Message    *string                 `json:"message,omitempty"`
+	RequestId  *string                 `json:"requestId,omitempty"`
+	ServerTime *time.Time              `json:"serverTime,omitempty"`
+}
+
+// StandardResponseCode defines model for StandardResponse.Code.
+type StandardResponseCode string
+
+// UpdateReportRequest defines model for UpdateReportRequest.
+type UpdateReportRequest struct {
+	Status UpdateReportRequestStatus `json:"status"`
+}
+
+// UpdateReportRequestStatus defines model for UpdateReportRequest.Status.
+type UpdateReportRequestStatus string
+
+// GetApiAdminAccountsParams defines parameters for GetApiAdminAccounts.
+type GetApiAdminAccountsParams struct {
+	// Q Case-insensitive match on name or email
+	Q      *string                          `form:"q,omitempty" json:"q,omitempty"`
+	Role   *GetApiAdminAccountsParamsRole   `form:"role,omitempty" json:"role,omitempty"`
+	Status *GetApiAdminAccountsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Cursor Cursor for pagination
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Number of items to return (max 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetApiAdminAccountsParamsRole defines parameters for GetApiAdminAccounts.
+type GetApiAdminAccountsParamsRole string
+
+// GetApiAdminAccountsParamsStatus defines parameters for GetApiAdminAccounts.
+type GetApiAdminAccountsParamsStatus string
+
+// GetApiAdminReportsParams defines parameters for GetApiAdminReports.
+type GetApiAdminReportsParams struct {
+	Status     *GetApiAdminReportsParamsStatus     `form:"status,omitempty" json:"status,omitempty"`
+	TargetType *GetApiAdminReportsParamsTargetType `form:"target_type,omitempty" json:"target_type,omitempty"`
+
+	// Cursor Cursor for pagination
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Number of items to return (max 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetApiAdminReportsParamsStatus defines parameters for GetApiAdminReports.
+type GetApiAdminReportsParamsStatus string
+
+// GetApiAdminReportsParamsTargetType defines parameters for GetApiAdminReports.
+type GetApiAdminReportsParamsTargetType string
+
+// PutApiAdminReportsIdJSONRequestBody defines body for PutApiAdminReportsId for application/json ContentType.
+type PutApiAdminReportsIdJSONRequestBody = UpdateReportRequest
+
+// PostApiReportsJSONRequestBody defines body for PostApiReports for application/json ContentType.
+type PostApiReportsJSONRequestBody = CreateReportRequest
@@ -0,0 +1,188 @@
+package port
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fanzru/social-media-service-go/internal/app/admin"
+	"github.com/fanzru/social-media-service-go/internal/app/admin/port/genhttp"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"github.com/fanzru/social-media-service-go/pkg/response"
+)
+
+// Handler handles HTTP requests for administration and content reports
+type Handler struct {
+	service admin.AdminService
+}
+
+// NewHandler creates a new admin handler
+func NewHandler(service admin.AdminService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// GetApiAdminAccounts handles GET /api/admin/accounts
+func (h *Handler) GetApiAdminAccounts(w http.ResponseWriter, r *http.Request, params genhttp.GetApiAdminAccountsParams) {
+	ctx := r.Context()
+
+	filter := admin.AccountFilter{Limit: 20}
+	if params.Q != nil {
+		filter.Query = *params.Q
+	}
+	if params.Role != nil {
+		filter.Role = string(*params.Role)
+	}
+	if params.Status != nil {
+		filter.Status = string(*params.Status)
+	}
+	if params.Cursor != nil {
+		filter.Cursor = *params.Cursor
+	}
+	if params.Limit != nil {
+		filter.Limit = *params.Limit
+	}
+
+	accounts, err := h.service.ListAccounts(ctx, filter)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to list accounts", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	response.Success(ctx, "Accounts retrieved successfully", accounts).Send(w, http.StatusOK)
+}
+
+// DeleteApiAdminPostsId handles DELETE /api/admin/posts/{id}
+func (h *Handler) DeleteApiAdminPostsId(w http.ResponseWriter, r *http.Request, id int64) {
+	ctx := r.Context()
+
+	adminID, exists := middleware.GetUserID(ctx)
+	if !exists || adminID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.DeletePost(ctx, adminID, id); err != nil {
+		response.SendError(ctx, w, err, "Failed to delete post")
+		return
+	}
+
+	response.Success(ctx, "Post deleted successfully", nil).Send(w, http.StatusOK)
+}
+
+// DeleteApiAdminCommentsId handles DELETE /api/admin/comments/{id}
+func (h *Handler) DeleteApiAdminCommentsId(w http.ResponseWriter, r *http.Request, id int64) {
+	ctx := r.Context()
+
+	adminID, exists := middleware.GetUserID(ctx)
+	if !exists || adminID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.DeleteComment(ctx, adminID, id); err != nil {
+		response.SendError(ctx, w, err, "Failed to delete comment")
+		return
+	}
+
+	response.Success(ctx, "Comment deleted successfully", nil).Send(w, http.StatusOK)
+}
+
+// GetApiAdminReports handles GET /api/admin/reports
+func (h *Handler) GetApiAdminReports(w http.ResponseWriter, r *http.Request, params genhttp.GetApiAdminReportsParams) {
+	ctx := r.Context()
+
+	status := admin.ReportOpen
+	if params.Status != nil {
+		status = string(*params.Status)
+	}
+
+	targetType := ""
+	if params.TargetType != nil {
+		targetType = string(*params.TargetType)
+	}
+
+	cursor := ""
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	reports, err := h.service.ListReports(ctx, status, targetType, cursor, limit)
+	if err != nil {
+		response.SendError(ctx, w, err, "Failed to list reports")
+		return
+	}
+
+	response.Success(ctx, "Reports retrieved successfully", reports).Send(w, http.StatusOK)
+}
+
+// PutApiAdminReportsId handles PUT /api/admin/reports/{id}
+func (h *Handler) PutApiAdminReportsId(w http.ResponseWriter, r *http.Request, id int64) {
+	ctx := r.Context()
+
+	adminID, exists := middleware.GetUserID(ctx)
+	if !exists || adminID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	var req admin.UpdateReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(ctx, "Invalid request body", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.UpdateReport(ctx, adminID, id, &req)
+	if err != nil {
+		response.SendError(ctx, w, err, "Failed to update report")
+		return
+	}
+
+	response.Success(ctx, "Report updated successfully", report).Send(w, http.StatusOK)
+}
+
+// GetApiAdminStats handles GET /api/admin/stats
+func (h *Handler) GetApiAdminStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	stats, err := h.service.GetStats(ctx)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to get stats", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	response.Success(ctx, "Stats retrieved successfully", stats).Send(w, http.StatusOK)
+}
+
+// PostApiReports handles POST /api/reports
+func (h *Handler) PostApiReports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, exists := middleware.GetUserID(ctx)
+	if !exists || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	var req admin.CreateReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(ctx, "Invalid request body", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.CreateReport(ctx, userID, &req)
+	if err != nil {
+		response.SendError(ctx, w, err, "Failed to submit report")
+		return
+	}
+
+	response.Success(ctx, "Report submitted successfully", report).Send(w, http.StatusCreated)
+}
+
+// Implement the generated interface
+var _ genhttp.ServerInterface = (*Handler)(nil)
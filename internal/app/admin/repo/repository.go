@@ -0,0 +1,326 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/admin"
+	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
+)
+
+// reportColumns lists the reports columns scanned by scanReport, in order
+const reportColumns = `id, reporter_id, target_type, target_id, reason, status, resolved_by, created_at, resolved_at`
+
+// Repository implements admin repository interface
+type Repository struct {
+	db interface{} // Can be *sql.DB or *sqlwrap.DB
+}
+
+// NewRepository creates a new admin repository
+func NewRepository(db interface{}) *Repository {
+	return &Repository{db: db}
+}
+
+// ListAccounts lists accounts newest first, optionally filtered by name/email, role and status.
+// Soft-deleted accounts are left out.
+func (r *Repository) ListAccounts(ctx context.Context, filter admin.AccountFilter) (*admin.AccountListResponse, error) {
+	limit := filter.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	query := `
+		SELECT a.id, a.name, a.email, a.role, a.is_verified, a.status, a.last_login_at, a.last_seen_at, a.created_at,
+			(SELECT COUNT(*) FROM posts p WHERE p.creator_id = a.id AND p.deleted_at IS NULL) AS post_count,
+			(SELECT COUNT(*) FROM comments c WHERE c.creator_id = a.id AND c.deleted_at IS NULL) AS comment_count
+		FROM accounts a
+		WHERE a.deleted_at IS NULL
+	`
+	args := []interface{}{}
+
+	if filter.Query != "" {
+		args = append(args, "%"+escapeLike(strings.ToLower(filter.Query))+"%")
+		n := len(args)
+		query += fmt.Sprintf(` AND (lower(a.name) LIKE $%d ESCAPE '\' OR lower(a.email) LIKE $%d ESCAPE '\')`, n, n)
+	}
+	if filter.Role != "" {
+		args = append(args, filter.Role)
+		query += fmt.Sprintf(` AND a.role = $%d`, len(args))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		query += fmt.Sprintf(` AND a.status = $%d`, len(args))
+	}
+	if filter.Cursor != "" {
+		if id, err := decodeIDCursor(filter.Cursor); err == nil {
+			args = append(args, id)
+			query += fmt.Sprintf(` AND a.id < $%d`, len(args))
+		}
+	}
+
+	args = append(args, limit+1) // Get one extra to check if there are more
+	query += fmt.Sprintf(` ORDER BY a.id DESC LIMIT $%d`, len(args))
+
+	rows, err := r.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []admin.AccountSummary{}
+	for rows.Next() {
+		var a admin.AccountSummary
+		if err := rows.Scan(&a.ID, &a.Name, &a.Email, &a.Role, &a.IsVerified, &a.Status, &a.LastLoginAt, &a.LastSeenAt, &a.CreatedAt, &a.PostCount, &a.CommentCount); err != nil {
+			return nil, err
+		}
+		items = append(items, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	var nextCursor string
+	if hasMore && len(items) > 0 {
+		nextCursor = encodeIDCursor(items[len(items)-1].ID)
+	}
+
+	return &admin.AccountListResponse{
+		Items:   items,
+		Cursor:  nextCursor,
+		HasMore: hasMore,
+	}, nil
+}
+
+// DeletePost soft deletes a post regardless of its creator and returns its image path
+func (r *Repository) DeletePost(ctx context.Context, id int64) (string, error) {
+	query := `UPDATE posts SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL RETURNING image_path`
+
+	var imagePath string
+	err := r.queryRow(ctx, query, time.Now(), id).Scan(&imagePath)
+	return imagePath, err
+}
+
+// DeleteComment soft deletes a comment regardless of its creator
+func (r *Repository) DeleteComment(ctx context.Context, id int64) error {
+	query := `UPDATE comments SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL RETURNING id`
+
+	var deletedID int64
+	return r.queryRow(ctx, query, time.Now(), id).Scan(&deletedID)
+}
+
+// TargetExists reports whether a reportable post, comment or account exists
+func (r *Repository) TargetExists(ctx context.Context, targetType string, id int64) (bool, error) {
+	var table string
+	switch targetType {
+	case admin.TargetPost:
+		table = "posts"
+	case admin.TargetComment:
+		table = "comments"
+	case admin.TargetAccount:
+		table = "accounts"
+	default:
+		return false, nil
+	}
+
+	query := `SELECT EXISTS (SELECT 1 FROM ` + table + ` WHERE id = $1 AND deleted_at IS NULL)`
+
+	var exists bool
+	err := r.queryRow(ctx, query, id).Scan(&exists)
+	return exists, err
+}
+
+// CreateReport stores an open report and sets its ID and creation time
+func (r *Repository) CreateReport(ctx context.Context, report *admin.Report) error {
+	query := `
+		INSERT INTO reports (reporter_id, target_type, target_id, reason, status, created_at)
+		VALUES ($1, $2, $3, $4, 'open', $5)
+		ON CONFLICT (reporter_id, target_type, target_id) WHERE status = 'open' DO NOTHING
+		RETURNING id, status, created_at`
+
+	return r.queryRow(ctx, query, report.ReporterID, report.TargetType, report.TargetID, report.Reason, time.Now()).
+		Scan(&report.ID, &report.Status, &report.CreatedAt)
+}
+
+// ListReports lists reports oldest first so the queue is worked in arrival order
+func (r *Repository) ListReports(ctx context.Context, status, targetType, cursor string, limit int) (*admin.ReportListResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	query := `SELECT ` + reportColumns + ` FROM reports WHERE status = $1`
+	args := []interface{}{status}
+
+	if targetType != "" {
+		args = append(args, targetType)
+		query += fmt.Sprintf(` AND target_type = $%d`, len(args))
+	}
+	if cursor != "" {
+		if id, err := decodeIDCursor(cursor); err == nil {
+			args = append(args, id)
+			query += fmt.Sprintf(` AND id > $%d`, len(args))
+		}
+	}
+
+	args = append(args, limit+1) // Get one extra to check if there are more
+	query += fmt.Sprintf(` ORDER BY id ASC LIMIT $%d`, len(args))
+
+	rows, err := r.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []admin.Report{}
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	var nextCursor string
+	if hasMore && len(items) > 0 {
+		nextCursor = encodeIDCursor(items[len(items)-1].ID)
+	}
+
+	return &admin.ReportListResponse{
+		Items:   items,
+		Cursor:  nextCursor,
+		HasMore: hasMore,
+	}, nil
+}
+
+// UpdateReportStatus closes an open report
+func (r *Repository) UpdateReportStatus(ctx context.Context, id int64, status string, adminID int64) (*admin.Report, error) {
+	query := `
+		UPDATE reports
+		SET status = $2, resolved_by = $3, resolved_at = $4
+		WHERE id = $1 AND status = 'open'
+		RETURNING ` + reportColumns
+
+	return scanReport(r.queryRow(ctx, query, id, status, adminID, time.Now()))
+}
+
+// ResolveReports resolves the open reports on a target, e.g. after it was removed
+func (r *Repository) ResolveReports(ctx context.Context, targetType string, targetID int64, adminID int64) error {
+	query := `
+		UPDATE reports
+		SET status = 'resolved', resolved_by = $3, resolved_at = $4
+		WHERE target_type = $1 AND target_id = $2 AND status = 'open'`
+
+	return r.exec(ctx, query, targetType, targetID, adminID, time.Now())
+}
+
+// GetStats counts the main entities of the service in one round trip
+func (r *Repository) GetStats(ctx context.Context) (*admin.Stats, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM accounts WHERE deleted_at IS NULL),
+			(SELECT COUNT(*) FROM accounts WHERE deleted_at IS NULL AND status = 'active'),
+			(SELECT COUNT(*) FROM accounts WHERE deleted_at IS NULL AND status = 'deactivated'),
+			(SELECT COUNT(*) FROM accounts WHERE deleted_at IS NULL AND created_at > $1),
+			(SELECT COUNT(*) FROM posts WHERE deleted_at IS NULL),
+			(SELECT COUNT(*) FROM posts WHERE deleted_at IS NULL AND created_at > $1),
+			(SELECT COUNT(*) FROM comments WHERE deleted_at IS NULL),
+			(SELECT COUNT(*) FROM messages WHERE deleted_at IS NULL),
+			(SELECT COUNT(*) FROM stories WHERE expires_at > $2),
+			(SELECT COUNT(*) FROM sessions WHERE revoked_at IS NULL),
+			(SELECT COUNT(*) FROM reports WHERE status = 'open')`
+
+	now := time.Now()
+	var s admin.Stats
+	err := r.queryRow(ctx, query, now.Add(-24*time.Hour), now).Scan(
+		&s.Accounts, &s.ActiveAccounts, &s.DeactivatedAccounts, &s.NewAccounts24h,
+		&s.Posts, &s.NewPosts24h, &s.Comments, &s.Messages,
+		&s.ActiveStories, &s.ActiveSessions, &s.OpenReports,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// scanReport scans a row selected with reportColumns
+func scanReport(row interface {
+	Scan(dest ...interface{}) error
+}) (*admin.Report, error) {
+	var report admin.Report
+	err := row.Scan(&report.ID, &report.ReporterID, &report.TargetType, &report.TargetID, &report.Reason,
+		&report.Status, &report.ResolvedBy, &report.CreatedAt, &report.ResolvedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// query runs a statement that returns rows
+func (r *Repository) query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if db, ok := r.db.(*sql.DB); ok {
+		return db.QueryContext(ctx, query, args...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		return db.QueryContext(ctx, query, args...)
+	}
+	return nil, fmt.Errorf("unsupported database type %T", r.db)
+}
+
+// queryRow runs a statement that returns at most one row
+func (r *Repository) queryRow(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	var row *sql.Row
+	if db, ok := r.db.(*sql.DB); ok {
+		row = db.QueryRowContext(ctx, query, args...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		row = db.QueryRowContext(ctx, query, args...)
+	}
+	return row
+}
+
+// exec runs a statement that returns no rows
+func (r *Repository) exec(ctx context.Context, query string, args ...interface{}) error {
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		_, err = db.ExecContext(ctx, query, args...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		_, err = db.ExecContext(ctx, query, args...)
+	}
+	return err
+}
+
+// escapeLike escapes LIKE wildcards so user input is matched literally
+func escapeLike(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(s)
+}
+
+// encodeIDCursor creates an opaque cursor from a row ID
+func encodeIDCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+// decodeIDCursor parses a cursor created by encodeIDCursor
+func decodeIDCursor(cursor string) (int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(b), 10, 64)
+}
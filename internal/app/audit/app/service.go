@@ -0,0 +1,122 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/audit"
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+)
+
+// maxBatchSize is the most entries written in one insert
+const maxBatchSize = 100
+
+// Service records audit entries in the background and lists them for admins.
+// It implements middleware.AuditRecorder.
+type Service struct {
+	repo    audit.AuditRepository
+	entries chan audit.Entry
+	dropped atomic.Int64 // Entries dropped since last reported
+}
+
+// NewService creates a new audit service buffering up to bufferSize entries waiting to be
+// written; entries recorded while the buffer is full are dropped
+func NewService(repo audit.AuditRepository, bufferSize int) *Service {
+	if bufferSize <= 0 {
+		bufferSize = 1000
+	}
+	return &Service{
+		repo:    repo,
+		entries: make(chan audit.Entry, bufferSize),
+	}
+}
+
+// Record queues an audit entry to be written by Run, without waiting for the database
+func (s *Service) Record(ctx context.Context, entry middleware.AuditEntry) {
+	e := audit.Entry{
+		ActorID:    entry.ActorID,
+		Method:     entry.Method,
+		Path:       entry.Path,
+		Route:      entry.Route,
+		Entity:     entry.Entity,
+		EntityID:   entry.EntityID,
+		StatusCode: entry.Status,
+		Before:     entry.Before,
+		After:      entry.After,
+		RequestID:  entry.RequestID,
+		CreatedAt:  entry.CreatedAt,
+	}
+
+	select {
+	case s.entries <- e:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// Run writes queued entries in batches until the context is cancelled, then writes those
+// still queued. Entries are written at least every flushInterval, 1s when it is 0 or less.
+func (s *Service) Run(ctx context.Context, flushInterval time.Duration) {
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+
+	log := logger.GetGlobal()
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]audit.Entry, 0, maxBatchSize)
+	flush := func() {
+		if dropped := s.dropped.Swap(0); dropped > 0 {
+			log.Warn("Audit entries dropped, buffer full", "count", dropped)
+		}
+		if len(batch) == 0 {
+			return
+		}
+		// Write with a context of its own, so entries queued at shutdown are still written
+		writeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := s.repo.Insert(writeCtx, batch); err != nil {
+			log.Error("Failed to write audit entries", "count", len(batch), "error", err.Error())
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case e := <-s.entries:
+					batch = append(batch, e)
+					if len(batch) >= maxBatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		case e := <-s.entries:
+			batch = append(batch, e)
+			if len(batch) >= maxBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// List lists audit entries, newest first
+func (s *Service) List(ctx context.Context, filter audit.Filter) (*audit.EntryListResponse, error) {
+	entries, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Entry is a successful mutating API call kept in the audit trail
+type Entry struct {
+	ID         int64           `json:"id" db:"id"`
+	ActorID    *int64          `json:"actor_id,omitempty" db:"actor_id"` // Caller; nil for anonymous calls such as registration
+	Method     string          `json:"method" db:"method"`
+	Path       string          `json:"path" db:"path"`
+	Route      string          `json:"route" db:"route"` // Path with IDs replaced by {id}
+	Entity     string          `json:"entity" db:"entity"`
+	EntityID   *int64          `json:"entity_id,omitempty" db:"entity_id"`
+	StatusCode int             `json:"status_code" db:"status_code"`
+	Before     json.RawMessage `json:"before,omitempty" db:"before"` // Resource before the call
+	After      json.RawMessage `json:"after,omitempty" db:"after"`   // Data of the call's response
+	RequestID  string          `json:"request_id,omitempty" db:"request_id"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
+
+// Filter narrows an audit trail listing
+type Filter struct {
+	ActorID  *int64
+	Entity   string
+	EntityID *int64
+	Cursor   string
+	Limit    int
+}
+
+// EntryListResponse represents the response payload for listing audit entries
+type EntryListResponse struct {
+	Items   []Entry `json:"items"`
+	Cursor  string  `json:"cursor,omitempty"`
+	HasMore bool    `json:"has_more"`
+}
+
+// AuditRepository defines the interface for audit trail data access
+type AuditRepository interface {
+	Insert(ctx context.Context, entries []Entry) error
+	List(ctx context.Context, filter Filter) (*EntryListResponse, error)
+}
+
+// AuditService defines the interface for audit trail business logic
+type AuditService interface {
+	List(ctx context.Context, filter Filter) (*EntryListResponse, error)
+}
@@ -0,0 +1,52 @@
+package port
+
+import (
+	"net/http"
+
+	"github.com/fanzru/social-media-service-go/internal/app/audit"
+	"github.com/fanzru/social-media-service-go/internal/app/audit/port/genhttp"
+	"github.com/fanzru/social-media-service-go/pkg/response"
+)
+
+// Handler handles HTTP requests for the audit trail
+type Handler struct {
+	service audit.AuditService
+}
+
+// NewHandler creates a new audit handler
+func NewHandler(service audit.AuditService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// GetApiAdminAuditLogs handles GET /api/admin/audit-logs
+func (h *Handler) GetApiAdminAuditLogs(w http.ResponseWriter, r *http.Request, params genhttp.GetApiAdminAuditLogsParams) {
+	ctx := r.Context()
+
+	filter := audit.Filter{
+		ActorID:  params.ActorId,
+		EntityID: params.EntityId,
+		Limit:    20,
+	}
+	if params.Entity != nil {
+		filter.Entity = *params.Entity
+	}
+	if params.Cursor != nil {
+		filter.Cursor = *params.Cursor
+	}
+	if params.Limit != nil {
+		filter.Limit = *params.Limit
+	}
+
+	entries, err := h.service.List(ctx, filter)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to list audit logs", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	response.Success(ctx, "Audit logs retrieved successfully", entries).Send(w, http.StatusOK)
+}
+
+// Implement the generated interface
+var _ genhttp.ServerInterface = (*Handler)(nil)
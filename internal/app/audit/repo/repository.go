@@ -0,0 +1,158 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fanzru/social-media-service-go/internal/app/audit"
+	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
+)
+
+// entryColumns lists the audit_logs columns scanned by List, in order
+const entryColumns = `id, actor_id, method, path, route, entity, entity_id, status_code, before, after, request_id, created_at`
+
+// Repository implements audit repository interface
+type Repository struct {
+	db interface{} // Can be *sql.DB or *sqlwrap.DB
+}
+
+// NewRepository creates a new audit repository
+func NewRepository(db interface{}) *Repository {
+	return &Repository{db: db}
+}
+
+// Insert writes audit entries in one statement
+func (r *Repository) Insert(ctx context.Context, entries []audit.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	const columns = 11
+	values := make([]string, 0, len(entries))
+	args := make([]interface{}, 0, len(entries)*columns)
+	for i, e := range entries {
+		placeholders := make([]string, columns)
+		for j := range placeholders {
+			placeholders[j] = fmt.Sprintf("$%d", i*columns+j+1)
+		}
+		values = append(values, "("+strings.Join(placeholders, ", ")+")")
+		args = append(args, e.ActorID, e.Method, e.Path, e.Route, e.Entity, e.EntityID, e.StatusCode,
+			nullJSON(e.Before), nullJSON(e.After), e.RequestID, e.CreatedAt)
+	}
+
+	query := `
+		INSERT INTO audit_logs (actor_id, method, path, route, entity, entity_id, status_code, before, after, request_id, created_at)
+		VALUES ` + strings.Join(values, ", ")
+
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		_, err = db.ExecContext(ctx, query, args...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		_, err = db.ExecContext(ctx, query, args...)
+	}
+	return err
+}
+
+// List lists audit entries newest first, optionally filtered by actor and entity
+func (r *Repository) List(ctx context.Context, filter audit.Filter) (*audit.EntryListResponse, error) {
+	limit := filter.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	query := `SELECT ` + entryColumns + ` FROM audit_logs WHERE TRUE`
+	args := []interface{}{}
+
+	if filter.ActorID != nil {
+		args = append(args, *filter.ActorID)
+		query += fmt.Sprintf(` AND actor_id = $%d`, len(args))
+	}
+	if filter.Entity != "" {
+		args = append(args, filter.Entity)
+		query += fmt.Sprintf(` AND entity = $%d`, len(args))
+	}
+	if filter.EntityID != nil {
+		args = append(args, *filter.EntityID)
+		query += fmt.Sprintf(` AND entity_id = $%d`, len(args))
+	}
+	if filter.Cursor != "" {
+		if id, err := decodeIDCursor(filter.Cursor); err == nil {
+			args = append(args, id)
+			query += fmt.Sprintf(` AND id < $%d`, len(args))
+		}
+	}
+
+	args = append(args, limit+1) // Get one extra to check if there are more
+	query += fmt.Sprintf(` ORDER BY id DESC LIMIT $%d`, len(args))
+
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, args...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, args...)
+	} else {
+		return nil, fmt.Errorf("unsupported database type %T", r.db)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []audit.Entry{}
+	for rows.Next() {
+		var e audit.Entry
+		var before, after []byte
+		var requestID sql.NullString
+		if err := rows.Scan(&e.ID, &e.ActorID, &e.Method, &e.Path, &e.Route, &e.Entity, &e.EntityID, &e.StatusCode, &before, &after, &requestID, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Before, e.After, e.RequestID = before, after, requestID.String
+		items = append(items, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	var nextCursor string
+	if hasMore && len(items) > 0 {
+		nextCursor = encodeIDCursor(items[len(items)-1].ID)
+	}
+
+	return &audit.EntryListResponse{
+		Items:   items,
+		Cursor:  nextCursor,
+		HasMore: hasMore,
+	}, nil
+}
+
+// nullJSON passes a JSON snapshot to the database, or NULL when there is none
+func nullJSON(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
+
+// encodeIDCursor creates an opaque cursor from a row ID
+func encodeIDCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+// decodeIDCursor parses a cursor created by encodeIDCursor
+func decodeIDCursor(cursor string) (int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(b), 10, 64)
+}
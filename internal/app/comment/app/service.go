@@ -2,23 +2,37 @@ package app
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/fanzru/social-media-service-go/internal/app/comment"
 	"github.com/fanzru/social-media-service-go/internal/app/post"
+	"github.com/fanzru/social-media-service-go/pkg/eventbus"
+	"github.com/fanzru/social-media-service-go/pkg/metrics"
+	"github.com/fanzru/social-media-service-go/pkg/pagination"
+	"github.com/fanzru/social-media-service-go/pkg/realtime"
 )
 
 // Service implements comment service interface
 type Service struct {
 	repo     comment.CommentRepository
 	postRepo post.PostRepository
+	events   realtime.Publisher
+	changes  eventbus.Publisher
+	metrics  metrics.Sink
 }
 
-// NewService creates a new comment service
-func NewService(repo comment.CommentRepository, postRepo post.PostRepository) *Service {
+// NewService creates a new comment service; events may be nil to disable real-time updates,
+// changes may be nil when nothing subscribes to comment changes, and sink, which counts
+// created comments, may be nil
+func NewService(repo comment.CommentRepository, postRepo post.PostRepository, events realtime.Publisher, changes eventbus.Publisher, sink metrics.Sink) *Service {
 	return &Service{
 		repo:     repo,
 		postRepo: postRepo,
+		events:   events,
+		changes:  changes,
+		metrics:  sink,
 	}
 }
 
@@ -26,13 +40,27 @@ func NewService(repo comment.CommentRepository, postRepo post.PostRepository) *S
 func (s *Service) CreateComment(ctx context.Context, req *comment.CreateCommentRequest, creatorID int64) (*comment.Comment, error) {
 	// Validate content
 	if err := s.validateContent(req.Content); err != nil {
-		return nil, fmt.Errorf("invalid content: %w", err)
+		return nil, fmt.Errorf("%w: %w", comment.ErrInvalidContent, err)
 	}
 
-	// Check if post exists
-	_, err := s.postRepo.GetByID(ctx, req.PostID)
+	// Check if post exists and is visible to the commenter
+	p, err := s.postRepo.GetByID(ctx, req.PostID, creatorID)
 	if err != nil {
-		return nil, fmt.Errorf("post not found: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, post.ErrPostNotFound
+		}
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+
+	// Respect the post creator's comment permission setting
+	if p.CreatorID != creatorID {
+		allowed, err := s.repo.CanComment(ctx, p.CreatorID, creatorID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check comment permission: %w", err)
+		}
+		if !allowed {
+			return nil, comment.ErrCommentsRestricted
+		}
 	}
 
 	// Create comment
@@ -46,29 +74,57 @@ func (s *Service) CreateComment(ctx context.Context, req *comment.CreateCommentR
 	if err := s.repo.Create(ctx, newComment); err != nil {
 		return nil, fmt.Errorf("failed to create comment: %w", err)
 	}
+	s.publish(ctx, eventbus.CommentCreated, newComment)
+	metrics.CountEvent(s.metrics, metrics.CommentsCreated, nil)
+
+	// Push the comment to viewers of the post and notify its creator
+	if s.events != nil {
+		s.events.PublishToPost(p.ID, realtime.EventCommentCreated, newComment)
+		if p.CreatorID != creatorID {
+			s.events.PublishToAccount(p.CreatorID, realtime.EventNotification, realtime.Notification{
+				Kind:      realtime.NotificationComment,
+				ActorID:   creatorID,
+				PostID:    p.ID,
+				CommentID: newComment.ID,
+			})
+		}
+	}
 
 	return newComment, nil
 }
 
 // GetComment retrieves a comment by ID
 func (s *Service) GetComment(ctx context.Context, id int64) (*comment.Comment, error) {
-	comment, err := s.repo.GetByID(ctx, id)
+	fetched, err := s.repo.GetByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, comment.ErrCommentNotFound
+		}
 		return nil, fmt.Errorf("failed to get comment: %w", err)
 	}
 
-	return comment, nil
+	return fetched, nil
 }
 
 // GetPostComments retrieves comments for a specific post
-func (s *Service) GetPostComments(ctx context.Context, postID int64, cursor string, limit int) (*comment.CommentListResponse, error) {
-	// Check if post exists
-	_, err := s.postRepo.GetByID(ctx, postID)
+func (s *Service) GetPostComments(ctx context.Context, postID int64, viewerID int64, sort string, cursor string, limit int) (*comment.CommentListResponse, error) {
+	if sort == "" {
+		sort = comment.SortNewest
+	}
+	if !comment.IsValidSort(sort) {
+		return nil, fmt.Errorf("%w: %s", comment.ErrInvalidSort, sort)
+	}
+
+	// Check if post exists and is visible to the viewer
+	_, err := s.postRepo.GetByID(ctx, postID, viewerID)
 	if err != nil {
-		return nil, fmt.Errorf("post not found: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, post.ErrPostNotFound
+		}
+		return nil, fmt.Errorf("failed to get post: %w", err)
 	}
 
-	response, err := s.repo.GetByPostID(ctx, postID, cursor, limit)
+	response, err := s.repo.GetByPostID(ctx, postID, sort, cursor, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get post comments: %w", err)
 	}
@@ -86,22 +142,82 @@ func (s *Service) GetUserComments(ctx context.Context, creatorID int64, cursor s
 	return response, nil
 }
 
+// GetPostCommentsPage retrieves a page of a post's comments by page number, with the total
+// count, if the post is visible to the viewer
+func (s *Service) GetPostCommentsPage(ctx context.Context, postID int64, viewerID int64, sort string, page pagination.Params) (*comment.CommentPageResponse, error) {
+	if sort == "" {
+		sort = comment.SortNewest
+	}
+	if !comment.IsValidSort(sort) {
+		return nil, fmt.Errorf("%w: %s", comment.ErrInvalidSort, sort)
+	}
+
+	// Check if post exists and is visible to the viewer
+	_, err := s.postRepo.GetByID(ctx, postID, viewerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, post.ErrPostNotFound
+		}
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+
+	comments, total, err := s.repo.GetByPostIDPage(ctx, postID, sort, page.Offset(), page.PerPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post comments: %w", err)
+	}
+
+	return &comment.CommentPageResponse{Comments: comments, Pagination: pagination.NewInfo(page, total)}, nil
+}
+
+// GetUserCommentsPage retrieves a page of an account's comments by page number, with the
+// total count
+func (s *Service) GetUserCommentsPage(ctx context.Context, creatorID int64, page pagination.Params) (*comment.CommentPageResponse, error) {
+	comments, total, err := s.repo.GetByCreatorIDPage(ctx, creatorID, page.Offset(), page.PerPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user comments: %w", err)
+	}
+
+	return &comment.CommentPageResponse{Comments: comments, Pagination: pagination.NewInfo(page, total)}, nil
+}
+
+// CountPostComments counts a post's comments, for the total of a cursor listing whose
+// post was already checked to be visible
+func (s *Service) CountPostComments(ctx context.Context, postID int64) (int64, error) {
+	total, err := s.repo.CountByPostID(ctx, postID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count post comments: %w", err)
+	}
+	return total, nil
+}
+
+// CountUserComments counts an account's comments
+func (s *Service) CountUserComments(ctx context.Context, creatorID int64) (int64, error) {
+	total, err := s.repo.CountByCreatorID(ctx, creatorID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count user comments: %w", err)
+	}
+	return total, nil
+}
+
 // UpdateComment updates an existing comment
 func (s *Service) UpdateComment(ctx context.Context, id int64, req *comment.UpdateCommentRequest, creatorID int64) (*comment.Comment, error) {
 	// Get existing comment
 	existingComment, err := s.repo.GetByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, comment.ErrCommentNotFound
+		}
 		return nil, fmt.Errorf("failed to get comment: %w", err)
 	}
 
 	// Check if user owns the comment
 	if existingComment.CreatorID != creatorID {
-		return nil, fmt.Errorf("unauthorized: you can only update your own comments")
+		return nil, comment.ErrNotCommentOwner
 	}
 
 	// Validate content
 	if err := s.validateContent(req.Content); err != nil {
-		return nil, fmt.Errorf("invalid content: %w", err)
+		return nil, fmt.Errorf("%w: %w", comment.ErrInvalidContent, err)
 	}
 
 	// Update comment
@@ -109,6 +225,7 @@ func (s *Service) UpdateComment(ctx context.Context, id int64, req *comment.Upda
 	if err := s.repo.Update(ctx, existingComment); err != nil {
 		return nil, fmt.Errorf("failed to update comment: %w", err)
 	}
+	s.publish(ctx, eventbus.CommentUpdated, existingComment)
 
 	return existingComment, nil
 }
@@ -118,22 +235,33 @@ func (s *Service) DeleteComment(ctx context.Context, id int64, creatorID int64)
 	// Get existing comment
 	existingComment, err := s.repo.GetByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return comment.ErrCommentNotFound
+		}
 		return fmt.Errorf("failed to get comment: %w", err)
 	}
 
 	// Check if user owns the comment
 	if existingComment.CreatorID != creatorID {
-		return fmt.Errorf("unauthorized: you can only delete your own comments")
+		return comment.ErrNotCommentOwner
 	}
 
 	// Soft delete comment
 	if err := s.repo.SoftDelete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete comment: %w", err)
 	}
+	s.publish(ctx, eventbus.CommentDeleted, existingComment)
 
 	return nil
 }
 
+// publish announces a change to a comment, if anything subscribes to changes
+func (s *Service) publish(ctx context.Context, eventType string, c *comment.Comment) {
+	if s.changes != nil {
+		s.changes.Publish(ctx, eventbus.Event{Type: eventType, ID: c.ID, AccountID: c.CreatorID, PostID: c.PostID})
+	}
+}
+
 // GetLastComments gets the last N comments for a post
 func (s *Service) GetLastComments(ctx context.Context, postID int64, limit int) ([]comment.Comment, error) {
 	comments, err := s.repo.GetLastComments(ctx, postID, limit)
@@ -144,6 +272,54 @@ func (s *Service) GetLastComments(ctx context.Context, postID int64, limit int)
 	return comments, nil
 }
 
+// GetLatestCommentsForPosts gets the newest comments of several posts at once, keyed by post ID
+func (s *Service) GetLatestCommentsForPosts(ctx context.Context, postIDs []int64, limit int) (map[int64][]comment.Comment, error) {
+	comments, err := s.repo.GetLatestByPostIDs(ctx, postIDs, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// LikeComment records the account's like on a comment
+func (s *Service) LikeComment(ctx context.Context, id int64, accountID int64) error {
+	if err := s.ensureCommentExists(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Like(ctx, id, accountID); err != nil {
+		return fmt.Errorf("failed to like comment: %w", err)
+	}
+
+	return nil
+}
+
+// UnlikeComment removes the account's like from a comment
+func (s *Service) UnlikeComment(ctx context.Context, id int64, accountID int64) error {
+	if err := s.ensureCommentExists(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Unlike(ctx, id, accountID); err != nil {
+		return fmt.Errorf("failed to unlike comment: %w", err)
+	}
+
+	return nil
+}
+
+// ensureCommentExists returns ErrCommentNotFound when the comment is missing or deleted
+func (s *Service) ensureCommentExists(ctx context.Context, id int64) error {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return comment.ErrCommentNotFound
+		}
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	return nil
+}
+
 // validateContent validates the comment content
 func (s *Service) validateContent(content string) error {
 	if len(content) == 0 {
@@ -3,18 +3,49 @@ package comment
 import (
 	"context"
 	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
+	"github.com/fanzru/social-media-service-go/pkg/pagination"
+)
+
+// Errors returned by the comment service
+var (
+	ErrCommentNotFound    = apperr.NotFound("comment not found")
+	ErrNotCommentOwner    = apperr.Forbidden("you can only change your own comments")
+	ErrCommentsRestricted = apperr.Forbidden("comments are restricted on this post")
+	ErrInvalidContent     = apperr.Invalid("invalid content")
+	ErrInvalidSort        = apperr.Invalid("invalid sort")
+)
+
+// Comment list sort modes
+const (
+	SortNewest = "newest" // Most recent first (default)
+	SortOldest = "oldest" // Oldest first
+	SortTop    = "top"    // Most liked first
 )
 
+// IsValidSort reports whether sort is a supported comment list sort mode
+func IsValidSort(sort string) bool {
+	switch sort {
+	case SortNewest, SortOldest, SortTop:
+		return true
+	}
+	return false
+}
+
 // Comment represents a comment on a post
 type Comment struct {
-	ID          int64      `json:"id" db:"id"`
-	Content     string     `json:"content" db:"content"`
-	PostID      int64      `json:"post_id" db:"post_id"`
-	CreatorID   int64      `json:"creator_id" db:"creator_id"`
-	CreatorName string     `json:"creator_name" db:"creator_name"`
-	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
-	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	ID                int64      `json:"id" db:"id"`
+	Content           string     `json:"content" db:"content"`
+	PostID            int64      `json:"post_id" db:"post_id"`
+	CreatorID         int64      `json:"creator_id" db:"creator_id"`
+	CreatorName       string     `json:"creator_name" db:"creator_name"`
+	CreatorAvatarURL  string     `json:"creator_avatar_url,omitempty" db:"creator_avatar_url"`
+	CreatorIsVerified bool       `json:"creator_is_verified" db:"creator_is_verified"`
+	LikeCount         int64      `json:"like_count" db:"like_count"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
 }
 
 // CreateCommentRequest represents the request payload for creating a comment
@@ -37,9 +68,16 @@ type CommentListRequest struct {
 
 // CommentListResponse represents the response payload for listing comments
 type CommentListResponse struct {
-	Comments []Comment `json:"comments"`
-	Cursor   string    `json:"cursor,omitempty"`
-	HasMore  bool      `json:"has_more"`
+	Comments   []Comment         `json:"comments"`
+	Cursor     string            `json:"cursor,omitempty"`
+	HasMore    bool              `json:"has_more"`
+	Pagination pagination.Cursor `json:"pagination"`
+}
+
+// CommentPageResponse represents a page of comments with offset pagination
+type CommentPageResponse struct {
+	Comments   []Comment       `json:"comments"`
+	Pagination pagination.Info `json:"pagination"`
 }
 
 // CommentResponse represents the response payload for a single comment
@@ -51,21 +89,37 @@ type CommentResponse struct {
 type CommentRepository interface {
 	Create(ctx context.Context, comment *Comment) error
 	GetByID(ctx context.Context, id int64) (*Comment, error)
-	GetByPostID(ctx context.Context, postID int64, cursor string, limit int) (*CommentListResponse, error)
+	GetByPostID(ctx context.Context, postID int64, sort string, cursor string, limit int) (*CommentListResponse, error)
 	GetByCreatorID(ctx context.Context, creatorID int64, cursor string, limit int) (*CommentListResponse, error)
+	GetByPostIDPage(ctx context.Context, postID int64, sort string, offset int, limit int) ([]Comment, int64, error)
+	GetByCreatorIDPage(ctx context.Context, creatorID int64, offset int, limit int) ([]Comment, int64, error)
+	CountByPostID(ctx context.Context, postID int64) (int64, error)
+	CountByCreatorID(ctx context.Context, creatorID int64) (int64, error)
 	Update(ctx context.Context, comment *Comment) error
 	SoftDelete(ctx context.Context, id int64) error
 	GetLastComments(ctx context.Context, postID int64, limit int) ([]Comment, error)
 	GetCommentCount(ctx context.Context, postID int64) (int64, error)
+	GetCommentCounts(ctx context.Context, postIDs []int64) (map[int64]int64, error)
+	Like(ctx context.Context, commentID int64, accountID int64) error
+	Unlike(ctx context.Context, commentID int64, accountID int64) error
+	CanComment(ctx context.Context, postCreatorID int64, commenterID int64) (bool, error)
+	GetLatestByPostIDs(ctx context.Context, postIDs []int64, limit int) (map[int64][]Comment, error)
 }
 
 // CommentService defines the interface for comment business logic
 type CommentService interface {
 	CreateComment(ctx context.Context, req *CreateCommentRequest, creatorID int64) (*Comment, error)
 	GetComment(ctx context.Context, id int64) (*Comment, error)
-	GetPostComments(ctx context.Context, postID int64, cursor string, limit int) (*CommentListResponse, error)
+	GetPostComments(ctx context.Context, postID int64, viewerID int64, sort string, cursor string, limit int) (*CommentListResponse, error)
 	GetUserComments(ctx context.Context, creatorID int64, cursor string, limit int) (*CommentListResponse, error)
+	GetPostCommentsPage(ctx context.Context, postID int64, viewerID int64, sort string, page pagination.Params) (*CommentPageResponse, error)
+	GetUserCommentsPage(ctx context.Context, creatorID int64, page pagination.Params) (*CommentPageResponse, error)
+	CountPostComments(ctx context.Context, postID int64) (int64, error)
+	CountUserComments(ctx context.Context, creatorID int64) (int64, error)
 	UpdateComment(ctx context.Context, id int64, req *UpdateCommentRequest, creatorID int64) (*Comment, error)
 	DeleteComment(ctx context.Context, id int64, creatorID int64) error
 	GetLastComments(ctx context.Context, postID int64, limit int) ([]Comment, error)
+	GetLatestCommentsForPosts(ctx context.Context, postIDs []int64, limit int) (map[int64][]Comment, error)
+	LikeComment(ctx context.Context, id int64, accountID int64) error
+	UnlikeComment(ctx context.Context, id int64, accountID int64) error
 }
@@ -23,6 +23,13 @@ const (
 	UNAUTHORIZED        StandardResponseCode = "UNAUTHORIZED"
 )
 
+// Defines values for GetApiCommentsByPostPostIdParamsSort.
+const (
+	Newest GetApiCommentsByPostPostIdParamsSort = "newest"
+	Oldest GetApiCommentsByPostPostIdParamsSort = "oldest"
+	Top    GetApiCommentsByPostPostIdParamsSort = "top"
+)
+
 // CreateCommentRequest defines model for CreateCommentRequest.
 type CreateCommentRequest struct {
 	Content string `json:"content"`
@@ -50,20 +57,50 @@ type UpdateCommentRequest struct {
 
 // GetApiCommentsByPostPostIdParams defines parameters for GetApiCommentsByPostPostId.
 type GetApiCommentsByPostPostIdParams struct {
-	// Cursor Cursor for pagination
+	// Sort Sort order: newest first (default), oldest first, or top (most liked first)
+	Sort *GetApiCommentsByPostPostIdParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Fields Comma-separated comment fields to return, e.g. content,creator_name; id is always included
+	Fields *[]string `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Page Page number for offset pagination; with page or per_page the response has pagination totals instead of a cursor
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// PerPage Items per page for offset pagination (max 100)
+	PerPage *int `form:"per_page,omitempty" json:"per_page,omitempty"`
+
+	// Cursor Opaque signed cursor from the previous page's next_cursor. Must come from a previous response with the same sort mode.
 	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
 
 	// Limit Number of comments to return (max 100)
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// IncludeTotal Also count all comments in the listing as pagination.total_count, at the cost of an extra query
+	IncludeTotal *bool `form:"include_total,omitempty" json:"include_total,omitempty"`
 }
 
+// GetApiCommentsByPostPostIdParamsSort defines parameters for GetApiCommentsByPostPostId.
+type GetApiCommentsByPostPostIdParamsSort string
+
 // GetApiCommentsUserUserIdParams defines parameters for GetApiCommentsUserUserId.
 type GetApiCommentsUserUserIdParams struct {
-	// Cursor Cursor for pagination
+	// Fields Comma-separated comment fields to return, e.g. content,creator_name; id is always included
+	Fields *[]string `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Page Page number for offset pagination; with page or per_page the response has pagination totals instead of a cursor
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// PerPage Items per page for offset pagination (max 100)
+	PerPage *int `form:"per_page,omitempty" json:"per_page,omitempty"`
+
+	// Cursor Opaque signed cursor from the previous page's next_cursor
 	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
 
 	// Limit Number of comments to return (max 100)
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// IncludeTotal Also count all comments in the listing as pagination.total_count, at the cost of an extra query
+	IncludeTotal *bool `form:"include_total,omitempty" json:"include_total,omitempty"`
 }
 
 // PostApiCommentsByPostPostIdJSONRequestBody defines body for PostApiCommentsByPostPostId for application/json ContentType.
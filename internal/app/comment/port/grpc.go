@@ -0,0 +1,80 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fanzru/social-media-service-go/internal/app/comment"
+	"github.com/fanzru/social-media-service-go/internal/app/comment/port/gengrpc"
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GRPCServer serves the comment gRPC API
+type GRPCServer struct {
+	gengrpc.UnimplementedCommentServiceServer
+	service comment.CommentService
+}
+
+// NewGRPCServer creates a new comment gRPC server
+func NewGRPCServer(service comment.CommentService) *GRPCServer {
+	return &GRPCServer{service: service}
+}
+
+// Ensure GRPCServer implements gengrpc.CommentServiceServer
+var _ gengrpc.CommentServiceServer = (*GRPCServer)(nil)
+
+// GetComment implements gengrpc.CommentServiceServer
+func (s *GRPCServer) GetComment(ctx context.Context, req *gengrpc.GetCommentRequest) (*gengrpc.Comment, error) {
+	fetchedComment, err := s.service.GetComment(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, comment.ErrCommentNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return commentMessage(fetchedComment), nil
+}
+
+// ListPostComments implements gengrpc.CommentServiceServer
+func (s *GRPCServer) ListPostComments(ctx context.Context, req *gengrpc.ListPostCommentsRequest) (*gengrpc.ListCommentsResponse, error) {
+	viewerID, _ := middleware.GetUserID(ctx)
+
+	comments, err := s.service.GetPostComments(ctx, req.GetPostId(), viewerID, req.GetSort(), req.GetCursor(), int(req.GetLimit()))
+	if err != nil {
+		if errors.Is(err, apperr.ErrInvalid) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return listMessage(comments), nil
+}
+
+// commentMessage converts a comment to its protobuf message
+func commentMessage(c *comment.Comment) *gengrpc.Comment {
+	return &gengrpc.Comment{
+		Id:                c.ID,
+		Content:           c.Content,
+		PostId:            c.PostID,
+		CreatorId:         c.CreatorID,
+		CreatorName:       c.CreatorName,
+		CreatorIsVerified: c.CreatorIsVerified,
+		LikeCount:         c.LikeCount,
+		CreatedAt:         timestamppb.New(c.CreatedAt),
+		UpdatedAt:         timestamppb.New(c.UpdatedAt),
+	}
+}
+
+// listMessage converts a page of comments to its protobuf message
+func listMessage(list *comment.CommentListResponse) *gengrpc.ListCommentsResponse {
+	msg := &gengrpc.ListCommentsResponse{Cursor: list.Cursor, HasMore: list.HasMore}
+	for i := range list.Comments {
+		msg.Comments = append(msg.Comments, commentMessage(&list.Comments[i]))
+	}
+	return msg
+}
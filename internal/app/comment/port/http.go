@@ -7,6 +7,7 @@ import (
 	"github.com/fanzru/social-media-service-go/internal/app/comment"
 	"github.com/fanzru/social-media-service-go/internal/app/comment/port/genhttp"
 	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"github.com/fanzru/social-media-service-go/pkg/pagination"
 	"github.com/fanzru/social-media-service-go/pkg/response"
 )
 
@@ -43,11 +44,7 @@ func (h *Handler) PostApiCommentsByPostPostId(w http.ResponseWriter, r *http.Req
 
 	createdComment, err := h.service.CreateComment(r.Context(), createReq, userID)
 	if err != nil {
-		if err.Error() == "post not found" {
-			response.NotFound(r.Context(), "Post not found", []string{err.Error()}).Send(w, http.StatusNotFound)
-			return
-		}
-		response.InternalServerError(r.Context(), "Failed to create comment", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		response.SendError(r.Context(), w, err, "Failed to create comment")
 		return
 	}
 
@@ -56,6 +53,35 @@ func (h *Handler) PostApiCommentsByPostPostId(w http.ResponseWriter, r *http.Req
 
 // GetApiPostsPostIdComments handles GET /api/posts/{postId}/comments
 func (h *Handler) GetApiCommentsByPostPostId(w http.ResponseWriter, r *http.Request, postId int64, params genhttp.GetApiCommentsByPostPostIdParams) {
+	// Anonymous viewers only see comments on public posts
+	viewerID, _ := middleware.GetUserID(r.Context())
+
+	fields, ok := parseCommentFields(w, r, params.Fields)
+	if !ok {
+		return
+	}
+
+	if params.Page != nil || params.PerPage != nil {
+		page, ok := parsePage(w, r, params.Page, params.PerPage, params.Cursor)
+		if !ok {
+			return
+		}
+
+		sort := ""
+		if params.Sort != nil {
+			sort = string(*params.Sort)
+		}
+
+		comments, err := h.service.GetPostCommentsPage(r.Context(), postId, viewerID, sort, page)
+		if err != nil {
+			response.SendError(r.Context(), w, err, "Failed to get comments")
+			return
+		}
+
+		sendComments(w, r, "Comments retrieved successfully", comments, fields)
+		return
+	}
+
 	cursor := ""
 	if params.Cursor != nil {
 		cursor = *params.Cursor
@@ -66,20 +92,34 @@ func (h *Handler) GetApiCommentsByPostPostId(w http.ResponseWriter, r *http.Requ
 		limit = *params.Limit
 	}
 
-	comments, err := h.service.GetPostComments(r.Context(), postId, cursor, limit)
+	sort := ""
+	if params.Sort != nil {
+		sort = string(*params.Sort)
+	}
+
+	comments, err := h.service.GetPostComments(r.Context(), postId, viewerID, sort, cursor, limit)
 	if err != nil {
-		response.InternalServerError(r.Context(), "Failed to get comments", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		response.SendError(r.Context(), w, err, "Failed to get comments")
 		return
 	}
 
-	response.Success(r.Context(), "Comments retrieved successfully", comments).Send(w, http.StatusOK)
+	if params.IncludeTotal != nil && *params.IncludeTotal {
+		total, err := h.service.CountPostComments(r.Context(), postId)
+		if err != nil {
+			response.SendError(r.Context(), w, err, "Failed to get comments")
+			return
+		}
+		comments.Pagination.TotalCount = &total
+	}
+
+	sendComments(w, r, "Comments retrieved successfully", comments, fields)
 }
 
 // GetApiCommentsId handles GET /api/comments/{id}
 func (h *Handler) GetApiCommentsId(w http.ResponseWriter, r *http.Request, id int64) {
 	fetchedComment, err := h.service.GetComment(r.Context(), id)
 	if err != nil {
-		response.NotFound(r.Context(), "Comment not found", []string{err.Error()}).Send(w, http.StatusNotFound)
+		response.SendError(r.Context(), w, err, "Failed to get comment")
 		return
 	}
 
@@ -106,15 +146,7 @@ func (h *Handler) PutApiCommentsId(w http.ResponseWriter, r *http.Request, id in
 
 	updatedComment, err := h.service.UpdateComment(r.Context(), id, updateReq, userID)
 	if err != nil {
-		if err.Error() == "comment not found" {
-			response.NotFound(r.Context(), "Comment not found", []string{err.Error()}).Send(w, http.StatusNotFound)
-			return
-		}
-		if err.Error() == "unauthorized" {
-			response.Forbidden(r.Context(), "Not authorized to update this comment", []string{err.Error()}).Send(w, http.StatusForbidden)
-			return
-		}
-		response.InternalServerError(r.Context(), "Failed to update comment", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		response.SendError(r.Context(), w, err, "Failed to update comment")
 		return
 	}
 
@@ -131,23 +163,70 @@ func (h *Handler) DeleteApiCommentsId(w http.ResponseWriter, r *http.Request, id
 
 	err := h.service.DeleteComment(r.Context(), id, userID)
 	if err != nil {
-		if err.Error() == "comment not found" {
-			response.NotFound(r.Context(), "Comment not found", []string{err.Error()}).Send(w, http.StatusNotFound)
-			return
-		}
-		if err.Error() == "unauthorized" {
-			response.Forbidden(r.Context(), "Not authorized to delete this comment", []string{err.Error()}).Send(w, http.StatusForbidden)
-			return
-		}
-		response.InternalServerError(r.Context(), "Failed to delete comment", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		response.SendError(r.Context(), w, err, "Failed to delete comment")
 		return
 	}
 
 	response.Success(r.Context(), "Comment deleted successfully", nil).Send(w, http.StatusOK)
 }
 
+// PostApiCommentsIdLike handles POST /api/comments/{id}/like
+func (h *Handler) PostApiCommentsIdLike(w http.ResponseWriter, r *http.Request, id int64) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	err := h.service.LikeComment(r.Context(), id, userID)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to like comment")
+		return
+	}
+
+	response.Success(r.Context(), "Comment liked successfully", nil).Send(w, http.StatusOK)
+}
+
+// DeleteApiCommentsIdLike handles DELETE /api/comments/{id}/like
+func (h *Handler) DeleteApiCommentsIdLike(w http.ResponseWriter, r *http.Request, id int64) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	err := h.service.UnlikeComment(r.Context(), id, userID)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to unlike comment")
+		return
+	}
+
+	response.Success(r.Context(), "Comment unliked successfully", nil).Send(w, http.StatusOK)
+}
+
 // GetApiCommentsUserUserId handles GET /api/comments/user/{userId}
 func (h *Handler) GetApiCommentsUserUserId(w http.ResponseWriter, r *http.Request, userId int64, params genhttp.GetApiCommentsUserUserIdParams) {
+	fields, ok := parseCommentFields(w, r, params.Fields)
+	if !ok {
+		return
+	}
+
+	if params.Page != nil || params.PerPage != nil {
+		page, ok := parsePage(w, r, params.Page, params.PerPage, params.Cursor)
+		if !ok {
+			return
+		}
+
+		comments, err := h.service.GetUserCommentsPage(r.Context(), userId, page)
+		if err != nil {
+			response.InternalServerError(r.Context(), "Failed to get user comments", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+			return
+		}
+
+		sendComments(w, r, "User comments retrieved successfully", comments, fields)
+		return
+	}
+
 	cursor := ""
 	if params.Cursor != nil {
 		cursor = *params.Cursor
@@ -160,11 +239,64 @@ func (h *Handler) GetApiCommentsUserUserId(w http.ResponseWriter, r *http.Reques
 
 	comments, err := h.service.GetUserComments(r.Context(), userId, cursor, limit)
 	if err != nil {
-		response.InternalServerError(r.Context(), "Failed to get user comments", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		response.SendError(r.Context(), w, err, "Failed to get user comments")
 		return
 	}
 
-	response.Success(r.Context(), "User comments retrieved successfully", comments).Send(w, http.StatusOK)
+	if params.IncludeTotal != nil && *params.IncludeTotal {
+		total, err := h.service.CountUserComments(r.Context(), userId)
+		if err != nil {
+			response.InternalServerError(r.Context(), "Failed to get user comments", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+			return
+		}
+		comments.Pagination.TotalCount = &total
+	}
+
+	sendComments(w, r, "User comments retrieved successfully", comments, fields)
+}
+
+// parseCommentFields reads the fields parameter of comment lists, answering 400 for unknown fields
+func parseCommentFields(w http.ResponseWriter, r *http.Request, requested *[]string) (response.Fields, bool) {
+	if requested == nil {
+		return nil, true
+	}
+	fields, err := response.ParseFields(*requested, comment.Comment{})
+	if err != nil {
+		response.BadRequest(r.Context(), "Invalid fields", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return nil, false
+	}
+	return fields, true
+}
+
+// parsePage reads the offset pagination parameters, answering 400 when they are invalid or
+// combined with a cursor
+func parsePage(w http.ResponseWriter, r *http.Request, page *int, perPage *int, cursor *string) (pagination.Params, bool) {
+	if cursor != nil {
+		response.BadRequest(r.Context(), "Invalid pagination", []string{"use either cursor or page and per_page, not both"}).Send(w, http.StatusBadRequest)
+		return pagination.Params{}, false
+	}
+	params, err := pagination.New(page, perPage)
+	if err != nil {
+		response.BadRequest(r.Context(), "Invalid pagination", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return pagination.Params{}, false
+	}
+	return params, true
+}
+
+// sendComments sends a list of comments reduced to the requested fields
+func sendComments(w http.ResponseWriter, r *http.Request, message string, comments interface{}, fields response.Fields) {
+	// Protobuf clients get the gRPC list message; sparse fieldsets do not apply to it
+	if list, ok := comments.(*comment.CommentListResponse); ok && response.Prefers(r, response.ContentTypeProtobuf) {
+		response.Success(r.Context(), message, listMessage(list)).SendNegotiated(w, r, http.StatusOK)
+		return
+	}
+
+	data, err := fields.Select(comments, "comments")
+	if err != nil {
+		response.InternalServerError(r.Context(), "Failed to select fields", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+	response.Success(r.Context(), message, data).SendNegotiated(w, r, http.StatusOK)
 }
 
 // Implement the generated interface
@@ -7,57 +7,106 @@ import (
 	"time"
 
 	"github.com/fanzru/social-media-service-go/internal/app/comment"
+	"github.com/fanzru/social-media-service-go/pkg/pagination"
 	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
 )
 
 // Repository implements comment repository interface
 type Repository struct {
-	db interface{} // Can be *sql.DB or *sqlwrap.DB
+	db    interface{}              // Can be *sql.DB or *sqlwrap.DB
+	stmts map[string]*sqlwrap.Stmt // Prepared statements of the most frequent queries, keyed by their SQL
 }
 
-// NewRepository creates a new comment repository
-func NewRepository(db interface{}) *Repository {
-	return &Repository{db: db}
+// likeCountColumn selects the number of likes of the comment in the current row
+const likeCountColumn = `(SELECT COUNT(*) FROM comment_likes cl WHERE cl.comment_id = comments.id) AS like_count`
+
+// creatorVerifiedColumn selects whether the creator of the comment in the current row is verified
+const creatorVerifiedColumn = `COALESCE((SELECT a.is_verified FROM accounts a WHERE a.id = comments.creator_id), FALSE) AS creator_is_verified`
+
+// activeCreator is a WHERE condition leaving out comments of deactivated accounts
+const activeCreator = `NOT EXISTS (SELECT 1 FROM accounts da WHERE da.id = comments.creator_id AND da.status = 'deactivated')`
+
+// listingUserComments is the listing of cursors of an account's comments
+const listingUserComments = "comments:user"
+
+// postCommentsListing returns the listing of cursors of a post's comments in a sort mode,
+// so a cursor of one sort mode is rejected by the others
+func postCommentsListing(sort string) string {
+	switch sort {
+	case comment.SortOldest, comment.SortTop:
+		return "comments:" + sort
+	default:
+		return "comments:" + comment.SortNewest
+	}
 }
 
-// Create creates a new comment
-func (r *Repository) Create(ctx context.Context, comment *comment.Comment) error {
-	query := `
+// prepareTimeout bounds preparing the repository's statements at construction
+const prepareTimeout = 5 * time.Second
+
+// createQuery inserts a comment and returns its ID
+const createQuery = `
 		INSERT INTO comments (content, post_id, creator_id, creator_name, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id
 	`
 
+// getByIDQuery selects comment $1
+const getByIDQuery = `
+		SELECT id, content, post_id, creator_id, creator_name, ` + creatorVerifiedColumn + `, ` + likeCountColumn + `, created_at, updated_at, deleted_at
+		FROM comments
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+// NewRepository creates a new comment repository. It prepares creating and getting a
+// comment and the first and later pages of a post's comments in each sort mode; queries
+// that fail to prepare run unprepared.
+func NewRepository(db interface{}) *Repository {
+	ctx, cancel := context.WithTimeout(context.Background(), prepareTimeout)
+	defer cancel()
+
+	queries := []string{createQuery, getByIDQuery}
+	for _, sort := range []string{comment.SortNewest, comment.SortOldest, comment.SortTop} {
+		queries = append(queries, postCommentsQuery(sort, false), postCommentsQuery(sort, true))
+	}
+
+	return &Repository{db: db, stmts: sqlwrap.PrepareAll(ctx, db, queries...)}
+}
+
+// queryRowContext runs a query returning one row, on its prepared statement if it has one
+func (r *Repository) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if stmt, ok := r.stmts[query]; ok {
+		return stmt.QueryRowContext(ctx, args...)
+	}
+	if db, ok := r.db.(*sqlwrap.DB); ok {
+		return db.QueryRowContext(ctx, query, args...)
+	}
+	return r.db.(*sql.DB).QueryRowContext(ctx, query, args...)
+}
+
+// queryContext runs a query returning rows, on its prepared statement if it has one
+func (r *Repository) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if stmt, ok := r.stmts[query]; ok {
+		return stmt.QueryContext(ctx, args...)
+	}
+	if db, ok := r.db.(*sqlwrap.DB); ok {
+		return db.QueryContext(ctx, query, args...)
+	}
+	return r.db.(*sql.DB).QueryContext(ctx, query, args...)
+}
+
+// Create creates a new comment
+func (r *Repository) Create(ctx context.Context, comment *comment.Comment) error {
 	now := time.Now()
 	comment.CreatedAt = now
 	comment.UpdatedAt = now
 
-	var err error
-	if db, ok := r.db.(*sql.DB); ok {
-		err = db.QueryRowContext(ctx, query, comment.Content, comment.PostID, comment.CreatorID, comment.CreatorName, comment.CreatedAt, comment.UpdatedAt).Scan(&comment.ID)
-	} else if db, ok := r.db.(*sqlwrap.DB); ok {
-		err = db.QueryRowContext(ctx, query, comment.Content, comment.PostID, comment.CreatorID, comment.CreatorName, comment.CreatedAt, comment.UpdatedAt).Scan(&comment.ID)
-	}
-
-	return err
+	return r.queryRowContext(ctx, createQuery, comment.Content, comment.PostID, comment.CreatorID, comment.CreatorName, comment.CreatedAt, comment.UpdatedAt).Scan(&comment.ID)
 }
 
 // GetByID retrieves a comment by ID
 func (r *Repository) GetByID(ctx context.Context, id int64) (*comment.Comment, error) {
-	query := `
-		SELECT id, content, post_id, creator_id, creator_name, created_at, updated_at, deleted_at
-		FROM comments
-		WHERE id = $1 AND deleted_at IS NULL
-	`
-
 	var c comment.Comment
-	var err error
-	if db, ok := r.db.(*sql.DB); ok {
-		err = db.QueryRowContext(ctx, query, id).Scan(&c.ID, &c.Content, &c.PostID, &c.CreatorID, &c.CreatorName, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt)
-	} else if db, ok := r.db.(*sqlwrap.DB); ok {
-		err = db.QueryRowContext(ctx, query, id).Scan(&c.ID, &c.Content, &c.PostID, &c.CreatorID, &c.CreatorName, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt)
-	}
-
+	err := r.queryRowContext(ctx, getByIDQuery, id).Scan(&c.ID, &c.Content, &c.PostID, &c.CreatorID, &c.CreatorName, &c.CreatorIsVerified, &c.LikeCount, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -65,35 +114,29 @@ func (r *Repository) GetByID(ctx context.Context, id int64) (*comment.Comment, e
 	return &c, nil
 }
 
-// GetByPostID retrieves comments by post ID with cursor-based pagination
-func (r *Repository) GetByPostID(ctx context.Context, postID int64, cursor string, limit int) (*comment.CommentListResponse, error) {
+// GetByPostID retrieves comments by post ID with cursor-based pagination in the given sort mode.
+// newest and oldest use a created_at and id cursor; top adds like_count to it.
+func (r *Repository) GetByPostID(ctx context.Context, postID int64, sort string, cursor string, limit int) (*comment.CommentListResponse, error) {
 	if limit <= 0 || limit > 100 {
 		limit = 20
 	}
 
-	query := `
-		SELECT id, content, post_id, creator_id, creator_name, created_at, updated_at, deleted_at
-		FROM comments
-		WHERE post_id = $1 AND deleted_at IS NULL
-	`
 	args := []interface{}{postID}
 
+	listing := postCommentsListing(sort)
 	if cursor != "" {
-		query += ` AND created_at < $2`
-		args = append(args, cursor)
+		keys, err := pagination.DecodeCursor(cursor, listing)
+		if err != nil {
+			return nil, err
+		}
+		if sort == comment.SortTop {
+			args = append(args, keys.Count)
+		}
+		args = append(args, keys.CreatedAt, keys.ID)
 	}
-
-	query += ` ORDER BY created_at DESC LIMIT $` + fmt.Sprintf("%d", len(args)+1)
 	args = append(args, limit+1) // Get one extra to check if there are more
 
-	var rows *sql.Rows
-	var err error
-	if db, ok := r.db.(*sql.DB); ok {
-		rows, err = db.QueryContext(ctx, query, args...)
-	} else if db, ok := r.db.(*sqlwrap.DB); ok {
-		rows, err = db.QueryContext(ctx, query, args...)
-	}
-
+	rows, err := r.queryContext(ctx, postCommentsQuery(sort, cursor != ""), args...)
 	if err != nil {
 		return nil, err
 	}
@@ -102,7 +145,7 @@ func (r *Repository) GetByPostID(ctx context.Context, postID int64, cursor strin
 	var comments []comment.Comment
 	for rows.Next() {
 		var c comment.Comment
-		err := rows.Scan(&c.ID, &c.Content, &c.PostID, &c.CreatorID, &c.CreatorName, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt)
+		err := rows.Scan(&c.ID, &c.Content, &c.PostID, &c.CreatorID, &c.CreatorName, &c.CreatorIsVerified, &c.LikeCount, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -116,16 +159,57 @@ func (r *Repository) GetByPostID(ctx context.Context, postID int64, cursor strin
 
 	var nextCursor string
 	if hasMore && len(comments) > 0 {
-		nextCursor = comments[len(comments)-1].CreatedAt.Format(time.RFC3339Nano)
+		last := comments[len(comments)-1]
+		nextCursor = pagination.EncodeCursor(pagination.CursorKeys{Listing: listing, Count: last.LikeCount, CreatedAt: last.CreatedAt, ID: last.ID})
 	}
 
 	return &comment.CommentListResponse{
-		Comments: comments,
-		Cursor:   nextCursor,
-		HasMore:  hasMore,
+		Comments:   comments,
+		Cursor:     nextCursor,
+		HasMore:    hasMore,
+		Pagination: pagination.NewCursor(limit, nextCursor, hasMore),
 	}, nil
 }
 
+// postCommentsQuery builds the query of GetByPostID: the comments of post $1 in the sort
+// mode, after the cursor's keys in the following arguments when withCursor is set, limited
+// by the last argument
+func postCommentsQuery(sort string, withCursor bool) string {
+	query := `
+		SELECT id, content, post_id, creator_id, creator_name, creator_is_verified, like_count, created_at, updated_at, deleted_at
+		FROM (
+			SELECT id, content, post_id, creator_id, creator_name, ` + creatorVerifiedColumn + `, ` + likeCountColumn + `, created_at, updated_at, deleted_at
+			FROM comments
+			WHERE post_id = $1 AND deleted_at IS NULL AND ` + activeCreator + `
+		) c
+		WHERE TRUE
+	`
+	argCount := 1
+
+	switch sort {
+	case comment.SortOldest:
+		if withCursor {
+			query += ` AND (created_at, id) > ($2, $3)`
+			argCount += 2
+		}
+		query += ` ORDER BY created_at ASC, id ASC`
+	case comment.SortTop:
+		if withCursor {
+			query += ` AND (like_count, created_at, id) < ($2, $3, $4)`
+			argCount += 3
+		}
+		query += ` ORDER BY like_count DESC, created_at DESC, id DESC`
+	default:
+		if withCursor {
+			query += ` AND (created_at, id) < ($2, $3)`
+			argCount += 2
+		}
+		query += ` ORDER BY created_at DESC, id DESC`
+	}
+
+	return query + fmt.Sprintf(` LIMIT $%d`, argCount+1)
+}
+
 // GetByCreatorID retrieves comments by creator ID with cursor-based pagination
 func (r *Repository) GetByCreatorID(ctx context.Context, creatorID int64, cursor string, limit int) (*comment.CommentListResponse, error) {
 	if limit <= 0 || limit > 100 {
@@ -133,18 +217,22 @@ func (r *Repository) GetByCreatorID(ctx context.Context, creatorID int64, cursor
 	}
 
 	query := `
-		SELECT id, content, post_id, creator_id, creator_name, created_at, updated_at, deleted_at
+		SELECT id, content, post_id, creator_id, creator_name, ` + creatorVerifiedColumn + `, ` + likeCountColumn + `, created_at, updated_at, deleted_at
 		FROM comments
-		WHERE creator_id = $1 AND deleted_at IS NULL
+		WHERE creator_id = $1 AND deleted_at IS NULL AND ` + activeCreator + `
 	`
 	args := []interface{}{creatorID}
 
 	if cursor != "" {
-		query += ` AND created_at < $2`
-		args = append(args, cursor)
+		keys, err := pagination.DecodeCursor(cursor, listingUserComments)
+		if err != nil {
+			return nil, err
+		}
+		query += ` AND (created_at, id) < ($2, $3)`
+		args = append(args, keys.CreatedAt, keys.ID)
 	}
 
-	query += ` ORDER BY created_at DESC LIMIT $` + fmt.Sprintf("%d", len(args)+1)
+	query += ` ORDER BY created_at DESC, id DESC LIMIT $` + fmt.Sprintf("%d", len(args)+1)
 	args = append(args, limit+1) // Get one extra to check if there are more
 
 	var rows *sql.Rows
@@ -163,7 +251,7 @@ func (r *Repository) GetByCreatorID(ctx context.Context, creatorID int64, cursor
 	var comments []comment.Comment
 	for rows.Next() {
 		var c comment.Comment
-		err := rows.Scan(&c.ID, &c.Content, &c.PostID, &c.CreatorID, &c.CreatorName, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt)
+		err := rows.Scan(&c.ID, &c.Content, &c.PostID, &c.CreatorID, &c.CreatorName, &c.CreatorIsVerified, &c.LikeCount, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -177,16 +265,120 @@ func (r *Repository) GetByCreatorID(ctx context.Context, creatorID int64, cursor
 
 	var nextCursor string
 	if hasMore && len(comments) > 0 {
-		nextCursor = comments[len(comments)-1].CreatedAt.Format(time.RFC3339Nano)
+		last := comments[len(comments)-1]
+		nextCursor = pagination.EncodeCursor(pagination.CursorKeys{Listing: listingUserComments, CreatedAt: last.CreatedAt, ID: last.ID})
 	}
 
 	return &comment.CommentListResponse{
-		Comments: comments,
-		Cursor:   nextCursor,
-		HasMore:  hasMore,
+		Comments:   comments,
+		Cursor:     nextCursor,
+		HasMore:    hasMore,
+		Pagination: pagination.NewCursor(limit, nextCursor, hasMore),
 	}, nil
 }
 
+// GetByPostIDPage gets a page of a post's comments by offset in the given sort order, with
+// the number of comments in the whole listing
+func (r *Repository) GetByPostIDPage(ctx context.Context, postID int64, sort string, offset int, limit int) ([]comment.Comment, int64, error) {
+	where := postCommentsWhere
+
+	total, err := r.count(ctx, `SELECT COUNT(*) `+where, postID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, content, post_id, creator_id, creator_name, ` + creatorVerifiedColumn + `, ` + likeCountColumn + `, created_at, updated_at, deleted_at
+		` + where
+	switch sort {
+	case comment.SortOldest:
+		query += ` ORDER BY created_at ASC, id ASC`
+	case comment.SortTop:
+		query += ` ORDER BY like_count DESC, created_at DESC, id DESC`
+	default:
+		query += ` ORDER BY created_at DESC, id DESC`
+	}
+	query += ` LIMIT $2 OFFSET $3`
+
+	comments, err := r.queryComments(ctx, query, postID, limit, offset)
+	return comments, total, err
+}
+
+// GetByCreatorIDPage gets a page of an account's comments by offset, newest first, with the
+// number of comments in the whole listing
+func (r *Repository) GetByCreatorIDPage(ctx context.Context, creatorID int64, offset int, limit int) ([]comment.Comment, int64, error) {
+	where := creatorCommentsWhere
+
+	total, err := r.count(ctx, `SELECT COUNT(*) `+where, creatorID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, content, post_id, creator_id, creator_name, ` + creatorVerifiedColumn + `, ` + likeCountColumn + `, created_at, updated_at, deleted_at
+		` + where + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3`
+
+	comments, err := r.queryComments(ctx, query, creatorID, limit, offset)
+	return comments, total, err
+}
+
+// queryComments runs a query selecting the comment columns in the order GetByPostID scans them
+func (r *Repository) queryComments(ctx context.Context, query string, args ...interface{}) ([]comment.Comment, error) {
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, args...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []comment.Comment{}
+	for rows.Next() {
+		var c comment.Comment
+		err := rows.Scan(&c.ID, &c.Content, &c.PostID, &c.CreatorID, &c.CreatorName, &c.CreatorIsVerified, &c.LikeCount, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+
+	return comments, rows.Err()
+}
+
+// CountByPostID counts a post's comments
+func (r *Repository) CountByPostID(ctx context.Context, postID int64) (int64, error) {
+	return r.count(ctx, `SELECT COUNT(*) `+postCommentsWhere, postID)
+}
+
+// CountByCreatorID counts a creator's comments
+func (r *Repository) CountByCreatorID(ctx context.Context, creatorID int64) (int64, error) {
+	return r.count(ctx, `SELECT COUNT(*) `+creatorCommentsWhere, creatorID)
+}
+
+// FROM and WHERE clauses of the comments of post $1 and of creator $1
+const (
+	postCommentsWhere = `
+		FROM comments
+		WHERE post_id = $1 AND deleted_at IS NULL AND ` + activeCreator
+	creatorCommentsWhere = `
+		FROM comments
+		WHERE creator_id = $1 AND deleted_at IS NULL AND ` + activeCreator
+)
+
+// count runs a COUNT(*) query
+func (r *Repository) count(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	var total int64
+	err := r.queryRowContext(ctx, query, args...).Scan(&total)
+	return total, err
+}
+
 // Update updates an existing comment
 func (r *Repository) Update(ctx context.Context, comment *comment.Comment) error {
 	query := `
@@ -229,9 +421,9 @@ func (r *Repository) GetLastComments(ctx context.Context, postID int64, limit in
 	}
 
 	query := `
-		SELECT id, content, post_id, creator_id, creator_name, created_at, updated_at, deleted_at
+		SELECT id, content, post_id, creator_id, creator_name, ` + creatorVerifiedColumn + `, ` + likeCountColumn + `, created_at, updated_at, deleted_at
 		FROM comments
-		WHERE post_id = $1 AND deleted_at IS NULL
+		WHERE post_id = $1 AND deleted_at IS NULL AND ` + activeCreator + `
 		ORDER BY created_at DESC
 		LIMIT $2
 	`
@@ -252,7 +444,7 @@ func (r *Repository) GetLastComments(ctx context.Context, postID int64, limit in
 	var comments []comment.Comment
 	for rows.Next() {
 		var c comment.Comment
-		err := rows.Scan(&c.ID, &c.Content, &c.PostID, &c.CreatorID, &c.CreatorName, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt)
+		err := rows.Scan(&c.ID, &c.Content, &c.PostID, &c.CreatorID, &c.CreatorName, &c.CreatorIsVerified, &c.LikeCount, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -262,9 +454,53 @@ func (r *Repository) GetLastComments(ctx context.Context, postID int64, limit in
 	return comments, nil
 }
 
+// GetLatestByPostIDs gets the newest comments of several posts in one query, keyed by post ID
+func (r *Repository) GetLatestByPostIDs(ctx context.Context, postIDs []int64, limit int) (map[int64][]comment.Comment, error) {
+	if limit <= 0 {
+		limit = 2
+	}
+
+	query := `
+		SELECT id, content, post_id, creator_id, creator_name, creator_is_verified, like_count, created_at, updated_at, deleted_at
+		FROM (
+			SELECT id, content, post_id, creator_id, creator_name, ` + creatorVerifiedColumn + `, ` + likeCountColumn + `, created_at, updated_at, deleted_at,
+				ROW_NUMBER() OVER (PARTITION BY post_id ORDER BY created_at DESC, id DESC) AS rn
+			FROM comments
+			WHERE post_id = ANY($1) AND deleted_at IS NULL AND ` + activeCreator + `
+		) latest
+		WHERE rn <= $2
+		ORDER BY post_id, created_at DESC, id DESC
+	`
+
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, sqlwrap.Array(postIDs), limit)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, sqlwrap.Array(postIDs), limit)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := make(map[int64][]comment.Comment, len(postIDs))
+	for rows.Next() {
+		var c comment.Comment
+		err := rows.Scan(&c.ID, &c.Content, &c.PostID, &c.CreatorID, &c.CreatorName, &c.CreatorIsVerified, &c.LikeCount, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt)
+		if err != nil {
+			return nil, err
+		}
+		comments[c.PostID] = append(comments[c.PostID], c)
+	}
+
+	return comments, rows.Err()
+}
+
 // GetCommentCount gets the comment count for a post
 func (r *Repository) GetCommentCount(ctx context.Context, postID int64) (int64, error) {
-	query := `SELECT COUNT(*) FROM comments WHERE post_id = $1 AND deleted_at IS NULL`
+	query := `SELECT COUNT(*) FROM comments WHERE post_id = $1 AND deleted_at IS NULL AND ` + activeCreator
 
 	var count int64
 	var err error
@@ -276,3 +512,92 @@ func (r *Repository) GetCommentCount(ctx context.Context, postID int64) (int64,
 
 	return count, err
 }
+
+// GetCommentCounts gets the comment counts of several posts in one query, keyed by post ID.
+// Posts without comments are left out.
+func (r *Repository) GetCommentCounts(ctx context.Context, postIDs []int64) (map[int64]int64, error) {
+	query := `SELECT post_id, COUNT(*) FROM comments WHERE post_id = ANY($1) AND deleted_at IS NULL AND ` + activeCreator + ` GROUP BY post_id`
+
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, sqlwrap.Array(postIDs))
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, sqlwrap.Array(postIDs))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[int64]int64, len(postIDs))
+	for rows.Next() {
+		var postID, count int64
+		if err := rows.Scan(&postID, &count); err != nil {
+			return nil, err
+		}
+		counts[postID] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// Like records a like on a comment, ignoring repeated likes by the same account
+func (r *Repository) Like(ctx context.Context, commentID int64, accountID int64) error {
+	query := `
+		INSERT INTO comment_likes (comment_id, account_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (comment_id, account_id) DO NOTHING
+	`
+
+	now := time.Now()
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		_, err = db.ExecContext(ctx, query, commentID, accountID, now)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		_, err = db.ExecContext(ctx, query, commentID, accountID, now)
+	}
+
+	return err
+}
+
+// Unlike removes an account's like from a comment
+func (r *Repository) Unlike(ctx context.Context, commentID int64, accountID int64) error {
+	query := `DELETE FROM comment_likes WHERE comment_id = $1 AND account_id = $2`
+
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		_, err = db.ExecContext(ctx, query, commentID, accountID)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		_, err = db.ExecContext(ctx, query, commentID, accountID)
+	}
+
+	return err
+}
+
+// CanComment reports whether the commenter may comment on posts of the given creator,
+// based on the creator's comment permission setting
+func (r *Repository) CanComment(ctx context.Context, postCreatorID int64, commenterID int64) (bool, error) {
+	query := `
+		SELECT CASE comment_permission
+			WHEN 'everyone' THEN TRUE
+			WHEN 'followers' THEN EXISTS (
+				SELECT 1 FROM follows f WHERE f.follower_id = $2 AND f.following_id = a.id
+			)
+			ELSE FALSE
+		END
+		FROM accounts a
+		WHERE a.id = $1
+	`
+
+	var allowed bool
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, postCreatorID, commenterID).Scan(&allowed)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, postCreatorID, commenterID).Scan(&allowed)
+	}
+
+	return allowed, err
+}
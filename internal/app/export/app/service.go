@@ -0,0 +1,188 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/export"
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+)
+
+// staleJobAfter is how long a job may stay processing before another worker reclaims it
+const staleJobAfter = 15 * time.Minute
+
+// Service implements export service interface
+type Service struct {
+	repo export.ExportRepository
+	ttl  time.Duration
+	wake chan struct{}
+}
+
+// NewService creates a new export service; archives can be downloaded for ttl after completion
+func NewService(repo export.ExportRepository, ttl time.Duration) *Service {
+	return &Service{
+		repo: repo,
+		ttl:  ttl,
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// RequestExport queues an export of the account. If one is already queued or
+// running, that job is returned instead.
+func (s *Service) RequestExport(ctx context.Context, accountID int64) (*export.Job, error) {
+	job, err := s.repo.CreateJob(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return s.GetLatestExport(ctx, accountID)
+		}
+		return nil, fmt.Errorf("failed to create export: %w", err)
+	}
+
+	// Nudge the worker so the export does not wait for the next tick
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return job, nil
+}
+
+// GetLatestExport retrieves the status of the account's most recent export
+func (s *Service) GetLatestExport(ctx context.Context, accountID int64) (*export.Job, error) {
+	job, err := s.repo.GetLatestJob(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, export.ErrExportNotFound
+		}
+		return nil, fmt.Errorf("failed to get export: %w", err)
+	}
+
+	return job, nil
+}
+
+// GetArchive retrieves the ZIP archive of the account's latest completed export
+func (s *Service) GetArchive(ctx context.Context, accountID int64) ([]byte, error) {
+	archive, err := s.repo.GetArchive(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, export.ErrExportNotReady
+		}
+		return nil, fmt.Errorf("failed to get export archive: %w", err)
+	}
+
+	return archive, nil
+}
+
+// ProcessNext claims one queued export and builds its archive. It reports whether a job was processed.
+func (s *Service) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := s.repo.ClaimJob(ctx, time.Now().Add(-staleJobAfter))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim export: %w", err)
+	}
+
+	archive, err := s.buildArchive(ctx, job.AccountID)
+	if err != nil {
+		if failErr := s.repo.FailJob(ctx, job.ID, err.Error()); failErr != nil {
+			return true, fmt.Errorf("failed to mark export %d failed: %w", job.ID, failErr)
+		}
+		return true, fmt.Errorf("failed to build export %d: %w", job.ID, err)
+	}
+
+	if err := s.repo.CompleteJob(ctx, job.ID, archive, time.Now().Add(s.ttl)); err != nil {
+		return true, fmt.Errorf("failed to complete export %d: %w", job.ID, err)
+	}
+
+	return true, nil
+}
+
+// RunWorker processes queued exports and purges expired archives until the context is
+// cancelled. It is the only worker processing exports, so an interval of 0 or less falls
+// back to 30s rather than stopping it.
+func (s *Service) RunWorker(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	log := logger.GetGlobal()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := s.repo.PurgeExpired(ctx, time.Now())
+			if err != nil {
+				log.Error("Export purge failed", "error", err.Error())
+			} else if purged > 0 {
+				log.Info("Expired exports purged", "count", purged)
+			}
+		case <-s.wake:
+		}
+
+		for {
+			processed, err := s.ProcessNext(ctx)
+			if err != nil {
+				log.Error("Export processing failed", "error", err.Error())
+			}
+			if !processed {
+				break
+			}
+		}
+	}
+}
+
+// buildArchive assembles the account, posts and comments of an account into a ZIP archive
+func (s *Service) buildArchive(ctx context.Context, accountID int64) ([]byte, error) {
+	acc, err := s.repo.GetAccountData(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	posts, err := s.repo.GetPosts(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts: %w", err)
+	}
+
+	comments, err := s.repo.GetComments(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"account.json", acc},
+		{"posts.json", posts},
+		{"comments.json", comments},
+	}
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s: %w", f.name, err)
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.data); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close archive: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,91 @@
+package export
+
+import (
+	"context"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
+)
+
+// Errors returned by the export service
+var (
+	ErrExportNotFound = apperr.NotFound("export not found")
+	ErrExportNotReady = apperr.NotFound("export not ready")
+)
+
+// Export job statuses
+const (
+	StatusPending    = "pending"    // Queued, waiting for the worker
+	StatusProcessing = "processing" // Archive is being assembled
+	StatusCompleted  = "completed"  // Archive is ready for download
+	StatusFailed     = "failed"     // Assembling the archive failed
+)
+
+// Job represents an asynchronous data export of an account
+type Job struct {
+	ID          int64      `json:"id" db:"id"`
+	AccountID   int64      `json:"-" db:"account_id"`
+	Status      string     `json:"status" db:"status"`
+	Error       *string    `json:"error,omitempty" db:"error"`
+	SizeBytes   *int64     `json:"size_bytes,omitempty" db:"size_bytes"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+}
+
+// AccountData is the account section of an export archive
+type AccountData struct {
+	ID               int64     `json:"id"`
+	Name             string    `json:"name"`
+	Email            string    `json:"email"`
+	AvatarURL        string    `json:"avatar_url,omitempty"`
+	Role             string    `json:"role"`
+	IsVerified       bool      `json:"is_verified"`
+	SensitiveContent string    `json:"sensitive_content"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// PostData is a post in an export archive
+type PostData struct {
+	ID          int64     `json:"id"`
+	Caption     string    `json:"caption"`
+	ImageURL    string    `json:"image_url"`
+	Visibility  string    `json:"visibility"`
+	Latitude    *float64  `json:"latitude,omitempty"`
+	Longitude   *float64  `json:"longitude,omitempty"`
+	PlaceName   *string   `json:"place_name,omitempty"`
+	IsSensitive bool      `json:"is_sensitive"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CommentData is a comment in an export archive
+type CommentData struct {
+	ID        int64     `json:"id"`
+	PostID    int64     `json:"post_id"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ExportRepository defines the interface for export data access
+type ExportRepository interface {
+	CreateJob(ctx context.Context, accountID int64) (*Job, error)
+	GetLatestJob(ctx context.Context, accountID int64) (*Job, error)
+	GetArchive(ctx context.Context, accountID int64) ([]byte, error)
+	ClaimJob(ctx context.Context, staleBefore time.Time) (*Job, error)
+	CompleteJob(ctx context.Context, id int64, archive []byte, expiresAt time.Time) error
+	FailJob(ctx context.Context, id int64, message string) error
+	PurgeExpired(ctx context.Context, before time.Time) (int64, error)
+	GetAccountData(ctx context.Context, accountID int64) (*AccountData, error)
+	GetPosts(ctx context.Context, accountID int64) ([]PostData, error)
+	GetComments(ctx context.Context, accountID int64) ([]CommentData, error)
+}
+
+// ExportService defines the interface for export business logic
+type ExportService interface {
+	RequestExport(ctx context.Context, accountID int64) (*Job, error)
+	GetLatestExport(ctx context.Context, accountID int64) (*Job, error)
+	GetArchive(ctx context.Context, accountID int64) ([]byte, error)
+}
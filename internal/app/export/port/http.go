@@ -0,0 +1,91 @@
+package port
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/export"
+	"github.com/fanzru/social-media-service-go/internal/app/export/port/genhttp"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"github.com/fanzru/social-media-service-go/pkg/response"
+)
+
+// Handler handles HTTP requests for data exports
+type Handler struct {
+	service export.ExportService
+}
+
+// NewHandler creates a new export handler
+func NewHandler(service export.ExportService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// PostApiAccountExport handles POST /api/account/export
+func (h *Handler) PostApiAccountExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, exists := middleware.GetUserID(ctx)
+	if !exists || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	job, err := h.service.RequestExport(ctx, userID)
+	if err != nil {
+		response.InternalServerError(ctx, "Failed to request export", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	response.Success(ctx, "Export requested successfully", job).Send(w, http.StatusAccepted)
+}
+
+// GetApiAccountExport handles GET /api/account/export
+func (h *Handler) GetApiAccountExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, exists := middleware.GetUserID(ctx)
+	if !exists || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	job, err := h.service.GetLatestExport(ctx, userID)
+	if err != nil {
+		response.SendError(ctx, w, err, "Failed to get export")
+		return
+	}
+
+	response.Success(ctx, "Export retrieved successfully", job).Send(w, http.StatusOK)
+}
+
+// GetApiAccountExportDownload handles GET /api/account/export/download
+func (h *Handler) GetApiAccountExportDownload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, exists := middleware.GetUserID(ctx)
+	if !exists || userID == 0 {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	archive, err := h.service.GetArchive(ctx, userID)
+	if err != nil {
+		response.SendError(ctx, w, err, "Failed to download export")
+		return
+	}
+
+	filename := fmt.Sprintf("account-%d-export-%s.zip", userID, time.Now().UTC().Format("20060102"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(archive)))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(archive)
+}
+
+// Implement the generated interface
+var _ genhttp.ServerInterface = (*Handler)(nil)
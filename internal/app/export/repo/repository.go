@@ -0,0 +1,246 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/export"
+	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
+)
+
+// jobColumns lists the data_exports columns scanned by scanJob, in order
+const jobColumns = `id, account_id, status, error, size_bytes, created_at, completed_at, expires_at`
+
+// Repository implements export repository interface
+type Repository struct {
+	db interface{} // Can be *sql.DB or *sqlwrap.DB
+}
+
+// NewRepository creates a new export repository
+func NewRepository(db interface{}) *Repository {
+	return &Repository{db: db}
+}
+
+// CreateJob queues an export for the account. sql.ErrNoRows means a job is already
+// pending or processing for the account.
+func (r *Repository) CreateJob(ctx context.Context, accountID int64) (*export.Job, error) {
+	query := `
+		INSERT INTO data_exports (account_id, status, created_at)
+		VALUES ($1, 'pending', $2)
+		ON CONFLICT (account_id) WHERE status IN ('pending', 'processing') DO NOTHING
+		RETURNING ` + jobColumns
+
+	return r.queryJob(ctx, query, accountID, time.Now())
+}
+
+// GetLatestJob retrieves the most recent export job of the account
+func (r *Repository) GetLatestJob(ctx context.Context, accountID int64) (*export.Job, error) {
+	query := `
+		SELECT ` + jobColumns + `
+		FROM data_exports
+		WHERE account_id = $1
+		ORDER BY id DESC
+		LIMIT 1`
+
+	return r.queryJob(ctx, query, accountID)
+}
+
+// GetArchive retrieves the newest completed, unexpired archive of the account
+func (r *Repository) GetArchive(ctx context.Context, accountID int64) ([]byte, error) {
+	query := `
+		SELECT archive
+		FROM data_exports
+		WHERE account_id = $1 AND status = 'completed' AND archive IS NOT NULL AND expires_at > $2
+		ORDER BY id DESC
+		LIMIT 1`
+
+	var archive []byte
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, accountID, time.Now()).Scan(&archive)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, accountID, time.Now()).Scan(&archive)
+	}
+
+	return archive, err
+}
+
+// ClaimJob marks the oldest pending job as processing and returns it. Jobs stuck in
+// processing since before staleBefore, e.g. after a crash, are claimed again.
+// sql.ErrNoRows means there is nothing to do.
+func (r *Repository) ClaimJob(ctx context.Context, staleBefore time.Time) (*export.Job, error) {
+	query := `
+		UPDATE data_exports
+		SET status = 'processing', started_at = $2
+		WHERE id = (
+			SELECT id FROM data_exports
+			WHERE status = 'pending' OR (status = 'processing' AND started_at < $1)
+			ORDER BY id
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING ` + jobColumns
+
+	return r.queryJob(ctx, query, staleBefore, time.Now())
+}
+
+// CompleteJob stores the archive of a job and marks it completed
+func (r *Repository) CompleteJob(ctx context.Context, id int64, archive []byte, expiresAt time.Time) error {
+	query := `
+		UPDATE data_exports
+		SET status = 'completed', archive = $2, size_bytes = $3, completed_at = $4, expires_at = $5, error = NULL
+		WHERE id = $1`
+
+	return r.exec(ctx, query, id, archive, int64(len(archive)), time.Now(), expiresAt)
+}
+
+// FailJob marks a job failed with the given message
+func (r *Repository) FailJob(ctx context.Context, id int64, message string) error {
+	query := `
+		UPDATE data_exports
+		SET status = 'failed', error = $2, completed_at = $3
+		WHERE id = $1`
+
+	return r.exec(ctx, query, id, message, time.Now())
+}
+
+// PurgeExpired drops the archives of exports that expired before the given time
+func (r *Repository) PurgeExpired(ctx context.Context, before time.Time) (int64, error) {
+	query := `
+		UPDATE data_exports
+		SET archive = NULL
+		WHERE archive IS NOT NULL AND expires_at <= $1`
+
+	var result sql.Result
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		result, err = db.ExecContext(ctx, query, before)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		result, err = db.ExecContext(ctx, query, before)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// GetAccountData retrieves the account section of an export
+func (r *Repository) GetAccountData(ctx context.Context, accountID int64) (*export.AccountData, error) {
+	query := `
+		SELECT id, name, email, avatar_url, role, is_verified, sensitive_content, created_at, updated_at
+		FROM accounts
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	var a export.AccountData
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, accountID).Scan(&a.ID, &a.Name, &a.Email, &a.AvatarURL, &a.Role, &a.IsVerified, &a.SensitiveContent, &a.CreatedAt, &a.UpdatedAt)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, accountID).Scan(&a.ID, &a.Name, &a.Email, &a.AvatarURL, &a.Role, &a.IsVerified, &a.SensitiveContent, &a.CreatedAt, &a.UpdatedAt)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// GetPosts retrieves all posts created by the account, oldest first
+func (r *Repository) GetPosts(ctx context.Context, accountID int64) ([]export.PostData, error) {
+	query := `
+		SELECT id, caption, image_url, visibility, latitude, longitude, place_name, is_sensitive, created_at, updated_at
+		FROM posts
+		WHERE creator_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at ASC, id ASC`
+
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, accountID)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, accountID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	posts := []export.PostData{}
+	for rows.Next() {
+		var p export.PostData
+		err := rows.Scan(&p.ID, &p.Caption, &p.ImageURL, &p.Visibility, &p.Latitude, &p.Longitude, &p.PlaceName, &p.IsSensitive, &p.CreatedAt, &p.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, p)
+	}
+
+	return posts, rows.Err()
+}
+
+// GetComments retrieves all comments written by the account, oldest first
+func (r *Repository) GetComments(ctx context.Context, accountID int64) ([]export.CommentData, error) {
+	query := `
+		SELECT id, post_id, content, created_at, updated_at
+		FROM comments
+		WHERE creator_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at ASC, id ASC`
+
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, accountID)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, accountID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []export.CommentData{}
+	for rows.Next() {
+		var c export.CommentData
+		if err := rows.Scan(&c.ID, &c.PostID, &c.Content, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+
+	return comments, rows.Err()
+}
+
+// queryJob runs a query returning a single job row
+func (r *Repository) queryJob(ctx context.Context, query string, args ...interface{}) (*export.Job, error) {
+	var row *sql.Row
+	if db, ok := r.db.(*sql.DB); ok {
+		row = db.QueryRowContext(ctx, query, args...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		row = db.QueryRowContext(ctx, query, args...)
+	}
+
+	var j export.Job
+	err := row.Scan(&j.ID, &j.AccountID, &j.Status, &j.Error, &j.SizeBytes, &j.CreatedAt, &j.CompletedAt, &j.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &j, nil
+}
+
+// exec runs a statement that returns no rows
+func (r *Repository) exec(ctx context.Context, query string, args ...interface{}) error {
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		_, err = db.ExecContext(ctx, query, args...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		_, err = db.ExecContext(ctx, query, args...)
+	}
+	return err
+}
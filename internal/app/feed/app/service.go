@@ -0,0 +1,221 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fanzru/social-media-service-go/internal/app/account"
+	accountApp "github.com/fanzru/social-media-service-go/internal/app/account/app"
+	"github.com/fanzru/social-media-service-go/internal/app/feed"
+	"github.com/fanzru/social-media-service-go/internal/app/post"
+	"github.com/fanzru/social-media-service-go/pkg/cache"
+	"github.com/fanzru/social-media-service-go/pkg/eventbus"
+)
+
+// Feed limits
+const (
+	defaultFeedSize = 20
+	maxFeedSize     = 100
+	maxTitleRunes   = 100
+)
+
+// Config holds feed settings
+type Config struct {
+	BaseURL  string        // Public base URL feeds and post links are served from
+	Title    string        // Title of the site-wide feed
+	PostLink string        // Link of a post, "{id}" is replaced with the post ID
+	Size     int           // How many recent posts a feed lists
+	CacheTTL time.Duration // How long a built feed is reused; 0 disables caching
+}
+
+// Cache keys of built feeds
+const (
+	postsFeedKey      = "feed:posts"
+	userFeedKeyPrefix = "feed:user:"
+)
+
+// Service builds public feeds of recent posts using the post and account services. Feeds
+// are read anonymously, so they only hold posts everyone may see.
+type Service struct {
+	posts    post.PostService
+	accounts accountApp.Service
+	cache    cache.Store
+	config   Config
+}
+
+// NewService creates a new feed service; store may be nil to build every feed on request
+func NewService(posts post.PostService, accounts accountApp.Service, store cache.Store, config Config) *Service {
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	if config.Size <= 0 || config.Size > maxFeedSize {
+		config.Size = defaultFeedSize
+	}
+	return &Service{
+		posts:    posts,
+		accounts: accounts,
+		cache:    store,
+		config:   config,
+	}
+}
+
+// GetPostsFeed returns the newest public posts of every account
+func (s *Service) GetPostsFeed(ctx context.Context) (*feed.Feed, error) {
+	return s.cached(ctx, postsFeedKey, s.buildPostsFeed)
+}
+
+// buildPostsFeed builds the feed of the newest public posts of every account
+func (s *Service) buildPostsFeed(ctx context.Context) (*feed.Feed, error) {
+	posts, err := s.posts.GetPosts(ctx, 0, post.SortNewest, post.ListFilter{}, "", s.config.Size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts: %w", err)
+	}
+
+	return s.buildFeed(s.config.Title, "Recent public posts on "+s.config.Title, s.config.BaseURL, posts.Posts), nil
+}
+
+// GetUserFeed returns the newest public posts of an account. Private and deactivated
+// accounts have no feed.
+func (s *Service) GetUserFeed(ctx context.Context, accountID int64) (*feed.Feed, error) {
+	return s.cached(ctx, userFeedKey(accountID), func(ctx context.Context) (*feed.Feed, error) {
+		return s.buildUserFeed(ctx, accountID)
+	})
+}
+
+// buildUserFeed builds the feed of the newest public posts of an account
+func (s *Service) buildUserFeed(ctx context.Context, accountID int64) (*feed.Feed, error) {
+	acc, err := s.accounts.GetAccountByID(ctx, accountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, feed.ErrFeedNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	if acc.IsPrivate || acc.Status == account.StatusDeactivated {
+		return nil, feed.ErrFeedNotFound
+	}
+
+	posts, err := s.posts.GetPosts(ctx, 0, post.SortNewest, post.ListFilter{CreatorID: &accountID}, "", s.config.Size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user posts: %w", err)
+	}
+
+	title := acc.Name + " on " + s.config.Title
+	link := s.config.BaseURL + "/api/posts/by-user/" + strconv.FormatInt(accountID, 10)
+	return s.buildFeed(title, "Recent public posts by "+acc.Name, link, posts.Posts), nil
+}
+
+// cached returns the feed stored under key, or builds and stores it. Missing feeds are not
+// stored, so a feed appears as soon as its account becomes public.
+func (s *Service) cached(ctx context.Context, key string, build func(ctx context.Context) (*feed.Feed, error)) (*feed.Feed, error) {
+	if s.cache == nil || s.config.CacheTTL <= 0 {
+		return build(ctx)
+	}
+	if f, ok := s.cache.Get(ctx, key); ok {
+		return f.(*feed.Feed), nil
+	}
+
+	f, err := build(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Set(ctx, key, f, s.config.CacheTTL)
+	return f, nil
+}
+
+// CacheKeys returns the keys of the feeds a change makes stale. Post changes affect the
+// site-wide feed and the creator's feed, or every account's feed when the creator is not
+// known; account changes also show in both, through the author name and whether the
+// account has a public feed. Comments are not part of feeds.
+func CacheKeys(e eventbus.Event) []string {
+	switch e.Type {
+	case eventbus.PostCreated, eventbus.PostUpdated, eventbus.PostDeleted,
+		eventbus.AccountUpdated, eventbus.AccountDeleted:
+		if e.AccountID == 0 {
+			return []string{postsFeedKey, userFeedKeyPrefix + "*"}
+		}
+		return []string{postsFeedKey, userFeedKey(e.AccountID)}
+	}
+	return nil
+}
+
+// userFeedKey returns the cache key of an account's feed
+func userFeedKey(accountID int64) string {
+	return userFeedKeyPrefix + strconv.FormatInt(accountID, 10)
+}
+
+// FeedURL returns the absolute URL a feed is served at
+func (s *Service) FeedURL(path string, format string) string {
+	if format == feed.FormatAtom {
+		return s.config.BaseURL + path + "?format=" + feed.FormatAtom
+	}
+	return s.config.BaseURL + path
+}
+
+// buildFeed turns posts, newest first, into a feed
+func (s *Service) buildFeed(title string, description string, link string, posts []post.Post) *feed.Feed {
+	f := &feed.Feed{
+		Title:       title,
+		Description: description,
+		Link:        link,
+		Items:       make([]feed.Item, 0, len(posts)),
+	}
+
+	for _, p := range posts {
+		// Anonymous readers only get public posts from the post list already; feeds are
+		// cached by third parties, so make sure nothing else slips in
+		if p.Visibility != post.VisibilityPublic {
+			continue
+		}
+
+		item := feed.Item{
+			Title:       itemTitle(p),
+			Link:        s.postLink(p.ID),
+			Description: p.Caption,
+			Author:      p.CreatorName,
+			Published:   p.CreatedAt,
+			Updated:     p.UpdatedAt,
+		}
+		if !p.Blurred {
+			item.ImageURL = p.ImageURL
+		}
+		if item.Updated.Before(item.Published) {
+			item.Updated = item.Published
+		}
+		if item.Updated.After(f.Updated) {
+			f.Updated = item.Updated
+		}
+		f.Items = append(f.Items, item)
+	}
+
+	if f.Updated.IsZero() {
+		f.Updated = time.Now().UTC()
+	}
+	return f
+}
+
+// postLink returns the absolute link of a post
+func (s *Service) postLink(id int64) string {
+	link := strings.ReplaceAll(s.config.PostLink, "{id}", strconv.FormatInt(id, 10))
+	if strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://") {
+		return link
+	}
+	return s.config.BaseURL + "/" + strings.TrimLeft(link, "/")
+}
+
+// itemTitle uses the first line of the caption, shortened to maxTitleRunes
+func itemTitle(p post.Post) string {
+	title, _, _ := strings.Cut(strings.TrimSpace(p.Caption), "\n")
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "Post by " + p.CreatorName
+	}
+	if utf8.RuneCountInString(title) > maxTitleRunes {
+		title = string([]rune(title)[:maxTitleRunes-1]) + "…"
+	}
+	return title
+}
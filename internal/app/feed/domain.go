@@ -0,0 +1,45 @@
+package feed
+
+import (
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
+)
+
+// ErrFeedNotFound is returned for accounts that do not exist or have no public feed
+var ErrFeedNotFound = apperr.NotFound("feed not found")
+
+// Feed formats
+const (
+	FormatRSS  = "rss"  // RSS 2.0 (default)
+	FormatAtom = "atom" // Atom 1.0
+)
+
+// IsValidFormat reports whether f is a supported feed format
+func IsValidFormat(f string) bool {
+	switch f {
+	case FormatRSS, FormatAtom:
+		return true
+	}
+	return false
+}
+
+// Feed is a list of recent public posts, independent of the format it is rendered in
+type Feed struct {
+	Title       string
+	Description string
+	Link        string    // Page the feed is about
+	Updated     time.Time // When the newest item was last changed
+	Items       []Item
+}
+
+// Item is one post of a feed
+type Item struct {
+	Title       string
+	Link        string
+	Description string
+	Author      string
+	Published   time.Time
+	Updated     time.Time
+	ImageURL    string // Empty for posts without an image or whose image is blurred
+}
@@ -0,0 +1,251 @@
+package port
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/feed"
+	"github.com/fanzru/social-media-service-go/internal/app/feed/app"
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/response"
+)
+
+// cacheControl lets feed readers and proxies reuse a feed for a few minutes
+const cacheControl = "public, max-age=300"
+
+// Handler handles HTTP requests for the public RSS/Atom feeds
+type Handler struct {
+	service *app.Service
+	logger  *logger.Logger
+}
+
+// NewHandler creates a new feed handler
+func NewHandler(service *app.Service) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger.GetGlobal(),
+	}
+}
+
+// ServePosts handles GET /feeds/posts.xml, the newest public posts of every account
+func (h *Handler) ServePosts(w http.ResponseWriter, r *http.Request) {
+	format, ok := parseFormat(w, r)
+	if !ok {
+		return
+	}
+
+	f, err := h.service.GetPostsFeed(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to build posts feed", "error", err.Error())
+		response.InternalServerError(r.Context(), "Failed to get feed", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	h.writeFeed(w, r, format, f)
+}
+
+// ServeUser handles GET /feeds/users/{id}.xml, the newest public posts of an account. The
+// route captures the whole "{id}.xml" segment since patterns cannot hold a suffix.
+func (h *Handler) ServeUser(w http.ResponseWriter, r *http.Request) {
+	file := r.PathValue("file")
+	id, err := strconv.ParseInt(strings.TrimSuffix(file, ".xml"), 10, 64)
+	if !strings.HasSuffix(file, ".xml") || err != nil || id <= 0 {
+		response.NotFound(r.Context(), "Feed not found", []string{"feeds are served at /feeds/users/{id}.xml"}).Send(w, http.StatusNotFound)
+		return
+	}
+
+	format, ok := parseFormat(w, r)
+	if !ok {
+		return
+	}
+
+	f, err := h.service.GetUserFeed(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, feed.ErrFeedNotFound) {
+			response.SendError(r.Context(), w, err, "Failed to get feed")
+			return
+		}
+		h.logger.Error("Failed to build user feed", "user_id", id, "error", err.Error())
+		response.InternalServerError(r.Context(), "Failed to get feed", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	h.writeFeed(w, r, format, f)
+}
+
+// parseFormat reads the format parameter; RSS is the default
+func parseFormat(w http.ResponseWriter, r *http.Request) (string, bool) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		return feed.FormatRSS, true
+	}
+	if !feed.IsValidFormat(format) {
+		response.BadRequest(r.Context(), "Invalid format", []string{"format must be rss or atom"}).Send(w, http.StatusBadRequest)
+		return "", false
+	}
+	return format, true
+}
+
+// writeFeed renders the feed in the format, linking back to the URL it was requested at
+func (h *Handler) writeFeed(w http.ResponseWriter, r *http.Request, format string, f *feed.Feed) {
+	self := h.service.FeedURL(r.URL.Path, format)
+
+	var doc interface{}
+	contentType := "application/rss+xml; charset=utf-8"
+	if format == feed.FormatAtom {
+		doc = toAtom(f, self)
+		contentType = "application/atom+xml; charset=utf-8"
+	} else {
+		doc = toRSS(f, self)
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		h.logger.Error("Failed to encode feed", "error", err.Error())
+		response.InternalServerError(r.Context(), "Failed to encode feed", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("Last-Modified", f.Updated.UTC().Format(http.TimeFormat))
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	w.Write(body)
+}
+
+// rss is an RSS 2.0 document
+type rss struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	XMLNSAtom string     `xml:"xmlns:atom,attr"`
+	XMLNSDC   string     `xml:"xmlns:dc,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        string        `xml:"guid"`
+	Description string        `xml:"description"`
+	Author      string        `xml:"dc:creator,omitempty"`
+	PubDate     string        `xml:"pubDate"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"` // Unknown, which RSS readers accept as 0
+	Type   string `xml:"type,attr"`
+}
+
+// toRSS converts a feed to RSS 2.0. Authors go in dc:creator because the RSS author
+// element must be an email address.
+func toRSS(f *feed.Feed, self string) *rss {
+	doc := &rss{
+		Version:   "2.0",
+		XMLNSAtom: "http://www.w3.org/2005/Atom",
+		XMLNSDC:   "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Description:   f.Description,
+			LastBuildDate: f.Updated.UTC().Format(time.RFC1123Z),
+			AtomLink:      atomLink{Href: self, Rel: "self", Type: "application/rss+xml"},
+		},
+	}
+
+	for _, item := range f.Items {
+		ri := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			GUID:        item.Link,
+			Description: item.Description,
+			Author:      item.Author,
+			PubDate:     item.Published.UTC().Format(time.RFC1123Z),
+		}
+		if item.ImageURL != "" {
+			ri.Enclosure = &rssEnclosure{URL: item.ImageURL, Type: "image/jpeg"}
+		}
+		doc.Channel.Items = append(doc.Channel.Items, ri)
+	}
+	return doc
+}
+
+// atom is an Atom 1.0 document
+type atom struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Links     []atomLink  `xml:"link"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Author    atomAuthor  `xml:"author"`
+	Content   atomContent `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// toAtom converts a feed to Atom 1.0; images are linked as enclosures
+func toAtom(f *feed.Feed, self string) *atom {
+	doc := &atom{
+		ID:      self,
+		Title:   f.Title,
+		Updated: f.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: self, Rel: "self", Type: "application/atom+xml"},
+			{Href: f.Link, Rel: "alternate"},
+		},
+	}
+
+	for _, item := range f.Items {
+		entry := atomEntry{
+			ID:        item.Link,
+			Title:     item.Title,
+			Links:     []atomLink{{Href: item.Link, Rel: "alternate"}},
+			Published: item.Published.UTC().Format(time.RFC3339),
+			Updated:   item.Updated.UTC().Format(time.RFC3339),
+			Author:    atomAuthor{Name: item.Author},
+			Content:   atomContent{Type: "text", Body: item.Description},
+		}
+		if item.ImageURL != "" {
+			entry.Links = append(entry.Links, atomLink{Href: item.ImageURL, Rel: "enclosure", Type: "image/jpeg"})
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+	return doc
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/account"
+	"github.com/fanzru/social-media-service-go/internal/app/comment"
+	"github.com/fanzru/social-media-service-go/pkg/dataloader"
+)
+
+// How long a loader waits for more keys, and the most keys it fetches in one query
+const (
+	loaderWait     = 2 * time.Millisecond
+	loaderMaxBatch = 100
+)
+
+// commentsKey selects the newest comments of a post
+type commentsKey struct {
+	postID int64
+	limit  int
+}
+
+// loaders batch the nested lookups of one request
+type loaders struct {
+	accounts *dataloader.Loader[int64, *account.Account]
+	comments *dataloader.Loader[commentsKey, []comment.Comment]
+}
+
+type loadersKey struct{}
+
+// newLoaders creates the loaders for one request; they cache results, so they must not be shared
+func (s *Service) newLoaders() *loaders {
+	return &loaders{
+		accounts: dataloader.New(s.accounts.GetAccountsByIDs, loaderWait, loaderMaxBatch),
+		comments: dataloader.New(s.loadComments, loaderWait, loaderMaxBatch),
+	}
+}
+
+// loadComments fetches the newest comments of many posts, one query per distinct limit
+func (s *Service) loadComments(ctx context.Context, keys []commentsKey) (map[commentsKey][]comment.Comment, error) {
+	postIDsByLimit := map[int][]int64{}
+	for _, key := range keys {
+		postIDsByLimit[key.limit] = append(postIDsByLimit[key.limit], key.postID)
+	}
+
+	result := make(map[commentsKey][]comment.Comment, len(keys))
+	for limit, postIDs := range postIDsByLimit {
+		comments, err := s.comments.GetLatestCommentsForPosts(ctx, postIDs, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, postID := range postIDs {
+			result[commentsKey{postID: postID, limit: limit}] = comments[postID]
+		}
+	}
+	return result, nil
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,317 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/fanzru/social-media-service-go/internal/app/account"
+	accountApp "github.com/fanzru/social-media-service-go/internal/app/account/app"
+	"github.com/fanzru/social-media-service-go/internal/app/comment"
+	"github.com/fanzru/social-media-service-go/internal/app/post"
+	"github.com/fanzru/social-media-service-go/pkg/graphql"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+)
+
+// Query limits
+const (
+	defaultPageSize     = 20
+	maxPageSize         = 100
+	defaultCommentLimit = 20
+	maxCommentLimit     = 100
+	maxQueryDepth       = 10
+)
+
+// Service answers GraphQL queries over accounts, posts and comments using their services
+type Service struct {
+	accounts accountApp.Service
+	posts    post.PostService
+	comments comment.CommentService
+	schema   *graphql.Schema
+}
+
+// NewService creates a new GraphQL service
+func NewService(accounts accountApp.Service, posts post.PostService, comments comment.CommentService) *Service {
+	s := &Service{
+		accounts: accounts,
+		posts:    posts,
+		comments: comments,
+	}
+	s.schema = s.buildSchema()
+	return s
+}
+
+// Execute runs a query as the account in ctx, if any
+func (s *Service) Execute(ctx context.Context, req graphql.Request) *graphql.Response {
+	return s.schema.Execute(withLoaders(ctx, s.newLoaders()), req)
+}
+
+// SDL returns the schema in the GraphQL schema definition language
+func (s *Service) SDL() string {
+	return s.schema.SDL()
+}
+
+// buildSchema defines the types of the graph and how each field is resolved
+func (s *Service) buildSchema() *graphql.Schema {
+	timeScalar := &graphql.Scalar{Name: "Time", Description: "An RFC 3339 timestamp"}
+	visibility := &graphql.Enum{Name: "Visibility", Values: []string{post.VisibilityPublic, post.VisibilityFollowers, post.VisibilityPrivate}}
+
+	accountType := &graphql.Object{Name: "Account", Description: "A member of the network"}
+	postType := &graphql.Object{Name: "Post", Description: "A post with an image and caption"}
+	commentType := &graphql.Object{Name: "Comment", Description: "A comment on a post"}
+	connectionType := &graphql.Object{Name: "PostConnection", Description: "A page of posts"}
+
+	pageArgs := func() graphql.Args {
+		return graphql.Args{
+			"cursor": {Type: graphql.String, Description: "Cursor returned by the previous page"},
+			"limit":  {Type: graphql.Int, DefaultValue: int64(defaultPageSize), Description: fmt.Sprintf("Page size, at most %d", maxPageSize)},
+		}
+	}
+
+	accountType.Fields = graphql.Fields{
+		"id":         {Type: graphql.ID, Resolve: accountField(func(a *account.Account) interface{} { return strconv.FormatInt(a.ID, 10) })},
+		"name":       {Type: graphql.String, Resolve: accountField(func(a *account.Account) interface{} { return a.Name })},
+		"avatarUrl":  {Type: graphql.String, Resolve: accountField(func(a *account.Account) interface{} { return a.AvatarURL })},
+		"isVerified": {Type: graphql.Boolean, Resolve: accountField(func(a *account.Account) interface{} { return a.IsVerified })},
+		"isPrivate":  {Type: graphql.Boolean, Resolve: accountField(func(a *account.Account) interface{} { return a.IsPrivate })},
+		"createdAt":  {Type: timeScalar, Resolve: accountField(func(a *account.Account) interface{} { return a.CreatedAt })},
+		"email": {
+			Type:        graphql.String,
+			Description: "Only visible on your own account",
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+				acc := p.Source.(*account.Account)
+				if viewerID, ok := middleware.GetUserID(ctx); !ok || viewerID != acc.ID {
+					return nil, nil
+				}
+				return acc.Email, nil
+			},
+		},
+		"posts": {
+			Type:        connectionType,
+			Description: "Posts of the account you are allowed to see, newest first",
+			Args:        pageArgs(),
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+				limit, err := pageSize(p)
+				if err != nil {
+					return nil, err
+				}
+				viewerID, _ := middleware.GetUserID(ctx)
+				posts, err := s.posts.GetPostsByCreatorID(ctx, p.Source.(*account.Account).ID, viewerID, p.String("cursor"), limit)
+				if err != nil {
+					return nil, err
+				}
+				return posts, nil
+			},
+		},
+	}
+
+	postType.Fields = graphql.Fields{
+		"id":           {Type: graphql.ID, Resolve: postField(func(p *post.Post) interface{} { return strconv.FormatInt(p.ID, 10) })},
+		"caption":      {Type: graphql.String, Resolve: postField(func(p *post.Post) interface{} { return p.Caption })},
+		"imageUrl":     {Type: graphql.String, Resolve: postField(func(p *post.Post) interface{} { return p.ImageURL })},
+		"visibility":   {Type: visibility, Resolve: postField(func(p *post.Post) interface{} { return p.Visibility })},
+		"isSensitive":  {Type: graphql.Boolean, Resolve: postField(func(p *post.Post) interface{} { return p.IsSensitive })},
+		"blurred":      {Type: graphql.Boolean, Description: "Whether clients should blur the image for you", Resolve: postField(func(p *post.Post) interface{} { return p.Blurred })},
+		"latitude":     {Type: graphql.Float, Resolve: postField(func(p *post.Post) interface{} { return p.Latitude })},
+		"longitude":    {Type: graphql.Float, Resolve: postField(func(p *post.Post) interface{} { return p.Longitude })},
+		"placeName":    {Type: graphql.String, Resolve: postField(func(p *post.Post) interface{} { return p.PlaceName })},
+		"isPinned":     {Type: graphql.Boolean, Resolve: postField(func(p *post.Post) interface{} { return p.IsPinned })},
+		"commentCount": {Type: graphql.Int, Resolve: postField(func(p *post.Post) interface{} { return p.CommentCount })},
+		"createdAt":    {Type: timeScalar, Resolve: postField(func(p *post.Post) interface{} { return p.CreatedAt })},
+		"updatedAt":    {Type: timeScalar, Resolve: postField(func(p *post.Post) interface{} { return p.UpdatedAt })},
+		"author": {
+			Type:        accountType,
+			Description: "Null when the author's account was deactivated",
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+				return loadAccount(ctx, p.Source.(*post.Post).CreatorID)
+			},
+		},
+		"comments": {
+			Type:        graphql.NewList(commentType),
+			Description: "Newest comments first",
+			Args: graphql.Args{
+				"limit": {Type: graphql.Int, DefaultValue: int64(defaultCommentLimit), Description: fmt.Sprintf("At most %d", maxCommentLimit)},
+			},
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+				limit := p.Int64("limit")
+				if limit < 1 || limit > maxCommentLimit {
+					return nil, fmt.Errorf("limit must be between 1 and %d", maxCommentLimit)
+				}
+				key := commentsKey{postID: p.Source.(*post.Post).ID, limit: int(limit)}
+				comments, err := loadersFrom(ctx).comments.Load(ctx, key)
+				if err != nil {
+					return nil, err
+				}
+				return commentPointers(comments), nil
+			},
+		},
+	}
+
+	commentType.Fields = graphql.Fields{
+		"id":        {Type: graphql.ID, Resolve: commentField(func(c *comment.Comment) interface{} { return strconv.FormatInt(c.ID, 10) })},
+		"content":   {Type: graphql.String, Resolve: commentField(func(c *comment.Comment) interface{} { return c.Content })},
+		"likeCount": {Type: graphql.Int, Resolve: commentField(func(c *comment.Comment) interface{} { return c.LikeCount })},
+		"createdAt": {Type: timeScalar, Resolve: commentField(func(c *comment.Comment) interface{} { return c.CreatedAt })},
+		"updatedAt": {Type: timeScalar, Resolve: commentField(func(c *comment.Comment) interface{} { return c.UpdatedAt })},
+		"author": {
+			Type:        accountType,
+			Description: "Null when the author's account was deactivated",
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+				return loadAccount(ctx, p.Source.(*comment.Comment).CreatorID)
+			},
+		},
+	}
+
+	connectionType.Fields = graphql.Fields{
+		"items": {
+			Type: graphql.NewList(postType),
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+				return postPointers(p.Source.(*post.PostListResponse).Posts), nil
+			},
+		},
+		"cursor": {
+			Type:        graphql.String,
+			Description: "Pass to the next query to get the following page",
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*post.PostListResponse).Cursor, nil
+			},
+		},
+		"hasMore": {
+			Type: graphql.Boolean,
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*post.PostListResponse).HasMore, nil
+			},
+		},
+	}
+
+	queryType := &graphql.Object{Name: "Query", Fields: graphql.Fields{
+		"me": {
+			Type:        accountType,
+			Description: "The signed-in account, or null for anonymous requests",
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+				viewerID, ok := middleware.GetUserID(ctx)
+				if !ok {
+					return nil, nil
+				}
+				return loadAccount(ctx, viewerID)
+			},
+		},
+		"account": {
+			Type:        accountType,
+			Description: "An account by ID, or null when it does not exist or was deactivated",
+			Args:        graphql.Args{"id": {Type: graphql.ID, Required: true}},
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+				id, err := parseID(p.String("id"))
+				if err != nil {
+					return nil, err
+				}
+				return loadAccount(ctx, id)
+			},
+		},
+		"post": {
+			Type:        postType,
+			Description: "A post by ID, or null when it does not exist or you may not see it",
+			Args:        graphql.Args{"id": {Type: graphql.ID, Required: true}},
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+				id, err := parseID(p.String("id"))
+				if err != nil {
+					return nil, err
+				}
+				viewerID, _ := middleware.GetUserID(ctx)
+				fetched, err := s.posts.GetPost(ctx, id, viewerID)
+				if errors.Is(err, post.ErrPostNotFound) {
+					return nil, nil
+				}
+				if err != nil {
+					return nil, err
+				}
+				return fetched, nil
+			},
+		},
+		"feed": {
+			Type:        connectionType,
+			Description: "All posts you are allowed to see, newest first",
+			Args:        pageArgs(),
+			Resolve: func(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+				limit, err := pageSize(p)
+				if err != nil {
+					return nil, err
+				}
+				viewerID, _ := middleware.GetUserID(ctx)
+				posts, err := s.posts.GetAllPosts(ctx, viewerID, p.String("cursor"), limit)
+				if err != nil {
+					return nil, err
+				}
+				return posts, nil
+			},
+		},
+	}}
+
+	return &graphql.Schema{Query: queryType, MaxDepth: maxQueryDepth}
+}
+
+// loadAccount batches the lookup with the other accounts of the request; deactivated accounts are hidden
+func loadAccount(ctx context.Context, id int64) (interface{}, error) {
+	acc, err := loadersFrom(ctx).accounts.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if acc == nil || acc.Status == account.StatusDeactivated {
+		return nil, nil
+	}
+	return acc, nil
+}
+
+// pageSize validates the limit argument of a paginated field
+func pageSize(p graphql.ResolveParams) (int, error) {
+	limit := p.Int64("limit")
+	if limit < 1 || limit > maxPageSize {
+		return 0, fmt.Errorf("limit must be between 1 and %d", maxPageSize)
+	}
+	return int(limit), nil
+}
+
+func parseID(id string) (int64, error) {
+	parsed, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id %q", id)
+	}
+	return parsed, nil
+}
+
+func accountField(get func(*account.Account) interface{}) graphql.ResolveFunc {
+	return func(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(*account.Account)), nil
+	}
+}
+
+func postField(get func(*post.Post) interface{}) graphql.ResolveFunc {
+	return func(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(*post.Post)), nil
+	}
+}
+
+func commentField(get func(*comment.Comment) interface{}) graphql.ResolveFunc {
+	return func(ctx context.Context, p graphql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(*comment.Comment)), nil
+	}
+}
+
+// postPointers lets list items be passed to the Post resolvers by pointer
+func postPointers(posts []post.Post) []*post.Post {
+	pointers := make([]*post.Post, len(posts))
+	for i := range posts {
+		pointers[i] = &posts[i]
+	}
+	return pointers
+}
+
+// commentPointers lets list items be passed to the Comment resolvers by pointer
+func commentPointers(comments []comment.Comment) []*comment.Comment {
+	pointers := make([]*comment.Comment, len(comments))
+	for i := range comments {
+		pointers[i] = &comments[i]
+	}
+	return pointers
+}
@@ -0,0 +1,85 @@
+package port
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fanzru/social-media-service-go/internal/app/graphql/app"
+	"github.com/fanzru/social-media-service-go/pkg/graphql"
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+)
+
+// maxQueryBytes caps the size of a POSTed request body
+const maxQueryBytes = 1 << 20
+
+// Handler handles HTTP requests for the GraphQL endpoint
+type Handler struct {
+	service *app.Service
+	logger  *logger.Logger
+}
+
+// NewHandler creates a new GraphQL handler
+func NewHandler(service *app.Service) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger.GetGlobal(),
+	}
+}
+
+// ServeHTTP handles GET and POST /graphql. GET takes the query, operationName and JSON
+// encoded variables as URL parameters; POST takes them as a JSON body.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req graphql.Request
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				writeResult(w, http.StatusBadRequest, errorResponse("variables must be a JSON object"))
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxQueryBytes)).Decode(&req); err != nil {
+			writeResult(w, http.StatusBadRequest, errorResponse("request body must be a JSON object with a query"))
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeResult(w, http.StatusMethodNotAllowed, errorResponse("only GET and POST are supported"))
+		return
+	}
+
+	if req.Query == "" {
+		writeResult(w, http.StatusBadRequest, errorResponse("query is required"))
+		return
+	}
+
+	result := h.service.Execute(r.Context(), req)
+
+	// Requests that failed before execution have no data
+	status := http.StatusOK
+	if result.Data == nil {
+		status = http.StatusBadRequest
+	}
+	writeResult(w, status, result)
+}
+
+// ServeSchema handles GET /graphql/schema, describing the schema in SDL since introspection
+// queries are not supported
+func (h *Handler) ServeSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte(h.service.SDL()))
+}
+
+func errorResponse(message string) *graphql.Response {
+	return &graphql.Response{Errors: []*graphql.Error{{Message: message}}}
+}
+
+func writeResult(w http.ResponseWriter, status int, result *graphql.Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(result)
+}
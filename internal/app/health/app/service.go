@@ -2,32 +2,36 @@ package app
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/fanzru/social-media-service-go/internal/app/health"
+	"github.com/fanzru/social-media-service-go/pkg/buildinfo"
 )
 
 // Service implements health service interface
 type Service struct {
-	repo health.HealthRepository
+	repo      health.HealthRepository
+	startedAt time.Time // Stands in for the process start, as the service is created at startup
 }
 
 // NewService creates a new health service
 func NewService(repo health.HealthRepository) *Service {
 	return &Service{
-		repo: repo,
+		repo:      repo,
+		startedAt: time.Now(),
 	}
 }
 
 // GetHealth returns the overall health status
 func (s *Service) GetHealth(ctx context.Context) health.HealthResponse {
-	startTime := time.Now()
-
 	// Perform health checks
 	checks := []health.HealthCheck{
 		s.CheckDatabase(ctx),
 		s.CheckRedis(ctx),
 		s.CheckExternalAPI(ctx),
+		s.CheckObjectStorage(ctx),
+		s.CheckMetrics(ctx),
 	}
 
 	// Determine overall status
@@ -44,13 +48,15 @@ func (s *Service) GetHealth(ctx context.Context) health.HealthResponse {
 	return health.HealthResponse{
 		Status:    overallStatus,
 		Timestamp: time.Now(),
-		Version:   "1.0.0",
-		Uptime:    time.Since(startTime),
+		Version:   buildinfo.Version,
+		StartedAt: s.startedAt,
+		Uptime:    time.Since(s.startedAt).Round(time.Second).String(),
 		Checks:    checks,
 	}
 }
 
-// CheckDatabase checks database connectivity
+// CheckDatabase checks database connectivity and reports the connection pool statistics.
+// A pool with every connection in use is degraded, as further queries wait for one.
 func (s *Service) CheckDatabase(ctx context.Context) health.HealthCheck {
 	start := time.Now()
 	err := s.repo.PingDatabase(ctx)
@@ -62,10 +68,19 @@ func (s *Service) CheckDatabase(ctx context.Context) health.HealthCheck {
 		Duration:  duration,
 	}
 
-	if err != nil {
+	stats, statsErr := s.repo.DatabasePoolStats()
+	if statsErr == nil {
+		check.Details = stats
+	}
+
+	switch {
+	case err != nil:
 		check.Status = health.StatusUnhealthy
 		check.Message = err.Error()
-	} else {
+	case statsErr == nil && stats.Exhausted():
+		check.Status = health.StatusDegraded
+		check.Message = "Database connection pool is exhausted, queries are waiting for a connection"
+	default:
 		check.Status = health.StatusHealthy
 		check.Message = "Database connection is healthy"
 	}
@@ -118,3 +133,54 @@ func (s *Service) CheckExternalAPI(ctx context.Context) health.HealthCheck {
 
 	return check
 }
+
+// CheckObjectStorage checks the image bucket is reachable with the configured credentials
+func (s *Service) CheckObjectStorage(ctx context.Context) health.HealthCheck {
+	start := time.Now()
+	err := s.repo.PingObjectStorage(ctx)
+	duration := time.Since(start)
+
+	check := health.HealthCheck{
+		Service:   "object-storage",
+		Timestamp: time.Now(),
+		Duration:  duration,
+	}
+
+	if err != nil {
+		check.Status = health.StatusUnhealthy
+		check.Message = err.Error()
+	} else {
+		check.Status = health.StatusHealthy
+		check.Message = "Object storage is accessible"
+	}
+
+	return check
+}
+
+// CheckMetrics checks the InfluxDB connection metrics are written to. Metrics are not
+// needed to serve requests, so an outage degrades the service rather than failing it.
+func (s *Service) CheckMetrics(ctx context.Context) health.HealthCheck {
+	start := time.Now()
+	err := s.repo.PingMetrics(ctx)
+	duration := time.Since(start)
+
+	check := health.HealthCheck{
+		Service:   "metrics",
+		Timestamp: time.Now(),
+		Duration:  duration,
+	}
+
+	switch {
+	case errors.Is(err, health.ErrNotConfigured):
+		check.Status = health.StatusHealthy
+		check.Message = "InfluxDB metrics are disabled"
+	case err != nil:
+		check.Status = health.StatusDegraded
+		check.Message = err.Error()
+	default:
+		check.Status = health.StatusHealthy
+		check.Message = "InfluxDB connection is healthy"
+	}
+
+	return check
+}
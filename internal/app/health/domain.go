@@ -2,9 +2,13 @@ package health
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrNotConfigured is returned by a ping of an optional dependency that is turned off
+var ErrNotConfigured = errors.New("not configured")
+
 // HealthStatus represents the health status of the application
 type HealthStatus string
 
@@ -21,6 +25,23 @@ type HealthCheck struct {
 	Message   string        `json:"message,omitempty"`
 	Timestamp time.Time     `json:"timestamp"`
 	Duration  time.Duration `json:"duration"`
+	Details   interface{}   `json:"details,omitempty"` // Check-specific data, e.g. PoolStats for the database
+}
+
+// PoolStats describes the database connection pool
+type PoolStats struct {
+	MaxOpen        int     `json:"max_open"` // 0 is unlimited
+	Open           int     `json:"open"`
+	InUse          int     `json:"in_use"`
+	Idle           int     `json:"idle"`
+	WaitCount      int64   `json:"wait_count"`       // Queries that waited for a free connection since startup
+	WaitDurationMs float64 `json:"wait_duration_ms"` // Time they waited in total
+}
+
+// Exhausted reports whether every connection the pool may open is in use, so further
+// queries wait
+func (s PoolStats) Exhausted() bool {
+	return s.MaxOpen > 0 && s.InUse >= s.MaxOpen
 }
 
 // HealthResponse represents the overall health response
@@ -28,7 +49,8 @@ type HealthResponse struct {
 	Status    HealthStatus  `json:"status"`
 	Timestamp time.Time     `json:"timestamp"`
 	Version   string        `json:"version"`
-	Uptime    time.Duration `json:"uptime"`
+	StartedAt time.Time     `json:"started_at"` // When the process started
+	Uptime    string        `json:"uptime"`     // Time since StartedAt, e.g. 1h30m45s
 	Checks    []HealthCheck `json:"checks"`
 }
 
@@ -38,11 +60,16 @@ type HealthService interface {
 	CheckDatabase(ctx context.Context) HealthCheck
 	CheckRedis(ctx context.Context) HealthCheck
 	CheckExternalAPI(ctx context.Context) HealthCheck
+	CheckObjectStorage(ctx context.Context) HealthCheck
+	CheckMetrics(ctx context.Context) HealthCheck
 }
 
 // HealthRepository defines the interface for health data operations
 type HealthRepository interface {
 	PingDatabase(ctx context.Context) error
+	DatabasePoolStats() (PoolStats, error)
 	PingRedis(ctx context.Context) error
 	PingExternalAPI(ctx context.Context) error
+	PingObjectStorage(ctx context.Context) error
+	PingMetrics(ctx context.Context) error
 }
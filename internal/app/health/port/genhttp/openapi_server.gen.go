@@ -21,6 +21,9 @@ type ServerInterface interface {
 	// Readiness probe
 	// (GET /health/ready)
 	GetHealthReady(w http.ResponseWriter, r *http.Request)
+	// Build information
+	// (GET /health/version)
+	GetHealthVersion(w http.ResponseWriter, r *http.Request)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -74,6 +77,20 @@ func (siw *ServerInterfaceWrapper) GetHealthReady(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
+// GetHealthVersion operation middleware
+func (siw *ServerInterfaceWrapper) GetHealthVersion(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealthVersion(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -197,6 +214,7 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 	m.HandleFunc("GET "+options.BaseURL+"/health", wrapper.GetHealth)
 	m.HandleFunc("GET "+options.BaseURL+"/health/live", wrapper.GetHealthLive)
 	m.HandleFunc("GET "+options.BaseURL+"/health/ready", wrapper.GetHealthReady)
+	m.HandleFunc("GET "+options.BaseURL+"/health/version", wrapper.GetHealthVersion)
 
 	return m
 }
@@ -27,6 +27,9 @@ const (
 
 // HealthCheck defines model for HealthCheck.
 type HealthCheck struct {
+	// Details Check-specific data. The database check reports its connection pool: max_open (0 is unlimited), open, in_use and idle connections, and wait_count and wait_duration_ms of queries that waited for a free connection since startup. A pool with every connection in use is degraded.
+	Details *map[string]interface{} `json:"details,omitempty"`
+
 	// Duration How long the check took
 	Duration string `json:"duration"`
 
@@ -48,13 +51,16 @@ type HealthResponse struct {
 	// Checks Individual service health checks
 	Checks []HealthCheck `json:"checks"`
 
+	// StartedAt When the application process started
+	StartedAt time.Time `json:"started_at"`
+
 	// Status Health status of a service
 	Status HealthStatus `json:"status"`
 
 	// Timestamp When the health check was performed
 	Timestamp time.Time `json:"timestamp"`
 
-	// Uptime Application uptime
+	// Uptime Time since the application process started
 	Uptime string `json:"uptime"`
 
 	// Version Application version
@@ -96,3 +102,24 @@ type ReadinessResponse struct {
 
 // ReadinessResponseStatus Readiness status
 type ReadinessResponseStatus string
+
+// VersionResponse defines model for VersionResponse.
+type VersionResponse struct {
+	// Arch Architecture the binary runs on
+	Arch string `json:"arch"`
+
+	// BuildTime When the binary was built, "unknown" when not set at build time
+	BuildTime string `json:"build_time"`
+
+	// Commit Git commit the binary was built from, "unknown" when not known
+	Commit string `json:"commit"`
+
+	// GoVersion Go version the binary was built with
+	GoVersion string `json:"go_version"`
+
+	// Os Operating system the binary runs on
+	Os string `json:"os"`
+
+	// Version Application version, "dev" when not set at build time
+	Version string `json:"version"`
+}
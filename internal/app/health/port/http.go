@@ -7,6 +7,7 @@ import (
 
 	"github.com/fanzru/social-media-service-go/internal/app/health"
 	"github.com/fanzru/social-media-service-go/internal/app/health/port/genhttp"
+	"github.com/fanzru/social-media-service-go/pkg/buildinfo"
 	"github.com/fanzru/social-media-service-go/pkg/logger"
 	"github.com/fanzru/social-media-service-go/pkg/reqctx"
 )
@@ -112,7 +113,9 @@ func (h *Handler) GetHealthReady(w http.ResponseWriter, r *http.Request) {
 	var statusCode int
 	var status string
 
-	if healthResponse.Status == health.StatusHealthy {
+	// A degraded service, e.g. one whose metrics are not being recorded, still serves
+	// requests, so only unhealthy checks take it out of rotation
+	if healthResponse.Status != health.StatusUnhealthy {
 		statusCode = http.StatusOK
 		status = "ready"
 	} else {
@@ -138,3 +141,11 @@ func (h *Handler) GetHealthReady(w http.ResponseWriter, r *http.Request) {
 
 	json.NewEncoder(w).Encode(response)
 }
+
+// GetHealthVersion handles GET /health/version requests (implements genhttp.ServerInterface)
+func (h *Handler) GetHealthVersion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(buildinfo.Get())
+}
@@ -4,17 +4,38 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/health"
 )
 
+// pingTimeout bounds the object storage and metrics checks, so a hanging endpoint fails
+// the check instead of the health request
+const pingTimeout = 3 * time.Second
+
+// ObjectStorage is the storage uploads go to, checked by a cheap request to its bucket
+type ObjectStorage interface {
+	Ping(ctx context.Context) error
+}
+
+// MetricsBackend is the time series database metrics are written to
+type MetricsBackend interface {
+	Ping(ctx context.Context) error
+}
+
 // Repository implements health repository interface
 type Repository struct {
-	db interface{}
+	db      interface{}
+	storage ObjectStorage
+	metrics MetricsBackend // nil when metrics are not written to InfluxDB
 }
 
 // NewRepository creates a new health repository
-func NewRepository(db interface{}) *Repository {
+func NewRepository(db interface{}, storage ObjectStorage, metrics MetricsBackend) *Repository {
 	return &Repository{
-		db: db,
+		db:      db,
+		storage: storage,
+		metrics: metrics,
 	}
 }
 
@@ -31,6 +52,28 @@ func (r *Repository) PingDatabase(ctx context.Context) error {
 	}
 }
 
+// DatabasePoolStats returns the statistics of the database connection pool
+func (r *Repository) DatabasePoolStats() (health.PoolStats, error) {
+	var stats sql.DBStats
+	switch db := r.db.(type) {
+	case *sql.DB:
+		stats = db.Stats()
+	case interface{ Stats() sql.DBStats }:
+		stats = db.Stats()
+	default:
+		return health.PoolStats{}, fmt.Errorf("unsupported database type")
+	}
+
+	return health.PoolStats{
+		MaxOpen:        stats.MaxOpenConnections,
+		Open:           stats.OpenConnections,
+		InUse:          stats.InUse,
+		Idle:           stats.Idle,
+		WaitCount:      stats.WaitCount,
+		WaitDurationMs: float64(stats.WaitDuration) / float64(time.Millisecond),
+	}, nil
+}
+
 // PingRedis checks Redis connectivity (placeholder for future implementation)
 func (r *Repository) PingRedis(ctx context.Context) error {
 	// TODO: Implement Redis ping when Redis is added
@@ -42,3 +85,25 @@ func (r *Repository) PingExternalAPI(ctx context.Context) error {
 	// TODO: Implement external API ping when needed
 	return nil
 }
+
+// PingObjectStorage checks the bucket uploads go to can be reached with the configured
+// credentials
+func (r *Repository) PingObjectStorage(ctx context.Context) error {
+	if r.storage == nil {
+		return fmt.Errorf("object storage not configured")
+	}
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	return r.storage.Ping(ctx)
+}
+
+// PingMetrics checks the metrics backend is reachable, returning health.ErrNotConfigured
+// when there is none
+func (r *Repository) PingMetrics(ctx context.Context) error {
+	if r.metrics == nil {
+		return health.ErrNotConfigured
+	}
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	return r.metrics.Ping(ctx)
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/idempotency"
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+)
+
+// Service stores idempotency keys and the responses of the requests that used them.
+// It implements middleware.IdempotencyStore.
+type Service struct {
+	repo idempotency.IdempotencyRepository
+	ttl  time.Duration
+}
+
+// NewService creates a new idempotency service; keys can be replayed for ttl after first use
+func NewService(repo idempotency.IdempotencyRepository, ttl time.Duration) *Service {
+	return &Service{
+		repo: repo,
+		ttl:  ttl,
+	}
+}
+
+// Reserve claims an idempotency key for a request, or returns the response stored for it.
+// An expired key is claimed again as if it were new.
+func (s *Service) Reserve(ctx context.Context, accountID int64, key, fingerprint string) (*middleware.IdempotentResponse, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		inserted, err := s.repo.Insert(ctx, accountID, key, fingerprint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+		if inserted {
+			return nil, nil
+		}
+
+		rec, err := s.repo.Get(ctx, accountID, key)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				// Released or swept since the insert, try again
+				continue
+			}
+			return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+		}
+
+		if time.Since(rec.CreatedAt) >= s.ttl {
+			if err := s.repo.Delete(ctx, accountID, key); err != nil {
+				return nil, fmt.Errorf("failed to delete idempotency key: %w", err)
+			}
+			continue
+		}
+		if rec.Fingerprint != fingerprint {
+			return nil, middleware.ErrIdempotencyKeyMismatch
+		}
+		if rec.StatusCode == nil {
+			return nil, middleware.ErrIdempotencyKeyInProgress
+		}
+
+		resp := &middleware.IdempotentResponse{
+			StatusCode: *rec.StatusCode,
+			Body:       rec.Body,
+		}
+		if rec.ContentType != nil {
+			resp.ContentType = *rec.ContentType
+		}
+		return resp, nil
+	}
+
+	return nil, middleware.ErrIdempotencyKeyInProgress
+}
+
+// Complete stores the response of a reserved key
+func (s *Service) Complete(ctx context.Context, accountID int64, key string, resp middleware.IdempotentResponse) error {
+	if err := s.repo.Complete(ctx, accountID, key, resp.StatusCode, resp.ContentType, resp.Body); err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release frees a reserved key so the request can be retried
+func (s *Service) Release(ctx context.Context, accountID int64, key string) error {
+	if err := s.repo.Delete(ctx, accountID, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// SweepExpired deletes keys older than the ttl and returns how many were deleted
+func (s *Service) SweepExpired(ctx context.Context) (int64, error) {
+	count, err := s.repo.DeleteExpired(ctx, time.Now().Add(-s.ttl))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return count, nil
+}
+
+// RunExpirySweeper periodically sweeps expired idempotency keys until the context is
+// cancelled. An interval of 0 or less disables the sweep.
+func (s *Service) RunExpirySweeper(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	log := logger.GetGlobal()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := s.SweepExpired(ctx)
+			if err != nil {
+				log.Error("Idempotency key sweep failed", "error", err.Error())
+				continue
+			}
+			if count > 0 {
+				log.Info("Expired idempotency keys swept", "count", count)
+			}
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+// Record is a request made with an idempotency key. StatusCode is nil while the
+// request is still being processed.
+type Record struct {
+	AccountID   int64     `json:"account_id" db:"account_id"`
+	Key         string    `json:"key" db:"idempotency_key"`
+	Fingerprint string    `json:"fingerprint" db:"fingerprint"`
+	StatusCode  *int      `json:"status_code,omitempty" db:"status_code"`
+	ContentType *string   `json:"content_type,omitempty" db:"content_type"`
+	Body        []byte    `json:"-" db:"body"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// IdempotencyRepository defines the interface for idempotency key data access
+type IdempotencyRepository interface {
+	// Insert claims a key, reporting false when the account already used it
+	Insert(ctx context.Context, accountID int64, key, fingerprint string) (bool, error)
+	Get(ctx context.Context, accountID int64, key string) (*Record, error)
+	Complete(ctx context.Context, accountID int64, key string, statusCode int, contentType string, body []byte) error
+	Delete(ctx context.Context, accountID int64, key string) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
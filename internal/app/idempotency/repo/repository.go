@@ -0,0 +1,121 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/idempotency"
+	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
+)
+
+// Repository implements idempotency repository interface
+type Repository struct {
+	db interface{} // Can be *sql.DB or *sqlwrap.DB
+}
+
+// NewRepository creates a new idempotency repository
+func NewRepository(db interface{}) *Repository {
+	return &Repository{db: db}
+}
+
+// Insert claims an idempotency key for the account; it reports false when the key exists
+func (r *Repository) Insert(ctx context.Context, accountID int64, key, fingerprint string) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (account_id, idempotency_key, fingerprint, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (account_id, idempotency_key) DO NOTHING
+	`
+
+	var result sql.Result
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		result, err = db.ExecContext(ctx, query, accountID, key, fingerprint)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		result, err = db.ExecContext(ctx, query, accountID, key, fingerprint)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Get retrieves an idempotency key of the account
+func (r *Repository) Get(ctx context.Context, accountID int64, key string) (*idempotency.Record, error) {
+	query := `
+		SELECT account_id, idempotency_key, fingerprint, status_code, content_type, body, created_at
+		FROM idempotency_keys
+		WHERE account_id = $1 AND idempotency_key = $2
+	`
+
+	var rec idempotency.Record
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, accountID, key).Scan(&rec.AccountID, &rec.Key, &rec.Fingerprint, &rec.StatusCode, &rec.ContentType, &rec.Body, &rec.CreatedAt)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, accountID, key).Scan(&rec.AccountID, &rec.Key, &rec.Fingerprint, &rec.StatusCode, &rec.ContentType, &rec.Body, &rec.CreatedAt)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rec, nil
+}
+
+// Complete stores the response of an idempotency key
+func (r *Repository) Complete(ctx context.Context, accountID int64, key string, statusCode int, contentType string, body []byte) error {
+	query := `
+		UPDATE idempotency_keys SET status_code = $3, content_type = $4, body = $5
+		WHERE account_id = $1 AND idempotency_key = $2
+	`
+
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		_, err = db.ExecContext(ctx, query, accountID, key, statusCode, contentType, body)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		_, err = db.ExecContext(ctx, query, accountID, key, statusCode, contentType, body)
+	}
+
+	return err
+}
+
+// Delete removes an idempotency key of the account
+func (r *Repository) Delete(ctx context.Context, accountID int64, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE account_id = $1 AND idempotency_key = $2`
+
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		_, err = db.ExecContext(ctx, query, accountID, key)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		_, err = db.ExecContext(ctx, query, accountID, key)
+	}
+
+	return err
+}
+
+// DeleteExpired removes idempotency keys created before the given time and returns how many were removed
+func (r *Repository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE created_at < $1`
+
+	var result sql.Result
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		result, err = db.ExecContext(ctx, query, before)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		result, err = db.ExecContext(ctx, query, before)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
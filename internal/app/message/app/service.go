@@ -0,0 +1,139 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fanzru/social-media-service-go/internal/app/message"
+	"github.com/fanzru/social-media-service-go/pkg/realtime"
+)
+
+// Service implements message service interface
+type Service struct {
+	repo   message.MessageRepository
+	events realtime.Publisher
+}
+
+// NewService creates a new message service; events may be nil to disable real-time updates
+func NewService(repo message.MessageRepository, events realtime.Publisher) *Service {
+	return &Service{
+		repo:   repo,
+		events: events,
+	}
+}
+
+// StartConversation returns the conversation between the sender and the recipient, creating it if needed
+func (s *Service) StartConversation(ctx context.Context, senderID int64, req *message.StartConversationRequest) (*message.Conversation, error) {
+	if req.RecipientID == senderID {
+		return nil, message.ErrSelfConversation
+	}
+
+	// Check if recipient exists
+	exists, err := s.repo.AccountExists(ctx, req.RecipientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check recipient: %w", err)
+	}
+	if !exists {
+		return nil, message.ErrRecipientNotFound
+	}
+
+	conversation, err := s.repo.GetOrCreateConversation(ctx, senderID, req.RecipientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start conversation: %w", err)
+	}
+
+	return conversation, nil
+}
+
+// GetConversations retrieves conversations of an account
+func (s *Service) GetConversations(ctx context.Context, accountID int64, cursor string, limit int) (*message.ConversationListResponse, error) {
+	response, err := s.repo.GetConversationsByAccountID(ctx, accountID, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversations: %w", err)
+	}
+
+	return response, nil
+}
+
+// SendMessage sends a message in a conversation the sender participates in
+func (s *Service) SendMessage(ctx context.Context, conversationID int64, senderID int64, req *message.SendMessageRequest) (*message.Message, error) {
+	// Validate content
+	if err := s.validateContent(req.Content); err != nil {
+		return nil, fmt.Errorf("%w: %w", message.ErrInvalidContent, err)
+	}
+
+	conversation, err := s.getParticipatingConversation(ctx, conversationID, senderID)
+	if err != nil {
+		return nil, err
+	}
+
+	newMessage := &message.Message{
+		ConversationID: conversationID,
+		SenderID:       senderID,
+		Content:        req.Content,
+	}
+
+	if err := s.repo.CreateMessage(ctx, newMessage); err != nil {
+		return nil, fmt.Errorf("failed to send message: %w", err)
+	}
+
+	// Notify the other participant
+	if s.events != nil {
+		recipientID := conversation.ParticipantOneID
+		if recipientID == senderID {
+			recipientID = conversation.ParticipantTwoID
+		}
+		s.events.PublishToAccount(recipientID, realtime.EventNotification, realtime.Notification{
+			Kind:           realtime.NotificationMessage,
+			ActorID:        senderID,
+			ConversationID: conversationID,
+			MessageID:      newMessage.ID,
+		})
+	}
+
+	return newMessage, nil
+}
+
+// GetMessages retrieves the message history of a conversation the account participates in
+func (s *Service) GetMessages(ctx context.Context, conversationID int64, accountID int64, cursor string, limit int) (*message.MessageListResponse, error) {
+	if _, err := s.getParticipatingConversation(ctx, conversationID, accountID); err != nil {
+		return nil, err
+	}
+
+	response, err := s.repo.GetMessagesByConversationID(ctx, conversationID, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+
+	return response, nil
+}
+
+// getParticipatingConversation loads a conversation and hides it from accounts that are not part of it
+func (s *Service) getParticipatingConversation(ctx context.Context, conversationID int64, accountID int64) (*message.Conversation, error) {
+	conversation, err := s.repo.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, message.ErrConversationNotFound
+		}
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	if !conversation.HasParticipant(accountID) {
+		return nil, message.ErrConversationNotFound
+	}
+
+	return conversation, nil
+}
+
+// validateContent validates the message content
+func (s *Service) validateContent(content string) error {
+	if len(content) == 0 {
+		return fmt.Errorf("content is required")
+	}
+	if len(content) > 2000 {
+		return fmt.Errorf("content must be at most 2000 characters")
+	}
+	return nil
+}
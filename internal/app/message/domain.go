@@ -0,0 +1,84 @@
+package message
+
+import (
+	"context"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
+)
+
+// Errors returned by the message service
+var (
+	ErrConversationNotFound = apperr.NotFound("conversation not found")
+	ErrRecipientNotFound    = apperr.NotFound("recipient not found")
+	ErrSelfConversation     = apperr.Invalid("cannot start a conversation with yourself")
+	ErrInvalidContent       = apperr.Invalid("invalid message content")
+)
+
+// Conversation represents a direct conversation between two accounts
+type Conversation struct {
+	ID               int64      `json:"id" db:"id"`
+	ParticipantOneID int64      `json:"participant_one_id" db:"participant_one_id"`
+	ParticipantTwoID int64      `json:"participant_two_id" db:"participant_two_id"`
+	LastMessageAt    *time.Time `json:"last_message_at,omitempty" db:"last_message_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// HasParticipant reports whether the account takes part in the conversation
+func (c *Conversation) HasParticipant(accountID int64) bool {
+	return c.ParticipantOneID == accountID || c.ParticipantTwoID == accountID
+}
+
+// Message represents a direct message sent in a conversation
+type Message struct {
+	ID             int64      `json:"id" db:"id"`
+	ConversationID int64      `json:"conversation_id" db:"conversation_id"`
+	SenderID       int64      `json:"sender_id" db:"sender_id"`
+	Content        string     `json:"content" db:"content"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+// StartConversationRequest represents the request payload for starting a conversation
+type StartConversationRequest struct {
+	RecipientID int64 `json:"recipient_id" validate:"required"`
+}
+
+// SendMessageRequest represents the request payload for sending a message
+type SendMessageRequest struct {
+	Content string `json:"content" validate:"required,max=2000"`
+}
+
+// ConversationListResponse represents the response payload for listing conversations
+type ConversationListResponse struct {
+	Conversations []Conversation `json:"conversations"`
+	Cursor        string         `json:"cursor,omitempty"`
+	HasMore       bool           `json:"has_more"`
+}
+
+// MessageListResponse represents the response payload for listing messages
+type MessageListResponse struct {
+	Messages []Message `json:"messages"`
+	Cursor   string    `json:"cursor,omitempty"`
+	HasMore  bool      `json:"has_more"`
+}
+
+// MessageRepository defines the interface for message data access
+type MessageRepository interface {
+	GetOrCreateConversation(ctx context.Context, accountA, accountB int64) (*Conversation, error)
+	GetConversationByID(ctx context.Context, id int64) (*Conversation, error)
+	GetConversationsByAccountID(ctx context.Context, accountID int64, cursor string, limit int) (*ConversationListResponse, error)
+	CreateMessage(ctx context.Context, msg *Message) error
+	GetMessagesByConversationID(ctx context.Context, conversationID int64, cursor string, limit int) (*MessageListResponse, error)
+	AccountExists(ctx context.Context, accountID int64) (bool, error)
+}
+
+// MessageService defines the interface for message business logic
+type MessageService interface {
+	StartConversation(ctx context.Context, senderID int64, req *StartConversationRequest) (*Conversation, error)
+	GetConversations(ctx context.Context, accountID int64, cursor string, limit int) (*ConversationListResponse, error)
+	SendMessage(ctx context.Context, conversationID int64, senderID int64, req *SendMessageRequest) (*Message, error)
+	GetMessages(ctx context.Context, conversationID int64, accountID int64, cursor string, limit int) (*MessageListResponse, error)
+}
@@ -0,0 +1,134 @@
+package port
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/fanzru/social-media-service-go/internal/app/message"
+	"github.com/fanzru/social-media-service-go/internal/app/message/port/genhttp"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"github.com/fanzru/social-media-service-go/pkg/response"
+)
+
+// Handler handles HTTP requests for direct messages
+type Handler struct {
+	service message.MessageService
+}
+
+// NewHandler creates a new message handler
+func NewHandler(service message.MessageService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// PostApiConversations handles POST /api/conversations
+func (h *Handler) PostApiConversations(w http.ResponseWriter, r *http.Request) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	var req genhttp.StartConversationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(r.Context(), "Invalid request body", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	startReq := &message.StartConversationRequest{
+		RecipientID: req.RecipientId,
+	}
+
+	conversation, err := h.service.StartConversation(r.Context(), userID, startReq)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to start conversation")
+		return
+	}
+
+	response.Success(r.Context(), "Conversation retrieved successfully", conversation).Send(w, http.StatusOK)
+}
+
+// GetApiConversations handles GET /api/conversations
+func (h *Handler) GetApiConversations(w http.ResponseWriter, r *http.Request, params genhttp.GetApiConversationsParams) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	cursor := ""
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	conversations, err := h.service.GetConversations(r.Context(), userID, cursor, limit)
+	if err != nil {
+		response.InternalServerError(r.Context(), "Failed to get conversations", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	response.Success(r.Context(), "Conversations retrieved successfully", conversations).Send(w, http.StatusOK)
+}
+
+// PostApiConversationsIdMessages handles POST /api/conversations/{id}/messages
+func (h *Handler) PostApiConversationsIdMessages(w http.ResponseWriter, r *http.Request, id int64) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	var req genhttp.SendMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(r.Context(), "Invalid request body", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	sendReq := &message.SendMessageRequest{
+		Content: req.Content,
+	}
+
+	sentMessage, err := h.service.SendMessage(r.Context(), id, userID, sendReq)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to send message")
+		return
+	}
+
+	response.Success(r.Context(), "Message sent successfully", sentMessage).Send(w, http.StatusCreated)
+}
+
+// GetApiConversationsIdMessages handles GET /api/conversations/{id}/messages
+func (h *Handler) GetApiConversationsIdMessages(w http.ResponseWriter, r *http.Request, id int64, params genhttp.GetApiConversationsIdMessagesParams) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	cursor := ""
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	messages, err := h.service.GetMessages(r.Context(), id, userID, cursor, limit)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to get messages")
+		return
+	}
+
+	response.Success(r.Context(), "Messages retrieved successfully", messages).Send(w, http.StatusOK)
+}
+
+// Implement the generated interface
+var _ genhttp.ServerInterface = (*Handler)(nil)
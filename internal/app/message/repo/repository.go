@@ -0,0 +1,240 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/message"
+	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
+)
+
+// Repository implements message repository interface
+type Repository struct {
+	db interface{} // Can be *sql.DB or *sqlwrap.DB
+}
+
+// NewRepository creates a new message repository
+func NewRepository(db interface{}) *Repository {
+	return &Repository{db: db}
+}
+
+// GetOrCreateConversation returns the conversation between two accounts, creating it if needed
+func (r *Repository) GetOrCreateConversation(ctx context.Context, accountA, accountB int64) (*message.Conversation, error) {
+	// Participants are stored ordered so each pair maps to a single row
+	one, two := accountA, accountB
+	if one > two {
+		one, two = two, one
+	}
+
+	query := `
+		INSERT INTO conversations (participant_one_id, participant_two_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $3)
+		ON CONFLICT (participant_one_id, participant_two_id)
+		DO UPDATE SET participant_one_id = EXCLUDED.participant_one_id
+		RETURNING id, participant_one_id, participant_two_id, last_message_at, created_at, updated_at
+	`
+
+	now := time.Now()
+	var c message.Conversation
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, one, two, now).Scan(&c.ID, &c.ParticipantOneID, &c.ParticipantTwoID, &c.LastMessageAt, &c.CreatedAt, &c.UpdatedAt)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, one, two, now).Scan(&c.ID, &c.ParticipantOneID, &c.ParticipantTwoID, &c.LastMessageAt, &c.CreatedAt, &c.UpdatedAt)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// GetConversationByID retrieves a conversation by ID
+func (r *Repository) GetConversationByID(ctx context.Context, id int64) (*message.Conversation, error) {
+	query := `
+		SELECT id, participant_one_id, participant_two_id, last_message_at, created_at, updated_at
+		FROM conversations
+		WHERE id = $1
+	`
+
+	var c message.Conversation
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, id).Scan(&c.ID, &c.ParticipantOneID, &c.ParticipantTwoID, &c.LastMessageAt, &c.CreatedAt, &c.UpdatedAt)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, id).Scan(&c.ID, &c.ParticipantOneID, &c.ParticipantTwoID, &c.LastMessageAt, &c.CreatedAt, &c.UpdatedAt)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// GetConversationsByAccountID retrieves conversations of an account ordered by latest activity with cursor-based pagination
+func (r *Repository) GetConversationsByAccountID(ctx context.Context, accountID int64, cursor string, limit int) (*message.ConversationListResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	query := `
+		SELECT id, participant_one_id, participant_two_id, last_message_at, created_at, updated_at
+		FROM conversations
+		WHERE (participant_one_id = $1 OR participant_two_id = $1)
+	`
+	args := []interface{}{accountID}
+
+	if cursor != "" {
+		query += ` AND updated_at < $2`
+		args = append(args, cursor)
+	}
+
+	query += ` ORDER BY updated_at DESC LIMIT $` + fmt.Sprintf("%d", len(args)+1)
+	args = append(args, limit+1) // Get one extra to check if there are more
+
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, args...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var conversations []message.Conversation
+	for rows.Next() {
+		var c message.Conversation
+		err := rows.Scan(&c.ID, &c.ParticipantOneID, &c.ParticipantTwoID, &c.LastMessageAt, &c.CreatedAt, &c.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		conversations = append(conversations, c)
+	}
+
+	hasMore := len(conversations) > limit
+	if hasMore {
+		conversations = conversations[:limit]
+	}
+
+	var nextCursor string
+	if hasMore && len(conversations) > 0 {
+		nextCursor = conversations[len(conversations)-1].UpdatedAt.Format(time.RFC3339Nano)
+	}
+
+	return &message.ConversationListResponse{
+		Conversations: conversations,
+		Cursor:        nextCursor,
+		HasMore:       hasMore,
+	}, nil
+}
+
+// CreateMessage stores a new message and bumps the conversation's last activity
+func (r *Repository) CreateMessage(ctx context.Context, msg *message.Message) error {
+	query := `
+		WITH inserted AS (
+			INSERT INTO messages (conversation_id, sender_id, content, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $4)
+			RETURNING id
+		), touched AS (
+			UPDATE conversations SET last_message_at = $4, updated_at = $4 WHERE id = $1
+		)
+		SELECT id FROM inserted
+	`
+
+	now := time.Now()
+	msg.CreatedAt = now
+	msg.UpdatedAt = now
+
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, msg.ConversationID, msg.SenderID, msg.Content, now).Scan(&msg.ID)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, msg.ConversationID, msg.SenderID, msg.Content, now).Scan(&msg.ID)
+	}
+
+	return err
+}
+
+// GetMessagesByConversationID retrieves messages of a conversation, newest first, with cursor-based pagination
+func (r *Repository) GetMessagesByConversationID(ctx context.Context, conversationID int64, cursor string, limit int) (*message.MessageListResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	query := `
+		SELECT id, conversation_id, sender_id, content, created_at, updated_at, deleted_at
+		FROM messages
+		WHERE conversation_id = $1 AND deleted_at IS NULL
+	`
+	args := []interface{}{conversationID}
+
+	if cursor != "" {
+		query += ` AND created_at < $2`
+		args = append(args, cursor)
+	}
+
+	query += ` ORDER BY created_at DESC LIMIT $` + fmt.Sprintf("%d", len(args)+1)
+	args = append(args, limit+1) // Get one extra to check if there are more
+
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, args...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []message.Message
+	for rows.Next() {
+		var m message.Message
+		err := rows.Scan(&m.ID, &m.ConversationID, &m.SenderID, &m.Content, &m.CreatedAt, &m.UpdatedAt, &m.DeletedAt)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+
+	hasMore := len(messages) > limit
+	if hasMore {
+		messages = messages[:limit]
+	}
+
+	var nextCursor string
+	if hasMore && len(messages) > 0 {
+		nextCursor = messages[len(messages)-1].CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	return &message.MessageListResponse{
+		Messages: messages,
+		Cursor:   nextCursor,
+		HasMore:  hasMore,
+	}, nil
+}
+
+// AccountExists checks whether an active account exists
+func (r *Repository) AccountExists(ctx context.Context, accountID int64) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM accounts WHERE id = $1 AND deleted_at IS NULL)`
+
+	var exists bool
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, accountID).Scan(&exists)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, accountID).Scan(&exists)
+	}
+
+	return exists, err
+}
@@ -2,36 +2,94 @@ package app
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"math"
 	"mime/multipart"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/fanzru/social-media-service-go/internal/app/account"
 	"github.com/fanzru/social-media-service-go/internal/app/comment"
 	"github.com/fanzru/social-media-service-go/internal/app/post"
+	"github.com/fanzru/social-media-service-go/pkg/eventbus"
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/metrics"
+	"github.com/fanzru/social-media-service-go/pkg/pagination"
+	"github.com/fanzru/social-media-service-go/pkg/realtime"
 	"github.com/fanzru/social-media-service-go/pkg/storage"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
 )
 
+// hashtagPattern matches a hashtag filter without its leading '#'
+var hashtagPattern = regexp.MustCompile(`^[A-Za-z0-9_]{1,100}$`)
+
+// sharedReadTimeout bounds a repository read shared by concurrent requests, which runs
+// independently of the request that started it
+const sharedReadTimeout = 10 * time.Second
+
+// Image processing jobs
+const (
+	mediaJobTimeout    = 2 * time.Minute // Longest one image may take, waiting for a free image worker included
+	staleMediaJobAfter = 5 * time.Minute // Claims older than this belong to a stopped worker and are retried
+)
+
+// staleFanoutAfter is how old a claim on writing a post to timelines is before it is
+// taken to belong to a stopped worker and retried
+const staleFanoutAfter = 5 * time.Minute
+
 // Service implements post service interface
 type Service struct {
 	repo         post.PostRepository
 	commentRepo  comment.CommentRepository
 	imageStorage *storage.ImageStorageService
+	moderators   map[int64]bool
+	events       realtime.Publisher
+	changes      eventbus.Publisher
+	metrics      metrics.Sink
+	wake         chan struct{} // Nudges the image workers when a post awaits processing
+	fanout       chan struct{} // Nudges the timeline workers when a post awaits writing to timelines
+	maxFanout    int           // Followers beyond which an account's posts are read by timelines instead of written to them
+	reads        singleflight.Group
 }
 
-// NewService creates a new post service
-func NewService(repo post.PostRepository, commentRepo comment.CommentRepository, imageStorage *storage.ImageStorageService) *Service {
+// NewService creates a new post service; moderatorIDs are accounts allowed to enforce the
+// sensitive flag on any post in addition to those with the moderator or admin role.
+// events tells creators when their images are processed; it and changes may be nil when
+// nothing subscribes. Posts of accounts with more than maxFanout followers are not
+// written to each follower's timeline but read when a timeline is. Created posts are
+// counted on sink, which may be nil.
+func NewService(repo post.PostRepository, commentRepo comment.CommentRepository, imageStorage *storage.ImageStorageService, moderatorIDs []int64, maxFanout int, events realtime.Publisher, changes eventbus.Publisher, sink metrics.Sink) *Service {
+	moderators := make(map[int64]bool, len(moderatorIDs))
+	for _, id := range moderatorIDs {
+		moderators[id] = true
+	}
+
 	return &Service{
 		repo:         repo,
 		commentRepo:  commentRepo,
 		imageStorage: imageStorage,
+		moderators:   moderators,
+		events:       events,
+		changes:      changes,
+		metrics:      sink,
+		wake:         make(chan struct{}, 1),
+		fanout:       make(chan struct{}, 1),
+		maxFanout:    maxFanout,
 	}
 }
 
 // CreatePostWithImage creates a new post with image upload (HTTP handler version)
-func (s *Service) CreatePostWithImage(ctx context.Context, creatorID int64, caption string, file multipart.File, header *multipart.FileHeader) (*post.Post, error) {
+func (s *Service) CreatePostWithImage(ctx context.Context, creatorID int64, caption string, visibility string, isSensitive bool, location *post.Location, file multipart.File, header *multipart.FileHeader) (*post.Post, error) {
 	req := &post.CreatePostRequest{
-		Caption: caption,
+		Caption:     caption,
+		Visibility:  visibility,
+		Location:    location,
+		IsSensitive: isSensitive,
 	}
 	return s.createPostWithImage(ctx, req, creatorID, file, header)
 }
@@ -40,38 +98,208 @@ func (s *Service) CreatePostWithImage(ctx context.Context, creatorID int64, capt
 func (s *Service) createPostWithImage(ctx context.Context, req *post.CreatePostRequest, creatorID int64, file multipart.File, header *multipart.FileHeader) (*post.Post, error) {
 	// Validate caption
 	if err := s.validateCaption(req.Caption); err != nil {
-		return nil, fmt.Errorf("invalid caption: %w", err)
+		return nil, fmt.Errorf("%w: %w", post.ErrInvalidCaption, err)
 	}
 
-	// Process and upload image
-	imagePath, imageURL, err := s.imageStorage.ProcessAndUploadImage(file, header)
+	visibility, err := s.resolveVisibility(req.Visibility)
 	if err != nil {
-		return nil, fmt.Errorf("failed to process and upload image: %w", err)
+		return nil, err
+	}
+
+	if err := s.validateLocation(req.Location); err != nil {
+		return nil, fmt.Errorf("%w: %w", post.ErrInvalidLocation, err)
+	}
+
+	// Upload the original; an image worker makes the resized copy after the post is returned
+	image, err := s.imageStorage.UploadOriginal(file, header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload image: %w", err)
 	}
 
 	// Create post
 	newPost := &post.Post{
-		Caption:     req.Caption,
-		ImagePath:   imagePath,
-		ImageURL:    imageURL,
-		CreatorID:   creatorID,
-		CreatorName: "", // Will be populated from account service
+		Caption:           req.Caption,
+		ImagePath:         image.Path,
+		ImageURL:          image.URL,
+		CreatorID:         creatorID,
+		CreatorName:       "", // Will be populated from account service
+		Visibility:        visibility,
+		IsSensitive:       req.IsSensitive,
+		MediaStatus:       post.MediaProcessing,
+		OriginalImagePath: image.OriginalPath,
 	}
+	setLocation(newPost, req.Location)
 
 	if err := s.repo.Create(ctx, newPost); err != nil {
 		// If post creation fails, try to delete the uploaded image
-		s.imageStorage.DeleteImage(imagePath)
+		s.imageStorage.DeleteImage(image.Path)
 		return nil, fmt.Errorf("failed to create post: %w", err)
 	}
+	s.publish(ctx, eventbus.PostCreated, newPost.ID, creatorID)
+	metrics.CountEvent(s.metrics, metrics.PostsCreated, nil)
+
+	// Nudge the workers so the image and timelines do not wait for the next tick
+	nudge(s.wake)
+	nudge(s.fanout)
 
 	return newPost, nil
 }
 
+// ProcessNextImage claims one post whose image awaits processing, makes its resized copy
+// and marks the post ready, or failed when the image cannot be processed. The creator is
+// told either way. It reports whether a post was processed.
+func (s *Service) ProcessNextImage(ctx context.Context) (bool, error) {
+	job, err := s.repo.ClaimMediaJob(ctx, time.Now().Add(-staleMediaJobAfter))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim image: %w", err)
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, mediaJobTimeout)
+	processErr := s.imageStorage.ProcessOriginal(jobCtx, job.OriginalImagePath, job.ImagePath)
+	cancel()
+
+	status := post.MediaReady
+	if processErr != nil {
+		status = post.MediaFailed
+	}
+	if err := s.repo.SetMediaStatus(ctx, job.PostID, status); err != nil {
+		return true, fmt.Errorf("failed to mark image of post %d %s: %w", job.PostID, status, err)
+	}
+	s.publish(ctx, eventbus.PostUpdated, job.PostID, job.CreatorID)
+
+	if s.events != nil {
+		media := realtime.PostMedia{PostID: job.PostID, MediaStatus: status}
+		if status == post.MediaReady {
+			media.ImageURL = job.ImageURL
+		}
+		s.events.PublishToAccount(job.CreatorID, realtime.EventPostMedia, media)
+	}
+
+	if processErr != nil {
+		return true, fmt.Errorf("failed to process image of post %d: %w", job.PostID, processErr)
+	}
+	return true, nil
+}
+
+// RunImageWorker processes post images until the context is cancelled, checking for
+// waiting images every interval and whenever a post is created
+func (s *Service) RunImageWorker(ctx context.Context, interval time.Duration) {
+	runWorker(ctx, interval, s.wake, "Image processing failed", s.ProcessNextImage)
+}
+
+// GetTimeline retrieves the newest posts of the account and the accounts it follows, with
+// comment counts and last comments
+func (s *Service) GetTimeline(ctx context.Context, accountID int64, cursor string, limit int) (*post.PostListResponse, error) {
+	response, err := s.repo.GetTimeline(ctx, accountID, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timeline: %w", err)
+	}
+
+	if err := s.attachComments(ctx, response.Posts, true); err != nil {
+		return nil, err
+	}
+
+	if err := s.applySensitivePreference(ctx, accountID, response.Posts); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ProcessNextFanout claims one new post and writes it to the timelines of its creator and
+// the creator's followers. Posts of accounts with more than maxFanout followers are only
+// written to the creator's timeline, and the account becomes one whose posts timelines
+// read instead. It reports whether a post was written.
+func (s *Service) ProcessNextFanout(ctx context.Context) (bool, error) {
+	job, err := s.repo.ClaimFanoutJob(ctx, time.Now().Add(-staleFanoutAfter))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim timeline fan-out: %w", err)
+	}
+
+	followers, err := s.repo.CountFollowers(ctx, job.CreatorID)
+	if err != nil {
+		return true, fmt.Errorf("failed to count followers of account %d: %w", job.CreatorID, err)
+	}
+
+	toFollowers := followers <= int64(s.maxFanout)
+	if !toFollowers {
+		if err := s.repo.AddPullAccount(ctx, job.CreatorID); err != nil {
+			return true, fmt.Errorf("failed to mark account %d for timeline reads: %w", job.CreatorID, err)
+		}
+	}
+
+	if err := s.repo.FanOut(ctx, job, toFollowers); err != nil {
+		return true, fmt.Errorf("failed to write post %d to timelines: %w", job.PostID, err)
+	}
+	return true, nil
+}
+
+// RunFanoutWorker writes new posts to timelines until the context is cancelled, checking
+// for waiting posts every interval and whenever a post is created
+func (s *Service) RunFanoutWorker(ctx context.Context, interval time.Duration) {
+	runWorker(ctx, interval, s.fanout, "Timeline fan-out failed", s.ProcessNextFanout)
+}
+
+// runWorker calls processNext until it has nothing left every interval and whenever wake
+// receives, until the context is cancelled. Failures are logged with message. An interval
+// of 0 or less falls back to 30s, as the interval also retries jobs of stopped workers.
+func runWorker(ctx context.Context, interval time.Duration, wake <-chan struct{}, message string, processNext func(ctx context.Context) (bool, error)) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	log := logger.GetGlobal()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-wake:
+		}
+
+		for {
+			processed, err := processNext(ctx)
+			if err != nil {
+				log.Error(message, "error", err.Error())
+			}
+			if !processed {
+				break
+			}
+		}
+	}
+}
+
+// nudge wakes a worker waiting on wake, unless one is already due to wake
+func nudge(wake chan struct{}) {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
+
 // CreatePost creates a new post (legacy method for backward compatibility)
 func (s *Service) CreatePost(ctx context.Context, req *post.CreatePostRequest, creatorID int64, imagePath string) (*post.Post, error) {
 	// Validate caption
 	if err := s.validateCaption(req.Caption); err != nil {
-		return nil, fmt.Errorf("invalid caption: %w", err)
+		return nil, fmt.Errorf("%w: %w", post.ErrInvalidCaption, err)
+	}
+
+	visibility, err := s.resolveVisibility(req.Visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateLocation(req.Location); err != nil {
+		return nil, fmt.Errorf("%w: %w", post.ErrInvalidLocation, err)
 	}
 
 	// Generate image URL from path
@@ -84,94 +312,228 @@ func (s *Service) CreatePost(ctx context.Context, req *post.CreatePostRequest, c
 		ImageURL:    imageURL,
 		CreatorID:   creatorID,
 		CreatorName: "", // Will be populated from account service
+		Visibility:  visibility,
+		IsSensitive: req.IsSensitive,
 	}
+	setLocation(newPost, req.Location)
 
 	if err := s.repo.Create(ctx, newPost); err != nil {
 		return nil, fmt.Errorf("failed to create post: %w", err)
 	}
+	s.publish(ctx, eventbus.PostCreated, newPost.ID, creatorID)
+	metrics.CountEvent(s.metrics, metrics.PostsCreated, nil)
+	nudge(s.fanout)
 
 	return newPost, nil
 }
 
-// GetPost retrieves a post by ID
-func (s *Service) GetPost(ctx context.Context, id int64) (*post.Post, error) {
-	post, err := s.repo.GetByID(ctx, id)
+// GetPost retrieves a post by ID if it is visible to the viewer
+func (s *Service) GetPost(ctx context.Context, id int64, viewerID int64) (*post.Post, error) {
+	shared, err := s.sharedRead(ctx, fmt.Sprintf("post:%d:%d", id, viewerID), func(ctx context.Context) (interface{}, error) {
+		return s.repo.GetByID(ctx, id, viewerID)
+	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, post.ErrPostNotFound
+		}
 		return nil, fmt.Errorf("failed to get post: %w", err)
 	}
 
-	// Get comment count
-	commentCount, err := s.repo.GetCommentCount(ctx, id)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get comment count: %w", err)
+	posts := []post.Post{*shared.(*post.Post)} // A copy, as other requests share the result
+	if err := s.attachComments(ctx, posts, true); err != nil {
+		return nil, err
 	}
-	post.CommentCount = commentCount
+	fetched := &posts[0]
 
-	// Get last 2 comments
-	comments, err := s.repo.GetLastComments(ctx, id, 2)
+	preference, err := s.sensitivePreference(ctx, viewerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get last comments: %w", err)
+		return nil, err
 	}
-	post.Comments = comments
+	markBlurred(fetched, viewerID, preference)
 
-	return post, nil
+	return fetched, nil
 }
 
 // GetPostByID is an alias for GetPost for backward compatibility
-func (s *Service) GetPostByID(ctx context.Context, id int64) (*post.Post, error) {
-	return s.GetPost(ctx, id)
+func (s *Service) GetPostByID(ctx context.Context, id int64, viewerID int64) (*post.Post, error) {
+	return s.GetPost(ctx, id, viewerID)
+}
+
+// GetPostsByIDs retrieves the posts with the given IDs that are visible to the viewer, in
+// the requested order and with their comment counts and last 2 comments. IDs that are not
+// found are listed in MissingIDs.
+func (s *Service) GetPostsByIDs(ctx context.Context, ids []int64, viewerID int64) (*post.PostBatchResponse, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: at least one post ID is required", post.ErrInvalidIDs)
+	}
+	if len(ids) > post.MaxBatchSize {
+		return nil, fmt.Errorf("%w: at most %d posts can be fetched at once", post.ErrInvalidIDs, post.MaxBatchSize)
+	}
+
+	found, err := s.repo.GetByIDs(ctx, ids, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts: %w", err)
+	}
+
+	if err := s.attachComments(ctx, found, true); err != nil {
+		return nil, err
+	}
+
+	preference, err := s.sensitivePreference(ctx, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*post.Post, len(found))
+	for i := range found {
+		markBlurred(&found[i], viewerID, preference)
+		byID[found[i].ID] = &found[i]
+	}
+
+	// Keep the requested order; repeated IDs are returned once
+	response := &post.PostBatchResponse{Posts: []post.Post{}, MissingIDs: []int64{}}
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if p, ok := byID[id]; ok {
+			response.Posts = append(response.Posts, *p)
+		} else {
+			response.MissingIDs = append(response.MissingIDs, id)
+		}
+	}
+
+	return response, nil
+}
+
+// GetPostChanges sorts the posts changed after since into created, updated and deleted.
+// A page never ends between changes made at the same instant, so resuming from its
+// watermark skips nothing.
+func (s *Service) GetPostChanges(ctx context.Context, viewerID int64, since time.Time, limit int) (*post.PostChanges, error) {
+	if limit <= 0 {
+		limit = post.DefaultChangesLimit
+	}
+	if limit > post.MaxChangesLimit {
+		limit = post.MaxChangesLimit
+	}
+
+	// Get one extra to check if there are more
+	changes, err := s.repo.GetChangedSince(ctx, viewerID, since, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post changes: %w", err)
+	}
+
+	hasMore := len(changes) > limit
+	if hasMore {
+		next := changes[limit]
+		changes = changes[:limit]
+		end := len(changes)
+		for end > 0 && changes[end-1].ChangedAt.Equal(next.ChangedAt) {
+			end--
+		}
+		// Unless the whole page shares one instant, stop before it
+		if end > 0 {
+			changes = changes[:end]
+		}
+	}
+
+	result := &post.PostChanges{
+		Created:   []int64{},
+		Updated:   []int64{},
+		Deleted:   []int64{},
+		Watermark: since,
+		HasMore:   hasMore,
+	}
+	for _, c := range changes {
+		switch {
+		case c.DeletedAt != nil:
+			result.Deleted = append(result.Deleted, c.ID)
+		case c.CreatedAt.After(since):
+			result.Created = append(result.Created, c.ID)
+		default:
+			result.Updated = append(result.Updated, c.ID)
+		}
+		result.Watermark = c.ChangedAt
+	}
+
+	return result, nil
 }
 
 // GetUserPosts retrieves posts by creator ID
-func (s *Service) GetUserPosts(ctx context.Context, creatorID int64, cursor string, limit int) (*post.PostListResponse, error) {
-	response, err := s.repo.GetByCreatorID(ctx, creatorID, cursor, limit)
+func (s *Service) GetUserPosts(ctx context.Context, creatorID int64, viewerID int64, cursor string, limit int) (*post.PostListResponse, error) {
+	response, err := s.repo.GetByCreatorID(ctx, creatorID, viewerID, cursor, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user posts: %w", err)
 	}
 
 	// Add comment counts and last comments for each post
-	for i := range response.Posts {
-		commentCount, err := s.repo.GetCommentCount(ctx, response.Posts[i].ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get comment count for post %d: %w", response.Posts[i].ID, err)
-		}
-		response.Posts[i].CommentCount = commentCount
+	if err := s.attachComments(ctx, response.Posts, true); err != nil {
+		return nil, err
+	}
 
-		comments, err := s.repo.GetLastComments(ctx, response.Posts[i].ID, 2)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get last comments for post %d: %w", response.Posts[i].ID, err)
-		}
-		response.Posts[i].Comments = comments
+	if err := s.applySensitivePreference(ctx, viewerID, response.Posts); err != nil {
+		return nil, err
 	}
 
 	return response, nil
 }
 
 // GetPostsByCreatorID is an alias for GetUserPosts for backward compatibility
-func (s *Service) GetPostsByCreatorID(ctx context.Context, creatorID int64, cursor string, limit int) (*post.PostListResponse, error) {
-	return s.GetUserPosts(ctx, creatorID, cursor, limit)
+func (s *Service) GetPostsByCreatorID(ctx context.Context, creatorID int64, viewerID int64, cursor string, limit int) (*post.PostListResponse, error) {
+	return s.GetUserPosts(ctx, creatorID, viewerID, cursor, limit)
 }
 
 // GetAllPosts retrieves all posts
-func (s *Service) GetAllPosts(ctx context.Context, cursor string, limit int) (*post.PostListResponse, error) {
-	response, err := s.repo.GetAll(ctx, cursor, limit)
+func (s *Service) GetAllPosts(ctx context.Context, viewerID int64, cursor string, limit int) (*post.PostListResponse, error) {
+	return s.getAllPosts(ctx, viewerID, post.ListFilter{}, cursor, limit)
+}
+
+// getAllPosts retrieves the posts matching filter, newest first
+func (s *Service) getAllPosts(ctx context.Context, viewerID int64, filter post.ListFilter, cursor string, limit int) (*post.PostListResponse, error) {
+	response, err := s.repo.GetAll(ctx, viewerID, filter, cursor, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all posts: %w", err)
 	}
 
 	// Add comment counts and last comments for each post
-	for i := range response.Posts {
-		commentCount, err := s.repo.GetCommentCount(ctx, response.Posts[i].ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get comment count for post %d: %w", response.Posts[i].ID, err)
-		}
-		response.Posts[i].CommentCount = commentCount
+	if err := s.attachComments(ctx, response.Posts, true); err != nil {
+		return nil, err
+	}
 
-		comments, err := s.repo.GetLastComments(ctx, response.Posts[i].ID, 2)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get last comments for post %d: %w", response.Posts[i].ID, err)
-		}
-		response.Posts[i].Comments = comments
+	if err := s.applySensitivePreference(ctx, viewerID, response.Posts); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetNearbyPosts retrieves geotagged posts within radiusKm of a point, nearest first
+func (s *Service) GetNearbyPosts(ctx context.Context, viewerID int64, lat float64, lng float64, radiusKm float64, cursor string, limit int) (*post.PostListResponse, error) {
+	if err := s.validateLocation(&post.Location{Latitude: lat, Longitude: lng}); err != nil {
+		return nil, fmt.Errorf("%w: %w", post.ErrInvalidLocation, err)
+	}
+
+	if radiusKm == 0 {
+		radiusKm = post.DefaultNearbyRadiusKm
+	}
+	if radiusKm < 0 || radiusKm > post.MaxNearbyRadiusKm {
+		return nil, fmt.Errorf("%w: must be between 0 and %g km", post.ErrInvalidRadius, post.MaxNearbyRadiusKm)
+	}
+
+	response, err := s.repo.GetNearby(ctx, viewerID, lat, lng, radiusKm, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nearby posts: %w", err)
+	}
+
+	// Add comment counts and last comments for each post
+	if err := s.attachComments(ctx, response.Posts, true); err != nil {
+		return nil, err
+	}
+
+	if err := s.applySensitivePreference(ctx, viewerID, response.Posts); err != nil {
+		return nil, err
 	}
 
 	return response, nil
@@ -180,26 +542,42 @@ func (s *Service) GetAllPosts(ctx context.Context, cursor string, limit int) (*p
 // UpdatePost updates an existing post
 func (s *Service) UpdatePost(ctx context.Context, id int64, creatorID int64, req *post.UpdatePostRequest) (*post.Post, error) {
 	// Get existing post
-	existingPost, err := s.repo.GetByID(ctx, id)
+	existingPost, err := s.repo.GetByID(ctx, id, creatorID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, post.ErrPostNotFound
+		}
 		return nil, fmt.Errorf("failed to get post: %w", err)
 	}
 
 	// Check if user owns the post
 	if existingPost.CreatorID != creatorID {
-		return nil, fmt.Errorf("unauthorized: you can only update your own posts")
+		return nil, post.ErrNotPostOwner
 	}
 
 	// Validate caption
 	if err := s.validateCaption(req.Caption); err != nil {
-		return nil, fmt.Errorf("invalid caption: %w", err)
+		return nil, fmt.Errorf("%w: %w", post.ErrInvalidCaption, err)
 	}
 
 	// Update post
 	existingPost.Caption = req.Caption
+	if req.Visibility != "" {
+		if !post.IsValidVisibility(req.Visibility) {
+			return nil, fmt.Errorf("%w: %s", post.ErrInvalidVisibility, req.Visibility)
+		}
+		existingPost.Visibility = req.Visibility
+	}
+	if req.IsSensitive != nil {
+		if !*req.IsSensitive && existingPost.SensitiveLocked {
+			return nil, post.ErrSensitiveLocked
+		}
+		existingPost.IsSensitive = *req.IsSensitive
+	}
 	if err := s.repo.Update(ctx, existingPost); err != nil {
 		return nil, fmt.Errorf("failed to update post: %w", err)
 	}
+	s.publish(ctx, eventbus.PostUpdated, id, creatorID)
 
 	return existingPost, nil
 }
@@ -207,20 +585,24 @@ func (s *Service) UpdatePost(ctx context.Context, id int64, creatorID int64, req
 // DeletePost deletes a post
 func (s *Service) DeletePost(ctx context.Context, id int64, creatorID int64) error {
 	// Get existing post
-	existingPost, err := s.repo.GetByID(ctx, id)
+	existingPost, err := s.repo.GetByID(ctx, id, creatorID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return post.ErrPostNotFound
+		}
 		return fmt.Errorf("failed to get post: %w", err)
 	}
 
 	// Check if user owns the post
 	if existingPost.CreatorID != creatorID {
-		return fmt.Errorf("unauthorized: you can only delete your own posts")
+		return post.ErrNotPostOwner
 	}
 
 	// Soft delete post
 	if err := s.repo.SoftDelete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete post: %w", err)
 	}
+	s.publish(ctx, eventbus.PostDeleted, id, creatorID)
 
 	// Delete associated image from storage
 	if err := s.imageStorage.DeleteImage(existingPost.ImagePath); err != nil {
@@ -232,28 +614,374 @@ func (s *Service) DeletePost(ctx context.Context, id int64, creatorID int64) err
 	return nil
 }
 
+// PinPost pins one of the creator's posts to the top of their profile
+func (s *Service) PinPost(ctx context.Context, id int64, creatorID int64) error {
+	if err := s.checkOwnership(ctx, id, creatorID); err != nil {
+		return err
+	}
+
+	if err := s.repo.PinPost(ctx, creatorID, id); err != nil {
+		return fmt.Errorf("failed to pin post: %w", err)
+	}
+	s.publish(ctx, eventbus.PostUpdated, id, creatorID)
+
+	return nil
+}
+
+// UnpinPost removes the post from the top of the creator's profile
+func (s *Service) UnpinPost(ctx context.Context, id int64, creatorID int64) error {
+	if err := s.checkOwnership(ctx, id, creatorID); err != nil {
+		return err
+	}
+
+	if err := s.repo.UnpinPost(ctx, creatorID, id); err != nil {
+		return fmt.Errorf("failed to unpin post: %w", err)
+	}
+	s.publish(ctx, eventbus.PostUpdated, id, creatorID)
+
+	return nil
+}
+
+// ModerateSensitive lets a moderator enforce the sensitive flag on any post. Marking a post
+// sensitive locks the flag so its creator cannot clear it; unmarking releases the lock.
+func (s *Service) ModerateSensitive(ctx context.Context, id int64, moderatorID int64, isSensitive bool) error {
+	allowed, err := s.isModerator(ctx, moderatorID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return post.ErrNotModerator
+	}
+
+	if err := s.repo.SetSensitive(ctx, id, isSensitive, isSensitive); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return post.ErrPostNotFound
+		}
+		return fmt.Errorf("failed to update sensitive flag: %w", err)
+	}
+	// The creator is not loaded here, so subscribers learn only which post changed
+	s.publish(ctx, eventbus.PostUpdated, id, 0)
+
+	return nil
+}
+
+// publish announces a change to a post of creatorID, if anything subscribes to changes
+func (s *Service) publish(ctx context.Context, eventType string, id int64, creatorID int64) {
+	if s.changes != nil {
+		s.changes.Publish(ctx, eventbus.Event{Type: eventType, ID: id, AccountID: creatorID, PostID: id})
+	}
+}
+
+// LikePost likes a post visible to the account
+func (s *Service) LikePost(ctx context.Context, id int64, accountID int64) error {
+	if err := s.ensureVisible(ctx, id, accountID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Like(ctx, id, accountID); err != nil {
+		return fmt.Errorf("failed to like post: %w", err)
+	}
+
+	return nil
+}
+
+// UnlikePost removes the account's like from a post visible to it
+func (s *Service) UnlikePost(ctx context.Context, id int64, accountID int64) error {
+	if err := s.ensureVisible(ctx, id, accountID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Unlike(ctx, id, accountID); err != nil {
+		return fmt.Errorf("failed to unlike post: %w", err)
+	}
+
+	return nil
+}
+
+// ensureVisible checks that a post exists and the viewer may see it
+func (s *Service) ensureVisible(ctx context.Context, id int64, viewerID int64) error {
+	if _, err := s.repo.GetByID(ctx, id, viewerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return post.ErrPostNotFound
+		}
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+	return nil
+}
+
+// isModerator reports whether the account may moderate posts
+func (s *Service) isModerator(ctx context.Context, accountID int64) (bool, error) {
+	if s.moderators[accountID] {
+		return true, nil
+	}
+
+	role, err := s.repo.GetAccountRole(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get account role: %w", err)
+	}
+
+	return role == account.RoleModerator || role == account.RoleAdmin, nil
+}
+
+// checkOwnership ensures the post exists and belongs to the creator
+func (s *Service) checkOwnership(ctx context.Context, id int64, creatorID int64) error {
+	existingPost, err := s.repo.GetByID(ctx, id, creatorID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return post.ErrPostNotFound
+		}
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+
+	if existingPost.CreatorID != creatorID {
+		return post.ErrNotPostOwner
+	}
+
+	return nil
+}
+
 // GetPostsWithComments retrieves posts sorted by comment count with last 2 comments
-func (s *Service) GetPostsWithComments(ctx context.Context, cursor string, limit int) (*post.PostListResponse, error) {
-	response, err := s.repo.GetPostsSortedByComments(ctx, cursor, limit)
+func (s *Service) GetPostsWithComments(ctx context.Context, viewerID int64, cursor string, limit int) (*post.PostListResponse, error) {
+	return s.getPostsWithComments(ctx, viewerID, post.ListFilter{}, cursor, limit)
+}
+
+// getPostsWithComments retrieves the posts matching filter sorted by comment count
+func (s *Service) getPostsWithComments(ctx context.Context, viewerID int64, filter post.ListFilter, cursor string, limit int) (*post.PostListResponse, error) {
+	shared, err := s.sharedRead(ctx, postsByCommentsKey(viewerID, filter, cursor, limit), func(ctx context.Context) (interface{}, error) {
+		return s.repo.GetPostsSortedByComments(ctx, viewerID, filter, cursor, limit)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get posts sorted by comments: %w", err)
 	}
 
+	// Copy the page, as other requests share it and the posts are completed below
+	response := *shared.(*post.PostListResponse)
+	response.Posts = append([]post.Post(nil), response.Posts...)
+
 	// Add last 2 comments for each post
-	for i := range response.Posts {
-		comments, err := s.repo.GetLastComments(ctx, response.Posts[i].ID, 2)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get last comments for post %d: %w", response.Posts[i].ID, err)
-		}
-		response.Posts[i].Comments = comments
+	if err := s.attachComments(ctx, response.Posts, false); err != nil {
+		return nil, err
+	}
+
+	if err := s.applySensitivePreference(ctx, viewerID, response.Posts); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// postsByCommentsKey identifies a page of posts sorted by comment count for sharedRead
+func postsByCommentsKey(viewerID int64, filter post.ListFilter, cursor string, limit int) string {
+	var key strings.Builder
+	fmt.Fprintf(&key, "posts:comments:%d:%d:%q:%q", viewerID, limit, cursor, filter.Hashtag)
+	if filter.CreatedAfter != nil {
+		fmt.Fprintf(&key, ":after=%d", filter.CreatedAfter.UnixNano())
+	}
+	if filter.CreatedBefore != nil {
+		fmt.Fprintf(&key, ":before=%d", filter.CreatedBefore.UnixNano())
+	}
+	if filter.CreatorID != nil {
+		fmt.Fprintf(&key, ":creator=%d", *filter.CreatorID)
+	}
+	if filter.HasImage != nil {
+		fmt.Fprintf(&key, ":image=%t", *filter.HasImage)
+	}
+	return key.String()
+}
+
+// sharedRead runs read once for concurrent callers with the same key, so a burst of
+// identical requests, such as for a viral post, costs one repository call. Callers must
+// not modify the shared result. The read is not cancelled with the request that started
+// it, since others may wait for it; each caller stops waiting when its own request ends.
+func (s *Service) sharedRead(ctx context.Context, key string, read func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	results := s.reads.DoChan(key, func() (interface{}, error) {
+		readCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sharedReadTimeout)
+		defer cancel()
+		return read(readCtx)
+	})
+
+	select {
+	case result := <-results:
+		return result.Val, result.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// GetPosts retrieves the posts matching filter in the given sort order with cursor
+// pagination: most commented (the default), newest or most liked
+func (s *Service) GetPosts(ctx context.Context, viewerID int64, sort string, filter post.ListFilter, cursor string, limit int) (*post.PostListResponse, error) {
+	filter, err := s.validateFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	switch sort {
+	case "", post.SortMostCommented:
+		return s.getPostsWithComments(ctx, viewerID, filter, cursor, limit)
+	case post.SortNewest:
+		return s.getAllPosts(ctx, viewerID, filter, cursor, limit)
+	case post.SortMostLiked:
+		return s.getPostsSortedByLikes(ctx, viewerID, filter, cursor, limit)
+	default:
+		return nil, fmt.Errorf("%w: %s", post.ErrInvalidSort, sort)
+	}
+}
+
+// validateFilter checks a post list filter and normalizes its hashtag
+func (s *Service) validateFilter(filter post.ListFilter) (post.ListFilter, error) {
+	filter.Hashtag = strings.TrimPrefix(strings.TrimSpace(filter.Hashtag), "#")
+	if filter.Hashtag != "" && !hashtagPattern.MatchString(filter.Hashtag) {
+		return filter, fmt.Errorf("%w: hashtag must be 1-100 letters, digits or underscores", post.ErrInvalidFilter)
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return filter, fmt.Errorf("%w: created_after must be before created_before", post.ErrInvalidFilter)
+	}
+	return filter, nil
+}
+
+// getPostsSortedByLikes retrieves posts sorted by like count with comment counts and last 2 comments
+func (s *Service) getPostsSortedByLikes(ctx context.Context, viewerID int64, filter post.ListFilter, cursor string, limit int) (*post.PostListResponse, error) {
+	response, err := s.repo.GetPostsSortedByLikes(ctx, viewerID, filter, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts sorted by likes: %w", err)
+	}
+
+	if err := s.attachComments(ctx, response.Posts, true); err != nil {
+		return nil, err
+	}
+
+	if err := s.applySensitivePreference(ctx, viewerID, response.Posts); err != nil {
+		return nil, err
 	}
 
 	return response, nil
 }
 
 // GetPostsSortedByComments is an alias for GetPostsWithComments for backward compatibility
-func (s *Service) GetPostsSortedByComments(ctx context.Context, cursor string, limit int) (*post.PostListResponse, error) {
-	return s.GetPostsWithComments(ctx, cursor, limit)
+func (s *Service) GetPostsSortedByComments(ctx context.Context, viewerID int64, cursor string, limit int) (*post.PostListResponse, error) {
+	return s.GetPostsWithComments(ctx, viewerID, cursor, limit)
+}
+
+// GetPostsPage retrieves a page of the posts matching filter in the given sort order by
+// page number, with their comment counts, last 2 comments and the total count
+func (s *Service) GetPostsPage(ctx context.Context, viewerID int64, sort string, filter post.ListFilter, page pagination.Params) (*post.PostPageResponse, error) {
+	if sort == "" {
+		sort = post.SortMostCommented
+	}
+	if !post.IsValidSort(sort) {
+		return nil, fmt.Errorf("%w: %s", post.ErrInvalidSort, sort)
+	}
+	filter, err := s.validateFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, total, err := s.repo.GetAllPage(ctx, viewerID, sort, filter, page.Offset(), page.PerPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts: %w", err)
+	}
+
+	if err := s.attachComments(ctx, posts, false); err != nil {
+		return nil, err
+	}
+
+	if err := s.applySensitivePreference(ctx, viewerID, posts); err != nil {
+		return nil, err
+	}
+
+	return &post.PostPageResponse{Posts: posts, Pagination: pagination.NewInfo(page, total)}, nil
+}
+
+// GetUserPostsPage retrieves a page of a creator's posts by page number, with comment
+// counts, last comments and the total count
+func (s *Service) GetUserPostsPage(ctx context.Context, creatorID int64, viewerID int64, page pagination.Params) (*post.PostPageResponse, error) {
+	posts, total, err := s.repo.GetByCreatorIDPage(ctx, creatorID, viewerID, page.Offset(), page.PerPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user posts: %w", err)
+	}
+
+	if err := s.attachComments(ctx, posts, true); err != nil {
+		return nil, err
+	}
+
+	if err := s.applySensitivePreference(ctx, viewerID, posts); err != nil {
+		return nil, err
+	}
+
+	return &post.PostPageResponse{Posts: posts, Pagination: pagination.NewInfo(page, total)}, nil
+}
+
+// CountPosts counts the posts matching filter that are visible to the viewer
+func (s *Service) CountPosts(ctx context.Context, viewerID int64, filter post.ListFilter) (int64, error) {
+	filter, err := s.validateFilter(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	total, err := s.repo.CountAll(ctx, viewerID, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count posts: %w", err)
+	}
+	return total, nil
+}
+
+// CountUserPosts counts a creator's posts that are visible to the viewer
+func (s *Service) CountUserPosts(ctx context.Context, creatorID int64, viewerID int64) (int64, error) {
+	total, err := s.repo.CountByCreatorID(ctx, creatorID, viewerID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count user posts: %w", err)
+	}
+	return total, nil
+}
+
+// attachComments fills in the last 2 comments of each post, and the comment count when
+// withCounts is set, with one query each for the whole page, run concurrently
+func (s *Service) attachComments(ctx context.Context, posts []post.Post, withCounts bool) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(posts))
+	for i := range posts {
+		ids[i] = posts[i].ID
+	}
+
+	// The queries are independent, so they run at once; the first failure cancels the other
+	var counts map[int64]int64
+	var comments map[int64][]comment.Comment
+	group, groupCtx := errgroup.WithContext(ctx)
+	if withCounts {
+		group.Go(func() error {
+			var err error
+			if counts, err = s.commentRepo.GetCommentCounts(groupCtx, ids); err != nil {
+				return fmt.Errorf("failed to get comment counts: %w", err)
+			}
+			return nil
+		})
+	}
+	group.Go(func() error {
+		var err error
+		if comments, err = s.repo.GetLastCommentsForPosts(groupCtx, ids, 2); err != nil {
+			return fmt.Errorf("failed to get last comments: %w", err)
+		}
+		return nil
+	})
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	for i := range posts {
+		if withCounts {
+			posts[i].CommentCount = counts[posts[i].ID]
+		}
+		posts[i].Comments = comments[posts[i].ID]
+	}
+
+	return nil
 }
 
 // validateCaption validates the post caption
@@ -264,6 +992,81 @@ func (s *Service) validateCaption(caption string) error {
 	return nil
 }
 
+// resolveVisibility validates the requested visibility, defaulting to public
+func (s *Service) resolveVisibility(visibility string) (string, error) {
+	if visibility == "" {
+		return post.VisibilityPublic, nil
+	}
+	if !post.IsValidVisibility(visibility) {
+		return "", fmt.Errorf("%w: %s", post.ErrInvalidVisibility, visibility)
+	}
+	return visibility, nil
+}
+
+// applySensitivePreference flags sensitive posts in a list as blurred according to the
+// viewer's preference. Posts the viewer wants hidden are already left out by the repository.
+func (s *Service) applySensitivePreference(ctx context.Context, viewerID int64, posts []post.Post) error {
+	preference, err := s.sensitivePreference(ctx, viewerID)
+	if err != nil {
+		return err
+	}
+
+	for i := range posts {
+		markBlurred(&posts[i], viewerID, preference)
+	}
+
+	return nil
+}
+
+// sensitivePreference returns how the viewer wants sensitive posts shown
+func (s *Service) sensitivePreference(ctx context.Context, viewerID int64) (string, error) {
+	preference, err := s.repo.GetSensitiveContentPreference(ctx, viewerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get sensitive content preference: %w", err)
+	}
+	return preference, nil
+}
+
+// markBlurred flags another account's sensitive post as blurred unless the viewer chose to
+// see sensitive content as is. A post fetched directly is blurred for viewers who hide
+// sensitive content too.
+func markBlurred(p *post.Post, viewerID int64, preference string) {
+	if p.IsSensitive && p.CreatorID != viewerID && preference != account.SensitiveContentShow {
+		p.Blurred = true
+	}
+}
+
+// validateLocation validates an optional post location
+func (s *Service) validateLocation(location *post.Location) error {
+	if location == nil {
+		return nil
+	}
+	if math.IsNaN(location.Latitude) || location.Latitude < -90 || location.Latitude > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if math.IsNaN(location.Longitude) || location.Longitude < -180 || location.Longitude > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+	if len(location.PlaceName) > 255 {
+		return fmt.Errorf("place name must be at most 255 characters")
+	}
+	return nil
+}
+
+// setLocation copies an optional location onto the post
+func setLocation(p *post.Post, location *post.Location) {
+	if location == nil {
+		return
+	}
+	lat, lng := location.Latitude, location.Longitude
+	p.Latitude = &lat
+	p.Longitude = &lng
+	if location.PlaceName != "" {
+		placeName := location.PlaceName
+		p.PlaceName = &placeName
+	}
+}
+
 // generateImageURL generates the public URL for an image
 func (s *Service) generateImageURL(imagePath string) string {
 	// Extract filename from path
@@ -6,34 +6,130 @@ import (
 	"time"
 
 	"github.com/fanzru/social-media-service-go/internal/app/comment"
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
+	"github.com/fanzru/social-media-service-go/pkg/pagination"
 )
 
+// Errors returned by the post service
+var (
+	ErrPostNotFound      = apperr.NotFound("post not found")
+	ErrNotPostOwner      = apperr.Forbidden("you can only change your own posts")
+	ErrNotModerator      = apperr.Forbidden("only moderators can enforce the sensitive flag")
+	ErrSensitiveLocked   = apperr.Forbidden("sensitive flag is locked by a moderator")
+	ErrInvalidCaption    = apperr.Invalid("invalid caption")
+	ErrInvalidVisibility = apperr.Invalid("invalid visibility")
+	ErrInvalidLocation   = apperr.Invalid("invalid location")
+	ErrInvalidRadius     = apperr.Invalid("invalid radius")
+	ErrInvalidIDs        = apperr.Invalid("invalid ids")
+	ErrInvalidSort       = apperr.Invalid("invalid sort")
+	ErrInvalidFilter     = apperr.Invalid("invalid filter")
+)
+
+// Post visibility levels
+const (
+	VisibilityPublic    = "public"    // Visible to everyone
+	VisibilityFollowers = "followers" // Visible to the creator's followers
+	VisibilityPrivate   = "private"   // Visible to the creator only
+)
+
+// IsValidVisibility reports whether v is a supported visibility level
+func IsValidVisibility(v string) bool {
+	switch v {
+	case VisibilityPublic, VisibilityFollowers, VisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// Media statuses of a post's image
+const (
+	MediaProcessing = "processing" // The original is stored; image_url is served once processing ends
+	MediaReady      = "ready"      // image_url is served
+	MediaFailed     = "failed"     // The image could not be processed
+)
+
+// Post list sort modes
+const (
+	SortMostCommented = "most_commented" // Most comments first (default)
+	SortNewest        = "newest"         // Most recent first
+	SortMostLiked     = "most_liked"     // Most likes first
+)
+
+// IsValidSort reports whether s is a supported post list sort mode
+func IsValidSort(s string) bool {
+	switch s {
+	case SortMostCommented, SortNewest, SortMostLiked:
+		return true
+	}
+	return false
+}
+
+// ListFilter narrows the post list; nil fields and an empty Hashtag leave their condition out
+type ListFilter struct {
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	CreatorID     *int64
+	HasImage      *bool
+	Hashtag       string // Without the leading '#'
+}
+
+// Nearby search limits, in kilometers
+const (
+	DefaultNearbyRadiusKm = 5.0
+	MaxNearbyRadiusKm     = 100.0
+)
+
+// Location represents where a post was made
+type Location struct {
+	Latitude  float64 `json:"latitude" validate:"min=-90,max=90"`
+	Longitude float64 `json:"longitude" validate:"min=-180,max=180"`
+	PlaceName string  `json:"place_name,omitempty" validate:"max=255"`
+}
+
 // Post represents a social media post
 type Post struct {
-	ID          int64      `json:"id" db:"id"`
-	Caption     string     `json:"caption" db:"caption"`
-	ImagePath   string     `json:"image_path" db:"image_path"`
-	ImageURL    string     `json:"image_url" db:"image_url"`
-	CreatorID   int64      `json:"creator_id" db:"creator_id"`
-	CreatorName string     `json:"creator_name" db:"creator_name"`
-	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
-	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	ID                int64      `json:"id" db:"id"`
+	Caption           string     `json:"caption" db:"caption"`
+	ImagePath         string     `json:"image_path" db:"image_path"`
+	ImageURL          string     `json:"image_url" db:"image_url"`
+	CreatorID         int64      `json:"creator_id" db:"creator_id"`
+	CreatorName       string     `json:"creator_name" db:"creator_name"`
+	CreatorIsVerified bool       `json:"creator_is_verified" db:"creator_is_verified"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	Visibility        string     `json:"visibility" db:"visibility"`
+	Latitude          *float64   `json:"latitude,omitempty" db:"latitude"`
+	Longitude         *float64   `json:"longitude,omitempty" db:"longitude"`
+	PlaceName         *string    `json:"place_name,omitempty" db:"place_name"`
+	IsSensitive       bool       `json:"is_sensitive" db:"is_sensitive"`
+	SensitiveLocked   bool       `json:"-" db:"sensitive_locked"` // Set by moderators, the creator cannot clear the flag
+	MediaStatus       string     `json:"media_status" db:"media_status"`
+	OriginalImagePath string     `json:"-" db:"image_original_path"` // Original awaiting processing; only written on create
 
 	// Computed fields
+	Blurred      bool              `json:"blurred,omitempty" db:"-"`
+	DistanceKm   *float64          `json:"distance_km,omitempty" db:"distance_km"`
+	IsPinned     bool              `json:"is_pinned" db:"is_pinned"`
+	LikeCount    int64             `json:"like_count" db:"like_count"`
 	CommentCount int64             `json:"comment_count,omitempty" db:"comment_count"`
 	Comments     []comment.Comment `json:"comments,omitempty" db:"comments"`
 }
 
 // CreatePostRequest represents the request payload for creating a post
 type CreatePostRequest struct {
-	Caption string `json:"caption" validate:"required,max=1000"`
+	Caption     string    `json:"caption" validate:"required,max=1000"`
+	Visibility  string    `json:"visibility,omitempty" validate:"omitempty,oneof=public followers private"`
+	Location    *Location `json:"location,omitempty"`
+	IsSensitive bool      `json:"is_sensitive,omitempty"`
 	// Image will be handled separately via multipart form
 }
 
 // UpdatePostRequest represents the request payload for updating a post
 type UpdatePostRequest struct {
-	Caption string `json:"caption" validate:"max=1000"`
+	Caption     string `json:"caption" validate:"max=1000"`
+	Visibility  string `json:"visibility,omitempty" validate:"omitempty,oneof=public followers private"`
+	IsSensitive *bool  `json:"is_sensitive,omitempty"`
 }
 
 // PostListRequest represents the request payload for listing posts
@@ -44,9 +140,92 @@ type PostListRequest struct {
 
 // PostListResponse represents the response payload for listing posts
 type PostListResponse struct {
-	Posts   []Post `json:"posts"`
-	Cursor  string `json:"cursor,omitempty"`
-	HasMore bool   `json:"has_more"`
+	Posts      []Post            `json:"posts"`
+	Cursor     string            `json:"cursor,omitempty"`
+	HasMore    bool              `json:"has_more"`
+	Pagination pagination.Cursor `json:"pagination"`
+}
+
+// PostPageResponse represents a page of posts with offset pagination
+type PostPageResponse struct {
+	Posts      []Post          `json:"posts"`
+	Pagination pagination.Info `json:"pagination"`
+}
+
+// MaxBatchSize is the most posts that can be fetched by ID in one call
+const MaxBatchSize = 100
+
+// PostBatchResponse represents the response payload for fetching posts by ID
+type PostBatchResponse struct {
+	Posts      []Post  `json:"posts"`
+	MissingIDs []int64 `json:"missing_ids"` // Requested posts that do not exist or are not visible
+}
+
+// Delta sync limits
+const (
+	DefaultChangesLimit = 500
+	MaxChangesLimit     = 1000
+)
+
+// MediaJob is a post whose image awaits processing
+type MediaJob struct {
+	PostID            int64
+	CreatorID         int64
+	OriginalImagePath string
+	ImagePath         string
+	ImageURL          string
+}
+
+// FanoutJob is a new post waiting to be written to the timelines of its creator and
+// the creator's followers
+type FanoutJob struct {
+	PostID    int64
+	CreatorID int64
+	CreatedAt time.Time
+}
+
+// ImportPost is a post migrated from another platform
+type ImportPost struct {
+	ExternalID string // ID on the source platform, referenced by the post's comments
+	Caption    string
+	ImageURL   string // Kept as is; the image is not copied to storage
+	CreatorID  int64
+	Visibility string
+	CreatedAt  time.Time
+}
+
+// ImportComment is a comment migrated from another platform
+type ImportComment struct {
+	PostExternalID string // ExternalID of the post, imported in the same batch
+	Content        string
+	CreatorID      int64
+	CreatedAt      time.Time
+}
+
+// ImportResult counts the rows an import inserted
+type ImportResult struct {
+	Posts           int64
+	Comments        int64
+	SkippedPosts    int64 // Posts whose creator does not exist
+	SkippedComments int64 // Comments whose creator or post does not exist
+}
+
+// PostChange is a post created, updated or deleted at ChangedAt
+type PostChange struct {
+	ID        int64
+	CreatedAt time.Time
+	DeletedAt *time.Time
+	ChangedAt time.Time // The latest of created_at, updated_at and deleted_at
+}
+
+// PostChanges represents the response payload for delta sync. Each post is listed once,
+// under its latest change.
+type PostChanges struct {
+	Created   []int64   `json:"created"`
+	Updated   []int64   `json:"updated"`
+	Deleted   []int64   `json:"deleted"`
+	Watermark time.Time `json:"watermark"` // Pass as since on the next call
+	HasMore   bool      `json:"has_more"`
 }
 
 // PostResponse represents the response payload for a single post
@@ -54,30 +233,73 @@ type PostResponse struct {
 	Post Post `json:"post"`
 }
 
-// PostRepository defines the interface for post data access
+// PostRepository defines the interface for post data access.
+// Read methods take the ID of the requesting account (0 for anonymous) and only
+// return posts whose visibility allows that account to see them.
 type PostRepository interface {
 	Create(ctx context.Context, post *Post) error
-	GetByID(ctx context.Context, id int64) (*Post, error)
-	GetByCreatorID(ctx context.Context, creatorID int64, cursor string, limit int) (*PostListResponse, error)
-	GetAll(ctx context.Context, cursor string, limit int) (*PostListResponse, error)
+	GetByID(ctx context.Context, id int64, viewerID int64) (*Post, error)
+	GetByIDs(ctx context.Context, ids []int64, viewerID int64) ([]Post, error)
+	GetChangedSince(ctx context.Context, viewerID int64, since time.Time, limit int) ([]PostChange, error)
+	GetByCreatorID(ctx context.Context, creatorID int64, viewerID int64, cursor string, limit int) (*PostListResponse, error)
+	GetAll(ctx context.Context, viewerID int64, filter ListFilter, cursor string, limit int) (*PostListResponse, error)
 	Update(ctx context.Context, post *Post) error
 	SoftDelete(ctx context.Context, id int64) error
-	GetCommentCount(ctx context.Context, postID int64) (int64, error)
-	GetLastComments(ctx context.Context, postID int64, limit int) ([]comment.Comment, error)
-	GetPostsSortedByComments(ctx context.Context, cursor string, limit int) (*PostListResponse, error)
+	GetLastCommentsForPosts(ctx context.Context, postIDs []int64, limit int) (map[int64][]comment.Comment, error)
+	GetPostsSortedByComments(ctx context.Context, viewerID int64, filter ListFilter, cursor string, limit int) (*PostListResponse, error)
+	GetPostsSortedByLikes(ctx context.Context, viewerID int64, filter ListFilter, cursor string, limit int) (*PostListResponse, error)
+	GetAllPage(ctx context.Context, viewerID int64, sort string, filter ListFilter, offset int, limit int) ([]Post, int64, error)
+	GetByCreatorIDPage(ctx context.Context, creatorID int64, viewerID int64, offset int, limit int) ([]Post, int64, error)
+	CountAll(ctx context.Context, viewerID int64, filter ListFilter) (int64, error)
+	CountByCreatorID(ctx context.Context, creatorID int64, viewerID int64) (int64, error)
+	GetNearby(ctx context.Context, viewerID int64, lat float64, lng float64, radiusKm float64, cursor string, limit int) (*PostListResponse, error)
+	PinPost(ctx context.Context, accountID int64, postID int64) error
+	UnpinPost(ctx context.Context, accountID int64, postID int64) error
+	SetSensitive(ctx context.Context, id int64, isSensitive bool, locked bool) error
+	GetSensitiveContentPreference(ctx context.Context, accountID int64) (string, error)
+	GetAccountRole(ctx context.Context, accountID int64) (string, error)
+	Like(ctx context.Context, postID int64, accountID int64) error
+	Unlike(ctx context.Context, postID int64, accountID int64) error
+	ClaimMediaJob(ctx context.Context, staleBefore time.Time) (*MediaJob, error)
+	SetMediaStatus(ctx context.Context, postID int64, status string) error
+	// GetTimeline returns the newest posts of the account and the accounts it follows
+	GetTimeline(ctx context.Context, accountID int64, cursor string, limit int) (*PostListResponse, error)
+	ClaimFanoutJob(ctx context.Context, staleBefore time.Time) (*FanoutJob, error)
+	CountFollowers(ctx context.Context, accountID int64) (int64, error)
+	// AddPullAccount makes followers' timelines read the account's posts instead of having them written
+	AddPullAccount(ctx context.Context, accountID int64) error
+	// FanOut writes a post to its creator's timeline, and to the followers' too when toFollowers
+	// is set, then completes the job
+	FanOut(ctx context.Context, job *FanoutJob, toFollowers bool) error
+	// Import bulk inserts posts and their comments in one transaction
+	Import(ctx context.Context, posts []ImportPost, comments []ImportComment) (*ImportResult, error)
 }
 
 // PostService defines the interface for post business logic
 type PostService interface {
 	CreatePost(ctx context.Context, req *CreatePostRequest, creatorID int64, imagePath string) (*Post, error)
-	CreatePostWithImage(ctx context.Context, creatorID int64, caption string, file multipart.File, header *multipart.FileHeader) (*Post, error)
-	GetPost(ctx context.Context, id int64) (*Post, error)
-	GetPostByID(ctx context.Context, id int64) (*Post, error)
-	GetUserPosts(ctx context.Context, creatorID int64, cursor string, limit int) (*PostListResponse, error)
-	GetPostsByCreatorID(ctx context.Context, creatorID int64, cursor string, limit int) (*PostListResponse, error)
-	GetAllPosts(ctx context.Context, cursor string, limit int) (*PostListResponse, error)
-	GetPostsSortedByComments(ctx context.Context, cursor string, limit int) (*PostListResponse, error)
+	CreatePostWithImage(ctx context.Context, creatorID int64, caption string, visibility string, isSensitive bool, location *Location, file multipart.File, header *multipart.FileHeader) (*Post, error)
+	GetPost(ctx context.Context, id int64, viewerID int64) (*Post, error)
+	GetPostByID(ctx context.Context, id int64, viewerID int64) (*Post, error)
+	GetPostsByIDs(ctx context.Context, ids []int64, viewerID int64) (*PostBatchResponse, error)
+	GetPostChanges(ctx context.Context, viewerID int64, since time.Time, limit int) (*PostChanges, error)
+	GetUserPosts(ctx context.Context, creatorID int64, viewerID int64, cursor string, limit int) (*PostListResponse, error)
+	GetPostsByCreatorID(ctx context.Context, creatorID int64, viewerID int64, cursor string, limit int) (*PostListResponse, error)
+	GetAllPosts(ctx context.Context, viewerID int64, cursor string, limit int) (*PostListResponse, error)
+	GetPostsSortedByComments(ctx context.Context, viewerID int64, cursor string, limit int) (*PostListResponse, error)
+	GetPosts(ctx context.Context, viewerID int64, sort string, filter ListFilter, cursor string, limit int) (*PostListResponse, error)
+	GetPostsPage(ctx context.Context, viewerID int64, sort string, filter ListFilter, page pagination.Params) (*PostPageResponse, error)
+	GetUserPostsPage(ctx context.Context, creatorID int64, viewerID int64, page pagination.Params) (*PostPageResponse, error)
+	CountPosts(ctx context.Context, viewerID int64, filter ListFilter) (int64, error)
+	CountUserPosts(ctx context.Context, creatorID int64, viewerID int64) (int64, error)
+	GetNearbyPosts(ctx context.Context, viewerID int64, lat float64, lng float64, radiusKm float64, cursor string, limit int) (*PostListResponse, error)
+	GetTimeline(ctx context.Context, accountID int64, cursor string, limit int) (*PostListResponse, error)
 	UpdatePost(ctx context.Context, id int64, creatorID int64, req *UpdatePostRequest) (*Post, error)
 	DeletePost(ctx context.Context, id int64, creatorID int64) error
-	GetPostsWithComments(ctx context.Context, cursor string, limit int) (*PostListResponse, error)
+	GetPostsWithComments(ctx context.Context, viewerID int64, cursor string, limit int) (*PostListResponse, error)
+	PinPost(ctx context.Context, id int64, creatorID int64) error
+	UnpinPost(ctx context.Context, id int64, creatorID int64) error
+	ModerateSensitive(ctx context.Context, id int64, moderatorID int64, isSensitive bool) error
+	LikePost(ctx context.Context, id int64, accountID int64) error
+	UnlikePost(ctx context.Context, id int64, accountID int64) error
 }
@@ -13,6 +13,13 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for PostVisibility.
+const (
+	PostVisibilityFollowers PostVisibility = "followers"
+	PostVisibilityPrivate   PostVisibility = "private"
+	PostVisibilityPublic    PostVisibility = "public"
+)
+
 // Defines values for StandardResponseCode.
 const (
 	BADREQUEST          StandardResponseCode = "BAD_REQUEST"
@@ -25,6 +32,28 @@ const (
 	UNAUTHORIZED        StandardResponseCode = "UNAUTHORIZED"
 )
 
+// Defines values for GetApiPostsParamsSort.
+const (
+	MostCommented GetApiPostsParamsSort = "most_commented"
+	MostLiked     GetApiPostsParamsSort = "most_liked"
+	Newest        GetApiPostsParamsSort = "newest"
+)
+
+// Defines values for PostApiPostsMultipartBodyVisibility.
+const (
+	PostApiPostsMultipartBodyVisibilityFollowers PostApiPostsMultipartBodyVisibility = "followers"
+	PostApiPostsMultipartBodyVisibilityPrivate   PostApiPostsMultipartBodyVisibility = "private"
+	PostApiPostsMultipartBodyVisibilityPublic    PostApiPostsMultipartBodyVisibility = "public"
+)
+
+// ModerateSensitiveRequest defines model for ModerateSensitiveRequest.
+type ModerateSensitiveRequest struct {
+	IsSensitive bool `json:"is_sensitive"`
+}
+
+// PostVisibility public - everyone, followers - accounts following the creator, private - only the creator
+type PostVisibility string
+
 // StandardResponse defines model for StandardResponse.
 type StandardResponse struct {
 	Code *StandardResponseCode `json:"code,omitempty"`
@@ -43,34 +72,151 @@ type StandardResponseCode string
 // UpdatePostRequest defines model for UpdatePostRequest.
 type UpdatePostRequest struct {
 	Caption string `json:"caption"`
+
+	// IsSensitive Mark or unmark the post as sensitive; cannot be cleared once a moderator marked it
+	IsSensitive *bool `json:"is_sensitive,omitempty"`
+
+	// Visibility public - everyone, followers - accounts following the creator, private - only the creator
+	Visibility *PostVisibility `json:"visibility,omitempty"`
 }
 
 // GetApiPostsParams defines parameters for GetApiPosts.
 type GetApiPostsParams struct {
-	// Cursor Cursor for pagination
+	// Ids Comma-separated IDs of up to 100 posts to fetch in one call, returned in the given order with their latest comments; cursor and limit are ignored
+	Ids *[]int64 `form:"ids,omitempty" json:"ids,omitempty"`
+
+	// Sort Sort order: most commented first (default), newest first, or most liked first
+	Sort *GetApiPostsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// CreatedAfter Only posts created after this time
+	CreatedAfter *time.Time `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore Only posts created before this time
+	CreatedBefore *time.Time `form:"created_before,omitempty" json:"created_before,omitempty"`
+
+	// CreatorId Only posts by this account
+	CreatorId *int64 `form:"creator_id,omitempty" json:"creator_id,omitempty"`
+
+	// HasImage Only posts with (true) or without (false) an image
+	HasImage *bool `form:"has_image,omitempty" json:"has_image,omitempty"`
+
+	// Hashtag Only posts whose caption has this hashtag, with or without the leading '#'; letters, digits and underscores
+	Hashtag *string `form:"hashtag,omitempty" json:"hashtag,omitempty"`
+
+	// Fields Comma-separated post fields to return, e.g. caption,image_url,comment_count; id is always included
+	Fields *[]string `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Page Page number for offset pagination; with page or per_page the response has pagination totals instead of a cursor
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// PerPage Items per page for offset pagination (max 100)
+	PerPage *int `form:"per_page,omitempty" json:"per_page,omitempty"`
+
+	// Cursor Opaque signed cursor from the previous page's next_cursor
 	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
 
 	// Limit Number of posts to return (max 100)
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// IncludeTotal Also count all posts in the listing as pagination.total_count, at the cost of an extra query
+	IncludeTotal *bool `form:"include_total,omitempty" json:"include_total,omitempty"`
 }
 
+// GetApiPostsParamsSort defines parameters for GetApiPosts.
+type GetApiPostsParamsSort string
+
 // PostApiPostsMultipartBody defines parameters for PostApiPosts.
 type PostApiPostsMultipartBody struct {
 	Caption string `json:"caption"`
 
 	// Image Image file (PNG, JPG, JPEG, BMP)
 	Image openapi_types.File `json:"image"`
+
+	// IsSensitive Mark the post as sensitive content
+	IsSensitive *bool `json:"is_sensitive,omitempty"`
+
+	// Latitude Latitude of the post location (requires longitude)
+	Latitude *float64 `json:"latitude,omitempty"`
+
+	// Longitude Longitude of the post location (requires latitude)
+	Longitude *float64 `json:"longitude,omitempty"`
+
+	// PlaceName Human readable place name (requires latitude and longitude)
+	PlaceName *string `json:"place_name,omitempty"`
+
+	// Visibility Who can see the post
+	Visibility *PostApiPostsMultipartBodyVisibility `json:"visibility,omitempty"`
 }
 
+// PostApiPostsMultipartBodyVisibility defines parameters for PostApiPosts.
+type PostApiPostsMultipartBodyVisibility string
+
 // GetApiPostsByUserUserIdParams defines parameters for GetApiPostsByUserUserId.
 type GetApiPostsByUserUserIdParams struct {
-	// Cursor Cursor for pagination
+	// Fields Comma-separated post fields to return, e.g. caption,image_url,comment_count; id is always included
+	Fields *[]string `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Page Page number for offset pagination; with page or per_page the response has pagination totals instead of a cursor
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// PerPage Items per page for offset pagination (max 100)
+	PerPage *int `form:"per_page,omitempty" json:"per_page,omitempty"`
+
+	// Cursor Opaque signed cursor from the previous page's next_cursor
 	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
 
 	// Limit Number of posts to return (max 100)
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// IncludeTotal Also count all posts in the listing as pagination.total_count, at the cost of an extra query
+	IncludeTotal *bool `form:"include_total,omitempty" json:"include_total,omitempty"`
 }
 
+// GetApiPostsChangesParams defines parameters for GetApiPostsChanges.
+type GetApiPostsChangesParams struct {
+	// Since Watermark from the previous call; only changes after it are returned
+	Since time.Time `form:"since" json:"since"`
+
+	// Limit Number of changes to return (max 1000)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetApiPostsNearbyParams defines parameters for GetApiPostsNearby.
+type GetApiPostsNearbyParams struct {
+	// Lat Latitude of the center point
+	Lat float64 `form:"lat" json:"lat"`
+
+	// Lng Longitude of the center point
+	Lng float64 `form:"lng" json:"lng"`
+
+	// Radius Search radius in kilometers (max 100)
+	Radius *float64 `form:"radius,omitempty" json:"radius,omitempty"`
+
+	// Fields Comma-separated post fields to return, e.g. caption,image_url,comment_count; id is always included
+	Fields *[]string `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Cursor Opaque signed cursor from the previous page's next_cursor
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Number of posts to return (max 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetApiPostsTimelineParams defines parameters for GetApiPostsTimeline.
+type GetApiPostsTimelineParams struct {
+	// Fields Comma-separated post fields to return, e.g. caption,image_url,comment_count; id is always included
+	Fields *[]string `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// Cursor Opaque signed cursor from the previous page's next_cursor
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Number of posts to return (max 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PutApiModerationPostsIdSensitiveJSONRequestBody defines body for PutApiModerationPostsIdSensitive for application/json ContentType.
+type PutApiModerationPostsIdSensitiveJSONRequestBody = ModerateSensitiveRequest
+
 // PostApiPostsMultipartRequestBody defines body for PostApiPosts for multipart/form-data ContentType.
 type PostApiPostsMultipartRequestBody PostApiPostsMultipartBody
 
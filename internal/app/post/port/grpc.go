@@ -0,0 +1,101 @@
+package port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fanzru/social-media-service-go/internal/app/post"
+	"github.com/fanzru/social-media-service-go/internal/app/post/port/gengrpc"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GRPCServer serves the post gRPC API
+type GRPCServer struct {
+	gengrpc.UnimplementedPostServiceServer
+	service post.PostService
+}
+
+// NewGRPCServer creates a new post gRPC server
+func NewGRPCServer(service post.PostService) *GRPCServer {
+	return &GRPCServer{service: service}
+}
+
+// Ensure GRPCServer implements gengrpc.PostServiceServer
+var _ gengrpc.PostServiceServer = (*GRPCServer)(nil)
+
+// GetPost implements gengrpc.PostServiceServer
+func (s *GRPCServer) GetPost(ctx context.Context, req *gengrpc.GetPostRequest) (*gengrpc.Post, error) {
+	viewerID, _ := middleware.GetUserID(ctx)
+
+	fetchedPost, err := s.service.GetPost(ctx, req.GetId(), viewerID)
+	if err != nil {
+		if errors.Is(err, post.ErrPostNotFound) {
+			return nil, status.Error(codes.NotFound, "post not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return postMessage(fetchedPost), nil
+}
+
+// ListPosts implements gengrpc.PostServiceServer
+func (s *GRPCServer) ListPosts(ctx context.Context, req *gengrpc.ListPostsRequest) (*gengrpc.ListPostsResponse, error) {
+	viewerID, _ := middleware.GetUserID(ctx)
+
+	posts, err := s.service.GetAllPosts(ctx, viewerID, req.GetCursor(), int(req.GetLimit()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return listMessage(posts), nil
+}
+
+// ListAccountPosts implements gengrpc.PostServiceServer
+func (s *GRPCServer) ListAccountPosts(ctx context.Context, req *gengrpc.ListAccountPostsRequest) (*gengrpc.ListPostsResponse, error) {
+	viewerID, _ := middleware.GetUserID(ctx)
+
+	posts, err := s.service.GetPostsByCreatorID(ctx, req.GetAccountId(), viewerID, req.GetCursor(), int(req.GetLimit()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return listMessage(posts), nil
+}
+
+// postMessage converts a post to its protobuf message
+func postMessage(p *post.Post) *gengrpc.Post {
+	msg := &gengrpc.Post{
+		Id:                p.ID,
+		Caption:           p.Caption,
+		ImageUrl:          p.ImageURL,
+		CreatorId:         p.CreatorID,
+		CreatorName:       p.CreatorName,
+		CreatorIsVerified: p.CreatorIsVerified,
+		Visibility:        p.Visibility,
+		IsSensitive:       p.IsSensitive,
+		Blurred:           p.Blurred,
+		IsPinned:          p.IsPinned,
+		CommentCount:      p.CommentCount,
+		CreatedAt:         timestamppb.New(p.CreatedAt),
+		UpdatedAt:         timestamppb.New(p.UpdatedAt),
+	}
+	if p.Latitude != nil && p.Longitude != nil {
+		msg.Location = &gengrpc.Location{Latitude: *p.Latitude, Longitude: *p.Longitude}
+		if p.PlaceName != nil {
+			msg.Location.PlaceName = *p.PlaceName
+		}
+	}
+	return msg
+}
+
+// listMessage converts a page of posts to its protobuf message
+func listMessage(list *post.PostListResponse) *gengrpc.ListPostsResponse {
+	msg := &gengrpc.ListPostsResponse{Cursor: list.Cursor, HasMore: list.HasMore}
+	for i := range list.Posts {
+		msg.Posts = append(msg.Posts, postMessage(&list.Posts[i]))
+	}
+	return msg
+}
@@ -2,12 +2,17 @@ package port
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/fanzru/social-media-service-go/internal/app/post"
 	"github.com/fanzru/social-media-service-go/internal/app/post/port/genhttp"
 	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"github.com/fanzru/social-media-service-go/pkg/pagination"
 	"github.com/fanzru/social-media-service-go/pkg/response"
+	"github.com/fanzru/social-media-service-go/pkg/storage"
 )
 
 // Handler handles HTTP requests for posts
@@ -30,7 +35,7 @@ func (h *Handler) PostApiPosts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := r.ParseMultipartForm(32 << 20)
+	err := r.ParseMultipartForm(storage.MaxUploadMemory)
 	if err != nil {
 		response.BadRequest(r.Context(), "Failed to parse multipart form", []string{err.Error()}).Send(w, http.StatusBadRequest)
 		return
@@ -42,6 +47,27 @@ func (h *Handler) PostApiPosts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	visibility := r.FormValue("visibility")
+	if visibility != "" && !post.IsValidVisibility(visibility) {
+		response.BadRequest(r.Context(), "Invalid visibility", []string{"visibility must be one of public, followers, private"}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	isSensitive := false
+	if value := r.FormValue("is_sensitive"); value != "" {
+		isSensitive, err = strconv.ParseBool(value)
+		if err != nil {
+			response.BadRequest(r.Context(), "Invalid is_sensitive", []string{"is_sensitive must be a boolean"}).Send(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	location, err := parseLocation(r)
+	if err != nil {
+		response.BadRequest(r.Context(), "Invalid location", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return
+	}
+
 	file, header, err := r.FormFile("image")
 	if err != nil {
 		response.BadRequest(r.Context(), "Image file is required", []string{"image field is missing"}).Send(w, http.StatusBadRequest)
@@ -49,17 +75,67 @@ func (h *Handler) PostApiPosts(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
-	createdPost, err := h.service.CreatePostWithImage(r.Context(), userID, caption, file, header)
+	createdPost, err := h.service.CreatePostWithImage(r.Context(), userID, caption, visibility, isSensitive, location, file, header)
 	if err != nil {
-		response.InternalServerError(r.Context(), "Failed to create post", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		response.SendError(r.Context(), w, err, "Failed to create post")
 		return
 	}
 
 	response.Success(r.Context(), "Post created successfully", createdPost).Send(w, http.StatusCreated)
 }
 
-// GetApiPosts handles GET /api/posts
+// GetApiPosts handles GET /api/posts, and GET /api/posts?ids=1,2,3 for fetching posts by ID
 func (h *Handler) GetApiPosts(w http.ResponseWriter, r *http.Request, params genhttp.GetApiPostsParams) {
+	// Anonymous viewers only see public posts
+	viewerID, _ := middleware.GetUserID(r.Context())
+
+	fields, ok := parsePostFields(w, r, params.Fields)
+	if !ok {
+		return
+	}
+
+	if params.Ids != nil {
+		posts, err := h.service.GetPostsByIDs(r.Context(), *params.Ids, viewerID)
+		if err != nil {
+			response.SendError(r.Context(), w, err, "Failed to get posts")
+			return
+		}
+
+		sendPosts(w, r, "Posts retrieved successfully", posts, fields)
+		return
+	}
+
+	sort := ""
+	if params.Sort != nil {
+		sort = string(*params.Sort)
+	}
+
+	filter := post.ListFilter{
+		CreatedAfter:  params.CreatedAfter,
+		CreatedBefore: params.CreatedBefore,
+		CreatorID:     params.CreatorId,
+		HasImage:      params.HasImage,
+	}
+	if params.Hashtag != nil {
+		filter.Hashtag = *params.Hashtag
+	}
+
+	if params.Page != nil || params.PerPage != nil {
+		page, ok := parsePage(w, r, params.Page, params.PerPage, params.Cursor)
+		if !ok {
+			return
+		}
+
+		posts, err := h.service.GetPostsPage(r.Context(), viewerID, sort, filter, page)
+		if err != nil {
+			response.SendError(r.Context(), w, err, "Failed to get posts")
+			return
+		}
+
+		sendPosts(w, r, "Posts retrieved successfully", posts, fields)
+		return
+	}
+
 	cursor := ""
 	if params.Cursor != nil {
 		cursor = *params.Cursor
@@ -70,23 +146,124 @@ func (h *Handler) GetApiPosts(w http.ResponseWriter, r *http.Request, params gen
 		limit = *params.Limit
 	}
 
-	posts, err := h.service.GetPostsSortedByComments(r.Context(), cursor, limit)
+	posts, err := h.service.GetPosts(r.Context(), viewerID, sort, filter, cursor, limit)
 	if err != nil {
-		response.InternalServerError(r.Context(), "Failed to get posts", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		response.SendError(r.Context(), w, err, "Failed to get posts")
 		return
 	}
 
-	response.Success(r.Context(), "Posts retrieved successfully", posts).Send(w, http.StatusOK)
+	if params.IncludeTotal != nil && *params.IncludeTotal {
+		total, err := h.service.CountPosts(r.Context(), viewerID, filter)
+		if err != nil {
+			response.SendError(r.Context(), w, err, "Failed to get posts")
+			return
+		}
+		posts.Pagination.TotalCount = &total
+	}
+
+	sendPosts(w, r, "Posts retrieved successfully", posts, fields)
+}
+
+// GetApiPostsNearby handles GET /api/posts/nearby
+func (h *Handler) GetApiPostsNearby(w http.ResponseWriter, r *http.Request, params genhttp.GetApiPostsNearbyParams) {
+	viewerID, _ := middleware.GetUserID(r.Context())
+
+	fields, ok := parsePostFields(w, r, params.Fields)
+	if !ok {
+		return
+	}
+
+	radius := 0.0
+	if params.Radius != nil {
+		radius = *params.Radius
+	}
+
+	cursor := ""
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	posts, err := h.service.GetNearbyPosts(r.Context(), viewerID, params.Lat, params.Lng, radius, cursor, limit)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to get nearby posts")
+		return
+	}
+
+	sendPosts(w, r, "Nearby posts retrieved successfully", posts, fields)
+}
+
+// GetApiPostsTimeline handles GET /api/posts/timeline
+func (h *Handler) GetApiPostsTimeline(w http.ResponseWriter, r *http.Request, params genhttp.GetApiPostsTimelineParams) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	fields, ok := parsePostFields(w, r, params.Fields)
+	if !ok {
+		return
+	}
+
+	cursor := ""
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	posts, err := h.service.GetTimeline(r.Context(), userID, cursor, limit)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to get timeline")
+		return
+	}
+
+	sendPosts(w, r, "Timeline retrieved successfully", posts, fields)
+}
+
+// GetApiPostsChanges handles GET /api/posts/changes
+func (h *Handler) GetApiPostsChanges(w http.ResponseWriter, r *http.Request, params genhttp.GetApiPostsChangesParams) {
+	viewerID, _ := middleware.GetUserID(r.Context())
+
+	limit := post.DefaultChangesLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	changes, err := h.service.GetPostChanges(r.Context(), viewerID, params.Since, limit)
+	if err != nil {
+		response.InternalServerError(r.Context(), "Failed to get post changes", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	response.Success(r.Context(), "Post changes retrieved successfully", changes).Send(w, http.StatusOK)
 }
 
 // GetApiPostsId handles GET /api/posts/{id}
 func (h *Handler) GetApiPostsId(w http.ResponseWriter, r *http.Request, id int64) {
-	fetchedPost, err := h.service.GetPostByID(r.Context(), id)
+	viewerID, _ := middleware.GetUserID(r.Context())
+
+	fetchedPost, err := h.service.GetPostByID(r.Context(), id, viewerID)
 	if err != nil {
-		response.NotFound(r.Context(), "Post not found", []string{err.Error()}).Send(w, http.StatusNotFound)
+		response.SendError(r.Context(), w, err, "Failed to get post")
 		return
 	}
 
+	// The tag covers what changes without bumping updated_at: comments, likes and blurring
+	etagParts := []interface{}{fetchedPost.ID, fetchedPost.UpdatedAt, fetchedPost.CreatorName, fetchedPost.CreatorIsVerified, fetchedPost.Blurred, fetchedPost.CommentCount, fetchedPost.LikeCount}
+	for _, c := range fetchedPost.Comments {
+		etagParts = append(etagParts, c.ID, c.UpdatedAt, c.LikeCount)
+	}
+	response.SetETag(w, etagParts...)
+
 	response.Success(r.Context(), "Post retrieved successfully", fetchedPost).Send(w, http.StatusOK)
 }
 
@@ -107,18 +284,14 @@ func (h *Handler) PutApiPostsId(w http.ResponseWriter, r *http.Request, id int64
 	updateReq := &post.UpdatePostRequest{
 		Caption: req.Caption,
 	}
+	if req.Visibility != nil {
+		updateReq.Visibility = string(*req.Visibility)
+	}
+	updateReq.IsSensitive = req.IsSensitive
 
 	updatedPost, err := h.service.UpdatePost(r.Context(), id, userID, updateReq)
 	if err != nil {
-		if err.Error() == "post not found" {
-			response.NotFound(r.Context(), "Post not found", []string{err.Error()}).Send(w, http.StatusNotFound)
-			return
-		}
-		if err.Error() == "unauthorized" {
-			response.Forbidden(r.Context(), "Not authorized to update this post", []string{err.Error()}).Send(w, http.StatusForbidden)
-			return
-		}
-		response.InternalServerError(r.Context(), "Failed to update post", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		response.SendError(r.Context(), w, err, "Failed to update post")
 		return
 	}
 
@@ -135,23 +308,129 @@ func (h *Handler) DeleteApiPostsId(w http.ResponseWriter, r *http.Request, id in
 
 	err := h.service.DeletePost(r.Context(), id, userID)
 	if err != nil {
-		if err.Error() == "post not found" {
-			response.NotFound(r.Context(), "Post not found", []string{err.Error()}).Send(w, http.StatusNotFound)
-			return
-		}
-		if err.Error() == "unauthorized" {
-			response.Forbidden(r.Context(), "Not authorized to delete this post", []string{err.Error()}).Send(w, http.StatusForbidden)
-			return
-		}
-		response.InternalServerError(r.Context(), "Failed to delete post", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		response.SendError(r.Context(), w, err, "Failed to delete post")
 		return
 	}
 
 	response.Success(r.Context(), "Post deleted successfully", nil).Send(w, http.StatusOK)
 }
 
+// PostApiPostsIdPin handles POST /api/posts/{id}/pin
+func (h *Handler) PostApiPostsIdPin(w http.ResponseWriter, r *http.Request, id int64) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	err := h.service.PinPost(r.Context(), id, userID)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to pin post")
+		return
+	}
+
+	response.Success(r.Context(), "Post pinned successfully", nil).Send(w, http.StatusOK)
+}
+
+// DeleteApiPostsIdPin handles DELETE /api/posts/{id}/pin
+func (h *Handler) DeleteApiPostsIdPin(w http.ResponseWriter, r *http.Request, id int64) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	err := h.service.UnpinPost(r.Context(), id, userID)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to unpin post")
+		return
+	}
+
+	response.Success(r.Context(), "Post unpinned successfully", nil).Send(w, http.StatusOK)
+}
+
+// PostApiPostsIdLike handles POST /api/posts/{id}/like
+func (h *Handler) PostApiPostsIdLike(w http.ResponseWriter, r *http.Request, id int64) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	err := h.service.LikePost(r.Context(), id, userID)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to like post")
+		return
+	}
+
+	response.Success(r.Context(), "Post liked successfully", nil).Send(w, http.StatusOK)
+}
+
+// DeleteApiPostsIdLike handles DELETE /api/posts/{id}/like
+func (h *Handler) DeleteApiPostsIdLike(w http.ResponseWriter, r *http.Request, id int64) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	err := h.service.UnlikePost(r.Context(), id, userID)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to unlike post")
+		return
+	}
+
+	response.Success(r.Context(), "Post unliked successfully", nil).Send(w, http.StatusOK)
+}
+
+// PutApiModerationPostsIdSensitive handles PUT /api/moderation/posts/{id}/sensitive
+func (h *Handler) PutApiModerationPostsIdSensitive(w http.ResponseWriter, r *http.Request, id int64) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	var req genhttp.ModerateSensitiveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(r.Context(), "Invalid request body", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	err := h.service.ModerateSensitive(r.Context(), id, userID, req.IsSensitive)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to update sensitive flag")
+		return
+	}
+
+	response.Success(r.Context(), "Sensitive flag updated successfully", nil).Send(w, http.StatusOK)
+}
+
 // GetApiPostsUserUserId handles GET /api/posts/user/{userId}
 func (h *Handler) GetApiPostsByUserUserId(w http.ResponseWriter, r *http.Request, userId int64, params genhttp.GetApiPostsByUserUserIdParams) {
+	viewerID, _ := middleware.GetUserID(r.Context())
+
+	fields, ok := parsePostFields(w, r, params.Fields)
+	if !ok {
+		return
+	}
+
+	if params.Page != nil || params.PerPage != nil {
+		page, ok := parsePage(w, r, params.Page, params.PerPage, params.Cursor)
+		if !ok {
+			return
+		}
+
+		posts, err := h.service.GetUserPostsPage(r.Context(), userId, viewerID, page)
+		if err != nil {
+			response.InternalServerError(r.Context(), "Failed to get user posts", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+			return
+		}
+
+		sendPosts(w, r, "User posts retrieved successfully", posts, fields)
+		return
+	}
+
 	cursor := ""
 	if params.Cursor != nil {
 		cursor = *params.Cursor
@@ -162,13 +441,95 @@ func (h *Handler) GetApiPostsByUserUserId(w http.ResponseWriter, r *http.Request
 		limit = *params.Limit
 	}
 
-	posts, err := h.service.GetPostsByCreatorID(r.Context(), userId, cursor, limit)
+	posts, err := h.service.GetPostsByCreatorID(r.Context(), userId, viewerID, cursor, limit)
 	if err != nil {
-		response.InternalServerError(r.Context(), "Failed to get user posts", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		response.SendError(r.Context(), w, err, "Failed to get user posts")
 		return
 	}
 
-	response.Success(r.Context(), "User posts retrieved successfully", posts).Send(w, http.StatusOK)
+	if params.IncludeTotal != nil && *params.IncludeTotal {
+		total, err := h.service.CountUserPosts(r.Context(), userId, viewerID)
+		if err != nil {
+			response.InternalServerError(r.Context(), "Failed to get user posts", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+			return
+		}
+		posts.Pagination.TotalCount = &total
+	}
+
+	sendPosts(w, r, "User posts retrieved successfully", posts, fields)
+}
+
+// parsePostFields reads the fields parameter of post lists, answering 400 for unknown fields
+func parsePostFields(w http.ResponseWriter, r *http.Request, requested *[]string) (response.Fields, bool) {
+	if requested == nil {
+		return nil, true
+	}
+	fields, err := response.ParseFields(*requested, post.Post{})
+	if err != nil {
+		response.BadRequest(r.Context(), "Invalid fields", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return nil, false
+	}
+	return fields, true
+}
+
+// parsePage reads the offset pagination parameters, answering 400 when they are invalid or
+// combined with a cursor
+func parsePage(w http.ResponseWriter, r *http.Request, page *int, perPage *int, cursor *string) (pagination.Params, bool) {
+	if cursor != nil {
+		response.BadRequest(r.Context(), "Invalid pagination", []string{"use either cursor or page and per_page, not both"}).Send(w, http.StatusBadRequest)
+		return pagination.Params{}, false
+	}
+	params, err := pagination.New(page, perPage)
+	if err != nil {
+		response.BadRequest(r.Context(), "Invalid pagination", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return pagination.Params{}, false
+	}
+	return params, true
+}
+
+// sendPosts sends a list of posts reduced to the requested fields
+func sendPosts(w http.ResponseWriter, r *http.Request, message string, posts interface{}, fields response.Fields) {
+	// Protobuf clients get the gRPC list message; sparse fieldsets do not apply to it
+	if list, ok := posts.(*post.PostListResponse); ok && response.Prefers(r, response.ContentTypeProtobuf) {
+		response.Success(r.Context(), message, listMessage(list)).SendNegotiated(w, r, http.StatusOK)
+		return
+	}
+
+	data, err := fields.Select(posts, "posts")
+	if err != nil {
+		response.InternalServerError(r.Context(), "Failed to select fields", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+	response.Success(r.Context(), message, data).SendNegotiated(w, r, http.StatusOK)
+}
+
+// parseLocation reads the optional latitude, longitude and place_name form fields.
+// Latitude and longitude must be given together; place_name requires both.
+func parseLocation(r *http.Request) (*post.Location, error) {
+	latValue := r.FormValue("latitude")
+	lngValue := r.FormValue("longitude")
+	placeName := strings.TrimSpace(r.FormValue("place_name"))
+
+	if latValue == "" && lngValue == "" {
+		if placeName != "" {
+			return nil, fmt.Errorf("place_name requires latitude and longitude")
+		}
+		return nil, nil
+	}
+	if latValue == "" || lngValue == "" {
+		return nil, fmt.Errorf("latitude and longitude must be provided together")
+	}
+
+	lat, err := strconv.ParseFloat(latValue, 64)
+	if err != nil {
+		return nil, fmt.Errorf("latitude must be a number")
+	}
+	lng, err := strconv.ParseFloat(lngValue, 64)
+	if err != nil {
+		return nil, fmt.Errorf("longitude must be a number")
+	}
+
+	return &post.Location{Latitude: lat, Longitude: lng, PlaceName: placeName}, nil
 }
 
 // Implement the generated interface
@@ -3,90 +3,229 @@ package repo
 import (
 	"context"
 	"database/sql"
-	"encoding/base64"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/fanzru/social-media-service-go/internal/app/account"
 	"github.com/fanzru/social-media-service-go/internal/app/comment"
 	"github.com/fanzru/social-media-service-go/internal/app/post"
+	"github.com/fanzru/social-media-service-go/pkg/pagination"
 	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
+	"github.com/jackc/pgx/v5"
 )
 
+// postColumns lists the posts columns scanned by postFields, in order
+const postColumns = `id, caption, image_path, image_url, creator_id, creator_name, created_at, updated_at, deleted_at, visibility,
+			latitude, longitude, place_name, is_sensitive, sensitive_locked, media_status,
+			COALESCE((SELECT a.is_verified FROM accounts a WHERE a.id = creator_id), FALSE) AS creator_is_verified,
+			(SELECT COUNT(*) FROM post_likes pl WHERE pl.post_id = id) AS like_count`
+
+// earthRadiusKm is the mean Earth radius used for haversine distances
+const earthRadiusKm = 6371.0
+
+// Listings of cursors, so a cursor of one listing is rejected by the others
+const (
+	listingPosts           = "posts:newest"
+	listingPostsByComments = "posts:comments"
+	listingPostsByLikes    = "posts:likes"
+	listingUserPosts       = "posts:user"
+	listingNearbyPosts     = "posts:nearby"
+	listingTimeline        = "posts:timeline"
+)
+
+// prepareTimeout bounds preparing the repository's statements at construction
+const prepareTimeout = 5 * time.Second
+
+// createQuery inserts a post, queues it to be written to timelines and returns its ID
+const createQuery = `
+		WITH created AS (
+			INSERT INTO posts (caption, image_path, image_url, creator_id, creator_name, visibility, latitude, longitude, place_name, is_sensitive, media_status, image_original_path, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, COALESCE(NULLIF($11, ''), 'ready'), NULLIF($12, ''), $13, $14)
+			RETURNING id, media_status
+		), queued AS (
+			INSERT INTO timeline_fanouts (post_id) SELECT id FROM created
+		)
+		SELECT id, media_status FROM created
+	`
+
+// getByIDQuery selects post $1 if it is visible to viewer $2
+var getByIDQuery = `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE id = $1 AND deleted_at IS NULL AND ` + visibleTo("$2") + `
+	`
+
 // Repository implements post repository interface
 type Repository struct {
-	db interface{} // Can be *sql.DB or *sqlwrap.DB
+	db    interface{}              // Can be *sql.DB or *sqlwrap.DB
+	stmts map[string]*sqlwrap.Stmt // Prepared statements of the most frequent queries, keyed by their SQL
 }
 
-// NewRepository creates a new post repository
+// NewRepository creates a new post repository. It prepares the queries run on every post
+// view and feed page: creating and getting a post, and the first and later pages of the
+// unfiltered feed in each sort order. Queries that fail to prepare run unprepared.
 func NewRepository(db interface{}) *Repository {
-	return &Repository{db: db}
+	ctx, cancel := context.WithTimeout(context.Background(), prepareTimeout)
+	defer cancel()
+
+	unfilteredWhere, unfilteredArgs := allPostsWhere(0, post.ListFilter{})
+	queries := []string{
+		createQuery,
+		getByIDQuery,
+		getAllQuery("", len(unfilteredArgs), false),
+		getAllQuery("", len(unfilteredArgs), true),
+		`SELECT COUNT(*) ` + unfilteredWhere,
+	}
+	for _, sort := range []string{post.SortNewest, post.SortMostLiked, post.SortMostCommented} {
+		queries = append(queries, allPostsPageQuery(unfilteredWhere, sort, len(unfilteredArgs)))
+	}
+
+	return &Repository{db: db, stmts: sqlwrap.PrepareAll(ctx, db, queries...)}
+}
+
+// queryRowContext runs a query returning one row, on its prepared statement if it has one
+func (r *Repository) queryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if stmt, ok := r.stmts[query]; ok {
+		return stmt.QueryRowContext(ctx, args...)
+	}
+	if db, ok := r.db.(*sqlwrap.DB); ok {
+		return db.QueryRowContext(ctx, query, args...)
+	}
+	return r.db.(*sql.DB).QueryRowContext(ctx, query, args...)
+}
+
+// queryContext runs a query returning rows, on its prepared statement if it has one
+func (r *Repository) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if stmt, ok := r.stmts[query]; ok {
+		return stmt.QueryContext(ctx, args...)
+	}
+	if db, ok := r.db.(*sqlwrap.DB); ok {
+		return db.QueryContext(ctx, query, args...)
+	}
+	return r.db.(*sql.DB).QueryContext(ctx, query, args...)
 }
 
 // Create creates a new post
 func (r *Repository) Create(ctx context.Context, post *post.Post) error {
-	query := `
-		INSERT INTO posts (caption, image_path, image_url, creator_id, creator_name, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id
-	`
-
 	now := time.Now()
 	post.CreatedAt = now
 	post.UpdatedAt = now
 
+	return r.queryRowContext(ctx, createQuery, post.Caption, post.ImagePath, post.ImageURL, post.CreatorID, post.CreatorName, post.Visibility, post.Latitude, post.Longitude, post.PlaceName, post.IsSensitive, post.MediaStatus, post.OriginalImagePath, post.CreatedAt, post.UpdatedAt).Scan(&post.ID, &post.MediaStatus)
+}
+
+// GetByID retrieves a post by ID if it is visible to the viewer
+func (r *Repository) GetByID(ctx context.Context, id int64, viewerID int64) (*post.Post, error) {
+	var p post.Post
+	err := r.queryRowContext(ctx, getByIDQuery, id, viewerID).Scan(postFields(&p)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// GetByIDs retrieves the posts among ids that are visible to the viewer in one query, in
+// no particular order
+func (r *Repository) GetByIDs(ctx context.Context, ids []int64, viewerID int64) ([]post.Post, error) {
+	query := `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE id = ANY($1) AND deleted_at IS NULL AND ` + visibleTo("$2") + `
+	`
+
+	var rows *sql.Rows
 	var err error
 	if db, ok := r.db.(*sql.DB); ok {
-		err = db.QueryRowContext(ctx, query, post.Caption, post.ImagePath, post.ImageURL, post.CreatorID, post.CreatorName, post.CreatedAt, post.UpdatedAt).Scan(&post.ID)
+		rows, err = db.QueryContext(ctx, query, sqlwrap.Array(ids), viewerID)
 	} else if db, ok := r.db.(*sqlwrap.DB); ok {
-		err = db.QueryRowContext(ctx, query, post.Caption, post.ImagePath, post.ImageURL, post.CreatorID, post.CreatorName, post.CreatedAt, post.UpdatedAt).Scan(&post.ID)
+		rows, err = db.QueryContext(ctx, query, sqlwrap.Array(ids), viewerID)
 	}
 
-	return err
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []post.Post
+	for rows.Next() {
+		var p post.Post
+		if err := rows.Scan(postFields(&p)...); err != nil {
+			return nil, err
+		}
+		posts = append(posts, p)
+	}
+
+	return posts, rows.Err()
 }
 
-// GetByID retrieves a post by ID
-func (r *Repository) GetByID(ctx context.Context, id int64) (*post.Post, error) {
+// GetChangedSince lists the posts visible to the viewer that were created, updated or
+// deleted after since, oldest change first. updated_at is set on creation, so it covers new
+// posts too.
+func (r *Repository) GetChangedSince(ctx context.Context, viewerID int64, since time.Time, limit int) ([]post.PostChange, error) {
 	query := `
-		SELECT id, caption, image_path, image_url, creator_id, creator_name, created_at, updated_at, deleted_at
+		SELECT id, created_at, deleted_at, GREATEST(created_at, updated_at, deleted_at) AS changed_at
 		FROM posts
-		WHERE id = $1 AND deleted_at IS NULL
+		WHERE (updated_at > $1 OR deleted_at > $1) AND ` + visibleTo("$2") + `
+		ORDER BY changed_at ASC, id ASC
+		LIMIT $3
 	`
 
-	var p post.Post
+	var rows *sql.Rows
 	var err error
 	if db, ok := r.db.(*sql.DB); ok {
-		err = db.QueryRowContext(ctx, query, id).Scan(&p.ID, &p.Caption, &p.ImagePath, &p.ImageURL, &p.CreatorID, &p.CreatorName, &p.CreatedAt, &p.UpdatedAt, &p.DeletedAt)
+		rows, err = db.QueryContext(ctx, query, since, viewerID, limit)
 	} else if db, ok := r.db.(*sqlwrap.DB); ok {
-		err = db.QueryRowContext(ctx, query, id).Scan(&p.ID, &p.Caption, &p.ImagePath, &p.ImageURL, &p.CreatorID, &p.CreatorName, &p.CreatedAt, &p.UpdatedAt, &p.DeletedAt)
+		rows, err = db.QueryContext(ctx, query, since, viewerID, limit)
 	}
 
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	return &p, nil
+	var changes []post.PostChange
+	for rows.Next() {
+		var c post.PostChange
+		if err := rows.Scan(&c.ID, &c.CreatedAt, &c.DeletedAt, &c.ChangedAt); err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
 }
 
-// GetByCreatorID retrieves posts by creator ID with cursor-based pagination
-func (r *Repository) GetByCreatorID(ctx context.Context, creatorID int64, cursor string, limit int) (*post.PostListResponse, error) {
+// GetByCreatorID retrieves posts by creator ID visible to the viewer with cursor-based pagination.
+// The creator's pinned post is excluded from the paginated list and returned first on the
+// first page only, on top of the requested limit.
+func (r *Repository) GetByCreatorID(ctx context.Context, creatorID int64, viewerID int64, cursor string, limit int) (*post.PostListResponse, error) {
 	if limit <= 0 || limit > 100 {
 		limit = 20
 	}
 
 	query := `
-		SELECT id, caption, image_path, image_url, creator_id, creator_name, created_at, updated_at, deleted_at
+		SELECT ` + postColumns + `
 		FROM posts
-		WHERE creator_id = $1 AND deleted_at IS NULL
+		WHERE creator_id = $1 AND deleted_at IS NULL AND ` + visibleTo("$2") + ` AND ` + notHiddenFor("$2") + `
+			AND id IS DISTINCT FROM (SELECT pinned_post_id FROM accounts WHERE id = $1)
 	`
-	args := []interface{}{creatorID}
+	args := []interface{}{creatorID, viewerID}
 
 	if cursor != "" {
-		query += ` AND created_at < $2`
-		args = append(args, cursor)
+		keys, err := pagination.DecodeCursor(cursor, listingUserPosts)
+		if err != nil {
+			return nil, err
+		}
+		query += ` AND (created_at, id) < ($3, $4)`
+		args = append(args, keys.CreatedAt, keys.ID)
 	}
 
-	query += ` ORDER BY created_at DESC LIMIT $` + fmt.Sprintf("%d", len(args)+1)
+	query += ` ORDER BY created_at DESC, id DESC LIMIT $` + fmt.Sprintf("%d", len(args)+1)
 	args = append(args, limit+1) // Get one extra to check if there are more
 
 	var rows *sql.Rows
@@ -105,7 +244,7 @@ func (r *Repository) GetByCreatorID(ctx context.Context, creatorID int64, cursor
 	var posts []post.Post
 	for rows.Next() {
 		var p post.Post
-		err := rows.Scan(&p.ID, &p.Caption, &p.ImagePath, &p.ImageURL, &p.CreatorID, &p.CreatorName, &p.CreatedAt, &p.UpdatedAt, &p.DeletedAt)
+		err := rows.Scan(postFields(&p)...)
 		if err != nil {
 			return nil, err
 		}
@@ -119,45 +258,105 @@ func (r *Repository) GetByCreatorID(ctx context.Context, creatorID int64, cursor
 
 	var nextCursor string
 	if hasMore && len(posts) > 0 {
-		nextCursor = posts[len(posts)-1].CreatedAt.Format(time.RFC3339Nano)
+		last := posts[len(posts)-1]
+		nextCursor = pagination.EncodeCursor(pagination.CursorKeys{Listing: listingUserPosts, CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	if cursor == "" {
+		pinned, err := r.getPinnedPost(ctx, creatorID, viewerID)
+		if err != nil {
+			return nil, err
+		}
+		if pinned != nil {
+			posts = append([]post.Post{*pinned}, posts...)
+		}
 	}
 
 	return &post.PostListResponse{
-		Posts:   posts,
-		Cursor:  nextCursor,
-		HasMore: hasMore,
+		Posts:      posts,
+		Cursor:     nextCursor,
+		HasMore:    hasMore,
+		Pagination: pagination.NewCursor(limit, nextCursor, hasMore),
 	}, nil
 }
 
-// GetAll retrieves all posts with cursor-based pagination
-func (r *Repository) GetAll(ctx context.Context, cursor string, limit int) (*post.PostListResponse, error) {
-	if limit <= 0 || limit > 100 {
-		limit = 20
-	}
-
+// getPinnedPost retrieves the creator's pinned post if it exists and is visible to the viewer
+func (r *Repository) getPinnedPost(ctx context.Context, creatorID int64, viewerID int64) (*post.Post, error) {
 	query := `
-		SELECT id, caption, image_path, image_url, creator_id, creator_name, created_at, updated_at, deleted_at
+		SELECT ` + postColumns + `
 		FROM posts
-		WHERE deleted_at IS NULL
+		WHERE id = (SELECT pinned_post_id FROM accounts WHERE id = $1)
+			AND creator_id = $1 AND deleted_at IS NULL AND ` + visibleTo("$2") + ` AND ` + notHiddenFor("$2") + `
 	`
-	args := []interface{}{}
 
-	if cursor != "" {
-		query += ` AND created_at < $1`
-		args = append(args, cursor)
+	var p post.Post
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, creatorID, viewerID).Scan(postFields(&p)...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, creatorID, viewerID).Scan(postFields(&p)...)
 	}
 
-	query += ` ORDER BY created_at DESC LIMIT $` + fmt.Sprintf("%d", len(args)+1)
-	args = append(args, limit+1) // Get one extra to check if there are more
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	var rows *sql.Rows
+	p.IsPinned = true
+	return &p, nil
+}
+
+// PinPost sets the account's pinned post, replacing any previous one
+func (r *Repository) PinPost(ctx context.Context, accountID int64, postID int64) error {
+	query := `UPDATE accounts SET pinned_post_id = $1, updated_at = $2 WHERE id = $3 AND deleted_at IS NULL`
+
+	now := time.Now()
 	var err error
 	if db, ok := r.db.(*sql.DB); ok {
-		rows, err = db.QueryContext(ctx, query, args...)
+		_, err = db.ExecContext(ctx, query, postID, now, accountID)
 	} else if db, ok := r.db.(*sqlwrap.DB); ok {
-		rows, err = db.QueryContext(ctx, query, args...)
+		_, err = db.ExecContext(ctx, query, postID, now, accountID)
+	}
+
+	return err
+}
+
+// UnpinPost clears the account's pinned post if it is the given post
+func (r *Repository) UnpinPost(ctx context.Context, accountID int64, postID int64) error {
+	query := `UPDATE accounts SET pinned_post_id = NULL, updated_at = $1 WHERE id = $2 AND pinned_post_id = $3`
+
+	now := time.Now()
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		_, err = db.ExecContext(ctx, query, now, accountID, postID)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		_, err = db.ExecContext(ctx, query, now, accountID, postID)
+	}
+
+	return err
+}
+
+// GetAll retrieves all posts visible to the viewer with cursor-based pagination
+func (r *Repository) GetAll(ctx context.Context, viewerID int64, filter post.ListFilter, cursor string, limit int) (*post.PostListResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	conditions, args := filterConditions(filter, []interface{}{viewerID})
+	query := getAllQuery(conditions, len(args), cursor != "")
+
+	if cursor != "" {
+		keys, err := pagination.DecodeCursor(cursor, listingPosts)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, keys.CreatedAt, keys.ID)
 	}
+	args = append(args, limit+1) // Get one extra to check if there are more
 
+	rows, err := r.queryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -166,7 +365,7 @@ func (r *Repository) GetAll(ctx context.Context, cursor string, limit int) (*pos
 	var posts []post.Post
 	for rows.Next() {
 		var p post.Post
-		err := rows.Scan(&p.ID, &p.Caption, &p.ImagePath, &p.ImageURL, &p.CreatorID, &p.CreatorName, &p.CreatedAt, &p.UpdatedAt, &p.DeletedAt)
+		err := rows.Scan(postFields(&p)...)
 		if err != nil {
 			return nil, err
 		}
@@ -180,31 +379,143 @@ func (r *Repository) GetAll(ctx context.Context, cursor string, limit int) (*pos
 
 	var nextCursor string
 	if hasMore && len(posts) > 0 {
-		nextCursor = posts[len(posts)-1].CreatedAt.Format(time.RFC3339Nano)
+		last := posts[len(posts)-1]
+		nextCursor = pagination.EncodeCursor(pagination.CursorKeys{Listing: listingPosts, CreatedAt: last.CreatedAt, ID: last.ID})
 	}
 
 	return &post.PostListResponse{
-		Posts:   posts,
-		Cursor:  nextCursor,
-		HasMore: hasMore,
+		Posts:      posts,
+		Cursor:     nextCursor,
+		HasMore:    hasMore,
+		Pagination: pagination.NewCursor(limit, nextCursor, hasMore),
 	}, nil
 }
 
+// getAllQuery builds the query of GetAll: posts visible to viewer $1 matching conditions,
+// whose values are the arguments up to argCount, after the cursor's created_at and id when
+// withCursor is set, newest first and limited by the last argument
+func getAllQuery(conditions string, argCount int, withCursor bool) string {
+	query := `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE deleted_at IS NULL AND ` + visibleTo("$1") + ` AND ` + notHiddenFor("$1") + `
+	` + conditions
+
+	if withCursor {
+		query += fmt.Sprintf(` AND (created_at, id) < ($%d, $%d)`, argCount+1, argCount+2)
+		argCount += 2
+	}
+
+	return query + fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d`, argCount+1)
+}
+
 // Update updates an existing post
 func (r *Repository) Update(ctx context.Context, post *post.Post) error {
 	query := `
 		UPDATE posts 
-		SET caption = $1, updated_at = $2
-		WHERE id = $3 AND deleted_at IS NULL
+		SET caption = $1, visibility = $2, is_sensitive = $3, updated_at = $4
+		WHERE id = $5 AND deleted_at IS NULL
 	`
 
 	post.UpdatedAt = time.Now()
 
 	var err error
 	if db, ok := r.db.(*sql.DB); ok {
-		_, err = db.ExecContext(ctx, query, post.Caption, post.UpdatedAt, post.ID)
+		_, err = db.ExecContext(ctx, query, post.Caption, post.Visibility, post.IsSensitive, post.UpdatedAt, post.ID)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		_, err = db.ExecContext(ctx, query, post.Caption, post.Visibility, post.IsSensitive, post.UpdatedAt, post.ID)
+	}
+
+	return err
+}
+
+// SetSensitive sets the sensitive flag of a post and whether it is locked by a moderator.
+// It returns sql.ErrNoRows when the post does not exist.
+func (r *Repository) SetSensitive(ctx context.Context, id int64, isSensitive bool, locked bool) error {
+	query := `
+		UPDATE posts SET is_sensitive = $1, sensitive_locked = $2, updated_at = $3
+		WHERE id = $4 AND deleted_at IS NULL
+		RETURNING id
+	`
+
+	now := time.Now()
+	var updatedID int64
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, isSensitive, locked, now, id).Scan(&updatedID)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, isSensitive, locked, now, id).Scan(&updatedID)
+	}
+
+	return err
+}
+
+// GetSensitiveContentPreference returns how the account wants sensitive posts shown.
+// Anonymous viewers and unknown accounts get the default, blur.
+func (r *Repository) GetSensitiveContentPreference(ctx context.Context, accountID int64) (string, error) {
+	query := `SELECT sensitive_content FROM accounts WHERE id = $1 AND deleted_at IS NULL`
+
+	var preference string
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, accountID).Scan(&preference)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, accountID).Scan(&preference)
+	}
+
+	if err == sql.ErrNoRows {
+		return account.SensitiveContentBlur, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return preference, nil
+}
+
+// GetAccountRole returns the role of an account
+func (r *Repository) GetAccountRole(ctx context.Context, accountID int64) (string, error) {
+	query := `SELECT role FROM accounts WHERE id = $1 AND deleted_at IS NULL`
+
+	var role string
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, accountID).Scan(&role)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, accountID).Scan(&role)
+	}
+
+	return role, err
+}
+
+// Like records that an account likes a post; liking twice is a no-op
+func (r *Repository) Like(ctx context.Context, postID int64, accountID int64) error {
+	query := `
+		INSERT INTO post_likes (post_id, account_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (post_id, account_id) DO NOTHING
+	`
+
+	now := time.Now()
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		_, err = db.ExecContext(ctx, query, postID, accountID, now)
 	} else if db, ok := r.db.(*sqlwrap.DB); ok {
-		_, err = db.ExecContext(ctx, query, post.Caption, post.UpdatedAt, post.ID)
+		_, err = db.ExecContext(ctx, query, postID, accountID, now)
+	}
+
+	return err
+}
+
+// Unlike removes an account's like from a post
+func (r *Repository) Unlike(ctx context.Context, postID int64, accountID int64) error {
+	query := `DELETE FROM post_likes WHERE post_id = $1 AND account_id = $2`
+
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		_, err = db.ExecContext(ctx, query, postID, accountID)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		_, err = db.ExecContext(ctx, query, postID, accountID)
 	}
 
 	return err
@@ -225,41 +536,359 @@ func (r *Repository) SoftDelete(ctx context.Context, id int64) error {
 	return err
 }
 
-// GetCommentCount gets the comment count for a post
-func (r *Repository) GetCommentCount(ctx context.Context, postID int64) (int64, error) {
-	query := `SELECT COUNT(*) FROM comments WHERE post_id = $1 AND deleted_at IS NULL`
+// ClaimMediaJob claims the oldest post whose image awaits processing, or whose claim is
+// older than staleBefore because its worker stopped. It returns sql.ErrNoRows when there is
+// none.
+func (r *Repository) ClaimMediaJob(ctx context.Context, staleBefore time.Time) (*post.MediaJob, error) {
+	query := `
+		UPDATE posts SET media_claimed_at = $2
+		WHERE id = (
+			SELECT id FROM posts
+			WHERE media_status = 'processing' AND deleted_at IS NULL
+				AND (media_claimed_at IS NULL OR media_claimed_at < $1)
+			ORDER BY id
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING id, creator_id, COALESCE(image_original_path, ''), image_path, image_url`
 
-	var count int64
+	var job post.MediaJob
+	err := r.queryRowContext(ctx, query, staleBefore, time.Now()).Scan(&job.PostID, &job.CreatorID, &job.OriginalImagePath, &job.ImagePath, &job.ImageURL)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// SetMediaStatus records the outcome of processing a post's image and releases its claim.
+// updated_at changes too, so delta sync reports the post.
+func (r *Repository) SetMediaStatus(ctx context.Context, postID int64, status string) error {
+	query := `UPDATE posts SET media_status = $1, media_claimed_at = NULL, updated_at = $2 WHERE id = $3`
+
+	now := time.Now()
 	var err error
 	if db, ok := r.db.(*sql.DB); ok {
-		err = db.QueryRowContext(ctx, query, postID).Scan(&count)
+		_, err = db.ExecContext(ctx, query, status, now, postID)
 	} else if db, ok := r.db.(*sqlwrap.DB); ok {
-		err = db.QueryRowContext(ctx, query, postID).Scan(&count)
+		_, err = db.ExecContext(ctx, query, status, now, postID)
 	}
 
-	return count, err
+	return err
 }
 
-// GetLastComments gets the last N comments for a post
-func (r *Repository) GetLastComments(ctx context.Context, postID int64, limit int) ([]comment.Comment, error) {
+// GetTimeline gets the newest posts of the account and the accounts it follows that it may
+// see, with a cursor of created_at and id. Most come from the account's timeline entries;
+// posts of followed pull accounts, which are not written to timelines, are read from
+// posts and merged in.
+func (r *Repository) GetTimeline(ctx context.Context, accountID int64, cursor string, limit int) (*post.PostListResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	var entriesAfter, postsAfter string
+	args := []interface{}{accountID}
+	if cursor != "" {
+		keys, err := pagination.DecodeCursor(cursor, listingTimeline)
+		if err != nil {
+			return nil, err
+		}
+		entriesAfter = ` AND (te.posted_at, te.post_id) < ($2, $3)`
+		postsAfter = ` AND (created_at, id) < ($2, $3)`
+		args = append(args, keys.CreatedAt, keys.ID)
+	}
+	limitArg := fmt.Sprintf("$%d", len(args)+1)
+	args = append(args, limit+1) // Get one extra to check if there are more
+
+	// Entries are scanned in timeline order and joined to their posts, so the scan stops
+	// after a page. Posts of accounts no longer followed are left out.
+	entriesQuery := `
+		SELECT ` + postColumns + `
+		FROM timeline_entries te
+			JOIN posts ON posts.id = te.post_id
+		WHERE te.account_id = $1 AND deleted_at IS NULL AND ` + visibleTo("$1") + ` AND ` + notHiddenFor("$1") + `
+			AND (creator_id = $1 OR EXISTS (SELECT 1 FROM follows tf WHERE tf.follower_id = $1 AND tf.following_id = creator_id))` +
+		entriesAfter + `
+		ORDER BY te.posted_at DESC, te.post_id DESC
+		LIMIT ` + limitArg
+
+	// Posts written to the timeline before their creator became a pull account are
+	// already among the entries
+	pulledQuery := `
+		SELECT ` + postColumns + `
+		FROM posts
+		WHERE creator_id IN (
+				SELECT tp.account_id FROM timeline_pull_accounts tp
+					JOIN follows tf ON tf.following_id = tp.account_id AND tf.follower_id = $1
+			)
+			AND deleted_at IS NULL AND ` + visibleTo("$1") + ` AND ` + notHiddenFor("$1") + `
+			AND NOT EXISTS (SELECT 1 FROM timeline_entries te WHERE te.account_id = $1 AND te.post_id = id)` +
+		postsAfter + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT ` + limitArg
+
+	var posts []post.Post
+	for _, query := range []string{entriesQuery, pulledQuery} {
+		found, err := r.queryPosts(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, found...)
+	}
+	sort.Slice(posts, func(i, j int) bool {
+		if !posts[i].CreatedAt.Equal(posts[j].CreatedAt) {
+			return posts[i].CreatedAt.After(posts[j].CreatedAt)
+		}
+		return posts[i].ID > posts[j].ID
+	})
+
+	hasMore := len(posts) > limit
+	if hasMore {
+		posts = posts[:limit]
+	}
+
+	var nextCursor string
+	if hasMore && len(posts) > 0 {
+		last := posts[len(posts)-1]
+		nextCursor = pagination.EncodeCursor(pagination.CursorKeys{Listing: listingTimeline, CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return &post.PostListResponse{
+		Posts:      posts,
+		Cursor:     nextCursor,
+		HasMore:    hasMore,
+		Pagination: pagination.NewCursor(limit, nextCursor, hasMore),
+	}, nil
+}
+
+// queryPosts runs a query selecting postColumns
+func (r *Repository) queryPosts(ctx context.Context, query string, args ...interface{}) ([]post.Post, error) {
+	rows, err := r.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []post.Post
+	for rows.Next() {
+		var p post.Post
+		if err := rows.Scan(postFields(&p)...); err != nil {
+			return nil, err
+		}
+		posts = append(posts, p)
+	}
+	return posts, rows.Err()
+}
+
+// ClaimFanoutJob claims the oldest post waiting to be written to timelines, or whose claim
+// is older than staleBefore because its worker stopped. It returns sql.ErrNoRows when
+// there is none.
+func (r *Repository) ClaimFanoutJob(ctx context.Context, staleBefore time.Time) (*post.FanoutJob, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE timeline_fanouts SET claimed_at = $2
+			WHERE post_id = (
+				SELECT post_id FROM timeline_fanouts
+				WHERE claimed_at IS NULL OR claimed_at < $1
+				ORDER BY post_id
+				FOR UPDATE SKIP LOCKED
+				LIMIT 1
+			)
+			RETURNING post_id
+		)
+		SELECT p.id, p.creator_id, p.created_at
+		FROM claimed JOIN posts p ON p.id = claimed.post_id`
+
+	var job post.FanoutJob
+	err := r.queryRowContext(ctx, query, staleBefore, time.Now()).Scan(&job.PostID, &job.CreatorID, &job.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CountFollowers counts the accounts following an account
+func (r *Repository) CountFollowers(ctx context.Context, accountID int64) (int64, error) {
+	return r.count(ctx, `SELECT COUNT(*) FROM follows WHERE following_id = $1`, accountID)
+}
+
+// AddPullAccount marks an account whose posts followers' timelines read from posts
+func (r *Repository) AddPullAccount(ctx context.Context, accountID int64) error {
+	query := `INSERT INTO timeline_pull_accounts (account_id) VALUES ($1) ON CONFLICT DO NOTHING`
+
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		_, err = db.ExecContext(ctx, query, accountID)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		_, err = db.ExecContext(ctx, query, accountID)
+	}
+
+	return err
+}
+
+// FanOut writes a post to its creator's timeline, and to the timelines of the creator's
+// followers when toFollowers is set, then removes the job. Entries already written are
+// kept, so a job retried after its worker stopped midway completes the rest.
+func (r *Repository) FanOut(ctx context.Context, job *post.FanoutJob, toFollowers bool) error {
+	insertQuery := `
+		INSERT INTO timeline_entries (account_id, post_id, posted_at)
+		SELECT $2::BIGINT, $1::BIGINT, $3::TIMESTAMPTZ
+		UNION ALL
+		SELECT follower_id, $1, $3 FROM follows WHERE following_id = $2 AND $4
+		ON CONFLICT DO NOTHING`
+	deleteQuery := `DELETE FROM timeline_fanouts WHERE post_id = $1`
+
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		if _, err = db.ExecContext(ctx, insertQuery, job.PostID, job.CreatorID, job.CreatedAt, toFollowers); err == nil {
+			_, err = db.ExecContext(ctx, deleteQuery, job.PostID)
+		}
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		if _, err = db.ExecContext(ctx, insertQuery, job.PostID, job.CreatorID, job.CreatedAt, toFollowers); err == nil {
+			_, err = db.ExecContext(ctx, deleteQuery, job.PostID)
+		}
+	}
+
+	return err
+}
+
+// importTablesQuery creates the temporary tables imported rows are copied into. They are
+// dropped when the import's transaction ends.
+const importTablesQuery = `
+	CREATE TEMP TABLE import_posts (
+		external_id TEXT NOT NULL,
+		caption TEXT NOT NULL,
+		image_url TEXT NOT NULL,
+		creator_id BIGINT NOT NULL,
+		visibility TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		id BIGINT
+	) ON COMMIT DROP;
+	CREATE TEMP TABLE import_comments (
+		post_external_id TEXT NOT NULL,
+		content TEXT NOT NULL,
+		creator_id BIGINT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL
+	) ON COMMIT DROP`
+
+// Import bulk inserts posts and their comments migrated from another platform in one
+// transaction. The rows are streamed with COPY into temporary tables and inserted from
+// there with one statement per table, orders of magnitude faster than an INSERT per row.
+// Posts whose creator does not exist are skipped, and so are comments whose creator or
+// post does not exist; comments can only refer to posts of the same import. Imported
+// posts are not written to timelines.
+func (r *Repository) Import(ctx context.Context, posts []post.ImportPost, comments []post.ImportComment) (*post.ImportResult, error) {
+	result := &post.ImportResult{}
+	err := sqlwrap.WithPgxConn(ctx, r.db, func(conn *pgx.Conn) error {
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+
+		if _, err := tx.Exec(ctx, importTablesQuery); err != nil {
+			return fmt.Errorf("failed to create import tables: %w", err)
+		}
+
+		_, err = tx.CopyFrom(ctx, pgx.Identifier{"import_posts"},
+			[]string{"external_id", "caption", "image_url", "creator_id", "visibility", "created_at"},
+			pgx.CopyFromSlice(len(posts), func(i int) ([]interface{}, error) {
+				p := posts[i]
+				return []interface{}{p.ExternalID, p.Caption, p.ImageURL, p.CreatorID, p.Visibility, p.CreatedAt}, nil
+			}))
+		if err != nil {
+			return fmt.Errorf("failed to copy posts: %w", err)
+		}
+		_, err = tx.CopyFrom(ctx, pgx.Identifier{"import_comments"},
+			[]string{"post_external_id", "content", "creator_id", "created_at"},
+			pgx.CopyFromSlice(len(comments), func(i int) ([]interface{}, error) {
+				c := comments[i]
+				return []interface{}{c.PostExternalID, c.Content, c.CreatorID, c.CreatedAt}, nil
+			}))
+		if err != nil {
+			return fmt.Errorf("failed to copy comments: %w", err)
+		}
+
+		var duplicate string
+		err = tx.QueryRow(ctx, `SELECT external_id FROM import_posts GROUP BY external_id HAVING COUNT(*) > 1 LIMIT 1`).Scan(&duplicate)
+		if err == nil {
+			return fmt.Errorf("duplicate post external ID %q", duplicate)
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		// Number the posts up front, so their comments can be linked to them
+		if _, err := tx.Exec(ctx, `
+			UPDATE import_posts i SET id = nextval(pg_get_serial_sequence('posts', 'id'))
+			WHERE EXISTS (SELECT 1 FROM accounts a WHERE a.id = i.creator_id)`); err != nil {
+			return fmt.Errorf("failed to number posts: %w", err)
+		}
+
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO posts (id, caption, image_path, image_url, creator_id, creator_name, visibility, created_at, updated_at)
+			SELECT i.id, i.caption, '', i.image_url, i.creator_id, a.name, i.visibility, i.created_at, i.created_at
+			FROM import_posts i
+				JOIN accounts a ON a.id = i.creator_id
+			WHERE i.id IS NOT NULL`)
+		if err != nil {
+			return fmt.Errorf("failed to insert posts: %w", err)
+		}
+		result.Posts = tag.RowsAffected()
+
+		tag, err = tx.Exec(ctx, `
+			INSERT INTO comments (content, post_id, creator_id, creator_name, created_at, updated_at)
+			SELECT c.content, p.id, c.creator_id, a.name, c.created_at, c.created_at
+			FROM import_comments c
+				JOIN import_posts p ON p.external_id = c.post_external_id AND p.id IS NOT NULL
+				JOIN accounts a ON a.id = c.creator_id`)
+		if err != nil {
+			return fmt.Errorf("failed to insert comments: %w", err)
+		}
+		result.Comments = tag.RowsAffected()
+
+		return tx.Commit(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.SkippedPosts = int64(len(posts)) - result.Posts
+	result.SkippedComments = int64(len(comments)) - result.Comments
+	return result, nil
+}
+
+// GetLastCommentsForPosts gets the last N comments of several posts in one query, keyed by
+// post ID. Commenter name, avatar and verified status are joined from accounts so previews
+// can be rendered without profile lookups.
+func (r *Repository) GetLastCommentsForPosts(ctx context.Context, postIDs []int64, limit int) (map[int64][]comment.Comment, error) {
 	if limit <= 0 {
-		limit = 2 // Default to 2 as per requirement
+		limit = 2
 	}
 
 	query := `
-		SELECT id, content, post_id, creator_id, creator_name, created_at, updated_at, deleted_at
-		FROM comments
-		WHERE post_id = $1 AND deleted_at IS NULL
-		ORDER BY created_at DESC
-		LIMIT $2
+		SELECT id, content, post_id, creator_id, creator_name, creator_avatar_url, creator_is_verified, like_count, created_at, updated_at, deleted_at
+		FROM (
+			SELECT c.id, c.content, c.post_id, c.creator_id,
+				COALESCE(NULLIF(c.creator_name, ''), a.name, '') AS creator_name,
+				COALESCE(a.avatar_url, '') AS creator_avatar_url,
+				COALESCE(a.is_verified, FALSE) AS creator_is_verified,
+				(SELECT COUNT(*) FROM comment_likes cl WHERE cl.comment_id = c.id) AS like_count,
+				c.created_at, c.updated_at, c.deleted_at,
+				ROW_NUMBER() OVER (PARTITION BY c.post_id ORDER BY c.created_at DESC, c.id DESC) AS rn
+			FROM comments c
+			LEFT JOIN accounts a ON a.id = c.creator_id
+			WHERE c.post_id = ANY($1) AND c.deleted_at IS NULL AND COALESCE(a.status, 'active') <> 'deactivated'
+		) latest
+		WHERE rn <= $2
+		ORDER BY post_id, created_at DESC, id DESC
 	`
 
 	var rows *sql.Rows
 	var err error
 	if db, ok := r.db.(*sql.DB); ok {
-		rows, err = db.QueryContext(ctx, query, postID, limit)
+		rows, err = db.QueryContext(ctx, query, sqlwrap.Array(postIDs), limit)
 	} else if db, ok := r.db.(*sqlwrap.DB); ok {
-		rows, err = db.QueryContext(ctx, query, postID, limit)
+		rows, err = db.QueryContext(ctx, query, sqlwrap.Array(postIDs), limit)
 	}
 
 	if err != nil {
@@ -267,41 +896,45 @@ func (r *Repository) GetLastComments(ctx context.Context, postID int64, limit in
 	}
 	defer rows.Close()
 
-	var comments []comment.Comment
+	comments := make(map[int64][]comment.Comment, len(postIDs))
 	for rows.Next() {
 		var c comment.Comment
-		err := rows.Scan(&c.ID, &c.Content, &c.PostID, &c.CreatorID, &c.CreatorName, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt)
+		err := rows.Scan(&c.ID, &c.Content, &c.PostID, &c.CreatorID, &c.CreatorName, &c.CreatorAvatarURL, &c.CreatorIsVerified, &c.LikeCount, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt)
 		if err != nil {
 			return nil, err
 		}
-		comments = append(comments, c)
+		comments[c.PostID] = append(comments[c.PostID], c)
 	}
 
-	return comments, nil
+	return comments, rows.Err()
 }
 
-// GetPostsSortedByComments gets posts sorted by comment count with cursor-based pagination
-func (r *Repository) GetPostsSortedByComments(ctx context.Context, cursor string, limit int) (*post.PostListResponse, error) {
+// GetPostsSortedByComments gets posts visible to the viewer sorted by comment count with cursor-based pagination
+func (r *Repository) GetPostsSortedByComments(ctx context.Context, viewerID int64, filter post.ListFilter, cursor string, limit int) (*post.PostListResponse, error) {
 	if limit <= 0 || limit > 100 {
 		limit = 20
 	}
 
 	query := `
-		SELECT id, caption, image_path, image_url, creator_id, creator_name, created_at, updated_at, deleted_at, comment_count
+		SELECT ` + postColumns + `, comment_count
 		FROM posts_with_comment_count
-		WHERE deleted_at IS NULL
+		WHERE deleted_at IS NULL AND ` + visibleTo("$1") + ` AND ` + notHiddenFor("$1") + `
 	`
-	args := []interface{}{}
+	args := []interface{}{viewerID}
+
+	conditions, args := filterConditions(filter, args)
+	query += conditions
 
 	if cursor != "" {
-		cc, ct, err := decodeCommentsCursor(cursor)
-		if err == nil {
-			query += ` AND (comment_count < $1 OR (comment_count = $1 AND created_at < $2))`
-			args = append(args, cc, ct)
+		keys, err := pagination.DecodeCursor(cursor, listingPostsByComments)
+		if err != nil {
+			return nil, err
 		}
+		query += fmt.Sprintf(` AND (comment_count, created_at, id) < ($%d, $%d, $%d)`, len(args)+1, len(args)+2, len(args)+3)
+		args = append(args, keys.Count, keys.CreatedAt, keys.ID)
 	}
 
-	query += ` ORDER BY comment_count DESC, created_at DESC LIMIT $` + fmt.Sprintf("%d", len(args)+1)
+	query += ` ORDER BY comment_count DESC, created_at DESC, id DESC LIMIT $` + fmt.Sprintf("%d", len(args)+1)
 	args = append(args, limit+1) // Get one extra to check if there are more
 
 	var rows *sql.Rows
@@ -320,7 +953,7 @@ func (r *Repository) GetPostsSortedByComments(ctx context.Context, cursor string
 	var posts []post.Post
 	for rows.Next() {
 		var p post.Post
-		err := rows.Scan(&p.ID, &p.Caption, &p.ImagePath, &p.ImageURL, &p.CreatorID, &p.CreatorName, &p.CreatedAt, &p.UpdatedAt, &p.DeletedAt, &p.CommentCount)
+		err := rows.Scan(append(postFields(&p), &p.CommentCount)...)
 		if err != nil {
 			return nil, err
 		}
@@ -335,40 +968,378 @@ func (r *Repository) GetPostsSortedByComments(ctx context.Context, cursor string
 	var nextCursor string
 	if hasMore && len(posts) > 0 {
 		last := posts[len(posts)-1]
-		nextCursor = encodeCommentsCursor(last.CommentCount, last.CreatedAt)
+		nextCursor = pagination.EncodeCursor(pagination.CursorKeys{Listing: listingPostsByComments, Count: last.CommentCount, CreatedAt: last.CreatedAt, ID: last.ID})
 	}
 
 	return &post.PostListResponse{
-		Posts:   posts,
-		Cursor:  nextCursor,
-		HasMore: hasMore,
+		Posts:      posts,
+		Cursor:     nextCursor,
+		HasMore:    hasMore,
+		Pagination: pagination.NewCursor(limit, nextCursor, hasMore),
 	}, nil
 }
 
-// encodeCommentsCursor creates a stable cursor combining comment_count and created_at
-func encodeCommentsCursor(commentCount int64, createdAt time.Time) string {
-	plain := fmt.Sprintf("%d|%s", commentCount, createdAt.Format(time.RFC3339Nano))
-	return base64.RawURLEncoding.EncodeToString([]byte(plain))
+// GetPostsSortedByLikes gets posts sorted by like count (desc), then created_at (desc), with
+// a cursor of like_count, created_at and id
+func (r *Repository) GetPostsSortedByLikes(ctx context.Context, viewerID int64, filter post.ListFilter, cursor string, limit int) (*post.PostListResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	query := `
+		SELECT * FROM (
+			SELECT ` + postColumns + `
+			FROM posts
+			WHERE deleted_at IS NULL AND ` + visibleTo("$1") + ` AND ` + notHiddenFor("$1") + `
+		) liked
+		WHERE TRUE
+	`
+	args := []interface{}{viewerID}
+
+	conditions, args := filterConditions(filter, args)
+	query += conditions
+
+	if cursor != "" {
+		keys, err := pagination.DecodeCursor(cursor, listingPostsByLikes)
+		if err != nil {
+			return nil, err
+		}
+		query += fmt.Sprintf(` AND (like_count, created_at, id) < ($%d, $%d, $%d)`, len(args)+1, len(args)+2, len(args)+3)
+		args = append(args, keys.Count, keys.CreatedAt, keys.ID)
+	}
+
+	query += ` ORDER BY like_count DESC, created_at DESC, id DESC LIMIT $` + fmt.Sprintf("%d", len(args)+1)
+	args = append(args, limit+1) // Get one extra to check if there are more
+
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, args...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, args...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []post.Post
+	for rows.Next() {
+		var p post.Post
+		err := rows.Scan(postFields(&p)...)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, p)
+	}
+
+	hasMore := len(posts) > limit
+	if hasMore {
+		posts = posts[:limit]
+	}
+
+	var nextCursor string
+	if hasMore && len(posts) > 0 {
+		last := posts[len(posts)-1]
+		nextCursor = pagination.EncodeCursor(pagination.CursorKeys{Listing: listingPostsByLikes, Count: last.LikeCount, CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return &post.PostListResponse{
+		Posts:      posts,
+		Cursor:     nextCursor,
+		HasMore:    hasMore,
+		Pagination: pagination.NewCursor(limit, nextCursor, hasMore),
+	}, nil
 }
 
-// decodeCommentsCursor parses the composite cursor back to values
-func decodeCommentsCursor(cursor string) (int64, time.Time, error) {
-	b, err := base64.RawURLEncoding.DecodeString(cursor)
+// GetAllPage gets a page of posts in the given sort order by offset, with the number of
+// posts in the whole listing
+func (r *Repository) GetAllPage(ctx context.Context, viewerID int64, sort string, filter post.ListFilter, offset int, limit int) ([]post.Post, int64, error) {
+	where, args := allPostsWhere(viewerID, filter)
+
+	total, err := r.count(ctx, `SELECT COUNT(*) `+where, args...)
 	if err != nil {
-		return 0, time.Time{}, err
+		return nil, 0, err
 	}
-	parts := strings.SplitN(string(b), "|", 2)
-	if len(parts) != 2 {
-		return 0, time.Time{}, fmt.Errorf("invalid cursor format")
+
+	query := allPostsPageQuery(where, sort, len(args))
+	args = append(args, limit, offset)
+
+	rows, err := r.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
 	}
-	var cc int64
-	_, err = fmt.Sscanf(parts[0], "%d", &cc)
+	defer rows.Close()
+
+	posts := []post.Post{}
+	for rows.Next() {
+		var p post.Post
+		if err := rows.Scan(append(postFields(&p), &p.CommentCount)...); err != nil {
+			return nil, 0, err
+		}
+		posts = append(posts, p)
+	}
+
+	return posts, total, rows.Err()
+}
+
+// allPostsPageQuery builds the query of GetAllPage on the clause from allPostsWhere with
+// argCount arguments, limited and offset by the two arguments after them
+func allPostsPageQuery(where string, sort string, argCount int) string {
+	query := `SELECT ` + postColumns + `, comment_count ` + where
+	switch sort {
+	case post.SortNewest:
+		query += ` ORDER BY created_at DESC, id DESC`
+	case post.SortMostLiked:
+		query += ` ORDER BY like_count DESC, created_at DESC, id DESC`
+	default:
+		query += ` ORDER BY comment_count DESC, created_at DESC, id DESC`
+	}
+	return query + fmt.Sprintf(` LIMIT $%d OFFSET $%d`, argCount+1, argCount+2)
+}
+
+// GetByCreatorIDPage gets a page of a creator's posts visible to the viewer by offset, with
+// the number of posts in the whole listing. The pinned post comes first, then the newest.
+func (r *Repository) GetByCreatorIDPage(ctx context.Context, creatorID int64, viewerID int64, offset int, limit int) ([]post.Post, int64, error) {
+	where := creatorPostsWhere
+
+	total, err := r.count(ctx, `SELECT COUNT(*) `+where, creatorID, viewerID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + postColumns + `,
+			id IS NOT DISTINCT FROM (SELECT pinned_post_id FROM accounts WHERE id = $1) AS is_pinned ` + where + `
+		ORDER BY is_pinned DESC, created_at DESC, id DESC
+		LIMIT $3 OFFSET $4`
+
+	var rows *sql.Rows
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, creatorID, viewerID, limit, offset)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, creatorID, viewerID, limit, offset)
+	}
+
 	if err != nil {
-		return 0, time.Time{}, err
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	posts := []post.Post{}
+	for rows.Next() {
+		var p post.Post
+		if err := rows.Scan(append(postFields(&p), &p.IsPinned)...); err != nil {
+			return nil, 0, err
+		}
+		posts = append(posts, p)
+	}
+
+	return posts, total, rows.Err()
+}
+
+// CountAll counts the posts matching filter that are visible to the viewer
+func (r *Repository) CountAll(ctx context.Context, viewerID int64, filter post.ListFilter) (int64, error) {
+	where, args := allPostsWhere(viewerID, filter)
+	return r.count(ctx, `SELECT COUNT(*) `+where, args...)
+}
+
+// CountByCreatorID counts a creator's posts that are visible to the viewer
+func (r *Repository) CountByCreatorID(ctx context.Context, creatorID int64, viewerID int64) (int64, error) {
+	return r.count(ctx, `SELECT COUNT(*) `+creatorPostsWhere, creatorID, viewerID)
+}
+
+// allPostsWhere is the FROM and WHERE clause of the posts matching filter visible to the
+// viewer, with its arguments
+func allPostsWhere(viewerID int64, filter post.ListFilter) (string, []interface{}) {
+	conditions, args := filterConditions(filter, []interface{}{viewerID})
+	return `
+		FROM posts_with_comment_count
+		WHERE deleted_at IS NULL AND ` + visibleTo("$1") + ` AND ` + notHiddenFor("$1") + conditions, args
+}
+
+// creatorPostsWhere is the FROM and WHERE clause of the posts of creator $1 visible to viewer $2
+var creatorPostsWhere = `
+		FROM posts
+		WHERE creator_id = $1 AND deleted_at IS NULL AND ` + visibleTo("$2") + ` AND ` + notHiddenFor("$2")
+
+// count runs a COUNT(*) query
+func (r *Repository) count(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	var total int64
+	err := r.queryRowContext(ctx, query, args...).Scan(&total)
+	return total, err
+}
+
+// GetNearby retrieves geotagged posts visible to the viewer within radiusKm of the given
+// point, nearest first, with cursor-based pagination
+func (r *Repository) GetNearby(ctx context.Context, viewerID int64, lat float64, lng float64, radiusKm float64, cursor string, limit int) (*post.PostListResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	minLat, maxLat, minLng, maxLng, wrapsLng := boundingBox(lat, lng, radiusKm)
+
+	// Bounding box narrows the candidates using idx_posts_location before the exact haversine check
+	inner := `
+			SELECT ` + postColumns + `,
+				2 * ` + fmt.Sprintf("%g", earthRadiusKm) + ` * ASIN(LEAST(1, SQRT(
+					POWER(SIN(RADIANS(latitude - $2) / 2), 2) +
+					COS(RADIANS($2)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - $3) / 2), 2)
+				))) AS distance_km
+			FROM posts
+			WHERE deleted_at IS NULL AND latitude IS NOT NULL AND ` + visibleTo("$1") + ` AND ` + notHiddenFor("$1") + `
+				AND latitude BETWEEN $4 AND $5`
+	args := []interface{}{viewerID, lat, lng, minLat, maxLat}
+
+	if !wrapsLng {
+		inner += ` AND longitude BETWEEN $6 AND $7`
+		args = append(args, minLng, maxLng)
+	}
+
+	args = append(args, radiusKm)
+	query := `SELECT * FROM (` + inner + `
+		) nearby
+		WHERE distance_km <= $` + fmt.Sprintf("%d", len(args))
+
+	if cursor != "" {
+		keys, err := pagination.DecodeCursor(cursor, listingNearbyPosts)
+		if err != nil {
+			return nil, err
+		}
+		query += fmt.Sprintf(` AND (distance_km, id) > ($%d, $%d)`, len(args)+1, len(args)+2)
+		args = append(args, keys.Distance, keys.ID)
+	}
+
+	query += ` ORDER BY distance_km, id LIMIT $` + fmt.Sprintf("%d", len(args)+1)
+	args = append(args, limit+1) // Get one extra to check if there are more
+
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, args...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, args...)
 	}
-	ct, err := time.Parse(time.RFC3339Nano, parts[1])
+
 	if err != nil {
-		return 0, time.Time{}, err
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []post.Post
+	for rows.Next() {
+		var p post.Post
+		var distance float64
+		err := rows.Scan(append(postFields(&p), &distance)...)
+		if err != nil {
+			return nil, err
+		}
+		p.DistanceKm = &distance
+		posts = append(posts, p)
 	}
-	return cc, ct, nil
+
+	hasMore := len(posts) > limit
+	if hasMore {
+		posts = posts[:limit]
+	}
+
+	var nextCursor string
+	if hasMore && len(posts) > 0 {
+		last := posts[len(posts)-1]
+		nextCursor = pagination.EncodeCursor(pagination.CursorKeys{Listing: listingNearbyPosts, Distance: *last.DistanceKm, CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return &post.PostListResponse{
+		Posts:      posts,
+		Cursor:     nextCursor,
+		HasMore:    hasMore,
+		Pagination: pagination.NewCursor(limit, nextCursor, hasMore),
+	}, nil
+}
+
+// postFields returns scan destinations for postColumns
+func postFields(p *post.Post) []interface{} {
+	return []interface{}{
+		&p.ID, &p.Caption, &p.ImagePath, &p.ImageURL, &p.CreatorID, &p.CreatorName, &p.CreatedAt, &p.UpdatedAt, &p.DeletedAt, &p.Visibility,
+		&p.Latitude, &p.Longitude, &p.PlaceName, &p.IsSensitive, &p.SensitiveLocked, &p.MediaStatus,
+		&p.CreatorIsVerified, &p.LikeCount,
+	}
+}
+
+// boundingBox returns the latitude/longitude box enclosing the circle of radiusKm around a
+// point. wrapsLng is true when the box crosses the antimeridian or a pole, in which case
+// longitude cannot be used to narrow the search.
+func boundingBox(lat float64, lng float64, radiusKm float64) (minLat, maxLat, minLng, maxLng float64, wrapsLng bool) {
+	latDelta := radiusKm / earthRadiusKm * 180 / math.Pi
+	minLat = math.Max(lat-latDelta, -90)
+	maxLat = math.Min(lat+latDelta, 90)
+	if minLat == -90 || maxLat == 90 {
+		return minLat, maxLat, 0, 0, true
+	}
+
+	lngDelta := latDelta / math.Cos(lat*math.Pi/180)
+	minLng = lng - lngDelta
+	maxLng = lng + lngDelta
+	if minLng < -180 || maxLng > 180 {
+		return minLat, maxLat, 0, 0, true
+	}
+
+	return minLat, maxLat, minLng, maxLng, false
+}
+
+// filterConditions translates a list filter to SQL conditions, each starting with AND, and
+// appends their values to args. Hashtags are validated by the service, so they are safe in
+// the regular expression.
+func filterConditions(filter post.ListFilter, args []interface{}) (string, []interface{}) {
+	var conditions strings.Builder
+	next := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.CreatedAfter != nil {
+		conditions.WriteString(` AND created_at > ` + next(*filter.CreatedAfter))
+	}
+	if filter.CreatedBefore != nil {
+		conditions.WriteString(` AND created_at < ` + next(*filter.CreatedBefore))
+	}
+	if filter.CreatorID != nil {
+		conditions.WriteString(` AND creator_id = ` + next(*filter.CreatorID))
+	}
+	if filter.HasImage != nil {
+		if *filter.HasImage {
+			conditions.WriteString(` AND COALESCE(image_path, '') <> ''`)
+		} else {
+			conditions.WriteString(` AND COALESCE(image_path, '') = ''`)
+		}
+	}
+	if filter.Hashtag != "" {
+		conditions.WriteString(` AND caption ~* ('#' || ` + next(filter.Hashtag) + ` || '([^[:alnum:]_]|$)')`)
+	}
+
+	return conditions.String(), args
+}
+
+// visibleTo returns a WHERE condition restricting posts to those the viewer bound at
+// the given placeholder may see: the viewer's own posts, public posts of public
+// accounts, and public or followers-only posts of accounts the viewer follows.
+// Private accounts therefore show every post to followers only. Posts of
+// deactivated accounts are never visible.
+func visibleTo(viewerPlaceholder string) string {
+	return `(creator_id = ` + viewerPlaceholder + ` OR (visibility = 'public' AND NOT EXISTS (
+			SELECT 1 FROM accounts pa WHERE pa.id = creator_id AND pa.is_private
+		)) OR (visibility IN ('public', 'followers') AND EXISTS (
+			SELECT 1 FROM follows f WHERE f.follower_id = ` + viewerPlaceholder + ` AND f.following_id = creator_id
+		))) AND ` + activeCreator
+}
+
+// activeCreator is a WHERE condition leaving out rows whose creator_id belongs to a deactivated account
+const activeCreator = `NOT EXISTS (SELECT 1 FROM accounts da WHERE da.id = creator_id AND da.status = 'deactivated')`
+
+// notHiddenFor returns a WHERE condition leaving out other accounts' sensitive posts when
+// the viewer bound at the given placeholder prefers sensitive content hidden
+func notHiddenFor(viewerPlaceholder string) string {
+	return `(NOT is_sensitive OR creator_id = ` + viewerPlaceholder + ` OR NOT EXISTS (
+			SELECT 1 FROM accounts a WHERE a.id = ` + viewerPlaceholder + ` AND a.sensitive_content = 'hide'
+		))`
 }
@@ -0,0 +1,168 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/post"
+	"github.com/fanzru/social-media-service-go/pkg/pagination"
+)
+
+// recordingDriver is a database/sql driver that records the queries run on it and returns
+// no rows, to check the SQL and arguments a repository method builds
+type recordingDriver struct {
+	mu      sync.Mutex
+	queries []recordedQuery
+}
+
+type recordedQuery struct {
+	query string
+	args  []driver.Value
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return &recordingConn{driver: d}, nil }
+
+func (d *recordingDriver) last(t *testing.T) recordedQuery {
+	t.Helper()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.queries) == 0 {
+		t.Fatal("no query was run")
+	}
+	return d.queries[len(d.queries)-1]
+}
+
+type recordingConn struct {
+	driver *recordingDriver
+}
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *recordingConn) Close() error                        { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c *recordingConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	c.driver.mu.Lock()
+	c.driver.queries = append(c.driver.queries, recordedQuery{query: query, args: values})
+	c.driver.mu.Unlock()
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string         { return nil }
+func (emptyRows) Close() error              { return nil }
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
+
+var (
+	registerOnce sync.Once
+	recorder     = &recordingDriver{}
+)
+
+func newRecordingRepository(t *testing.T) *Repository {
+	t.Helper()
+	registerOnce.Do(func() { sql.Register("post-repo-recorder", recorder) })
+	db, err := sql.Open("post-repo-recorder", "")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return &Repository{db: db}
+}
+
+var placeholder = regexp.MustCompile(`\$(\d+)`)
+
+// checkPlaceholders fails the test unless the query references exactly $1 to $n for its n
+// arguments
+func checkPlaceholders(t *testing.T, q recordedQuery) {
+	t.Helper()
+	used := make(map[int]bool)
+	for _, m := range placeholder.FindAllStringSubmatch(q.query, -1) {
+		n, _ := strconv.Atoi(m[1])
+		used[n] = true
+	}
+	if len(used) != len(q.args) {
+		t.Errorf("query references %d placeholders for %d arguments:\n%s", len(used), len(q.args), q.query)
+	}
+	for n := 1; n <= len(q.args); n++ {
+		if !used[n] {
+			t.Errorf("argument $%d is never referenced:\n%s", n, q.query)
+		}
+	}
+}
+
+func TestGetPostsSortedByCommentsBindsCursor(t *testing.T) {
+	pagination.SetCursorSecret("test-secret")
+	createdAt := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	creatorID := int64(3)
+	createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cursor := pagination.EncodeCursor(pagination.CursorKeys{Listing: listingPostsByComments, Count: 7, CreatedAt: createdAt, ID: 42})
+
+	tests := map[string]struct {
+		filter post.ListFilter
+		cursor string
+		// Arguments expected after the viewer and the filter's
+		wantTail []driver.Value
+	}{
+		"first page":             {cursor: "", wantTail: []driver.Value{int64(21)}},
+		"next page":              {cursor: cursor, wantTail: []driver.Value{int64(7), createdAt, int64(42), int64(21)}},
+		"next page with filters": {filter: post.ListFilter{CreatedAfter: &createdAfter, CreatorID: &creatorID, Hashtag: "go"}, cursor: cursor, wantTail: []driver.Value{int64(7), createdAt, int64(42), int64(21)}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := newRecordingRepository(t)
+			if _, err := r.GetPostsSortedByComments(context.Background(), 99, tt.filter, tt.cursor, 20); err != nil {
+				t.Fatalf("GetPostsSortedByComments: %v", err)
+			}
+
+			q := recorder.last(t)
+			checkPlaceholders(t, q)
+			if q.args[0] != int64(99) {
+				t.Errorf("$1 = %v, want the viewer ID 99", q.args[0])
+			}
+			if len(q.args) < len(tt.wantTail) {
+				t.Fatalf("got %d arguments, want at least %d", len(q.args), len(tt.wantTail))
+			}
+			tail := q.args[len(q.args)-len(tt.wantTail):]
+			for i, want := range tt.wantTail {
+				got := tail[i]
+				if wantTime, ok := want.(time.Time); ok {
+					if gotTime, ok := got.(time.Time); !ok || !gotTime.Equal(wantTime) {
+						t.Errorf("argument %d = %v, want %v", len(q.args)-len(tt.wantTail)+i+1, got, want)
+					}
+					continue
+				}
+				if got != want {
+					t.Errorf("argument %d = %v, want %v", len(q.args)-len(tt.wantTail)+i+1, got, want)
+				}
+			}
+			if tt.cursor != "" {
+				keyset := "(comment_count, created_at, id) < "
+				if !strings.Contains(q.query, keyset) {
+					t.Errorf("query does not page on %q:\n%s", keyset, q.query)
+				}
+			}
+		})
+	}
+}
+
+func TestGetPostsSortedByCommentsRejectsForeignCursor(t *testing.T) {
+	pagination.SetCursorSecret("test-secret")
+	r := newRecordingRepository(t)
+
+	cursor := pagination.EncodeCursor(pagination.CursorKeys{Listing: "posts:likes", Count: 7, ID: 42})
+	if _, err := r.GetPostsSortedByComments(context.Background(), 99, post.ListFilter{}, cursor, 20); err != pagination.ErrInvalidCursor {
+		t.Errorf("GetPostsSortedByComments with a cursor of another listing error = %v, want ErrInvalidCursor", err)
+	}
+}
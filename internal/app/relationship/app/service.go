@@ -0,0 +1,109 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fanzru/social-media-service-go/internal/app/relationship"
+)
+
+// timelineBackfill is how many of an account's newest posts are added to the timeline of
+// a new follower, so it does not start empty
+const timelineBackfill = 50
+
+// Service implements relationship service interface
+type Service struct {
+	repo relationship.RelationshipRepository
+}
+
+// NewService creates a new relationship service
+func NewService(repo relationship.RelationshipRepository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// Follow makes the account follow another. Private accounts cannot be followed, as there
+// are no follow requests for them to approve, and neither can accounts blocked in either
+// direction.
+func (s *Service) Follow(ctx context.Context, followerID, followingID int64) error {
+	if followerID == followingID {
+		return relationship.ErrCannotFollowSelf
+	}
+
+	target, err := s.getTarget(ctx, followerID, followingID)
+	if err != nil {
+		return err
+	}
+	if target.Blocked {
+		return relationship.ErrFollowBlocked
+	}
+	if target.IsPrivate {
+		return relationship.ErrAccountPrivate
+	}
+
+	if err := s.repo.Follow(ctx, followerID, followingID, timelineBackfill); err != nil {
+		return fmt.Errorf("failed to follow account: %w", err)
+	}
+
+	return nil
+}
+
+// Unfollow stops the account following another; unfollowing an account not followed has
+// no effect
+func (s *Service) Unfollow(ctx context.Context, followerID, followingID int64) error {
+	if _, err := s.getTarget(ctx, followerID, followingID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Unfollow(ctx, followerID, followingID); err != nil {
+		return fmt.Errorf("failed to unfollow account: %w", err)
+	}
+
+	return nil
+}
+
+// Block blocks another account. Neither account follows the other afterwards, and
+// neither can follow the other while the block lasts.
+func (s *Service) Block(ctx context.Context, blockerID, blockedID int64) error {
+	if blockerID == blockedID {
+		return relationship.ErrCannotBlockSelf
+	}
+	if _, err := s.getTarget(ctx, blockerID, blockedID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Block(ctx, blockerID, blockedID); err != nil {
+		return fmt.Errorf("failed to block account: %w", err)
+	}
+
+	return nil
+}
+
+// Unblock removes the account's block of another; follows removed by the block are not
+// restored
+func (s *Service) Unblock(ctx context.Context, blockerID, blockedID int64) error {
+	if _, err := s.getTarget(ctx, blockerID, blockedID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Unblock(ctx, blockerID, blockedID); err != nil {
+		return fmt.Errorf("failed to unblock account: %w", err)
+	}
+
+	return nil
+}
+
+// getTarget returns the account a relationship is changed with
+func (s *Service) getTarget(ctx context.Context, accountID, targetID int64) (*relationship.Target, error) {
+	target, err := s.repo.GetTarget(ctx, accountID, targetID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, relationship.ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	return target, nil
+}
@@ -0,0 +1,47 @@
+package relationship
+
+import (
+	"context"
+
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
+)
+
+// Errors returned by the relationship service
+var (
+	ErrAccountNotFound  = apperr.NotFound("account not found")
+	ErrCannotFollowSelf = apperr.Invalid("you cannot follow yourself")
+	ErrCannotBlockSelf  = apperr.Invalid("you cannot block yourself")
+	ErrAccountPrivate   = apperr.Forbidden("private accounts cannot be followed")
+	ErrFollowBlocked    = apperr.Forbidden("you cannot follow this account")
+)
+
+// Target is the account a relationship is changed with, as seen by the account changing it
+type Target struct {
+	ID        int64
+	IsPrivate bool
+	Blocked   bool // Either account blocks the other
+}
+
+// RelationshipRepository defines the interface for relationship data access
+type RelationshipRepository interface {
+	// GetTarget returns an active account as seen by accountID, or sql.ErrNoRows
+	GetTarget(ctx context.Context, accountID, targetID int64) (*Target, error)
+	// Follow makes followerID follow followingID and adds up to backfill of their newest
+	// posts to the follower's timeline; following again has no effect
+	Follow(ctx context.Context, followerID, followingID int64, backfill int) error
+	// Unfollow removes the follow and the followed account's posts from the follower's timeline
+	Unfollow(ctx context.Context, followerID, followingID int64) error
+	// Block makes blockerID block blockedID and removes the follows between them; blocking
+	// again has no effect
+	Block(ctx context.Context, blockerID, blockedID int64) error
+	// Unblock removes the block
+	Unblock(ctx context.Context, blockerID, blockedID int64) error
+}
+
+// RelationshipService defines the interface for relationship business logic
+type RelationshipService interface {
+	Follow(ctx context.Context, followerID, followingID int64) error
+	Unfollow(ctx context.Context, followerID, followingID int64) error
+	Block(ctx context.Context, blockerID, blockedID int64) error
+	Unblock(ctx context.Context, blockerID, blockedID int64) error
+}
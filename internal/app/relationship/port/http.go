@@ -0,0 +1,89 @@
+package port
+
+import (
+	"net/http"
+
+	"github.com/fanzru/social-media-service-go/internal/app/relationship"
+	"github.com/fanzru/social-media-service-go/internal/app/relationship/port/genhttp"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"github.com/fanzru/social-media-service-go/pkg/response"
+)
+
+// Handler handles HTTP requests for relationships: follows and blocks
+type Handler struct {
+	service relationship.RelationshipService
+}
+
+// NewHandler creates a new relationship handler
+func NewHandler(service relationship.RelationshipService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// PostApiAccountsIdFollow handles POST /api/accounts/{id}/follow
+func (h *Handler) PostApiAccountsIdFollow(w http.ResponseWriter, r *http.Request, id int64) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.Follow(r.Context(), userID, id); err != nil {
+		response.SendError(r.Context(), w, err, "Failed to follow account")
+		return
+	}
+
+	response.Success(r.Context(), "Account followed successfully", nil).Send(w, http.StatusOK)
+}
+
+// DeleteApiAccountsIdFollow handles DELETE /api/accounts/{id}/follow
+func (h *Handler) DeleteApiAccountsIdFollow(w http.ResponseWriter, r *http.Request, id int64) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.Unfollow(r.Context(), userID, id); err != nil {
+		response.SendError(r.Context(), w, err, "Failed to unfollow account")
+		return
+	}
+
+	response.Success(r.Context(), "Account unfollowed successfully", nil).Send(w, http.StatusOK)
+}
+
+// PostApiAccountsIdBlock handles POST /api/accounts/{id}/block
+func (h *Handler) PostApiAccountsIdBlock(w http.ResponseWriter, r *http.Request, id int64) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.Block(r.Context(), userID, id); err != nil {
+		response.SendError(r.Context(), w, err, "Failed to block account")
+		return
+	}
+
+	response.Success(r.Context(), "Account blocked successfully", nil).Send(w, http.StatusOK)
+}
+
+// DeleteApiAccountsIdBlock handles DELETE /api/accounts/{id}/block
+func (h *Handler) DeleteApiAccountsIdBlock(w http.ResponseWriter, r *http.Request, id int64) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.service.Unblock(r.Context(), userID, id); err != nil {
+		response.SendError(r.Context(), w, err, "Failed to unblock account")
+		return
+	}
+
+	response.Success(r.Context(), "Account unblocked successfully", nil).Send(w, http.StatusOK)
+}
+
+// Implement the generated interface
+var _ genhttp.ServerInterface = (*Handler)(nil)
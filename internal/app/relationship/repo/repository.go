@@ -0,0 +1,117 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/fanzru/social-media-service-go/internal/app/relationship"
+	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
+)
+
+// Repository implements relationship repository interface
+type Repository struct {
+	db interface{} // Can be *sql.DB or *sqlwrap.DB
+}
+
+// NewRepository creates a new relationship repository
+func NewRepository(db interface{}) *Repository {
+	return &Repository{db: db}
+}
+
+// GetTarget returns an account that is not deactivated, with whether it is private and
+// whether it and accountID block each other in either direction
+func (r *Repository) GetTarget(ctx context.Context, accountID, targetID int64) (*relationship.Target, error) {
+	query := `
+		SELECT a.id, a.is_private, EXISTS (
+			SELECT 1 FROM blocks b
+			WHERE (b.blocker_id = $1 AND b.blocked_id = a.id) OR (b.blocker_id = a.id AND b.blocked_id = $1)
+		)
+		FROM accounts a
+		WHERE a.id = $2 AND a.status <> 'deactivated'`
+
+	var target relationship.Target
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, accountID, targetID).Scan(&target.ID, &target.IsPrivate, &target.Blocked)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, accountID, targetID).Scan(&target.ID, &target.IsPrivate, &target.Blocked)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// Follow inserts the follow and, when it is new, writes the newest backfill posts of the
+// followed account to the follower's timeline. Posts of pull accounts are left out; their
+// followers' timelines read them from posts.
+func (r *Repository) Follow(ctx context.Context, followerID, followingID int64, backfill int) error {
+	query := `
+		WITH followed AS (
+			INSERT INTO follows (follower_id, following_id) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+			RETURNING following_id
+		)
+		INSERT INTO timeline_entries (account_id, post_id, posted_at)
+		SELECT $1, p.id, p.created_at
+		FROM posts p
+			JOIN followed ON followed.following_id = p.creator_id
+		WHERE p.deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM timeline_pull_accounts tp WHERE tp.account_id = p.creator_id)
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT $3
+		ON CONFLICT DO NOTHING`
+
+	return r.exec(ctx, query, followerID, followingID, backfill)
+}
+
+// Unfollow deletes the follow and the followed account's entries in the follower's timeline
+func (r *Repository) Unfollow(ctx context.Context, followerID, followingID int64) error {
+	query := `
+		WITH unfollowed AS (
+			DELETE FROM follows WHERE follower_id = $1 AND following_id = $2
+			RETURNING following_id
+		)
+		DELETE FROM timeline_entries te
+		USING posts p, unfollowed
+		WHERE te.account_id = $1 AND te.post_id = p.id AND p.creator_id = unfollowed.following_id`
+
+	return r.exec(ctx, query, followerID, followingID)
+}
+
+// Block inserts the block and deletes the follows between the two accounts in either
+// direction, with the entries they put in each other's timelines
+func (r *Repository) Block(ctx context.Context, blockerID, blockedID int64) error {
+	query := `
+		WITH blocked AS (
+			INSERT INTO blocks (blocker_id, blocked_id) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		), unfollowed AS (
+			DELETE FROM follows
+			WHERE (follower_id = $1 AND following_id = $2) OR (follower_id = $2 AND following_id = $1)
+			RETURNING follower_id, following_id
+		)
+		DELETE FROM timeline_entries te
+		USING posts p, unfollowed u
+		WHERE te.account_id = u.follower_id AND te.post_id = p.id AND p.creator_id = u.following_id`
+
+	return r.exec(ctx, query, blockerID, blockedID)
+}
+
+// Unblock deletes the block
+func (r *Repository) Unblock(ctx context.Context, blockerID, blockedID int64) error {
+	return r.exec(ctx, `DELETE FROM blocks WHERE blocker_id = $1 AND blocked_id = $2`, blockerID, blockedID)
+}
+
+// exec runs a statement that returns no rows
+func (r *Repository) exec(ctx context.Context, query string, args ...interface{}) error {
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		_, err = db.ExecContext(ctx, query, args...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		_, err = db.ExecContext(ctx, query, args...)
+	}
+
+	return err
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fanzru/social-media-service-go/internal/app/search"
+)
+
+// Service implements search service interface
+type Service struct {
+	repo search.SearchRepository
+}
+
+// NewService creates a new search service
+func NewService(repo search.SearchRepository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// SearchAccounts searches active accounts by name
+func (s *Service) SearchAccounts(ctx context.Context, query string, cursor string, limit int) (*search.AccountSearchResponse, error) {
+	query = strings.TrimSpace(query)
+	if err := s.validateQuery(query); err != nil {
+		return nil, fmt.Errorf("%w: %w", search.ErrInvalidQuery, err)
+	}
+
+	response, err := s.repo.SearchAccounts(ctx, query, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search accounts: %w", err)
+	}
+
+	return response, nil
+}
+
+// validateQuery validates the search term
+func (s *Service) validateQuery(query string) error {
+	if query == "" {
+		return fmt.Errorf("q is required")
+	}
+	if utf8.RuneCountInString(query) > 100 {
+		return fmt.Errorf("q must be at most 100 characters")
+	}
+	return nil
+}
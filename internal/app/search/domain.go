@@ -0,0 +1,38 @@
+package search
+
+import (
+	"context"
+
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
+)
+
+// ErrInvalidQuery is returned for empty or too long search terms
+var ErrInvalidQuery = apperr.Invalid("invalid search query")
+
+// AccountResult represents an account matched by a search
+type AccountResult struct {
+	ID        int64  `json:"id" db:"id"`
+	Name      string `json:"name" db:"name"`
+	AvatarURL string `json:"avatar_url,omitempty" db:"avatar_url"`
+
+	// Ranking fields, used for ordering and pagination only
+	PrefixMatch bool    `json:"-" db:"prefix_match"`
+	Score       float64 `json:"-" db:"score"`
+}
+
+// AccountSearchResponse represents the response payload for an account search
+type AccountSearchResponse struct {
+	Accounts []AccountResult `json:"accounts"`
+	Cursor   string          `json:"cursor,omitempty"`
+	HasMore  bool            `json:"has_more"`
+}
+
+// SearchRepository defines the interface for search data access
+type SearchRepository interface {
+	SearchAccounts(ctx context.Context, query string, cursor string, limit int) (*AccountSearchResponse, error)
+}
+
+// SearchService defines the interface for search business logic
+type SearchService interface {
+	SearchAccounts(ctx context.Context, query string, cursor string, limit int) (*AccountSearchResponse, error)
+}
@@ -0,0 +1,45 @@
+package port
+
+import (
+	"net/http"
+
+	"github.com/fanzru/social-media-service-go/internal/app/search"
+	"github.com/fanzru/social-media-service-go/internal/app/search/port/genhttp"
+	"github.com/fanzru/social-media-service-go/pkg/response"
+)
+
+// Handler handles HTTP requests for search
+type Handler struct {
+	service search.SearchService
+}
+
+// NewHandler creates a new search handler
+func NewHandler(service search.SearchService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// GetApiSearchAccounts handles GET /api/search/accounts
+func (h *Handler) GetApiSearchAccounts(w http.ResponseWriter, r *http.Request, params genhttp.GetApiSearchAccountsParams) {
+	cursor := ""
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	limit := 20
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	accounts, err := h.service.SearchAccounts(r.Context(), params.Q, cursor, limit)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to search accounts")
+		return
+	}
+
+	response.Success(r.Context(), "Accounts retrieved successfully", accounts).Send(w, http.StatusOK)
+}
+
+// Implement the generated interface
+var _ genhttp.ServerInterface = (*Handler)(nil)
@@ -0,0 +1,140 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fanzru/social-media-service-go/internal/app/search"
+	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
+)
+
+// similarityThreshold is the minimum trigram similarity for a fuzzy match
+const similarityThreshold = 0.3
+
+// Repository implements search repository interface
+type Repository struct {
+	db interface{} // Can be *sql.DB or *sqlwrap.DB
+}
+
+// NewRepository creates a new search repository
+func NewRepository(db interface{}) *Repository {
+	return &Repository{db: db}
+}
+
+// SearchAccounts finds active, discoverable accounts whose name starts with or resembles the query.
+// Results are ordered by prefix match, then similarity, then ID, with keyset pagination on that order.
+func (r *Repository) SearchAccounts(ctx context.Context, query string, cursor string, limit int) (*search.AccountSearchResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	term := strings.ToLower(query)
+	sqlQuery := `
+		SELECT id, name, avatar_url, prefix_match, score
+		FROM (
+			SELECT id, name, avatar_url,
+				lower(name) LIKE $1 ESCAPE '\' AS prefix_match,
+				similarity(lower(name), $2)::float8 AS score
+			FROM accounts
+			WHERE deleted_at IS NULL AND status = 'active' AND discoverable
+				AND (lower(name) LIKE $1 ESCAPE '\' OR similarity(lower(name), $2) >= $3)
+		) matches
+		WHERE TRUE
+	`
+	args := []interface{}{escapeLike(term) + "%", term, similarityThreshold}
+
+	if cursor != "" {
+		prefix, score, id, err := decodeSearchCursor(cursor)
+		if err == nil {
+			// Keyset on (prefix_match DESC, score DESC, id ASC)
+			sqlQuery += ` AND (prefix_match::int, score, -id) < ($4::int, $5, -$6::bigint)`
+			args = append(args, boolToInt(prefix), score, id)
+		}
+	}
+
+	sqlQuery += ` ORDER BY prefix_match DESC, score DESC, id ASC LIMIT $` + fmt.Sprintf("%d", len(args)+1)
+	args = append(args, limit+1) // Get one extra to check if there are more
+
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, sqlQuery, args...)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, sqlQuery, args...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := []search.AccountResult{}
+	for rows.Next() {
+		var a search.AccountResult
+		if err := rows.Scan(&a.ID, &a.Name, &a.AvatarURL, &a.PrefixMatch, &a.Score); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, a)
+	}
+
+	hasMore := len(accounts) > limit
+	if hasMore {
+		accounts = accounts[:limit]
+	}
+
+	var nextCursor string
+	if hasMore && len(accounts) > 0 {
+		last := accounts[len(accounts)-1]
+		nextCursor = encodeSearchCursor(last.PrefixMatch, last.Score, last.ID)
+	}
+
+	return &search.AccountSearchResponse{
+		Accounts: accounts,
+		Cursor:   nextCursor,
+		HasMore:  hasMore,
+	}, nil
+}
+
+// escapeLike escapes LIKE wildcards so user input is matched literally
+func escapeLike(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(s)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// encodeSearchCursor creates a cursor from the ranking of the last returned account
+func encodeSearchCursor(prefixMatch bool, score float64, id int64) string {
+	plain := fmt.Sprintf("%d|%s|%d", boolToInt(prefixMatch), strconv.FormatFloat(score, 'g', -1, 64), id)
+	return base64.RawURLEncoding.EncodeToString([]byte(plain))
+}
+
+// decodeSearchCursor parses the composite cursor back to values
+func decodeSearchCursor(cursor string) (bool, float64, int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	parts := strings.SplitN(string(b), "|", 3)
+	if len(parts) != 3 {
+		return false, 0, 0, fmt.Errorf("invalid cursor format")
+	}
+	score, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	return parts[0] == "1", score, id, nil
+}
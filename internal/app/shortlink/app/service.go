@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/fanzru/social-media-service-go/internal/app/post"
+	"github.com/fanzru/social-media-service-go/internal/app/shortlink"
+)
+
+const (
+	slugAlphabet    = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	slugLength      = 7
+	maxSlugAttempts = 5
+)
+
+// Service implements short link service interface
+type Service struct {
+	repo       shortlink.ShortLinkRepository
+	postRepo   post.PostRepository
+	baseURL    string
+	postTarget string
+}
+
+// NewService creates a new short link service. Short links are served from baseURL and
+// redirect to postTarget, where "{id}" is replaced with the post ID.
+func NewService(repo shortlink.ShortLinkRepository, postRepo post.PostRepository, baseURL string, postTarget string) *Service {
+	return &Service{
+		repo:       repo,
+		postRepo:   postRepo,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		postTarget: postTarget,
+	}
+}
+
+// GetOrCreate returns the short link of a post, creating one on first use
+func (s *Service) GetOrCreate(ctx context.Context, postID int64, viewerID int64) (*shortlink.ShortLink, error) {
+	// Check if post exists and is visible to the viewer
+	if _, err := s.postRepo.GetByID(ctx, postID, viewerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, post.ErrPostNotFound
+		}
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+
+	for attempt := 0; attempt < maxSlugAttempts; attempt++ {
+		link, err := s.repo.GetByPostID(ctx, postID)
+		if err == nil {
+			s.fillURL(link)
+			return link, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get short link: %w", err)
+		}
+
+		slug, err := generateSlug()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate slug: %w", err)
+		}
+
+		link = &shortlink.ShortLink{Slug: slug, PostID: postID}
+		err = s.repo.Create(ctx, link)
+		if err == nil {
+			s.fillURL(link)
+			return link, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to create short link: %w", err)
+		}
+		// Slug collision or a concurrent request created the link; look again
+	}
+
+	return nil, fmt.Errorf("failed to create short link: no free slug after %d attempts", maxSlugAttempts)
+}
+
+// Resolve counts a click on a short link and returns the URL it redirects to
+func (s *Service) Resolve(ctx context.Context, slug string) (string, error) {
+	postID, err := s.repo.RecordClick(ctx, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", shortlink.ErrShortLinkNotFound
+		}
+		return "", fmt.Errorf("failed to record click: %w", err)
+	}
+
+	return strings.ReplaceAll(s.postTarget, "{id}", strconv.FormatInt(postID, 10)), nil
+}
+
+// fillURL sets the public URL of a short link
+func (s *Service) fillURL(link *shortlink.ShortLink) {
+	link.URL = s.baseURL + "/p/" + link.Slug
+}
+
+// generateSlug returns a random base62 slug
+func generateSlug() (string, error) {
+	max := big.NewInt(int64(len(slugAlphabet)))
+	slug := make([]byte, slugLength)
+	for i := range slug {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		slug[i] = slugAlphabet[n.Int64()]
+	}
+	return string(slug), nil
+}
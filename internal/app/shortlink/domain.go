@@ -0,0 +1,36 @@
+package shortlink
+
+import (
+	"context"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
+)
+
+// ErrShortLinkNotFound is returned for slugs that do not exist
+var ErrShortLinkNotFound = apperr.NotFound("short link not found")
+
+// ShortLink represents a shareable short link to a post
+type ShortLink struct {
+	ID         int64     `json:"-" db:"id"`
+	Slug       string    `json:"slug" db:"slug"`
+	PostID     int64     `json:"post_id" db:"post_id"`
+	ClickCount int64     `json:"click_count" db:"click_count"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+
+	// Computed fields
+	URL string `json:"url" db:"-"`
+}
+
+// ShortLinkRepository defines the interface for short link data access
+type ShortLinkRepository interface {
+	Create(ctx context.Context, link *ShortLink) error
+	GetByPostID(ctx context.Context, postID int64) (*ShortLink, error)
+	RecordClick(ctx context.Context, slug string) (int64, error)
+}
+
+// ShortLinkService defines the interface for short link business logic
+type ShortLinkService interface {
+	GetOrCreate(ctx context.Context, postID int64, viewerID int64) (*ShortLink, error)
+	Resolve(ctx context.Context, slug string) (string, error)
+}
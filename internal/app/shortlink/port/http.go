@@ -0,0 +1,53 @@
+package port
+
+import (
+	"net/http"
+
+	"github.com/fanzru/social-media-service-go/internal/app/shortlink"
+	"github.com/fanzru/social-media-service-go/internal/app/shortlink/port/genhttp"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"github.com/fanzru/social-media-service-go/pkg/response"
+)
+
+// Handler handles HTTP requests for short links
+type Handler struct {
+	service shortlink.ShortLinkService
+}
+
+// NewHandler creates a new short link handler
+func NewHandler(service shortlink.ShortLinkService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// PostApiPostsIdShortLink handles POST /api/posts/{id}/short-link
+func (h *Handler) PostApiPostsIdShortLink(w http.ResponseWriter, r *http.Request, id int64) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	link, err := h.service.GetOrCreate(r.Context(), id, userID)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to get short link")
+		return
+	}
+
+	response.Success(r.Context(), "Short link retrieved successfully", link).Send(w, http.StatusOK)
+}
+
+// GetPSlug handles GET /p/{slug}
+func (h *Handler) GetPSlug(w http.ResponseWriter, r *http.Request, slug string) {
+	target, err := h.service.Resolve(r.Context(), slug)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to resolve short link")
+		return
+	}
+
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// Implement the generated interface
+var _ genhttp.ServerInterface = (*Handler)(nil)
@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/fanzru/social-media-service-go/internal/app/shortlink"
+	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
+)
+
+// Repository implements short link repository interface
+type Repository struct {
+	db interface{} // Can be *sql.DB or *sqlwrap.DB
+}
+
+// NewRepository creates a new short link repository
+func NewRepository(db interface{}) *Repository {
+	return &Repository{db: db}
+}
+
+// Create inserts a new short link. When the slug is taken or the post already has a
+// short link no row is inserted and sql.ErrNoRows is returned.
+func (r *Repository) Create(ctx context.Context, link *shortlink.ShortLink) error {
+	query := `
+		INSERT INTO short_links (slug, post_id, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT DO NOTHING
+		RETURNING id, click_count, created_at
+	`
+
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, link.Slug, link.PostID).Scan(&link.ID, &link.ClickCount, &link.CreatedAt)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, link.Slug, link.PostID).Scan(&link.ID, &link.ClickCount, &link.CreatedAt)
+	}
+
+	return err
+}
+
+// GetByPostID retrieves the short link of a post
+func (r *Repository) GetByPostID(ctx context.Context, postID int64) (*shortlink.ShortLink, error) {
+	query := `
+		SELECT id, slug, post_id, click_count, created_at
+		FROM short_links
+		WHERE post_id = $1
+	`
+
+	var link shortlink.ShortLink
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, postID).Scan(&link.ID, &link.Slug, &link.PostID, &link.ClickCount, &link.CreatedAt)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, postID).Scan(&link.ID, &link.Slug, &link.PostID, &link.ClickCount, &link.CreatedAt)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+// RecordClick increments the click count of a short link and returns the post ID it
+// points to. Links to deleted posts are treated as missing.
+func (r *Repository) RecordClick(ctx context.Context, slug string) (int64, error) {
+	query := `
+		UPDATE short_links l
+		SET click_count = l.click_count + 1
+		FROM posts p
+		WHERE l.slug = $1 AND p.id = l.post_id AND p.deleted_at IS NULL
+		RETURNING l.post_id
+	`
+
+	var postID int64
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, slug).Scan(&postID)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, slug).Scan(&postID)
+	}
+
+	return postID, err
+}
@@ -0,0 +1,187 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/story"
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/storage"
+)
+
+// Service implements story service interface
+type Service struct {
+	repo         story.StoryRepository
+	imageStorage *storage.ImageStorageService
+	ttl          time.Duration
+}
+
+// NewService creates a new story service; stories expire ttl after creation
+func NewService(repo story.StoryRepository, imageStorage *storage.ImageStorageService, ttl time.Duration) *Service {
+	return &Service{
+		repo:         repo,
+		imageStorage: imageStorage,
+		ttl:          ttl,
+	}
+}
+
+// CreateStory uploads the image and creates a story that expires after the configured TTL
+func (s *Service) CreateStory(ctx context.Context, creatorID int64, file multipart.File, header *multipart.FileHeader) (*story.Story, error) {
+	// Process and upload image
+	imagePath, imageURL, err := s.imageStorage.ProcessAndUploadImage(file, header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process and upload image: %w", err)
+	}
+
+	now := time.Now()
+	newStory := &story.Story{
+		CreatorID: creatorID,
+		ImagePath: imagePath,
+		ImageURL:  imageURL,
+		ExpiresAt: now.Add(s.ttl),
+		CreatedAt: now,
+	}
+
+	if err := s.repo.Create(ctx, newStory); err != nil {
+		// If story creation fails, try to delete the uploaded image
+		s.imageStorage.DeleteImage(imagePath)
+		return nil, fmt.Errorf("failed to create story: %w", err)
+	}
+
+	return newStory, nil
+}
+
+// GetFeed retrieves active stories of the viewer and followed accounts grouped by account
+func (s *Service) GetFeed(ctx context.Context, viewerID int64) (*story.StoryFeedResponse, error) {
+	stories, err := s.repo.GetFeed(ctx, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stories feed: %w", err)
+	}
+
+	// Stories arrive grouped by creator; keep that order when building the groups
+	items := []story.StoryFeedItem{}
+	for _, st := range stories {
+		if len(items) == 0 || items[len(items)-1].AccountID != st.CreatorID {
+			items = append(items, story.StoryFeedItem{AccountID: st.CreatorID})
+		}
+		item := &items[len(items)-1]
+		item.Stories = append(item.Stories, st)
+		if !st.Viewed {
+			item.HasUnseen = true
+		}
+	}
+
+	return &story.StoryFeedResponse{Items: items}, nil
+}
+
+// ViewStory records that the viewer has seen a story
+func (s *Service) ViewStory(ctx context.Context, storyID int64, viewerID int64) error {
+	existingStory, err := s.getActiveStory(ctx, storyID)
+	if err != nil {
+		return err
+	}
+
+	// Only the creator and their followers can see a story
+	canView, err := s.repo.CanView(ctx, existingStory, viewerID)
+	if err != nil {
+		return fmt.Errorf("failed to check story access: %w", err)
+	}
+	if !canView {
+		return story.ErrStoryNotFound
+	}
+
+	// Creators viewing their own stories are not counted
+	if existingStory.CreatorID == viewerID {
+		return nil
+	}
+
+	if err := s.repo.RecordView(ctx, storyID, viewerID); err != nil {
+		return fmt.Errorf("failed to record story view: %w", err)
+	}
+
+	return nil
+}
+
+// GetStoryViews retrieves the viewers of a story owned by the creator
+func (s *Service) GetStoryViews(ctx context.Context, storyID int64, creatorID int64) ([]story.StoryView, error) {
+	existingStory, err := s.getActiveStory(ctx, storyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user owns the story
+	if existingStory.CreatorID != creatorID {
+		return nil, story.ErrNotStoryOwner
+	}
+
+	views, err := s.repo.GetViews(ctx, storyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get story views: %w", err)
+	}
+	if views == nil {
+		views = []story.StoryView{}
+	}
+
+	return views, nil
+}
+
+// SweepExpired soft deletes expired stories and removes their images from storage
+func (s *Service) SweepExpired(ctx context.Context) (int, error) {
+	expired, err := s.repo.ExpireStories(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire stories: %w", err)
+	}
+
+	for _, st := range expired {
+		// Image cleanup is best effort; the story is already hidden
+		s.imageStorage.DeleteImage(st.ImagePath)
+	}
+
+	return len(expired), nil
+}
+
+// RunExpirySweeper periodically sweeps expired stories until the context is cancelled. An
+// interval of 0 or less disables the sweep; expired stories are still hidden, but their
+// images are kept.
+func (s *Service) RunExpirySweeper(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	log := logger.GetGlobal()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := s.SweepExpired(ctx)
+			if err != nil {
+				log.Error("Story expiry sweep failed", "error", err.Error())
+				continue
+			}
+			if count > 0 {
+				log.Info("Expired stories swept", "count", count)
+			}
+		}
+	}
+}
+
+// getActiveStory loads a story that has not expired yet
+func (s *Service) getActiveStory(ctx context.Context, storyID int64) (*story.Story, error) {
+	existingStory, err := s.repo.GetActiveByID(ctx, storyID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, story.ErrStoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get story: %w", err)
+	}
+
+	return existingStory, nil
+}
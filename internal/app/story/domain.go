@@ -0,0 +1,67 @@
+package story
+
+import (
+	"context"
+	"mime/multipart"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
+)
+
+// Errors returned by the story service
+var (
+	ErrStoryNotFound = apperr.NotFound("story not found")
+	ErrNotStoryOwner = apperr.Forbidden("only the creator can see who viewed a story")
+)
+
+// Story represents an image story that expires after a fixed time
+type Story struct {
+	ID        int64      `json:"id" db:"id"`
+	CreatorID int64      `json:"creator_id" db:"creator_id"`
+	ImagePath string     `json:"image_path" db:"image_path"`
+	ImageURL  string     `json:"image_url" db:"image_url"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+
+	// Computed fields
+	Viewed bool `json:"viewed" db:"viewed"`
+}
+
+// StoryView represents a single view of a story
+type StoryView struct {
+	ViewerID int64     `json:"viewer_id" db:"viewer_id"`
+	ViewedAt time.Time `json:"viewed_at" db:"viewed_at"`
+}
+
+// StoryFeedItem groups the active stories of one account
+type StoryFeedItem struct {
+	AccountID int64   `json:"account_id"`
+	HasUnseen bool    `json:"has_unseen"`
+	Stories   []Story `json:"stories"`
+}
+
+// StoryFeedResponse represents the response payload for the stories feed
+type StoryFeedResponse struct {
+	Items []StoryFeedItem `json:"items"`
+}
+
+// StoryRepository defines the interface for story data access
+type StoryRepository interface {
+	Create(ctx context.Context, story *Story) error
+	GetActiveByID(ctx context.Context, id int64) (*Story, error)
+	GetFeed(ctx context.Context, viewerID int64) ([]Story, error)
+	CanView(ctx context.Context, story *Story, viewerID int64) (bool, error)
+	RecordView(ctx context.Context, storyID int64, viewerID int64) error
+	GetViews(ctx context.Context, storyID int64) ([]StoryView, error)
+	ExpireStories(ctx context.Context, before time.Time) ([]Story, error)
+}
+
+// StoryService defines the interface for story business logic
+type StoryService interface {
+	CreateStory(ctx context.Context, creatorID int64, file multipart.File, header *multipart.FileHeader) (*Story, error)
+	GetFeed(ctx context.Context, viewerID int64) (*StoryFeedResponse, error)
+	ViewStory(ctx context.Context, storyID int64, viewerID int64) error
+	GetStoryViews(ctx context.Context, storyID int64, creatorID int64) ([]StoryView, error)
+	SweepExpired(ctx context.Context) (int, error)
+}
@@ -0,0 +1,107 @@
+package port
+
+import (
+	"net/http"
+
+	"github.com/fanzru/social-media-service-go/internal/app/story"
+	"github.com/fanzru/social-media-service-go/internal/app/story/port/genhttp"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"github.com/fanzru/social-media-service-go/pkg/response"
+	"github.com/fanzru/social-media-service-go/pkg/storage"
+)
+
+// Handler handles HTTP requests for stories
+type Handler struct {
+	service story.StoryService
+}
+
+// NewHandler creates a new story handler
+func NewHandler(service story.StoryService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// PostApiStories handles POST /api/stories
+func (h *Handler) PostApiStories(w http.ResponseWriter, r *http.Request) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	err := r.ParseMultipartForm(storage.MaxUploadMemory)
+	if err != nil {
+		response.BadRequest(r.Context(), "Failed to parse multipart form", []string{err.Error()}).Send(w, http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("image")
+	if err != nil {
+		response.BadRequest(r.Context(), "Image file is required", []string{"image field is missing"}).Send(w, http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	createdStory, err := h.service.CreateStory(r.Context(), userID, file, header)
+	if err != nil {
+		response.InternalServerError(r.Context(), "Failed to create story", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	response.Success(r.Context(), "Story created successfully", createdStory).Send(w, http.StatusCreated)
+}
+
+// GetApiStoriesFeed handles GET /api/stories/feed
+func (h *Handler) GetApiStoriesFeed(w http.ResponseWriter, r *http.Request) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	feed, err := h.service.GetFeed(r.Context(), userID)
+	if err != nil {
+		response.InternalServerError(r.Context(), "Failed to get stories feed", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	response.Success(r.Context(), "Stories feed retrieved successfully", feed).Send(w, http.StatusOK)
+}
+
+// PostApiStoriesIdView handles POST /api/stories/{id}/view
+func (h *Handler) PostApiStoriesIdView(w http.ResponseWriter, r *http.Request, id int64) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	err := h.service.ViewStory(r.Context(), id, userID)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to record story view")
+		return
+	}
+
+	response.Success(r.Context(), "Story view recorded successfully", nil).Send(w, http.StatusOK)
+}
+
+// GetApiStoriesIdViews handles GET /api/stories/{id}/views
+func (h *Handler) GetApiStoriesIdViews(w http.ResponseWriter, r *http.Request, id int64) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	views, err := h.service.GetStoryViews(r.Context(), id, userID)
+	if err != nil {
+		response.SendError(r.Context(), w, err, "Failed to get story views")
+		return
+	}
+
+	response.Success(r.Context(), "Story views retrieved successfully", views).Send(w, http.StatusOK)
+}
+
+// Implement the generated interface
+var _ genhttp.ServerInterface = (*Handler)(nil)
@@ -0,0 +1,207 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/story"
+	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
+)
+
+// Repository implements story repository interface
+type Repository struct {
+	db interface{} // Can be *sql.DB or *sqlwrap.DB
+}
+
+// NewRepository creates a new story repository
+func NewRepository(db interface{}) *Repository {
+	return &Repository{db: db}
+}
+
+// Create creates a new story
+func (r *Repository) Create(ctx context.Context, story *story.Story) error {
+	query := `
+		INSERT INTO stories (creator_id, image_path, image_url, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, story.CreatorID, story.ImagePath, story.ImageURL, story.ExpiresAt, story.CreatedAt).Scan(&story.ID)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, story.CreatorID, story.ImagePath, story.ImageURL, story.ExpiresAt, story.CreatedAt).Scan(&story.ID)
+	}
+
+	return err
+}
+
+// GetActiveByID retrieves a story by ID if it has not expired
+func (r *Repository) GetActiveByID(ctx context.Context, id int64) (*story.Story, error) {
+	query := `
+		SELECT id, creator_id, image_path, image_url, expires_at, created_at, deleted_at
+		FROM stories
+		WHERE id = $1 AND deleted_at IS NULL AND expires_at > NOW()
+	`
+
+	var s story.Story
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, id).Scan(&s.ID, &s.CreatorID, &s.ImagePath, &s.ImageURL, &s.ExpiresAt, &s.CreatedAt, &s.DeletedAt)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, id).Scan(&s.ID, &s.CreatorID, &s.ImagePath, &s.ImageURL, &s.ExpiresAt, &s.CreatedAt, &s.DeletedAt)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// GetFeed retrieves active stories of the viewer and the accounts they follow.
+// Stories are ordered by creator, with the creator that posted most recently first,
+// and oldest first within a creator.
+func (r *Repository) GetFeed(ctx context.Context, viewerID int64) ([]story.Story, error) {
+	query := `
+		SELECT s.id, s.creator_id, s.image_path, s.image_url, s.expires_at, s.created_at, s.deleted_at,
+			EXISTS (SELECT 1 FROM story_views v WHERE v.story_id = s.id AND v.viewer_id = $1) AS viewed
+		FROM stories s
+		WHERE s.deleted_at IS NULL AND s.expires_at > NOW()
+			AND (s.creator_id = $1 OR s.creator_id IN (SELECT following_id FROM follows WHERE follower_id = $1))
+			AND NOT EXISTS (SELECT 1 FROM accounts a WHERE a.id = s.creator_id AND a.status = 'deactivated')
+		ORDER BY MAX(s.created_at) OVER (PARTITION BY s.creator_id) DESC, s.creator_id, s.created_at ASC
+	`
+
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, viewerID)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, viewerID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stories []story.Story
+	for rows.Next() {
+		var s story.Story
+		err := rows.Scan(&s.ID, &s.CreatorID, &s.ImagePath, &s.ImageURL, &s.ExpiresAt, &s.CreatedAt, &s.DeletedAt, &s.Viewed)
+		if err != nil {
+			return nil, err
+		}
+		stories = append(stories, s)
+	}
+
+	return stories, nil
+}
+
+// CanView checks whether the viewer is the story creator or follows them
+func (r *Repository) CanView(ctx context.Context, story *story.Story, viewerID int64) (bool, error) {
+	if story.CreatorID == viewerID {
+		return true, nil
+	}
+
+	query := `SELECT EXISTS(SELECT 1 FROM follows WHERE follower_id = $1 AND following_id = $2)`
+
+	var follows bool
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		err = db.QueryRowContext(ctx, query, viewerID, story.CreatorID).Scan(&follows)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		err = db.QueryRowContext(ctx, query, viewerID, story.CreatorID).Scan(&follows)
+	}
+
+	return follows, err
+}
+
+// RecordView records a story view, ignoring repeated views by the same viewer
+func (r *Repository) RecordView(ctx context.Context, storyID int64, viewerID int64) error {
+	query := `
+		INSERT INTO story_views (story_id, viewer_id, viewed_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (story_id, viewer_id) DO NOTHING
+	`
+
+	now := time.Now()
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		_, err = db.ExecContext(ctx, query, storyID, viewerID, now)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		_, err = db.ExecContext(ctx, query, storyID, viewerID, now)
+	}
+
+	return err
+}
+
+// GetViews retrieves the views of a story, most recent first
+func (r *Repository) GetViews(ctx context.Context, storyID int64) ([]story.StoryView, error) {
+	query := `
+		SELECT viewer_id, viewed_at
+		FROM story_views
+		WHERE story_id = $1
+		ORDER BY viewed_at DESC
+	`
+
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, storyID)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, storyID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []story.StoryView
+	for rows.Next() {
+		var v story.StoryView
+		if err := rows.Scan(&v.ViewerID, &v.ViewedAt); err != nil {
+			return nil, err
+		}
+		views = append(views, v)
+	}
+
+	return views, nil
+}
+
+// ExpireStories soft deletes stories that expired before the given time and returns them
+func (r *Repository) ExpireStories(ctx context.Context, before time.Time) ([]story.Story, error) {
+	query := `
+		UPDATE stories SET deleted_at = $1
+		WHERE deleted_at IS NULL AND expires_at <= $1
+		RETURNING id, creator_id, image_path, image_url, expires_at, created_at, deleted_at
+	`
+
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, before)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, before)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stories []story.Story
+	for rows.Next() {
+		var s story.Story
+		err := rows.Scan(&s.ID, &s.CreatorID, &s.ImagePath, &s.ImageURL, &s.ExpiresAt, &s.CreatedAt, &s.DeletedAt)
+		if err != nil {
+			return nil, err
+		}
+		stories = append(stories, s)
+	}
+
+	return stories, nil
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/suggestion"
+)
+
+// activityWindow is how far back posts count as recent activity
+const activityWindow = 30 * 24 * time.Hour
+
+// Service implements suggestion service interface
+type Service struct {
+	repo suggestion.SuggestionRepository
+}
+
+// NewService creates a new suggestion service
+func NewService(repo suggestion.SuggestionRepository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// GetFollowSuggestions returns accounts the user may want to follow
+func (s *Service) GetFollowSuggestions(ctx context.Context, accountID int64, limit int) ([]suggestion.AccountSuggestion, error) {
+	if limit <= 0 || limit > 50 {
+		limit = 10
+	}
+
+	suggestions, err := s.repo.GetFollowSuggestions(ctx, accountID, time.Now().Add(-activityWindow), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follow suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
@@ -0,0 +1,25 @@
+package suggestion
+
+import (
+	"context"
+	"time"
+)
+
+// AccountSuggestion represents an account suggested to follow
+type AccountSuggestion struct {
+	ID            int64      `json:"id" db:"id"`
+	Name          string     `json:"name" db:"name"`
+	AvatarURL     string     `json:"avatar_url,omitempty" db:"avatar_url"`
+	MutualFollows int64      `json:"mutual_follows" db:"mutual_follows"`
+	LastActiveAt  *time.Time `json:"last_active_at,omitempty" db:"last_active_at"`
+}
+
+// SuggestionRepository defines the interface for suggestion data access
+type SuggestionRepository interface {
+	GetFollowSuggestions(ctx context.Context, accountID int64, activeSince time.Time, limit int) ([]AccountSuggestion, error)
+}
+
+// SuggestionService defines the interface for suggestion business logic
+type SuggestionService interface {
+	GetFollowSuggestions(ctx context.Context, accountID int64, limit int) ([]AccountSuggestion, error)
+}
@@ -0,0 +1,47 @@
+package port
+
+import (
+	"net/http"
+
+	"github.com/fanzru/social-media-service-go/internal/app/suggestion"
+	"github.com/fanzru/social-media-service-go/internal/app/suggestion/port/genhttp"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"github.com/fanzru/social-media-service-go/pkg/response"
+)
+
+// Handler handles HTTP requests for suggestions
+type Handler struct {
+	service suggestion.SuggestionService
+}
+
+// NewHandler creates a new suggestion handler
+func NewHandler(service suggestion.SuggestionService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// GetApiAccountsSuggestions handles GET /api/accounts/suggestions
+func (h *Handler) GetApiAccountsSuggestions(w http.ResponseWriter, r *http.Request, params genhttp.GetApiAccountsSuggestionsParams) {
+	userID, exists := middleware.GetUserID(r.Context())
+	if !exists || userID == 0 {
+		response.Unauthorized(r.Context(), "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	limit := 10
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	suggestions, err := h.service.GetFollowSuggestions(r.Context(), userID, limit)
+	if err != nil {
+		response.InternalServerError(r.Context(), "Failed to get suggestions", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+
+	response.Success(r.Context(), "Suggestions retrieved successfully", suggestions).Send(w, http.StatusOK)
+}
+
+// Implement the generated interface
+var _ genhttp.ServerInterface = (*Handler)(nil)
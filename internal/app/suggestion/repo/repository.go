@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/internal/app/suggestion"
+	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
+)
+
+// Repository implements suggestion repository interface
+type Repository struct {
+	db interface{} // Can be *sql.DB or *sqlwrap.DB
+}
+
+// NewRepository creates a new suggestion repository
+func NewRepository(db interface{}) *Repository {
+	return &Repository{db: db}
+}
+
+// GetFollowSuggestions ranks candidate accounts by how many of the accounts the user follows
+// also follow them, then by their latest post since activeSince. The user, accounts they
+// already follow, accounts blocked in either direction, and accounts that opted out of
+// discovery are excluded.
+func (r *Repository) GetFollowSuggestions(ctx context.Context, accountID int64, activeSince time.Time, limit int) ([]suggestion.AccountSuggestion, error) {
+	query := `
+		WITH my_follows AS (
+			SELECT following_id FROM follows WHERE follower_id = $1
+		), mutuals AS (
+			SELECT f.following_id AS account_id, COUNT(*) AS mutual_follows
+			FROM follows f
+			WHERE f.follower_id IN (SELECT following_id FROM my_follows)
+			GROUP BY f.following_id
+		), activity AS (
+			SELECT creator_id AS account_id, MAX(created_at) AS last_active_at
+			FROM posts
+			WHERE deleted_at IS NULL AND created_at >= $2
+			GROUP BY creator_id
+		)
+		SELECT a.id, a.name, a.avatar_url, COALESCE(m.mutual_follows, 0) AS mutual_follows, act.last_active_at
+		FROM accounts a
+			LEFT JOIN mutuals m ON m.account_id = a.id
+			LEFT JOIN activity act ON act.account_id = a.id
+		WHERE a.deleted_at IS NULL
+			AND a.status = 'active'
+			AND a.discoverable
+			AND a.id <> $1
+			AND (m.account_id IS NOT NULL OR act.account_id IS NOT NULL)
+			AND a.id NOT IN (SELECT following_id FROM my_follows)
+			AND NOT EXISTS (
+				SELECT 1 FROM blocks b
+				WHERE (b.blocker_id = $1 AND b.blocked_id = a.id)
+					OR (b.blocker_id = a.id AND b.blocked_id = $1)
+			)
+		ORDER BY mutual_follows DESC, act.last_active_at DESC NULLS LAST, a.id ASC
+		LIMIT $3
+	`
+
+	var rows *sql.Rows
+	var err error
+	if db, ok := r.db.(*sql.DB); ok {
+		rows, err = db.QueryContext(ctx, query, accountID, activeSince, limit)
+	} else if db, ok := r.db.(*sqlwrap.DB); ok {
+		rows, err = db.QueryContext(ctx, query, accountID, activeSince, limit)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := []suggestion.AccountSuggestion{}
+	for rows.Next() {
+		var s suggestion.AccountSuggestion
+		if err := rows.Scan(&s.ID, &s.Name, &s.AvatarURL, &s.MutualFollows, &s.LastActiveAt); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, s)
+	}
+
+	return suggestions, nil
+}
@@ -0,0 +1,4 @@
+-- Drop avatar columns from accounts table
+ALTER TABLE accounts
+DROP COLUMN IF EXISTS avatar_url,
+DROP COLUMN IF EXISTS avatar_path;
@@ -0,0 +1,4 @@
+-- Add avatar columns to accounts table
+ALTER TABLE accounts
+ADD COLUMN IF NOT EXISTS avatar_path VARCHAR(500) NOT NULL DEFAULT '',
+ADD COLUMN IF NOT EXISTS avatar_url VARCHAR(500) NOT NULL DEFAULT '';
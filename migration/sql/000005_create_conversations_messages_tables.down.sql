@@ -0,0 +1,4 @@
+-- Drop messages and conversations tables
+DROP TABLE IF EXISTS messages;
+
+DROP TABLE IF EXISTS conversations;
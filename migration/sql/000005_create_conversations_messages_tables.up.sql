@@ -0,0 +1,49 @@
+-- Create conversations table (one row per pair of accounts)
+CREATE TABLE IF NOT EXISTS conversations (
+    id BIGSERIAL PRIMARY KEY,
+    participant_one_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    participant_two_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    last_message_at TIMESTAMP
+    WITH
+        TIME ZONE NULL,
+        created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        updated_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        CONSTRAINT chk_conversations_participants_ordered CHECK (
+            participant_one_id < participant_two_id
+        ),
+        CONSTRAINT uq_conversations_participants UNIQUE (
+            participant_one_id,
+            participant_two_id
+        )
+);
+
+-- Create indexes for conversations
+CREATE INDEX IF NOT EXISTS idx_conversations_participant_two_id ON conversations (participant_two_id);
+
+CREATE INDEX IF NOT EXISTS idx_conversations_updated_at ON conversations (updated_at DESC);
+
+-- Create messages table
+CREATE TABLE IF NOT EXISTS messages (
+    id BIGSERIAL PRIMARY KEY,
+    conversation_id BIGINT NOT NULL REFERENCES conversations (id) ON DELETE CASCADE,
+    sender_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        updated_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        deleted_at TIMESTAMP
+    WITH
+        TIME ZONE NULL
+);
+
+-- Create indexes for messages
+CREATE INDEX IF NOT EXISTS idx_messages_conversation_id_created_at ON messages (conversation_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_messages_sender_id ON messages (sender_id);
@@ -0,0 +1,24 @@
+-- Restore the view without the visibility column
+DROP VIEW IF EXISTS posts_with_comment_count;
+
+DROP TABLE IF EXISTS follows;
+
+DROP INDEX IF EXISTS idx_posts_visibility;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS visibility;
+
+CREATE VIEW posts_with_comment_count AS
+SELECT p.*, COALESCE(
+        comment_counts.comment_count, 0
+    ) as comment_count
+FROM posts p
+    LEFT JOIN (
+        SELECT post_id, COUNT(*) as comment_count
+        FROM comments
+        WHERE
+            deleted_at IS NULL
+        GROUP BY
+            post_id
+    ) comment_counts ON p.id = comment_counts.post_id
+WHERE
+    p.deleted_at IS NULL;
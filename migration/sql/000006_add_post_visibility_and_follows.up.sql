@@ -0,0 +1,39 @@
+-- Add visibility to posts
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS visibility VARCHAR(20) NOT NULL DEFAULT 'public' CHECK (
+    visibility IN ('public', 'followers', 'private')
+);
+
+CREATE INDEX IF NOT EXISTS idx_posts_visibility ON posts (visibility);
+
+-- Create follows table (follower_id follows following_id)
+CREATE TABLE IF NOT EXISTS follows (
+    follower_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    following_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        PRIMARY KEY (follower_id, following_id),
+        CONSTRAINT chk_follows_not_self CHECK (follower_id <> following_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_follows_following_id ON follows (following_id);
+
+-- Recreate the view so p.* picks up the new visibility column
+DROP VIEW IF EXISTS posts_with_comment_count;
+
+CREATE VIEW posts_with_comment_count AS
+SELECT p.*, COALESCE(
+        comment_counts.comment_count, 0
+    ) as comment_count
+FROM posts p
+    LEFT JOIN (
+        SELECT post_id, COUNT(*) as comment_count
+        FROM comments
+        WHERE
+            deleted_at IS NULL
+        GROUP BY
+            post_id
+    ) comment_counts ON p.id = comment_counts.post_id
+WHERE
+    p.deleted_at IS NULL;
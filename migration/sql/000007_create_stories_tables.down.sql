@@ -0,0 +1,4 @@
+-- Drop story views and stories tables
+DROP TABLE IF EXISTS story_views;
+
+DROP TABLE IF EXISTS stories;
@@ -0,0 +1,35 @@
+-- Create stories table
+CREATE TABLE IF NOT EXISTS stories (
+    id BIGSERIAL PRIMARY KEY,
+    creator_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    image_path VARCHAR(500) NOT NULL,
+    image_url VARCHAR(500) NOT NULL,
+    expires_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL,
+        created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        deleted_at TIMESTAMP
+    WITH
+        TIME ZONE NULL
+);
+
+-- Create indexes for stories
+CREATE INDEX IF NOT EXISTS idx_stories_creator_id_created_at ON stories (creator_id, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_stories_expires_at ON stories (expires_at)
+WHERE
+    deleted_at IS NULL;
+
+-- Create story views table (one row per viewer per story)
+CREATE TABLE IF NOT EXISTS story_views (
+    story_id BIGINT NOT NULL REFERENCES stories (id) ON DELETE CASCADE,
+    viewer_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    viewed_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        PRIMARY KEY (story_id, viewer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_story_views_viewer_id ON story_views (viewer_id);
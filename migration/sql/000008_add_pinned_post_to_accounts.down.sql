@@ -0,0 +1,2 @@
+-- Remove pinned post from accounts
+ALTER TABLE accounts DROP COLUMN IF EXISTS pinned_post_id;
@@ -0,0 +1,3 @@
+-- Add pinned post to accounts
+ALTER TABLE accounts
+ADD COLUMN IF NOT EXISTS pinned_post_id BIGINT NULL REFERENCES posts (id) ON DELETE SET NULL;
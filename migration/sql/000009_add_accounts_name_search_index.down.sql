@@ -0,0 +1,2 @@
+-- Drop account search index
+DROP INDEX IF EXISTS idx_accounts_name_trgm;
@@ -0,0 +1,6 @@
+-- Enable trigram matching for fuzzy account search
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_accounts_name_trgm ON accounts USING GIN (lower(name) gin_trgm_ops)
+WHERE
+    deleted_at IS NULL;
@@ -0,0 +1,4 @@
+-- Drop blocks table
+DROP INDEX IF EXISTS idx_posts_creator_id_created_at;
+
+DROP TABLE IF EXISTS blocks;
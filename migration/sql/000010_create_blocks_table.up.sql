@@ -0,0 +1,17 @@
+-- Create blocks table (blocker_id blocks blocked_id)
+CREATE TABLE IF NOT EXISTS blocks (
+    blocker_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    blocked_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        PRIMARY KEY (blocker_id, blocked_id),
+        CONSTRAINT chk_blocks_not_self CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_blocks_blocked_id ON blocks (blocked_id);
+
+-- Speed up recent activity lookups for suggestions
+CREATE INDEX IF NOT EXISTS idx_posts_creator_id_created_at ON posts (creator_id, created_at DESC)
+WHERE
+    deleted_at IS NULL;
@@ -0,0 +1,2 @@
+-- Drop comment likes table
+DROP TABLE IF EXISTS comment_likes;
@@ -0,0 +1,11 @@
+-- Create comment likes table (one like per account per comment)
+CREATE TABLE IF NOT EXISTS comment_likes (
+    comment_id BIGINT NOT NULL REFERENCES comments (id) ON DELETE CASCADE,
+    account_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        PRIMARY KEY (comment_id, account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_likes_account_id ON comment_likes (account_id);
@@ -0,0 +1,2 @@
+-- Drop short links table
+DROP TABLE IF EXISTS short_links;
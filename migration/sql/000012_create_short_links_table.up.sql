@@ -0,0 +1,10 @@
+-- Create short links table (one short link per post)
+CREATE TABLE IF NOT EXISTS short_links (
+    id BIGSERIAL PRIMARY KEY,
+    slug VARCHAR(16) NOT NULL UNIQUE,
+    post_id BIGINT NOT NULL UNIQUE REFERENCES posts (id) ON DELETE CASCADE,
+    click_count BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW()
+);
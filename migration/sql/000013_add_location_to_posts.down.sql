@@ -0,0 +1,26 @@
+-- Restore the view without the location columns
+DROP VIEW IF EXISTS posts_with_comment_count;
+
+DROP INDEX IF EXISTS idx_posts_location;
+
+ALTER TABLE posts
+DROP CONSTRAINT IF EXISTS chk_posts_location,
+DROP COLUMN IF EXISTS place_name,
+DROP COLUMN IF EXISTS longitude,
+DROP COLUMN IF EXISTS latitude;
+
+CREATE VIEW posts_with_comment_count AS
+SELECT p.*, COALESCE(
+        comment_counts.comment_count, 0
+    ) as comment_count
+FROM posts p
+    LEFT JOIN (
+        SELECT post_id, COUNT(*) as comment_count
+        FROM comments
+        WHERE
+            deleted_at IS NULL
+        GROUP BY
+            post_id
+    ) comment_counts ON p.id = comment_counts.post_id
+WHERE
+    p.deleted_at IS NULL;
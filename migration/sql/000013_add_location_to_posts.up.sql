@@ -0,0 +1,37 @@
+-- Add optional location to posts
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION CHECK (
+    latitude BETWEEN -90 AND 90
+),
+ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION CHECK (
+    longitude BETWEEN -180 AND 180
+),
+ADD COLUMN IF NOT EXISTS place_name VARCHAR(255),
+ADD CONSTRAINT chk_posts_location CHECK (
+    (latitude IS NULL) = (longitude IS NULL)
+);
+
+-- Bounding box prefilter for nearby lookups
+CREATE INDEX IF NOT EXISTS idx_posts_location ON posts (latitude, longitude)
+WHERE
+    latitude IS NOT NULL
+    AND deleted_at IS NULL;
+
+-- Recreate the view so p.* picks up the new location columns
+DROP VIEW IF EXISTS posts_with_comment_count;
+
+CREATE VIEW posts_with_comment_count AS
+SELECT p.*, COALESCE(
+        comment_counts.comment_count, 0
+    ) as comment_count
+FROM posts p
+    LEFT JOIN (
+        SELECT post_id, COUNT(*) as comment_count
+        FROM comments
+        WHERE
+            deleted_at IS NULL
+        GROUP BY
+            post_id
+    ) comment_counts ON p.id = comment_counts.post_id
+WHERE
+    p.deleted_at IS NULL;
@@ -0,0 +1,29 @@
+-- Add sensitive content flag to posts; sensitive_locked is set when a moderator enforces the flag
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS is_sensitive BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN IF NOT EXISTS sensitive_locked BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Add per-account preference for how sensitive posts appear in lists
+ALTER TABLE accounts
+ADD COLUMN IF NOT EXISTS sensitive_content VARCHAR(10) NOT NULL DEFAULT 'blur' CHECK (
+    sensitive_content IN ('show', 'blur', 'hide')
+);
+
+-- Recreate the view so p.* picks up the new sensitive columns
+DROP VIEW IF EXISTS posts_with_comment_count;
+
+CREATE VIEW posts_with_comment_count AS
+SELECT p.*, COALESCE(
+        comment_counts.comment_count, 0
+    ) as comment_count
+FROM posts p
+    LEFT JOIN (
+        SELECT post_id, COUNT(*) as comment_count
+        FROM comments
+        WHERE
+            deleted_at IS NULL
+        GROUP BY
+            post_id
+    ) comment_counts ON p.id = comment_counts.post_id
+WHERE
+    p.deleted_at IS NULL;
@@ -0,0 +1,4 @@
+-- Remove role and verified badge from accounts
+ALTER TABLE accounts
+DROP COLUMN IF EXISTS is_verified,
+DROP COLUMN IF EXISTS role;
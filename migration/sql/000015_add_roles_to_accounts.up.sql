@@ -0,0 +1,6 @@
+-- Add role and verified badge to accounts
+ALTER TABLE accounts
+ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user' CHECK (
+    role IN ('user', 'moderator', 'admin')
+),
+ADD COLUMN IF NOT EXISTS is_verified BOOLEAN NOT NULL DEFAULT FALSE;
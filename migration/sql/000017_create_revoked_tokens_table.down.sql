@@ -0,0 +1,2 @@
+-- Drop revoked tokens table
+DROP TABLE IF EXISTS revoked_tokens;
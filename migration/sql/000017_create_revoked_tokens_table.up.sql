@@ -0,0 +1,13 @@
+-- Create revoked tokens table; access tokens whose jti is listed here are rejected
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti VARCHAR(128) PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    expires_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL,
+        revoked_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens (expires_at);
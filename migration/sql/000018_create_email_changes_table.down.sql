@@ -0,0 +1,2 @@
+-- Drop email changes table
+DROP TABLE IF EXISTS email_changes;
@@ -0,0 +1,12 @@
+-- Create email changes table; holds at most one pending address change per account
+CREATE TABLE IF NOT EXISTS email_changes (
+    account_id BIGINT PRIMARY KEY REFERENCES accounts (id) ON DELETE CASCADE,
+    new_email VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL,
+        created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW()
+);
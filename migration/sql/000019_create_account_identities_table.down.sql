@@ -0,0 +1,2 @@
+-- Drop account identities table
+DROP TABLE IF EXISTS account_identities;
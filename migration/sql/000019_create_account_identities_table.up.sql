@@ -0,0 +1,14 @@
+-- Create account identities table; links external OAuth identities to accounts
+CREATE TABLE IF NOT EXISTS account_identities (
+    id BIGSERIAL PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    provider VARCHAR(32) NOT NULL,
+    provider_user_id VARCHAR(255) NOT NULL,
+    email VARCHAR(255),
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        CONSTRAINT uq_account_identities_provider_user UNIQUE (provider, provider_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_identities_account_id ON account_identities (account_id);
@@ -0,0 +1,28 @@
+-- Create sessions table; one row per signed-in device
+CREATE TABLE IF NOT EXISTS sessions (
+    id BIGSERIAL PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        last_seen_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        revoked_at TIMESTAMP
+    WITH
+        TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_account_id ON sessions (account_id);
+
+-- Refresh tokens belong to the session they were issued for. rotated_at tells a token
+-- exchanged for a new one apart from one revoked on logout, for reuse detection.
+ALTER TABLE refresh_tokens
+ADD COLUMN IF NOT EXISTS session_id BIGINT REFERENCES sessions (id) ON DELETE CASCADE,
+ADD COLUMN IF NOT EXISTS rotated_at TIMESTAMP
+WITH
+    TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session_id ON refresh_tokens (session_id);
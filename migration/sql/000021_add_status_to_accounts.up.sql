@@ -0,0 +1,29 @@
+-- Add account status; deactivated accounts keep their data but their content is hidden
+ALTER TABLE accounts
+ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (
+    status IN ('active', 'deactivated')
+),
+ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP
+WITH
+    TIME ZONE NULL;
+
+-- Recreate the view so comments of deactivated accounts are not counted
+DROP VIEW IF EXISTS posts_with_comment_count;
+
+CREATE VIEW posts_with_comment_count AS
+SELECT p.*, COALESCE(
+        comment_counts.comment_count, 0
+    ) as comment_count
+FROM posts p
+    LEFT JOIN (
+        SELECT c.post_id, COUNT(*) as comment_count
+        FROM comments c
+            JOIN accounts a ON a.id = c.creator_id
+        WHERE
+            c.deleted_at IS NULL
+            AND a.status = 'active'
+        GROUP BY
+            c.post_id
+    ) comment_counts ON p.id = comment_counts.post_id
+WHERE
+    p.deleted_at IS NULL;
@@ -0,0 +1,2 @@
+-- Drop data exports table
+DROP TABLE IF EXISTS data_exports;
@@ -0,0 +1,34 @@
+-- Create data exports table; each row is an asynchronous GDPR export job
+CREATE TABLE IF NOT EXISTS data_exports (
+    id BIGSERIAL PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (
+        status IN (
+            'pending', 'processing', 'completed', 'failed'
+        )
+    ),
+    error TEXT,
+    archive BYTEA,
+    size_bytes BIGINT,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        started_at TIMESTAMP
+    WITH
+        TIME ZONE,
+        completed_at TIMESTAMP
+    WITH
+        TIME ZONE,
+        expires_at TIMESTAMP
+    WITH
+        TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_account_id ON data_exports (account_id, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_status ON data_exports (status);
+
+-- At most one export per account may be queued or running
+CREATE UNIQUE INDEX IF NOT EXISTS uq_data_exports_active ON data_exports (account_id)
+WHERE
+    status IN ('pending', 'processing');
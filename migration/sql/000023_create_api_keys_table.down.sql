@@ -0,0 +1,4 @@
+-- Drop api_keys table
+DROP INDEX IF EXISTS idx_api_keys_account_id;
+
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,8 @@
+-- Drop reports table
+DROP INDEX IF EXISTS idx_reports_target;
+
+DROP INDEX IF EXISTS idx_reports_status_id;
+
+DROP INDEX IF EXISTS uq_reports_open;
+
+DROP TABLE IF EXISTS reports;
@@ -0,0 +1,33 @@
+-- Create reports table; users flag posts, comments or accounts for admin review
+CREATE TABLE IF NOT EXISTS reports (
+    id BIGSERIAL PRIMARY KEY,
+    reporter_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    target_type VARCHAR(20) NOT NULL CHECK (
+        target_type IN ('post', 'comment', 'account')
+    ),
+    target_id BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (
+        status IN ('open', 'resolved', 'dismissed')
+    ),
+    resolved_by BIGINT REFERENCES accounts (id) ON DELETE SET NULL,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        resolved_at TIMESTAMP
+    WITH
+        TIME ZONE
+);
+
+-- A reporter can have one open report per target
+CREATE UNIQUE INDEX IF NOT EXISTS uq_reports_open ON reports (
+    reporter_id,
+    target_type,
+    target_id
+)
+WHERE
+    status = 'open';
+
+CREATE INDEX IF NOT EXISTS idx_reports_status_id ON reports (status, id);
+
+CREATE INDEX IF NOT EXISTS idx_reports_target ON reports (target_type, target_id);
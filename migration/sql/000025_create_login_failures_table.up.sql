@@ -0,0 +1,11 @@
+-- Track failed sign-ins per email and per client IP for throttling and lockout
+CREATE TABLE IF NOT EXISTS login_failures (
+    subject VARCHAR(320) PRIMARY KEY,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL,
+        locked_until TIMESTAMP
+    WITH
+        TIME ZONE
+);
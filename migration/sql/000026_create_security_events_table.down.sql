@@ -0,0 +1,3 @@
+-- Drop security_events table
+DROP TABLE IF EXISTS security_events;
+DROP FUNCTION IF EXISTS reject_security_event_change();
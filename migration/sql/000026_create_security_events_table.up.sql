@@ -0,0 +1,28 @@
+-- Append-only log of security relevant account events. account_id has no foreign key
+-- so the trail outlives a deleted account.
+CREATE TABLE IF NOT EXISTS security_events (
+    id BIGSERIAL PRIMARY KEY,
+    account_id BIGINT NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_events_account_id ON security_events (account_id, id DESC);
+
+-- Reject updates and deletes so recorded events cannot be rewritten
+CREATE OR REPLACE FUNCTION reject_security_event_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'security_events is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER security_events_append_only
+    BEFORE UPDATE OR DELETE ON security_events
+    FOR EACH ROW
+    EXECUTE FUNCTION reject_security_event_change();
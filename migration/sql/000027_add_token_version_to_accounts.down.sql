@@ -0,0 +1,2 @@
+-- Remove token_version from accounts
+ALTER TABLE accounts DROP COLUMN IF EXISTS token_version;
@@ -0,0 +1,3 @@
+-- Access tokens carry the token version they were issued with; bumping it signs out every device
+ALTER TABLE accounts
+ADD COLUMN IF NOT EXISTS token_version INTEGER NOT NULL DEFAULT 0;
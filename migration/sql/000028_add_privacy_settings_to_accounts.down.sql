@@ -0,0 +1,5 @@
+-- Remove privacy settings from accounts
+ALTER TABLE accounts
+DROP COLUMN IF EXISTS is_private,
+DROP COLUMN IF EXISTS comment_permission,
+DROP COLUMN IF EXISTS discoverable;
@@ -0,0 +1,9 @@
+-- Privacy settings: private accounts show their posts to followers only, comment_permission
+-- limits who may comment on the account's posts and undiscoverable accounts are left out
+-- of search and suggestions
+ALTER TABLE accounts
+ADD COLUMN IF NOT EXISTS is_private BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN IF NOT EXISTS comment_permission VARCHAR(20) NOT NULL DEFAULT 'everyone' CHECK (
+    comment_permission IN ('everyone', 'followers', 'nobody')
+),
+ADD COLUMN IF NOT EXISTS discoverable BOOLEAN NOT NULL DEFAULT TRUE;
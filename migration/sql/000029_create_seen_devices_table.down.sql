@@ -0,0 +1,2 @@
+-- Drop seen devices
+DROP TABLE IF EXISTS seen_devices;
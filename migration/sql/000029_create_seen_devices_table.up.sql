@@ -0,0 +1,16 @@
+-- Devices an account has signed in from, keyed by a fingerprint of IP address and user
+-- agent. A sign-in with an unknown fingerprint triggers a notification to the owner.
+CREATE TABLE IF NOT EXISTS seen_devices (
+    id BIGSERIAL PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    fingerprint VARCHAR(64) NOT NULL,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    first_seen_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        last_seen_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        UNIQUE (account_id, fingerprint)
+);
@@ -0,0 +1,4 @@
+-- Remove activity metadata from accounts
+ALTER TABLE accounts
+DROP COLUMN IF EXISTS last_login_at,
+DROP COLUMN IF EXISTS last_seen_at;
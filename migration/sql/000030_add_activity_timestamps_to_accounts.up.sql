@@ -0,0 +1,9 @@
+-- Activity metadata: last_login_at is set on every sign-in, last_seen_at follows
+-- authenticated requests and is written at most once a minute per session
+ALTER TABLE accounts
+ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMP
+WITH
+    TIME ZONE,
+ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMP
+WITH
+    TIME ZONE;
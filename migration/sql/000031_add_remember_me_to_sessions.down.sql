@@ -0,0 +1,2 @@
+-- Remove remember_me from sessions
+ALTER TABLE sessions DROP COLUMN IF EXISTS remember_me;
@@ -0,0 +1,4 @@
+-- Sessions signed in with remember_me get long-lived refresh tokens, others short-lived ones.
+-- Existing sessions were all long-lived.
+ALTER TABLE sessions
+ADD COLUMN IF NOT EXISTS remember_me BOOLEAN NOT NULL DEFAULT TRUE;
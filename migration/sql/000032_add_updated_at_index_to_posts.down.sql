@@ -0,0 +1,2 @@
+-- Remove the updated_at index from posts
+DROP INDEX IF EXISTS idx_posts_updated_at;
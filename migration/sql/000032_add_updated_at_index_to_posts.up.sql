@@ -0,0 +1,2 @@
+-- Delta sync looks up posts by updated_at; deleted_at is already indexed
+CREATE INDEX IF NOT EXISTS idx_posts_updated_at ON posts (updated_at);
@@ -0,0 +1,2 @@
+-- Drop post likes table
+DROP TABLE IF EXISTS post_likes;
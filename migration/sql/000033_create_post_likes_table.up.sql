@@ -0,0 +1,11 @@
+-- Create post likes table (one like per account per post)
+CREATE TABLE IF NOT EXISTS post_likes (
+    post_id BIGINT NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    account_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        PRIMARY KEY (post_id, account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_likes_account_id ON post_likes (account_id);
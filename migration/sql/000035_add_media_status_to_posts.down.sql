@@ -0,0 +1,27 @@
+-- Restore the view without the media columns
+DROP VIEW IF EXISTS posts_with_comment_count;
+
+DROP INDEX IF EXISTS idx_posts_media_processing;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS media_claimed_at,
+DROP COLUMN IF EXISTS image_original_path,
+DROP COLUMN IF EXISTS media_status;
+
+CREATE VIEW posts_with_comment_count AS
+SELECT p.*, COALESCE(
+        comment_counts.comment_count, 0
+    ) as comment_count
+FROM posts p
+    LEFT JOIN (
+        SELECT c.post_id, COUNT(*) as comment_count
+        FROM comments c
+            JOIN accounts a ON a.id = c.creator_id
+        WHERE
+            c.deleted_at IS NULL
+            AND a.status = 'active'
+        GROUP BY
+            c.post_id
+    ) comment_counts ON p.id = comment_counts.post_id
+WHERE
+    p.deleted_at IS NULL;
@@ -0,0 +1,36 @@
+-- Track background processing of post images: the original is stored on upload and the
+-- resized copy made later; media_claimed_at is set while a worker processes the image
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS media_status VARCHAR(20) NOT NULL DEFAULT 'ready' CHECK (
+    media_status IN ('processing', 'ready', 'failed')
+),
+ADD COLUMN IF NOT EXISTS image_original_path VARCHAR(500) NULL,
+ADD COLUMN IF NOT EXISTS media_claimed_at TIMESTAMP
+WITH
+    TIME ZONE NULL;
+
+-- Workers look up the posts whose image is waiting to be processed
+CREATE INDEX IF NOT EXISTS idx_posts_media_processing ON posts (id)
+WHERE
+    media_status = 'processing';
+
+-- Recreate the view so p.* picks up the new media columns
+DROP VIEW IF EXISTS posts_with_comment_count;
+
+CREATE VIEW posts_with_comment_count AS
+SELECT p.*, COALESCE(
+        comment_counts.comment_count, 0
+    ) as comment_count
+FROM posts p
+    LEFT JOIN (
+        SELECT c.post_id, COUNT(*) as comment_count
+        FROM comments c
+            JOIN accounts a ON a.id = c.creator_id
+        WHERE
+            c.deleted_at IS NULL
+            AND a.status = 'active'
+        GROUP BY
+            c.post_id
+    ) comment_counts ON p.id = comment_counts.post_id
+WHERE
+    p.deleted_at IS NULL;
@@ -0,0 +1,30 @@
+-- Count comments in the view again
+DROP VIEW IF EXISTS posts_with_comment_count;
+
+DROP TRIGGER IF EXISTS update_post_comment_counts_of_account ON accounts;
+DROP FUNCTION IF EXISTS update_post_comment_counts_of_account();
+
+DROP TRIGGER IF EXISTS update_post_comment_count ON comments;
+DROP FUNCTION IF EXISTS update_post_comment_count();
+
+DROP INDEX IF EXISTS idx_posts_comment_count;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS comment_count;
+
+CREATE VIEW posts_with_comment_count AS
+SELECT p.*, COALESCE(
+        comment_counts.comment_count, 0
+    ) as comment_count
+FROM posts p
+    LEFT JOIN (
+        SELECT c.post_id, COUNT(*) as comment_count
+        FROM comments c
+            JOIN accounts a ON a.id = c.creator_id
+        WHERE
+            c.deleted_at IS NULL
+            AND a.status = 'active'
+        GROUP BY
+            c.post_id
+    ) comment_counts ON p.id = comment_counts.post_id
+WHERE
+    p.deleted_at IS NULL;
@@ -0,0 +1,119 @@
+-- Keep each post's comment count on the post instead of counting every comment whenever
+-- posts are listed by comments. A comment counts while it is not deleted and its creator
+-- is active, as in the view before.
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS comment_count BIGINT NOT NULL DEFAULT 0;
+
+UPDATE posts p
+SET
+    comment_count = counts.comment_count
+FROM (
+        SELECT c.post_id, COUNT(*) AS comment_count
+        FROM comments c
+            JOIN accounts a ON a.id = c.creator_id
+        WHERE
+            c.deleted_at IS NULL
+            AND a.status = 'active'
+        GROUP BY
+            c.post_id
+    ) counts
+WHERE
+    p.id = counts.post_id;
+
+-- Listing by comments reads the index instead of sorting every post
+CREATE INDEX IF NOT EXISTS idx_posts_comment_count ON posts (
+    comment_count DESC,
+    created_at DESC,
+    id DESC
+)
+WHERE
+    deleted_at IS NULL;
+
+-- Adjust the count when a comment is created, deleted or restored. Counts are changed by
+-- increments, so concurrent comments on one post do not overwrite each other.
+CREATE OR REPLACE FUNCTION update_post_comment_count()
+RETURNS TRIGGER AS $$
+DECLARE
+    creator_active BOOLEAN;
+    delta BIGINT := 0;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        SELECT status = 'active' INTO creator_active FROM accounts WHERE id = OLD.creator_id;
+    ELSE
+        SELECT status = 'active' INTO creator_active FROM accounts WHERE id = NEW.creator_id;
+    END IF;
+    IF NOT COALESCE(creator_active, FALSE) THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.deleted_at IS NULL THEN
+        delta := delta - 1;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.deleted_at IS NULL THEN
+        delta := delta + 1;
+    END IF;
+
+    IF delta <> 0 THEN
+        UPDATE posts
+        SET comment_count = comment_count + delta
+        WHERE id = COALESCE(NEW.post_id, OLD.post_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER update_post_comment_count
+    AFTER INSERT OR DELETE OR UPDATE OF deleted_at ON comments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_post_comment_count();
+
+-- Add or remove an account's comments from the counts when it is deactivated, reactivated
+-- or deleted. A deleted account is handled before its comments are, which the comment
+-- trigger then skips.
+CREATE OR REPLACE FUNCTION update_post_comment_counts_of_account()
+RETURNS TRIGGER AS $$
+DECLARE
+    delta BIGINT := 0;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        IF OLD.status = 'active' THEN
+            delta := -1;
+        END IF;
+    ELSIF OLD.status = 'active' AND NEW.status <> 'active' THEN
+        delta := -1;
+    ELSIF OLD.status <> 'active' AND NEW.status = 'active' THEN
+        delta := 1;
+    END IF;
+
+    IF delta <> 0 THEN
+        UPDATE posts p
+        SET comment_count = p.comment_count + delta * counts.comment_count
+        FROM (
+            SELECT post_id, COUNT(*) AS comment_count
+            FROM comments
+            WHERE creator_id = OLD.id AND deleted_at IS NULL
+            GROUP BY post_id
+        ) counts
+        WHERE p.id = counts.post_id;
+    END IF;
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER update_post_comment_counts_of_account
+    BEFORE DELETE OR UPDATE OF status ON accounts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_post_comment_counts_of_account();
+
+-- The view keeps its name and columns but now reads the stored count
+DROP VIEW IF EXISTS posts_with_comment_count;
+
+CREATE VIEW posts_with_comment_count AS
+SELECT p.*
+FROM posts p
+WHERE
+    p.deleted_at IS NULL;
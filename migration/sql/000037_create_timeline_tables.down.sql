@@ -0,0 +1,4 @@
+-- Drop timeline tables
+DROP TABLE IF EXISTS timeline_pull_accounts;
+DROP TABLE IF EXISTS timeline_fanouts;
+DROP TABLE IF EXISTS timeline_entries;
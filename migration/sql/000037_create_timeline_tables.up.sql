@@ -0,0 +1,38 @@
+-- Home timelines: each new post is written to the timelines of its creator and the
+-- creator's followers, so reading a timeline scans one account's entries in order
+CREATE TABLE IF NOT EXISTS timeline_entries (
+    account_id BIGINT NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    post_id BIGINT NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    posted_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL,
+        PRIMARY KEY (account_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_timeline_entries_account_posted ON timeline_entries (
+    account_id,
+    posted_at DESC,
+    post_id DESC
+);
+
+CREATE INDEX IF NOT EXISTS idx_timeline_entries_post_id ON timeline_entries (post_id);
+
+-- New posts waiting to be written to timelines; claimed_at is set while a worker writes one
+CREATE TABLE IF NOT EXISTS timeline_fanouts (
+    post_id BIGINT PRIMARY KEY REFERENCES posts (id) ON DELETE CASCADE,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW(),
+        claimed_at TIMESTAMP
+    WITH
+        TIME ZONE NULL
+);
+
+-- Accounts with too many followers to write every post to each of their timelines; their
+-- posts are read from the posts table when a follower's timeline is read instead
+CREATE TABLE IF NOT EXISTS timeline_pull_accounts (
+    account_id BIGINT PRIMARY KEY REFERENCES accounts (id) ON DELETE CASCADE,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW()
+);
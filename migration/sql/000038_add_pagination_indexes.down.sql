@@ -0,0 +1,9 @@
+-- Drop pagination indexes and restore the creator index without the id tie-breaker
+CREATE INDEX IF NOT EXISTS idx_posts_creator_id_created_at ON posts (creator_id, created_at DESC)
+WHERE
+    deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_comments_creator_id_created_at_id;
+DROP INDEX IF EXISTS idx_comments_post_id_created_at_id;
+DROP INDEX IF EXISTS idx_posts_creator_id_created_at_id;
+DROP INDEX IF EXISTS idx_posts_created_at_id;
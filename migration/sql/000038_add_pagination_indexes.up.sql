@@ -0,0 +1,38 @@
+-- Composite indexes matching the keyset pagination queries, ordered by (created_at, id)
+-- with id as the tie-breaker, so a page is read from the index at its cursor instead of
+-- sorting every matching row. They are partial on deleted_at IS NULL like the queries, which
+-- keeps deleted rows out of them instead of leading with deleted_at.
+
+-- Site-wide post listing
+CREATE INDEX IF NOT EXISTS idx_posts_created_at_id ON posts (created_at DESC, id DESC)
+WHERE
+    deleted_at IS NULL;
+
+-- Posts of a creator; replaces the index without the id tie-breaker
+CREATE INDEX IF NOT EXISTS idx_posts_creator_id_created_at_id ON posts (
+    creator_id,
+    created_at DESC,
+    id DESC
+)
+WHERE
+    deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_posts_creator_id_created_at;
+
+-- Comments of a post, newest or oldest first
+CREATE INDEX IF NOT EXISTS idx_comments_post_id_created_at_id ON comments (
+    post_id,
+    created_at DESC,
+    id DESC
+)
+WHERE
+    deleted_at IS NULL;
+
+-- Comments of a creator
+CREATE INDEX IF NOT EXISTS idx_comments_creator_id_created_at_id ON comments (
+    creator_id,
+    created_at DESC,
+    id DESC
+)
+WHERE
+    deleted_at IS NULL;
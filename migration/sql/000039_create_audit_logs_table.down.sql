@@ -0,0 +1,2 @@
+-- Drop audit logs table
+DROP TABLE IF EXISTS audit_logs;
@@ -0,0 +1,21 @@
+-- Create audit logs table (successful mutating API calls, kept after their actor is deleted)
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id BIGINT,
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    route TEXT NOT NULL,
+    entity VARCHAR(50) NOT NULL,
+    entity_id BIGINT,
+    status_code INT NOT NULL,
+    before JSONB,
+    after JSONB,
+    request_id VARCHAR(255),
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs (actor_id, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs (entity, entity_id, id DESC);
@@ -0,0 +1,79 @@
+// Package apperr defines the kinds of errors services return, so transports can map
+// them to status codes without matching on error text.
+package apperr
+
+import "errors"
+
+// Error kinds; match them with errors.Is
+var (
+	ErrInvalid      = errors.New("invalid")      // The request is malformed or fails validation
+	ErrUnauthorized = errors.New("unauthorized") // Credentials are missing or wrong
+	ErrForbidden    = errors.New("forbidden")    // The caller may not perform the action
+	ErrNotFound     = errors.New("not found")    // The resource does not exist or is hidden from the caller
+	ErrConflict     = errors.New("conflict")     // The request conflicts with the current state
+	ErrUnavailable  = errors.New("unavailable")  // The service is temporarily overloaded; the request may be retried
+)
+
+// Error is a domain error of one kind. Its text is the detail shown to clients, so
+// services declare one per failure as a sentinel and wrap it to add detail:
+//
+//	var ErrPostNotFound = apperr.NotFound("post not found")
+//	return fmt.Errorf("%w: %s", post.ErrInvalidSort, sort)
+type Error struct {
+	kind error
+	msg  string
+}
+
+// New creates a domain error of the given kind
+func New(kind error, msg string) *Error {
+	return &Error{kind: kind, msg: msg}
+}
+
+// Invalid creates a domain error of kind ErrInvalid
+func Invalid(msg string) *Error {
+	return New(ErrInvalid, msg)
+}
+
+// Unauthorized creates a domain error of kind ErrUnauthorized
+func Unauthorized(msg string) *Error {
+	return New(ErrUnauthorized, msg)
+}
+
+// Forbidden creates a domain error of kind ErrForbidden
+func Forbidden(msg string) *Error {
+	return New(ErrForbidden, msg)
+}
+
+// NotFound creates a domain error of kind ErrNotFound
+func NotFound(msg string) *Error {
+	return New(ErrNotFound, msg)
+}
+
+// Conflict creates a domain error of kind ErrConflict
+func Conflict(msg string) *Error {
+	return New(ErrConflict, msg)
+}
+
+// Unavailable creates a domain error of kind ErrUnavailable
+func Unavailable(msg string) *Error {
+	return New(ErrUnavailable, msg)
+}
+
+func (e *Error) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the kind of the error
+func (e *Error) Unwrap() error {
+	return e.kind
+}
+
+// Kind returns the kind of an error, or nil when it is not a domain error
+func Kind(err error) error {
+	for _, kind := range []error{ErrInvalid, ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict, ErrUnavailable} {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Package buildinfo describes the running binary. Version, Commit and BuildTime are set
+// at build time with ldflags, e.g.
+//
+//	go build -ldflags "-X github.com/fanzru/social-media-service-go/pkg/buildinfo.Version=v1.2.0 \
+//		-X github.com/fanzru/social-media-service-go/pkg/buildinfo.Commit=$(git rev-parse HEAD) \
+//		-X github.com/fanzru/social-media-service-go/pkg/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// as the Makefile and Dockerfile do.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Set with -ldflags "-X"; see the package documentation
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info describes the running binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
+// Get returns the build information of the running binary. When the commit was not set
+// with ldflags, the one the Go toolchain records from the repository is used; values
+// that are not known, e.g. with go run, are "unknown".
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			if setting.Key == "vcs.revision" && info.Commit == "" {
+				info.Commit = setting.Value
+			}
+		}
+	}
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = "unknown"
+	}
+	return info
+}
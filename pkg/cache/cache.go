@@ -0,0 +1,116 @@
+// Package cache stores computed values for a while and drops them when the data they
+// were computed from changes, as announced by change events.
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/eventbus"
+)
+
+// Store keeps values under string keys until they expire or are deleted. Memory is the
+// in-process implementation; a shared store such as Redis can implement it to keep
+// several instances coherent.
+type Store interface {
+	Get(ctx context.Context, key string) (interface{}, bool)
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration)
+	Delete(ctx context.Context, keys ...string)
+	DeletePrefix(ctx context.Context, prefix string)
+}
+
+// maxExpiredScan is how many entries Memory holds before a Set sweeps expired ones out
+const maxExpiredScan = 1024
+
+// Memory is a Store in process memory
+type Memory struct {
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+// entry is a value with its expiry
+type entry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// NewMemory creates an empty in-memory store
+func NewMemory() *Memory {
+	return &Memory{entries: make(map[string]entry)}
+}
+
+// Get returns the value under key, unless it is missing or expired
+func (m *Memory) Get(ctx context.Context, key string) (interface{}, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expiresAt) {
+		delete(m.entries, key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+// Set stores a value under key for ttl
+func (m *Memory) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if len(m.entries) >= maxExpiredScan {
+		for k, e := range m.entries {
+			if now.After(e.expiresAt) {
+				delete(m.entries, k)
+			}
+		}
+	}
+	m.entries[key] = entry{value: value, expiresAt: now.Add(ttl)}
+}
+
+// Delete removes the values under keys
+func (m *Memory) Delete(ctx context.Context, keys ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		delete(m.entries, key)
+	}
+}
+
+// DeletePrefix removes the values whose key starts with prefix
+func (m *Memory) DeletePrefix(ctx context.Context, prefix string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.entries, key)
+		}
+	}
+}
+
+// KeysFunc returns the keys an event makes stale. Keys ending in "*" remove every key
+// starting with the text before it.
+type KeysFunc func(e eventbus.Event) []string
+
+// Invalidator returns an event handler deleting the keys each event makes stale from
+// store. Every cache owner contributes a KeysFunc for the keys it writes.
+func Invalidator(store Store, keys ...KeysFunc) eventbus.Handler {
+	return func(ctx context.Context, e eventbus.Event) {
+		for _, keysFor := range keys {
+			for _, key := range keysFor(e) {
+				if prefix, ok := strings.CutSuffix(key, "*"); ok {
+					store.DeletePrefix(ctx, prefix)
+				} else {
+					store.Delete(ctx, key)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LRU is a Store in process memory holding at most a fixed number of values. Storing a
+// value beyond that evicts the least recently used one, so hot keys stay cached while
+// the store's memory stays bounded.
+type LRU struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List               // Most recently used first; values are *lruEntry
+	entries  map[string]*list.Element // Key: cache key
+}
+
+// lruEntry is a value with its key, so an evicted element can be removed from the map
+type lruEntry struct {
+	key string
+	entry
+}
+
+// NewLRU creates an empty store holding at most capacity values, or one when capacity
+// is not positive
+func NewLRU(capacity int) *LRU {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &LRU{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get returns the value under key, unless it is missing or expired, and marks it used
+func (l *LRU) Get(ctx context.Context, key string) (interface{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	el, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*lruEntry)
+	if time.Now().After(e.expiresAt) {
+		l.remove(el)
+		return nil, false
+	}
+	l.order.MoveToFront(el)
+	return e.value, true
+}
+
+// Set stores a value under key for ttl, evicting the least recently used value when full
+func (l *LRU) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e := entry{value: value, expiresAt: time.Now().Add(ttl)}
+	if el, ok := l.entries[key]; ok {
+		el.Value.(*lruEntry).entry = e
+		l.order.MoveToFront(el)
+		return
+	}
+	if l.order.Len() >= l.capacity {
+		l.remove(l.order.Back())
+	}
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, entry: e})
+}
+
+// Delete removes the values under keys
+func (l *LRU) Delete(ctx context.Context, keys ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := l.entries[key]; ok {
+			l.remove(el)
+		}
+	}
+}
+
+// DeletePrefix removes the values whose key starts with prefix
+func (l *LRU) DeletePrefix(ctx context.Context, prefix string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, el := range l.entries {
+		if strings.HasPrefix(key, prefix) {
+			l.remove(el)
+		}
+	}
+}
+
+// remove drops an element; it must be called with l.mu held
+func (l *LRU) remove(el *list.Element) {
+	l.order.Remove(el)
+	delete(l.entries, el.Value.(*lruEntry).key)
+}
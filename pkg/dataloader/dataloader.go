@@ -0,0 +1,113 @@
+// Package dataloader batches and caches lookups made while serving one request, so
+// resolving a field for every item of a list costs one query instead of one per item.
+package dataloader
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BatchFunc fetches the values of many keys at once. Keys missing from the result
+// load as the zero value.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader collects the keys requested within a short window and fetches them in one batch.
+// Results are cached for the lifetime of the loader, so create one per request.
+type Loader[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	cache   map[K]*result[V]
+	pending *batch[K]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type batch[K comparable] struct {
+	ctx        context.Context
+	keys       []K
+	dispatched bool
+}
+
+// New creates a loader that waits up to wait for more keys before fetching, and fetches
+// at once when maxBatch keys are pending
+func New[K comparable, V any](fetch BatchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		cache:    map[K]*result[V]{},
+	}
+}
+
+// Load returns the value of a key, joining the pending batch or starting a new one
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	res, ok := l.cache[key]
+	if !ok {
+		res = &result[V]{done: make(chan struct{})}
+		l.cache[key] = res
+
+		if l.pending == nil {
+			b := &batch[K]{ctx: ctx}
+			l.pending = b
+			time.AfterFunc(l.wait, func() { l.dispatch(b) })
+		}
+		l.pending.keys = append(l.pending.keys, key)
+		if l.maxBatch > 0 && len(l.pending.keys) >= l.maxBatch {
+			go l.dispatch(l.pending)
+			l.pending = nil
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-res.done:
+		return res.value, res.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// dispatch fetches a batch once and hands the values to everyone waiting on its keys
+func (l *Loader[K, V]) dispatch(b *batch[K]) {
+	l.mu.Lock()
+	if b.dispatched {
+		l.mu.Unlock()
+		return
+	}
+	b.dispatched = true
+	if l.pending == b {
+		l.pending = nil
+	}
+	l.mu.Unlock()
+
+	values, err := l.safeFetch(b.ctx, b.keys)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range b.keys {
+		res := l.cache[key]
+		res.value, res.err = values[key], err
+		close(res.done)
+	}
+}
+
+// safeFetch turns a panicking batch function into an error so waiting loads never hang
+func (l *Loader[K, V]) safeFetch(ctx context.Context, keys []K) (values map[K]V, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			values, err = nil, fmt.Errorf("dataloader: batch function panicked: %v", r)
+		}
+	}()
+	return l.fetch(ctx, keys)
+}
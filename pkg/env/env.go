@@ -81,6 +81,26 @@ func GetStringSlice(key string, defaultValue []string) []string {
 	return defaultValue
 }
 
+// GetInt64Slice gets an environment variable as int64 slice (comma-separated) or returns a default value.
+// Items that are not valid integers are skipped.
+func GetInt64Slice(key string, defaultValue []int64) []int64 {
+	items := GetStringSlice(key, nil)
+	if len(items) == 0 {
+		return defaultValue
+	}
+
+	var result []int64
+	for _, item := range items {
+		if intValue, err := strconv.ParseInt(item, 10, 64); err == nil {
+			result = append(result, intValue)
+		}
+	}
+	if len(result) > 0 {
+		return result
+	}
+	return defaultValue
+}
+
 // Helper functions
 func splitString(s, sep string) []string {
 	var result []string
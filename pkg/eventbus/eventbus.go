@@ -0,0 +1,88 @@
+// Package eventbus delivers change events between services in the same process. Services
+// publish an event after each write, and subscribers such as cache invalidation react to
+// it before the write returns, so nothing reads stale data afterwards.
+package eventbus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+)
+
+// Event types
+const (
+	PostCreated    = "post.created"
+	PostUpdated    = "post.updated" // Caption, visibility, sensitivity or pin changed
+	PostDeleted    = "post.deleted"
+	CommentCreated = "comment.created"
+	CommentUpdated = "comment.updated"
+	CommentDeleted = "comment.deleted"
+	AccountUpdated = "account.updated" // Profile, settings, verification or status changed
+	AccountDeleted = "account.deleted"
+)
+
+// Event describes a change to one entity
+type Event struct {
+	Type      string
+	ID        int64 // ID of the post, comment or account that changed
+	AccountID int64 // Account owning the entity, or 0 when the publisher does not know it; the account itself for account events
+	PostID    int64 // Post of a comment, or the post itself for post events
+}
+
+// Handler reacts to an event
+type Handler func(ctx context.Context, e Event)
+
+// Publisher publishes change events; services may hold a nil Publisher when nothing
+// subscribes to their changes
+type Publisher interface {
+	Publish(ctx context.Context, e Event)
+}
+
+// Bus is a synchronous in-process Publisher that calls each subscriber of an event in the
+// order they subscribed
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler // Key: event type; "" holds handlers of every type
+}
+
+// New creates an event bus without subscribers
+func New() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe calls handler for events of the given types, or for every event when no type
+// is given
+func (b *Bus) Subscribe(handler Handler, types ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(types) == 0 {
+		types = []string{""}
+	}
+	for _, t := range types {
+		b.handlers[t] = append(b.handlers[t], handler)
+	}
+}
+
+// Publish delivers an event to its subscribers. A panicking subscriber is logged and
+// skipped, so one faulty subscriber cannot fail the write that published the event.
+func (b *Bus) Publish(ctx context.Context, e Event) {
+	b.mu.RLock()
+	handlers := append(append([]Handler(nil), b.handlers[e.Type]...), b.handlers[""]...)
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		deliver(ctx, handler, e)
+	}
+}
+
+// deliver calls one subscriber, recovering from its panic
+func deliver(ctx context.Context, handler Handler, e Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.GetGlobal().ErrorWithContext(ctx, "Event subscriber panicked", "type", e.Type, "id", e.ID, "panic", r)
+		}
+	}()
+	handler(ctx, e)
+}
@@ -0,0 +1,534 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+)
+
+// Request is a GraphQL request as sent over HTTP
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is the result of a request. Data is left out when the request failed
+// before execution started.
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Error is a GraphQL error; Path is set for errors raised while resolving a field
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Execute parses, validates and runs a query. Sibling items of a list are resolved
+// concurrently so loaders can batch the lookups they make.
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{toError(err)}}
+	}
+
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return &Response{Errors: []*Error{toError(err)}}
+	}
+	if op.Type != "query" {
+		return &Response{Errors: []*Error{{Message: fmt.Sprintf("Operation type %q is not supported; only queries are.", op.Type), Locations: []Location{op.Loc}}}}
+	}
+
+	if errs := s.validate(doc, op); len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+
+	vars, err := coerceVariables(op, req.Variables)
+	if err != nil {
+		return &Response{Errors: []*Error{toError(err)}}
+	}
+
+	e := &executor{doc: doc, vars: vars}
+	data := e.selectionSet(ctx, s.Query, nil, op.SelectionSet, nil)
+	return &Response{Data: data, Errors: e.errors}
+}
+
+// operation picks the operation to run: the named one, or the only one in the document
+func (d *Document) operation(name string) (*Operation, error) {
+	if name == "" {
+		if len(d.Operations) > 1 {
+			return nil, &Error{Message: "Must provide operation name if query contains multiple operations."}
+		}
+		return d.Operations[0], nil
+	}
+	for _, op := range d.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, &Error{Message: fmt.Sprintf("Unknown operation named %q.", name)}
+}
+
+func toError(err error) *Error {
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	return &Error{Message: err.Error()}
+}
+
+// coerceVariables applies defaults and checks that required variables were given
+func coerceVariables(op *Operation, provided map[string]interface{}) (map[string]interface{}, error) {
+	vars := map[string]interface{}{}
+	for _, def := range op.Variables {
+		value := provided[def.Name]
+		if value == nil {
+			if def.Default != nil {
+				value = valueFromAST(def.Default, nil)
+			} else if def.NonNull {
+				return nil, &Error{Message: fmt.Sprintf("Variable \"$%s\" of required type %q was not provided.", def.Name, def.Type), Locations: []Location{def.Loc}}
+			}
+		}
+		vars[def.Name] = value
+	}
+	return vars, nil
+}
+
+// valueFromAST converts a document value to Go: int64, float64, string, bool, nil,
+// []interface{} or map[string]interface{}
+func valueFromAST(v *Value, vars map[string]interface{}) interface{} {
+	switch v.Kind {
+	case KindVariable:
+		return vars[v.Raw]
+	case KindInt:
+		if n, err := strconv.ParseInt(v.Raw, 10, 64); err == nil {
+			return n
+		}
+		f, _ := strconv.ParseFloat(v.Raw, 64)
+		return f
+	case KindFloat:
+		f, _ := strconv.ParseFloat(v.Raw, 64)
+		return f
+	case KindString, KindEnum:
+		return v.Raw
+	case KindBoolean:
+		return v.Raw == "true"
+	case KindList:
+		list := make([]interface{}, len(v.List))
+		for i, item := range v.List {
+			list[i] = valueFromAST(item, vars)
+		}
+		return list
+	case KindObject:
+		obj := make(map[string]interface{}, len(v.Fields))
+		for _, f := range v.Fields {
+			obj[f.Name] = valueFromAST(f.Value, vars)
+		}
+		return obj
+	}
+	return nil
+}
+
+// validator checks a document against the schema before anything is resolved
+type validator struct {
+	doc      *Document
+	vars     map[string]bool
+	maxDepth int
+	errors   []*Error
+}
+
+func (s *Schema) validate(doc *Document, op *Operation) []*Error {
+	v := &validator{doc: doc, vars: map[string]bool{}, maxDepth: s.MaxDepth}
+	for _, def := range op.Variables {
+		v.vars[def.Name] = true
+	}
+	v.directives(op.Directives, op.Loc)
+	v.selections(s.Query, op.SelectionSet, 1, nil)
+	return v.errors
+}
+
+func (v *validator) fail(loc Location, format string, args ...interface{}) {
+	v.errors = append(v.errors, &Error{Message: fmt.Sprintf(format, args...), Locations: []Location{loc}})
+}
+
+func (v *validator) selections(obj *Object, sels []Selection, depth int, fragments []string) {
+	if v.maxDepth > 0 && depth > v.maxDepth {
+		v.fail(sels[0].location(), "Query is nested too deeply; the maximum depth is %d.", v.maxDepth)
+		return
+	}
+
+	for _, sel := range sels {
+		switch sel := sel.(type) {
+		case *Field:
+			v.directives(sel.Directives, sel.Loc)
+			v.field(obj, sel, depth, fragments)
+		case *FragmentSpread:
+			v.directives(sel.Directives, sel.Loc)
+			frag, ok := v.doc.Fragments[sel.Name]
+			if !ok {
+				v.fail(sel.Loc, "Unknown fragment %q.", sel.Name)
+				continue
+			}
+			if contains(fragments, sel.Name) {
+				v.fail(sel.Loc, "Cannot spread fragment %q within itself.", sel.Name)
+				continue
+			}
+			if frag.TypeCondition != obj.Name {
+				v.fail(sel.Loc, "Fragment %q cannot be spread here as objects of type %q can never be of type %q.", sel.Name, obj.Name, frag.TypeCondition)
+				continue
+			}
+			v.selections(obj, frag.SelectionSet, depth, append(fragments, sel.Name))
+		case *InlineFragment:
+			v.directives(sel.Directives, sel.Loc)
+			if sel.TypeCondition != "" && sel.TypeCondition != obj.Name {
+				v.fail(sel.Loc, "Fragment cannot be spread here as objects of type %q can never be of type %q.", obj.Name, sel.TypeCondition)
+				continue
+			}
+			v.selections(obj, sel.SelectionSet, depth, fragments)
+		}
+	}
+}
+
+func (v *validator) field(obj *Object, f *Field, depth int, fragments []string) {
+	if f.Name == "__typename" {
+		if len(f.SelectionSet) > 0 {
+			v.fail(f.Loc, "Field \"__typename\" must not have a selection since type \"String\" has no subfields.")
+		}
+		return
+	}
+
+	def, ok := obj.Fields[f.Name]
+	if !ok {
+		v.fail(f.Loc, "Cannot query field %q on type %q.", f.Name, obj.Name)
+		return
+	}
+
+	given := map[string]bool{}
+	for _, arg := range f.Arguments {
+		if _, ok := def.Args[arg.Name]; !ok {
+			v.fail(f.Loc, "Unknown argument %q on field \"%s.%s\".", arg.Name, obj.Name, f.Name)
+		}
+		v.value(arg.Value, f.Loc)
+		given[arg.Name] = true
+	}
+	for _, name := range sortedKeys(def.Args) {
+		if def.Args[name].Required && !given[name] {
+			v.fail(f.Loc, "Field %q argument %q of type \"%s!\" is required, but it was not provided.", f.Name, name, def.Args[name].Type)
+		}
+	}
+
+	if child, ok := namedType(def.Type).(*Object); ok {
+		if len(f.SelectionSet) == 0 {
+			v.fail(f.Loc, "Field %q of type %q must have a selection of subfields.", f.Name, def.Type)
+			return
+		}
+		v.selections(child, f.SelectionSet, depth+1, fragments)
+	} else if len(f.SelectionSet) > 0 {
+		v.fail(f.Loc, "Field %q must not have a selection since type %q has no subfields.", f.Name, def.Type)
+	}
+}
+
+// directives allows only @include and @skip, each with an "if" argument
+func (v *validator) directives(dirs []*Directive, loc Location) {
+	for _, d := range dirs {
+		if d.Name != "include" && d.Name != "skip" {
+			v.fail(loc, "Unknown directive \"@%s\".", d.Name)
+			continue
+		}
+		if len(d.Arguments) != 1 || d.Arguments[0].Name != "if" {
+			v.fail(loc, "Directive \"@%s\" takes a single \"if\" argument.", d.Name)
+			continue
+		}
+		v.value(d.Arguments[0].Value, loc)
+	}
+}
+
+// value checks that every variable a value references is declared
+func (v *validator) value(val *Value, loc Location) {
+	switch val.Kind {
+	case KindVariable:
+		if !v.vars[val.Raw] {
+			v.fail(loc, "Variable \"$%s\" is not defined.", val.Raw)
+		}
+	case KindList:
+		for _, item := range val.List {
+			v.value(item, loc)
+		}
+	case KindObject:
+		for _, f := range val.Fields {
+			v.value(f.Value, loc)
+		}
+	}
+}
+
+func namedType(t Type) Type {
+	for {
+		list, ok := t.(*List)
+		if !ok {
+			return t
+		}
+		t = list.OfType
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// executor resolves a validated operation
+type executor struct {
+	doc    *Document
+	vars   map[string]interface{}
+	mu     sync.Mutex
+	errors []*Error
+}
+
+// fieldGroup is the fields selected under one response key; their selection sets are merged
+type fieldGroup struct {
+	key    string
+	fields []*Field
+}
+
+func (e *executor) fail(f *Field, path []interface{}, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.errors = append(e.errors, &Error{Message: err.Error(), Locations: []Location{f.Loc}, Path: path})
+}
+
+// selectionSet resolves the fields of an object one after another
+func (e *executor) selectionSet(ctx context.Context, obj *Object, source interface{}, sels []Selection, path []interface{}) *orderedMap {
+	var groups []*fieldGroup
+	e.collect(obj, sels, &groups, map[string]bool{})
+
+	result := &orderedMap{keys: make([]string, len(groups)), values: make([]interface{}, len(groups))}
+	for i, g := range groups {
+		result.keys[i] = g.key
+		result.values[i] = e.field(ctx, obj, source, g, appendPath(path, g.key))
+	}
+	return result
+}
+
+// collect flattens fragments and drops skipped selections, grouping fields by response key
+func (e *executor) collect(obj *Object, sels []Selection, groups *[]*fieldGroup, visited map[string]bool) {
+	for _, sel := range sels {
+		switch sel := sel.(type) {
+		case *Field:
+			if !e.include(sel.Directives) {
+				continue
+			}
+			key := sel.ResponseKey()
+			found := false
+			for _, g := range *groups {
+				if g.key == key {
+					g.fields = append(g.fields, sel)
+					found = true
+					break
+				}
+			}
+			if !found {
+				*groups = append(*groups, &fieldGroup{key: key, fields: []*Field{sel}})
+			}
+		case *FragmentSpread:
+			if visited[sel.Name] || !e.include(sel.Directives) {
+				continue
+			}
+			visited[sel.Name] = true
+			if frag := e.doc.Fragments[sel.Name]; frag != nil && frag.TypeCondition == obj.Name {
+				e.collect(obj, frag.SelectionSet, groups, visited)
+			}
+		case *InlineFragment:
+			if !e.include(sel.Directives) {
+				continue
+			}
+			if sel.TypeCondition == "" || sel.TypeCondition == obj.Name {
+				e.collect(obj, sel.SelectionSet, groups, visited)
+			}
+		}
+	}
+}
+
+// include evaluates @skip and @include
+func (e *executor) include(dirs []*Directive) bool {
+	for _, d := range dirs {
+		cond, _ := valueFromAST(d.Arguments[0].Value, e.vars).(bool)
+		if (d.Name == "skip" && cond) || (d.Name == "include" && !cond) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *executor) field(ctx context.Context, obj *Object, source interface{}, g *fieldGroup, path []interface{}) interface{} {
+	f := g.fields[0]
+	if f.Name == "__typename" {
+		return obj.Name
+	}
+
+	def := obj.Fields[f.Name]
+	args, err := e.arguments(def, f)
+	if err != nil {
+		e.fail(f, path, err)
+		return nil
+	}
+
+	value, err := resolve(ctx, def, source, args)
+	if err != nil {
+		e.fail(f, path, err)
+		return nil
+	}
+
+	return e.complete(ctx, def.Type, g.fields, value, path)
+}
+
+// resolve runs a resolver, turning a panic into a field error
+func resolve(ctx context.Context, def *FieldDefinition, source interface{}, args map[string]interface{}) (value interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.GetGlobal().Error("GraphQL resolver panicked", "panic", fmt.Sprint(r))
+			err = fmt.Errorf("internal server error")
+		}
+	}()
+
+	if def.Resolve == nil {
+		return nil, nil
+	}
+	return def.Resolve(ctx, ResolveParams{Source: source, Args: args})
+}
+
+// arguments coerces the field's arguments to their declared types and applies defaults
+func (e *executor) arguments(def *FieldDefinition, f *Field) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	for name, a := range def.Args {
+		if a.DefaultValue != nil {
+			args[name] = a.DefaultValue
+		}
+	}
+
+	for _, arg := range f.Arguments {
+		raw := valueFromAST(arg.Value, e.vars)
+		if raw == nil {
+			continue
+		}
+		value, err := coerceArgument(def.Args[arg.Name].Type, raw)
+		if err != nil {
+			return nil, fmt.Errorf("argument %q has an invalid value: %v", arg.Name, err)
+		}
+		args[arg.Name] = value
+	}
+
+	for name, a := range def.Args {
+		if a.Required && args[name] == nil {
+			return nil, fmt.Errorf("argument %q of required type \"%s!\" was not provided", name, a.Type)
+		}
+	}
+	return args, nil
+}
+
+// complete shapes a resolved value by its type. Items of object lists are resolved concurrently.
+func (e *executor) complete(ctx context.Context, typ Type, fields []*Field, value interface{}, path []interface{}) interface{} {
+	if isNull(value) {
+		return nil
+	}
+
+	switch t := typ.(type) {
+	case *Object:
+		var sels []Selection
+		for _, f := range fields {
+			sels = append(sels, f.SelectionSet...)
+		}
+		return e.selectionSet(ctx, t, value, sels, path)
+	case *List:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			e.fail(fields[0], path, fmt.Errorf("expected a list for %s", t))
+			return nil
+		}
+
+		items := make([]interface{}, rv.Len())
+		if _, isObject := namedType(t).(*Object); !isObject {
+			for i := range items {
+				items[i] = e.complete(ctx, t.OfType, fields, rv.Index(i).Interface(), appendPath(path, i))
+			}
+			return items
+		}
+
+		var wg sync.WaitGroup
+		for i := range items {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				items[i] = e.complete(ctx, t.OfType, fields, rv.Index(i).Interface(), appendPath(path, i))
+			}(i)
+		}
+		wg.Wait()
+		return items
+	}
+	return value
+}
+
+// isNull reports whether a value is nil or a nil pointer, map or interface. Nil slices
+// are empty lists rather than null.
+func isNull(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(value); rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// appendPath copies the path so concurrently resolved siblings never share a backing array
+func appendPath(path []interface{}, elem interface{}) []interface{} {
+	out := make([]interface{}, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, elem)
+}
+
+// orderedMap is a JSON object that keeps the order fields were selected in
+type orderedMap struct {
+	keys   []string
+	values []interface{}
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(m.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,782 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Document is a parsed GraphQL request document
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation is a query, mutation or subscription definition
+type Operation struct {
+	Type         string // query, mutation or subscription
+	Name         string
+	Variables    []*VariableDefinition
+	Directives   []*Directive
+	SelectionSet []Selection
+	Loc          Location
+}
+
+// VariableDefinition declares an operation variable
+type VariableDefinition struct {
+	Name    string
+	Type    string // as written, e.g. "[ID!]!"
+	NonNull bool
+	Default *Value
+	Loc     Location
+}
+
+// Fragment is a named fragment definition
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Loc           Location
+}
+
+// Selection is a *Field, *FragmentSpread or *InlineFragment
+type Selection interface {
+	location() Location
+}
+
+// Field selects a field of the parent object
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    []*Argument
+	Directives   []*Directive
+	SelectionSet []Selection
+	Loc          Location
+}
+
+// ResponseKey is the key the field is written under in the result
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// FragmentSpread includes a named fragment
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+	Loc        Location
+}
+
+// InlineFragment includes a selection set, optionally for one type only
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Loc           Location
+}
+
+func (f *Field) location() Location          { return f.Loc }
+func (f *FragmentSpread) location() Location { return f.Loc }
+func (f *InlineFragment) location() Location { return f.Loc }
+
+// Argument is a named value passed to a field or directive
+type Argument struct {
+	Name  string
+	Value *Value
+}
+
+// Directive annotates a selection, e.g. @include(if: $flag)
+type Directive struct {
+	Name      string
+	Arguments []*Argument
+}
+
+// Value kinds
+const (
+	KindVariable = "Variable"
+	KindInt      = "Int"
+	KindFloat    = "Float"
+	KindString   = "String"
+	KindBoolean  = "Boolean"
+	KindNull     = "Null"
+	KindEnum     = "Enum"
+	KindList     = "List"
+	KindObject   = "Object"
+)
+
+// Value is a literal or variable reference in a document
+type Value struct {
+	Kind   string
+	Raw    string // variable name, number, string contents or enum name
+	List   []*Value
+	Fields []*Argument // object fields
+}
+
+// Location is a 1-based line and column in the document
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Parse parses an executable GraphQL document
+func Parse(source string) (*Document, error) {
+	p := &parser{lex: &lexer{src: source, line: 1, col: 1}}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	doc := &Document{Fragments: map[string]*Fragment{}}
+	for p.tok.kind != tokEOF {
+		switch {
+		case p.tok.is(tokPunct, "{"):
+			loc := p.tok.loc
+			sels, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{Type: "query", SelectionSet: sels, Loc: loc})
+		case p.tok.is(tokName, "query"), p.tok.is(tokName, "mutation"), p.tok.is(tokName, "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case p.tok.is(tokName, "fragment"):
+			frag, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := doc.Fragments[frag.Name]; exists {
+				return nil, &Error{Message: fmt.Sprintf("There can be only one fragment named %q.", frag.Name), Locations: []Location{frag.Loc}}
+			}
+			doc.Fragments[frag.Name] = frag
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(doc.Operations) == 0 {
+		return nil, &Error{Message: "Document contains no operations."}
+	}
+	return doc, nil
+}
+
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func (p *parser) next() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	what := p.tok.value
+	if p.tok.kind == tokEOF {
+		what = "<EOF>"
+	}
+	return &Error{Message: fmt.Sprintf("Syntax Error: Unexpected %q.", what), Locations: []Location{p.tok.loc}}
+}
+
+// expect consumes the given punctuator
+func (p *parser) expect(punct string) error {
+	if !p.tok.is(tokPunct, punct) {
+		return &Error{Message: fmt.Sprintf("Syntax Error: Expected %q, found %q.", punct, p.tok.value), Locations: []Location{p.tok.loc}}
+	}
+	return p.next()
+}
+
+// skip consumes the given punctuator if it is next
+func (p *parser) skip(punct string) (bool, error) {
+	if !p.tok.is(tokPunct, punct) {
+		return false, nil
+	}
+	return true, p.next()
+}
+
+func (p *parser) parseName() (string, error) {
+	if p.tok.kind != tokName {
+		return "", &Error{Message: fmt.Sprintf("Syntax Error: Expected Name, found %q.", p.tok.value), Locations: []Location{p.tok.loc}}
+	}
+	name := p.tok.value
+	return name, p.next()
+}
+
+func (p *parser) parseOperation() (*Operation, error) {
+	op := &Operation{Type: p.tok.value, Loc: p.tok.loc}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if p.tok.kind == tokName {
+		if op.Name, err = p.parseName(); err != nil {
+			return nil, err
+		}
+	}
+	if p.tok.is(tokPunct, "(") {
+		if op.Variables, err = p.parseVariableDefinitions(); err != nil {
+			return nil, err
+		}
+	}
+	if op.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if op.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinitions() ([]*VariableDefinition, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	var defs []*VariableDefinition
+	for {
+		if done, err := p.skip(")"); err != nil || done {
+			return defs, err
+		}
+
+		def := &VariableDefinition{Loc: p.tok.loc}
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		def.Name = name
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if def.Type, err = p.parseType(); err != nil {
+			return nil, err
+		}
+		def.NonNull = strings.HasSuffix(def.Type, "!")
+		if ok, err := p.skip("="); err != nil {
+			return nil, err
+		} else if ok {
+			if def.Default, err = p.parseValue(true); err != nil {
+				return nil, err
+			}
+		}
+		defs = append(defs, def)
+	}
+}
+
+// parseType returns a type reference as written, e.g. "[String!]"
+func (p *parser) parseType() (string, error) {
+	var typ string
+	if ok, err := p.skip("["); err != nil {
+		return "", err
+	} else if ok {
+		inner, err := p.parseType()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect("]"); err != nil {
+			return "", err
+		}
+		typ = "[" + inner + "]"
+	} else {
+		name, err := p.parseName()
+		if err != nil {
+			return "", err
+		}
+		typ = name
+	}
+
+	if ok, err := p.skip("!"); err != nil {
+		return "", err
+	} else if ok {
+		typ += "!"
+	}
+	return typ, nil
+}
+
+func (p *parser) parseFragment() (*Fragment, error) {
+	frag := &Fragment{Loc: p.tok.loc}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if frag.Name, err = p.parseName(); err != nil {
+		return nil, err
+	}
+	if frag.Name == "on" {
+		return nil, &Error{Message: "Syntax Error: Unexpected Name \"on\".", Locations: []Location{frag.Loc}}
+	}
+	if !p.tok.is(tokName, "on") {
+		return nil, p.unexpected()
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if frag.TypeCondition, err = p.parseName(); err != nil {
+		return nil, err
+	}
+	if frag.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if frag.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return frag, nil
+}
+
+func (p *parser) parseSelectionSet() ([]Selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var sels []Selection
+	for {
+		if done, err := p.skip("}"); err != nil {
+			return nil, err
+		} else if done {
+			if len(sels) == 0 {
+				return nil, &Error{Message: "Syntax Error: Expected Name, found \"}\".", Locations: []Location{p.tok.loc}}
+			}
+			return sels, nil
+		}
+		if p.tok.kind == tokEOF {
+			return nil, p.unexpected()
+		}
+
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+}
+
+func (p *parser) parseSelection() (Selection, error) {
+	loc := p.tok.loc
+	if ok, err := p.skip("..."); err != nil {
+		return nil, err
+	} else if ok {
+		return p.parseFragmentSelection(loc)
+	}
+
+	field := &Field{Loc: loc}
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	field.Name = name
+	if ok, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		field.Alias = name
+		if field.Name, err = p.parseName(); err != nil {
+			return nil, err
+		}
+	}
+	if p.tok.is(tokPunct, "(") {
+		if field.Arguments, err = p.parseArguments(); err != nil {
+			return nil, err
+		}
+	}
+	if field.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if p.tok.is(tokPunct, "{") {
+		if field.SelectionSet, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+// parseFragmentSelection parses what follows "...": a fragment spread or an inline fragment
+func (p *parser) parseFragmentSelection(loc Location) (Selection, error) {
+	if p.tok.kind == tokName && p.tok.value != "on" {
+		spread := &FragmentSpread{Loc: loc}
+		var err error
+		if spread.Name, err = p.parseName(); err != nil {
+			return nil, err
+		}
+		if spread.Directives, err = p.parseDirectives(); err != nil {
+			return nil, err
+		}
+		return spread, nil
+	}
+
+	inline := &InlineFragment{Loc: loc}
+	var err error
+	if p.tok.is(tokName, "on") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if inline.TypeCondition, err = p.parseName(); err != nil {
+			return nil, err
+		}
+	}
+	if inline.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if inline.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return inline, nil
+}
+
+func (p *parser) parseArguments() ([]*Argument, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	var args []*Argument
+	for {
+		if done, err := p.skip(")"); err != nil || done {
+			return args, err
+		}
+		arg, err := p.parseArgument(false)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+}
+
+func (p *parser) parseArgument(constant bool) (*Argument, error) {
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	value, err := p.parseValue(constant)
+	if err != nil {
+		return nil, err
+	}
+	return &Argument{Name: name, Value: value}, nil
+}
+
+func (p *parser) parseDirectives() ([]*Directive, error) {
+	var dirs []*Directive
+	for p.tok.is(tokPunct, "@") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		dir := &Directive{Name: name}
+		if p.tok.is(tokPunct, "(") {
+			if dir.Arguments, err = p.parseArguments(); err != nil {
+				return nil, err
+			}
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, nil
+}
+
+// parseValue parses a value; constant values, such as variable defaults, cannot reference variables
+func (p *parser) parseValue(constant bool) (*Value, error) {
+	tok := p.tok
+	switch {
+	case tok.is(tokPunct, "$") && !constant:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		return &Value{Kind: KindVariable, Raw: name}, nil
+	case tok.is(tokPunct, "["):
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		value := &Value{Kind: KindList}
+		for {
+			if done, err := p.skip("]"); err != nil || done {
+				return value, err
+			}
+			item, err := p.parseValue(constant)
+			if err != nil {
+				return nil, err
+			}
+			value.List = append(value.List, item)
+		}
+	case tok.is(tokPunct, "{"):
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		value := &Value{Kind: KindObject}
+		for {
+			if done, err := p.skip("}"); err != nil || done {
+				return value, err
+			}
+			field, err := p.parseArgument(constant)
+			if err != nil {
+				return nil, err
+			}
+			value.Fields = append(value.Fields, field)
+		}
+	case tok.kind == tokInt, tok.kind == tokFloat, tok.kind == tokString:
+		kind := map[int]string{tokInt: KindInt, tokFloat: KindFloat, tokString: KindString}[tok.kind]
+		return &Value{Kind: kind, Raw: tok.value}, p.next()
+	case tok.kind == tokName:
+		value := &Value{Kind: KindEnum, Raw: tok.value}
+		switch tok.value {
+		case "true", "false":
+			value.Kind = KindBoolean
+		case "null":
+			value.Kind = KindNull
+		}
+		return value, p.next()
+	}
+	return nil, p.unexpected()
+}
+
+// Token kinds
+const (
+	tokEOF = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+type token struct {
+	kind  int
+	value string
+	loc   Location
+}
+
+func (t token) is(kind int, value string) bool {
+	return t.kind == kind && t.value == value
+}
+
+type lexer struct {
+	src  string
+	pos  int
+	line int
+	col  int
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n && l.pos < len(l.src); i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	// Whitespace, commas and comments are insignificant
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' {
+			l.advance(1)
+			continue
+		}
+		if c == '#' {
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+			continue
+		}
+		break
+	}
+
+	loc := Location{Line: l.line, Column: l.col}
+	if l.pos >= len(l.src) {
+		return token{kind: tokEOF, loc: loc}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.advance(3)
+		return token{kind: tokPunct, value: "...", loc: loc}, nil
+	case strings.IndexByte("!$&()/:=@[]{|}", c) >= 0:
+		l.advance(1)
+		return token{kind: tokPunct, value: string(c), loc: loc}, nil
+	case c == '_' || isLetter(c):
+		start := l.pos
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		return token{kind: tokName, value: l.src[start:l.pos], loc: loc}, nil
+	case c == '-' || isDigit(c):
+		return l.number(loc)
+	case c == '"':
+		return l.string(loc)
+	}
+	return token{}, &Error{Message: fmt.Sprintf("Syntax Error: Unexpected character %q.", c), Locations: []Location{loc}}
+}
+
+func (l *lexer) number(loc Location) (token, error) {
+	start := l.pos
+	kind := tokInt
+	if l.src[l.pos] == '-' {
+		l.advance(1)
+	}
+	digits := func() int {
+		n := 0
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.advance(1)
+			n++
+		}
+		return n
+	}
+	if digits() == 0 {
+		return token{}, &Error{Message: "Syntax Error: Invalid number.", Locations: []Location{loc}}
+	}
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		kind = tokFloat
+		l.advance(1)
+		if digits() == 0 {
+			return token{}, &Error{Message: "Syntax Error: Invalid number.", Locations: []Location{loc}}
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = tokFloat
+		l.advance(1)
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.advance(1)
+		}
+		if digits() == 0 {
+			return token{}, &Error{Message: "Syntax Error: Invalid number.", Locations: []Location{loc}}
+		}
+	}
+	return token{kind: kind, value: l.src[start:l.pos], loc: loc}, nil
+}
+
+func (l *lexer) string(loc Location) (token, error) {
+	// Block strings keep their contents as written, minus the common indentation
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		end := strings.Index(l.src[l.pos+3:], `"""`)
+		if end < 0 {
+			return token{}, &Error{Message: "Syntax Error: Unterminated string.", Locations: []Location{loc}}
+		}
+		raw := l.src[l.pos+3 : l.pos+3+end]
+		l.advance(end + 6)
+		return token{kind: tokString, value: blockString(raw), loc: loc}, nil
+	}
+
+	start := l.pos
+	l.advance(1)
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case '\\':
+			l.advance(2)
+			continue
+		case '\n':
+			return token{}, &Error{Message: "Syntax Error: Unterminated string.", Locations: []Location{loc}}
+		case '"':
+			l.advance(1)
+			value, err := unescape(l.src[start+1 : l.pos-1])
+			if err != nil {
+				return token{}, &Error{Message: "Syntax Error: Invalid string.", Locations: []Location{loc}}
+			}
+			return token{kind: tokString, value: value, loc: loc}, nil
+		}
+		l.advance(1)
+	}
+	return token{}, &Error{Message: "Syntax Error: Unterminated string.", Locations: []Location{loc}}
+}
+
+// unescape resolves the escape sequences of a quoted string
+func unescape(s string) (string, error) {
+	if !strings.Contains(s, `\\`) {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i >= len(s) {
+			return "", fmt.Errorf("invalid escape")
+		}
+		switch s[i] {
+		case '"', '\\', '/':
+			b.WriteByte(s[i])
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			if i+4 >= len(s) {
+				return "", fmt.Errorf("invalid escape")
+			}
+			code, err := strconv.ParseUint(s[i+1:i+5], 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("invalid escape")
+			}
+			b.WriteRune(rune(code))
+			i += 4
+		default:
+			return "", fmt.Errorf("invalid escape")
+		}
+	}
+	return b.String(), nil
+}
+
+// blockString strips the common indentation and surrounding blank lines of a block string
+func blockString(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" {
+			continue
+		}
+		if n := len(line) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	for i := 1; i < len(lines) && indent > 0; i++ {
+		if len(lines[i]) >= indent {
+			lines[i] = lines[i][indent:]
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,290 @@
+// Package graphql executes GraphQL queries against a schema defined in Go. It covers the
+// query language (fragments, variables, aliases, @include and @skip) but not mutations,
+// subscriptions or introspection; Schema.SDL describes the schema to clients instead.
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Type is the type of a field: a *Scalar, *Enum, *Object or *List
+type Type interface {
+	String() string
+}
+
+// Scalar is a leaf type; resolved values are written to the response as JSON
+type Scalar struct {
+	Name        string
+	Description string
+}
+
+// Built-in scalars
+var (
+	Int     = &Scalar{Name: "Int"}
+	Float   = &Scalar{Name: "Float"}
+	String  = &Scalar{Name: "String"}
+	Boolean = &Scalar{Name: "Boolean"}
+	ID      = &Scalar{Name: "ID"}
+)
+
+// Enum is a leaf type restricted to a set of values
+type Enum struct {
+	Name        string
+	Description string
+	Values      []string
+}
+
+// Object is a type with fields
+type Object struct {
+	Name        string
+	Description string
+	Fields      Fields
+}
+
+// List is a list of another type
+type List struct {
+	OfType Type
+}
+
+func (t *Scalar) String() string { return t.Name }
+func (t *Enum) String() string   { return t.Name }
+func (t *Object) String() string { return t.Name }
+func (t *List) String() string   { return "[" + t.OfType.String() + "]" }
+
+// NewList wraps a type in a list
+func NewList(of Type) *List {
+	return &List{OfType: of}
+}
+
+// Fields maps field names to their definitions
+type Fields map[string]*FieldDefinition
+
+// FieldDefinition describes a field and how to resolve it
+type FieldDefinition struct {
+	Type        Type
+	Description string
+	Args        Args
+	Resolve     ResolveFunc
+}
+
+// Args maps argument names to their definitions
+type Args map[string]*ArgumentDefinition
+
+// ArgumentDefinition describes a field argument. Arguments take a scalar or enum type;
+// a nil DefaultValue means the argument is optional without a default.
+type ArgumentDefinition struct {
+	Type         Type
+	Description  string
+	Required     bool
+	DefaultValue interface{}
+}
+
+// ResolveFunc produces the value of a field from its parent value and arguments
+type ResolveFunc func(ctx context.Context, p ResolveParams) (interface{}, error)
+
+// ResolveParams holds what a resolver needs: the parent value and the coerced arguments.
+// Int arguments are int64, Float float64, ID, String and enum values string.
+type ResolveParams struct {
+	Source interface{}
+	Args   map[string]interface{}
+}
+
+// Int64 returns an Int argument, or 0 when it was not given
+func (p ResolveParams) Int64(name string) int64 {
+	v, _ := p.Args[name].(int64)
+	return v
+}
+
+// String returns an ID, String or enum argument, or "" when it was not given
+func (p ResolveParams) String(name string) string {
+	v, _ := p.Args[name].(string)
+	return v
+}
+
+// Bool returns a Boolean argument, or false when it was not given
+func (p ResolveParams) Bool(name string) bool {
+	v, _ := p.Args[name].(bool)
+	return v
+}
+
+// Schema is an executable schema; only the query root is supported
+type Schema struct {
+	Query    *Object
+	MaxDepth int // deepest allowed selection nesting, 0 for no limit
+}
+
+// coerceArgument converts an argument value to the Go type of its declared type
+func coerceArgument(typ Type, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch t := typ.(type) {
+	case *Enum:
+		if s, ok := value.(string); ok {
+			for _, v := range t.Values {
+				if v == s {
+					return s, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("expected one of %s", strings.Join(t.Values, ", "))
+	case *Scalar:
+		switch t.Name {
+		case "Int":
+			switch v := value.(type) {
+			case int64:
+				return v, nil
+			case float64:
+				if v == math.Trunc(v) && math.Abs(v) <= math.MaxInt64 {
+					return int64(v), nil
+				}
+			}
+			return nil, fmt.Errorf("expected an Int")
+		case "Float":
+			switch v := value.(type) {
+			case int64:
+				return float64(v), nil
+			case float64:
+				return v, nil
+			}
+			return nil, fmt.Errorf("expected a Float")
+		case "Boolean":
+			if v, ok := value.(bool); ok {
+				return v, nil
+			}
+			return nil, fmt.Errorf("expected a Boolean")
+		case "ID":
+			switch v := value.(type) {
+			case string:
+				return v, nil
+			case int64:
+				return strconv.FormatInt(v, 10), nil
+			case float64:
+				if v == math.Trunc(v) {
+					return strconv.FormatFloat(v, 'f', -1, 64), nil
+				}
+			}
+			return nil, fmt.Errorf("expected an ID")
+		default:
+			if v, ok := value.(string); ok {
+				return v, nil
+			}
+			return nil, fmt.Errorf("expected a %s", t.Name)
+		}
+	}
+	return nil, fmt.Errorf("unsupported argument type %s", typ)
+}
+
+// SDL renders the schema in the GraphQL schema definition language
+func (s *Schema) SDL() string {
+	types := map[string]Type{}
+	var collect func(Type)
+	collect = func(t Type) {
+		switch t := t.(type) {
+		case *List:
+			collect(t.OfType)
+		case *Object:
+			if _, seen := types[t.Name]; seen {
+				return
+			}
+			types[t.Name] = t
+			for _, f := range t.Fields {
+				collect(f.Type)
+				for _, a := range f.Args {
+					collect(a.Type)
+				}
+			}
+		case *Enum:
+			types[t.Name] = t
+		case *Scalar:
+			switch t.Name {
+			case "Int", "Float", "String", "Boolean", "ID":
+			default:
+				types[t.Name] = t
+			}
+		}
+	}
+	collect(s.Query)
+
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("schema {\n  query: " + s.Query.Name + "\n}\n")
+	for _, name := range names {
+		b.WriteString("\n")
+		switch t := types[name].(type) {
+		case *Scalar:
+			writeDescription(&b, "", t.Description)
+			b.WriteString("scalar " + t.Name + "\n")
+		case *Enum:
+			writeDescription(&b, "", t.Description)
+			b.WriteString("enum " + t.Name + " {\n")
+			for _, v := range t.Values {
+				b.WriteString("  " + v + "\n")
+			}
+			b.WriteString("}\n")
+		case *Object:
+			writeDescription(&b, "", t.Description)
+			b.WriteString("type " + t.Name + " {\n")
+			for _, fieldName := range sortedKeys(t.Fields) {
+				f := t.Fields[fieldName]
+				writeDescription(&b, "  ", f.Description)
+				b.WriteString("  " + fieldName + writeArgs(f.Args) + ": " + f.Type.String() + "\n")
+			}
+			b.WriteString("}\n")
+		}
+	}
+	return b.String()
+}
+
+func writeDescription(b *strings.Builder, indent, description string) {
+	if description != "" {
+		b.WriteString(indent + strconv.Quote(description) + "\n")
+	}
+}
+
+func writeArgs(args Args) string {
+	if len(args) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(args))
+	for _, name := range sortedKeys(args) {
+		a := args[name]
+		part := name + ": " + a.Type.String()
+		if a.Required {
+			part += "!"
+		}
+		switch v := a.DefaultValue.(type) {
+		case nil:
+		case string:
+			if _, isEnum := a.Type.(*Enum); isEnum {
+				part += " = " + v
+			} else {
+				part += " = " + strconv.Quote(v)
+			}
+		default:
+			part += fmt.Sprintf(" = %v", v)
+		}
+		parts = append(parts, part)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,149 @@
+// Package i18n translates the messages and errors of API responses. Bundles map the
+// English source text to its translation, so handlers keep writing English and the
+// response layer translates at send time.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Supported locales
+const (
+	English    = "en"
+	Indonesian = "id"
+)
+
+// DefaultLocale is used when the client does not ask for a supported locale
+const DefaultLocale = English
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// bundles maps a locale to its translations, keyed by the English text
+var bundles = mustLoadBundles()
+
+// mustLoadBundles reads the embedded bundles; locales/<locale>.json holds one locale
+func mustLoadBundles() map[string]map[string]string {
+	files, err := locales.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: failed to read bundles: %v", err))
+	}
+
+	loaded := make(map[string]map[string]string, len(files))
+	for _, file := range files {
+		data, err := locales.ReadFile(path.Join("locales", file.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: failed to read bundle %s: %v", file.Name(), err))
+		}
+		var bundle map[string]string
+		if err := json.Unmarshal(data, &bundle); err != nil {
+			panic(fmt.Sprintf("i18n: invalid bundle %s: %v", file.Name(), err))
+		}
+		loaded[strings.TrimSuffix(file.Name(), ".json")] = bundle
+	}
+	return loaded
+}
+
+// IsSupported reports whether a locale has a bundle
+func IsSupported(locale string) bool {
+	_, ok := bundles[locale]
+	return ok
+}
+
+// Negotiate picks the supported locale an Accept-Language header prefers, or
+// DefaultLocale. Only the primary language subtag is matched, so "id-ID" selects id.
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		locale string
+		q      float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if tag == "" || q <= 0 {
+			continue
+		}
+
+		locale, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		switch locale {
+		case "*":
+			locale = DefaultLocale
+		case "in": // Deprecated code for Indonesian
+			locale = Indonesian
+		}
+		if IsSupported(locale) {
+			candidates = append(candidates, candidate{locale: locale, q: q})
+		}
+	}
+	if len(candidates) == 0 {
+		return DefaultLocale
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].locale
+}
+
+// Translate returns the translation of an English text, or the text itself when the
+// locale has none. A capitalized text also matches the bundle's lower-case entry, as
+// domain errors become messages by capitalizing them, and "text: detail" translates
+// text while keeping the detail.
+func Translate(locale, text string) string {
+	bundle, ok := bundles[locale]
+	if !ok || text == "" {
+		return text
+	}
+
+	if translated, ok := bundle[text]; ok {
+		return translated
+	}
+	if lower := lowerFirst(text); lower != text {
+		if translated, ok := bundle[lower]; ok {
+			return upperFirst(translated)
+		}
+	}
+	if head, detail, ok := strings.Cut(text, ": "); ok {
+		if translated := Translate(locale, head); translated != head {
+			return translated + ": " + detail
+		}
+	}
+	return text
+}
+
+// TranslateAll translates each text into a new slice
+func TranslateAll(locale string, texts []string) []string {
+	if texts == nil {
+		return nil
+	}
+	translated := make([]string, len(texts))
+	for i, text := range texts {
+		translated[i] = Translate(locale, text)
+	}
+	return translated
+}
+
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
+
+func upperFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
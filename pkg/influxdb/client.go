@@ -3,54 +3,206 @@ package influxdb
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/influxdata/influxdb-client-go/v2/api"
+	influxhttp "github.com/influxdata/influxdb-client-go/v2/api/http"
 	"github.com/influxdata/influxdb-client-go/v2/api/write"
+
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/reqctx"
 )
 
-// Client represents an InfluxDB client
+// Options configures how points are buffered and sent
+type Options struct {
+	BatchSize     uint          // Points sent in one write request
+	FlushInterval time.Duration // Longest a point waits before it is sent
+	BufferSize    int           // Points waiting to be batched; further points are dropped
+	RetryInterval time.Duration // How often the connection is checked while InfluxDB is down
+}
+
+// Client represents an InfluxDB client. Points are written asynchronously: writes queue the
+// point and return, and a background writer sends them in batches. When the queue is full,
+// e.g. while InfluxDB is slow or down, points are dropped and counted instead of blocking
+// the request or query that produced them.
+//
+// The connection is checked in the background rather than when the client is created, so
+// an unreachable InfluxDB neither fails nor delays startup. While it is down, points wait
+// in the queue and are sent once a check succeeds again.
 type Client struct {
 	client   influxdb2.Client
-	writeAPI api.WriteAPIBlocking
+	writeAPI api.WriteAPI
 	org      string
 	bucket   string
-}
 
-// NewClient creates a new InfluxDB client
-func NewClient(serverURL, token, org, bucket string) (*Client, error) {
-	client := influxdb2.NewClient(serverURL, token)
+	mu        sync.RWMutex // Guards closing points against concurrent writes
+	closed    bool
+	points    chan *write.Point
+	closing   chan struct{} // Closed when Close is called, stopping the connection checks
+	done      chan struct{} // Closed when the forwarder has handed over every queued point
+	dropped   atomic.Int64
+	connected atomic.Bool // Whether the last connection check passed
+}
 
-	// Test connection
-	health, err := client.Health(context.Background())
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to InfluxDB: %w", err)
+// NewClient creates a new InfluxDB client and starts checking its connection in the
+// background
+func NewClient(serverURL, token, org, bucket string, opts Options) (*Client, error) {
+	if serverURL == "" {
+		return nil, fmt.Errorf("InfluxDB server URL is required")
 	}
 
-	if health.Status != "pass" {
-		return nil, fmt.Errorf("InfluxDB health check failed: %s", health.Status)
-	}
+	clientOpts := influxdb2.DefaultOptions().
+		SetBatchSize(opts.BatchSize).
+		SetFlushInterval(uint(opts.FlushInterval.Milliseconds()))
+	// Requests made for a traced request, such as health checks, carry its trace context
+	httpClient := clientOpts.HTTPClient()
+	httpClient.Transport = reqctx.Transport(httpClient.Transport)
+	client := influxdb2.NewClientWithOptions(serverURL, token, clientOpts)
 
-	writeAPI := client.WriteAPIBlocking(org, bucket)
+	writeAPI := client.WriteAPI(org, bucket)
+	writeAPI.SetWriteFailedCallback(func(batch string, err influxhttp.Error, retryAttempts uint) bool {
+		logger.GetGlobal().Warn("Failed to write metrics batch to InfluxDB", "error", err.Error(), "retryAttempts", retryAttempts)
+		return true
+	})
 
-	return &Client{
+	c := &Client{
 		client:   client,
 		writeAPI: writeAPI,
 		org:      org,
 		bucket:   bucket,
-	}, nil
+		points:   make(chan *write.Point, opts.BufferSize),
+		closing:  make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go c.monitor(opts.RetryInterval)
+	go c.forward(opts.FlushInterval)
+
+	return c, nil
 }
 
-// Close closes the InfluxDB connection
+// monitor checks the connection at once and then every interval until the client is
+// closed, logging when InfluxDB goes down or comes back
+func (c *Client) monitor(interval time.Duration) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	checked := false
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		err := c.Ping(ctx)
+		cancel()
+
+		switch {
+		case err != nil && (c.connected.Swap(false) || !checked):
+			logger.GetGlobal().Warn("InfluxDB is unreachable, metrics are queued until it is back", "error", err.Error(), "retryInterval", interval.String())
+		case err == nil && !c.connected.Swap(true) && checked:
+			logger.GetGlobal().Info("InfluxDB connection restored")
+		}
+		checked = true
+
+		select {
+		case <-c.closing:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// forward hands queued points to the batching write API, which blocks while it sends a
+// batch, and logs how many points were dropped since the last report. While InfluxDB is
+// down the points are left queued; when the client is closed then, they are discarded.
+func (c *Client) forward(reportInterval time.Duration) {
+	defer close(c.done)
+
+	if reportInterval <= 0 {
+		reportInterval = time.Second
+	}
+	ticker := time.NewTicker(reportInterval)
+	defer ticker.Stop()
+
+	var reported int64
+	closing, stopping := c.closing, false
+	for {
+		// A nil channel is never ready, which holds the points back while disconnected
+		var points chan *write.Point
+		if c.connected.Load() {
+			points = c.points
+		} else if stopping {
+			return
+		}
+
+		select {
+		case point, ok := <-points:
+			if !ok {
+				return
+			}
+			c.writeAPI.WritePoint(point)
+		case <-closing:
+			closing, stopping = nil, true
+		case <-ticker.C:
+			if dropped := c.dropped.Load(); dropped > reported {
+				logger.GetGlobal().Warn("InfluxDB write buffer full, metrics dropped", "dropped", dropped-reported, "droppedTotal", dropped)
+				reported = dropped
+			}
+		}
+	}
+}
+
+// Ping checks InfluxDB is reachable and reports itself healthy
+func (c *Client) Ping(ctx context.Context) error {
+	health, err := c.client.Health(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to InfluxDB: %w", err)
+	}
+	if health.Status != "pass" {
+		return fmt.Errorf("InfluxDB health check failed: %s", health.Status)
+	}
+	return nil
+}
+
+// Dropped returns how many points were dropped because the buffer was full
+func (c *Client) Dropped() int64 {
+	return c.dropped.Load()
+}
+
+// Close sends the buffered points and closes the InfluxDB connection
 func (c *Client) Close() {
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.points)
+		close(c.closing)
+	}
+	c.mu.Unlock()
+
+	<-c.done
+	c.writeAPI.Flush()
 	c.client.Close()
 }
 
-// WritePoint writes a data point to InfluxDB
+// WritePoint queues a data point for InfluxDB. It never blocks; the point is dropped when
+// the buffer is full or the client is closed.
 func (c *Client) WritePoint(measurement string, tags map[string]string, fields map[string]interface{}, timestamp time.Time) error {
 	point := write.NewPoint(measurement, tags, fields, timestamp)
-	return c.writeAPI.WritePoint(context.Background(), point)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		c.dropped.Add(1)
+		return nil
+	}
+	select {
+	case c.points <- point:
+	default:
+		c.dropped.Add(1)
+	}
+	return nil
 }
 
 // WriteMetric writes a metric with tags and fields
@@ -1,7 +1,12 @@
 package jwt
 
 import (
+	"crypto"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -9,69 +14,251 @@ import (
 
 // Claims represents the JWT claims
 type Claims struct {
-	AccountID int64  `json:"account_id"`
-	Email     string `json:"email"`
-	Name      string `json:"name"`
+	AccountID  int64  `json:"account_id"`
+	Email      string `json:"email"`
+	Name       string `json:"name"`
+	Role       string `json:"role"`
+	IsVerified bool   `json:"is_verified"`
+	SessionID  int64  `json:"sid,omitempty"`
+	// TokenVersion is the account's token version at issue; older versions are rejected
+	TokenVersion int `json:"ver,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// Service handles JWT operations
+// Key is a signing key identified by the kid header of the tokens it signs. HMAC keys
+// have a Secret; asymmetric keys have a PublicKey and, for the signing key, a PrivateKey.
+type Key struct {
+	ID         string
+	Secret     []byte
+	PrivateKey crypto.Signer
+	PublicKey  crypto.PublicKey
+	RetiredAt  time.Time // zero for the signing key
+}
+
+// Service handles JWT operations. Tokens are signed with one key, and verified with
+// whichever key their kid names, so keys can be rotated without signing everyone out.
 type Service struct {
-	secretKey []byte
-	expiresIn time.Duration
+	signingKey Key
+	method     jwt.SigningMethod
+	keys       map[string]Key
+	opts       Options
+	parser     *jwt.Parser
+}
+
+// Options configures the lifetime and validation of tokens
+type Options struct {
+	ExpiresIn   time.Duration
+	GracePeriod time.Duration // how long after its retirement a previous key is accepted
+	Issuer      string        // set on issued tokens and required on validated ones; empty skips the check
+	Audience    string        // set on issued tokens and required on validated ones; empty skips the check
+	Leeway      time.Duration // clock skew tolerated when checking exp, nbf and iat
 }
 
-// NewService creates a new JWT service
-func NewService(secretKey string, expiresIn time.Duration) *Service {
+// Errors returned by ValidateToken; match them with errors.Is
+var (
+	ErrTokenMalformed   = errors.New("token is malformed")
+	ErrSignatureInvalid = errors.New("token signature is invalid")
+	ErrUnknownKey       = errors.New("token signing key is unknown")
+	ErrRetiredKey       = errors.New("token signing key has been retired")
+	ErrTokenExpired     = errors.New("token has expired")
+	ErrTokenNotYetValid = errors.New("token is not valid yet")
+	ErrInvalidIssuer    = errors.New("token issuer is not accepted")
+	ErrInvalidAudience  = errors.New("token audience is not accepted")
+)
+
+// NewService creates a new JWT service. Tokens signed with a previous key are accepted
+// until the grace period after the key was retired.
+func NewService(signingKey Key, previousKeys []Key, opts Options) (*Service, error) {
+	if signingKey.ID == "" {
+		return nil, fmt.Errorf("signing key must have an id")
+	}
+	if len(signingKey.Secret) == 0 && signingKey.PrivateKey == nil {
+		return nil, fmt.Errorf("signing key must have a secret or a private key")
+	}
+	method, err := signingKey.signingMethod()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]Key{signingKey.ID: signingKey}
+	for _, key := range previousKeys {
+		if key.ID == "" {
+			return nil, fmt.Errorf("previous key must have an id")
+		}
+		if _, err := key.algorithm(); err != nil {
+			return nil, err
+		}
+		if key.RetiredAt.IsZero() {
+			return nil, fmt.Errorf("previous key %q has no retirement time", key.ID)
+		}
+		if _, ok := keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id %q", key.ID)
+		}
+		keys[key.ID] = key
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(opts.Leeway),
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+
 	return &Service{
-		secretKey: []byte(secretKey),
-		expiresIn: expiresIn,
+		signingKey: signingKey,
+		method:     method,
+		keys:       keys,
+		opts:       opts,
+		parser:     jwt.NewParser(parserOpts...),
+	}, nil
+}
+
+// ParseKeys parses previous keys written as "kid@retired-at=secret", with the retirement
+// time in RFC 3339, e.g. "2026-09@2026-10-01T00:00:00Z=old-secret"
+func ParseKeys(specs []string) ([]Key, error) {
+	keys := make([]Key, 0, len(specs))
+	for _, spec := range specs {
+		head, secret, ok := strings.Cut(spec, "=")
+		if !ok || secret == "" {
+			return nil, fmt.Errorf("invalid key %q: expected kid@retired-at=secret", spec)
+		}
+		id, retired, ok := strings.Cut(head, "@")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid key %q: expected kid@retired-at=secret", spec)
+		}
+		retiredAt, err := time.Parse(time.RFC3339, retired)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retirement time of key %q: %w", id, err)
+		}
+		keys = append(keys, Key{ID: id, Secret: []byte(secret), RetiredAt: retiredAt})
 	}
+	return keys, nil
 }
 
-// GenerateToken creates a new JWT token for the given account
-func (s *Service) GenerateToken(accountID int64, email, name string) (string, error) {
+// GenerateToken creates a new JWT token for the given account. A positive maxTTL shortens the
+// configured lifetime, so a token never outlives a shorter session.
+func (s *Service) GenerateToken(accountID, sessionID int64, tokenVersion int, email, name, role string, isVerified bool, maxTTL time.Duration) (string, error) {
 	now := time.Now()
+
+	// A random jti lets a single token be revoked without affecting others issued in the same second
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate token id: %w", err)
+	}
+
 	claims := Claims{
-		AccountID: accountID,
-		Email:     email,
-		Name:      name,
+		AccountID:    accountID,
+		Email:        email,
+		Name:         name,
+		Role:         role,
+		IsVerified:   isVerified,
+		SessionID:    sessionID,
+		TokenVersion: tokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    "social-media-service",
+			Issuer:    s.opts.Issuer,
 			Subject:   fmt.Sprintf("%d", accountID),
-			Audience:  []string{"social-media-api"},
-			ExpiresAt: jwt.NewNumericDate(now.Add(s.expiresIn)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.TokenTTL(maxTTL))),
 			NotBefore: jwt.NewNumericDate(now),
 			IssuedAt:  jwt.NewNumericDate(now),
-			ID:        fmt.Sprintf("%d-%d", accountID, now.Unix()),
+			ID:        fmt.Sprintf("%d-%s", accountID, hex.EncodeToString(id)),
 		},
 	}
+	if s.opts.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{s.opts.Audience}
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.secretKey)
+	token := jwt.NewWithClaims(s.method, claims)
+	token.Header["kid"] = s.signingKey.ID
+	if s.signingKey.PrivateKey != nil {
+		return token.SignedString(s.signingKey.PrivateKey)
+	}
+	return token.SignedString(s.signingKey.Secret)
 }
 
-// ValidateToken validates and parses a JWT token
+// ValidateToken validates and parses a JWT token. Rejections are reported as one of the
+// Err* errors of this package.
 func (s *Service) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.secretKey, nil
-	})
+	token, err := s.parser.ParseWithClaims(tokenString, &Claims{}, s.verificationKey)
 
 	if err != nil {
-		return nil, err
+		return nil, validationError(err)
 	}
 
 	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
 		return claims, nil
 	}
 
-	return nil, fmt.Errorf("invalid token")
+	return nil, ErrTokenMalformed
+}
+
+// validationError maps a parser error to the matching error of this package
+func validationError(err error) error {
+	for _, known := range []error{ErrUnknownKey, ErrRetiredKey, ErrSignatureInvalid} {
+		if errors.Is(err, known) {
+			return known
+		}
+	}
+
+	switch {
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		return ErrSignatureInvalid
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return ErrTokenNotYetValid
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return ErrInvalidIssuer
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return ErrInvalidAudience
+	}
+	return ErrTokenMalformed
+}
+
+// verificationKey selects the key named by the token's kid. Tokens issued before kids were
+// added have none and belong to the signing key's ID. The token's alg must be the key's own,
+// so a public key can never be used as an HMAC secret.
+func (s *Service) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		kid = s.signingKey.ID
+	}
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	if !key.usable(time.Now(), s.opts.GracePeriod) {
+		return nil, ErrRetiredKey
+	}
+
+	alg, err := key.algorithm()
+	if err != nil {
+		return nil, err
+	}
+	if token.Method.Alg() != alg {
+		return nil, ErrSignatureInvalid
+	}
+
+	if len(key.Secret) > 0 {
+		return key.Secret, nil
+	}
+	return key.PublicKey, nil
+}
+
+// TokenTTL returns the lifetime of an access token limited to maxTTL when that is positive
+func (s *Service) TokenTTL(maxTTL time.Duration) time.Duration {
+	if maxTTL > 0 && maxTTL < s.opts.ExpiresIn {
+		return maxTTL
+	}
+	return s.opts.ExpiresIn
 }
 
 // GetExpiresInSeconds returns the expiration time in seconds
 func (s *Service) GetExpiresInSeconds() int64 {
-	return int64(s.expiresIn.Seconds())
+	return int64(s.opts.ExpiresIn.Seconds())
 }
@@ -0,0 +1,223 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/infrastructure/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// KeysFromConfig builds the signing key and the previous keys still accepted for verification.
+// HS256 signs with JWT_SECRET; RS256 and EdDSA sign with a PEM private key from a file or the
+// environment, so other services can verify tokens with the published public key.
+func KeysFromConfig(cfg *config.JWTConfig) (Key, []Key, error) {
+	signingKey := Key{ID: cfg.KeyID}
+	switch cfg.Algorithm {
+	case AlgorithmHS256:
+		signingKey.Secret = []byte(cfg.Secret)
+	case AlgorithmRS256, AlgorithmEdDSA:
+		data := []byte(strings.ReplaceAll(cfg.PrivateKey, `\n`, "\n"))
+		if cfg.PrivateKeyFile != "" {
+			var err error
+			if data, err = os.ReadFile(cfg.PrivateKeyFile); err != nil {
+				return Key{}, nil, fmt.Errorf("failed to read private key: %w", err)
+			}
+		}
+		privateKey, err := parsePrivateKeyPEM(data)
+		if err != nil {
+			return Key{}, nil, err
+		}
+		signingKey.PrivateKey = privateKey
+		signingKey.PublicKey = privateKey.Public()
+		if alg, _ := signingKey.algorithm(); alg != cfg.Algorithm {
+			return Key{}, nil, fmt.Errorf("private key does not match algorithm %s", cfg.Algorithm)
+		}
+	default:
+		return Key{}, nil, fmt.Errorf("unsupported signing algorithm: %s", cfg.Algorithm)
+	}
+
+	previousKeys, err := ParseKeys(cfg.PreviousKeys)
+	if err != nil {
+		return Key{}, nil, err
+	}
+
+	// Previous public keys reuse the "kid@retired-at=value" format with a PEM file path as value
+	publicKeys, err := ParseKeys(cfg.PreviousPublicKeys)
+	if err != nil {
+		return Key{}, nil, err
+	}
+	for _, key := range publicKeys {
+		data, err := os.ReadFile(string(key.Secret))
+		if err != nil {
+			return Key{}, nil, fmt.Errorf("failed to read public key %q: %w", key.ID, err)
+		}
+		publicKey, err := parsePublicKeyPEM(data)
+		if err != nil {
+			return Key{}, nil, fmt.Errorf("invalid public key %q: %w", key.ID, err)
+		}
+		previousKeys = append(previousKeys, Key{ID: key.ID, PublicKey: publicKey, RetiredAt: key.RetiredAt})
+	}
+
+	return signingKey, previousKeys, nil
+}
+
+// parsePrivateKeyPEM decodes a PKCS #1 RSA or PKCS #8 RSA/Ed25519 private key
+func parsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("private key is not PEM encoded")
+	}
+
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, nil
+	case ed25519.PrivateKey:
+		return k, nil
+	}
+	return nil, fmt.Errorf("unsupported private key type %T", key)
+}
+
+// parsePublicKeyPEM decodes a PKCS #1 RSA or PKIX RSA/Ed25519 public key
+func parsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("public key is not PEM encoded")
+	}
+
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return k, nil
+	case ed25519.PublicKey:
+		return k, nil
+	}
+	return nil, fmt.Errorf("unsupported public key type %T", key)
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA exponent
+	Curve     string `json:"crv,omitempty"` // OKP curve
+	X         string `json:"x,omitempty"`   // OKP public key
+}
+
+// JWKS is a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys that currently verify tokens. HMAC keys are secret
+// and never published.
+func (s *Service) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	now := time.Now()
+	for _, key := range s.keys {
+		if key.PublicKey == nil || !key.usable(now, s.opts.GracePeriod) {
+			continue
+		}
+		set.Keys = append(set.Keys, key.jwk())
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyID < set.Keys[j].KeyID })
+	return set
+}
+
+// JWKSHandler serves the key set at /.well-known/jwks.json
+func (s *Service) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		json.NewEncoder(w).Encode(s.JWKS())
+	})
+}
+
+// jwk encodes the public half of an asymmetric key
+func (k Key) jwk() JWK {
+	enc := base64.RawURLEncoding
+	switch pub := k.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			KeyType:   "RSA",
+			KeyID:     k.ID,
+			Use:       "sig",
+			Algorithm: AlgorithmRS256,
+			N:         enc.EncodeToString(pub.N.Bytes()),
+			E:         enc.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return JWK{
+			KeyType:   "OKP",
+			KeyID:     k.ID,
+			Use:       "sig",
+			Algorithm: AlgorithmEdDSA,
+			Curve:     "Ed25519",
+			X:         enc.EncodeToString(pub),
+		}
+	}
+	return JWK{KeyID: k.ID}
+}
+
+// algorithm returns the JWT algorithm the key signs with
+func (k Key) algorithm() (string, error) {
+	if len(k.Secret) > 0 {
+		return AlgorithmHS256, nil
+	}
+	switch k.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return AlgorithmRS256, nil
+	case ed25519.PublicKey:
+		return AlgorithmEdDSA, nil
+	}
+	return "", fmt.Errorf("key %q has no secret or supported public key", k.ID)
+}
+
+// signingMethod maps the key's algorithm to its JWT signing method
+func (k Key) signingMethod() (jwt.SigningMethod, error) {
+	alg, err := k.algorithm()
+	if err != nil {
+		return nil, err
+	}
+	return jwt.GetSigningMethod(alg), nil
+}
+
+// usable reports whether a token signed with the key is still accepted
+func (k Key) usable(now time.Time, gracePeriod time.Duration) bool {
+	return k.RetiredAt.IsZero() || !now.After(k.RetiredAt.Add(gracePeriod))
+}
@@ -2,10 +2,15 @@ package logger
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/fanzru/social-media-service-go/pkg/reqctx"
 )
@@ -23,6 +28,8 @@ const (
 // Logger wraps slog.Logger with additional functionality
 type Logger struct {
 	*slog.Logger
+	detailLevel slog.Level
+	redactor    *Redactor
 }
 
 // Config holds logger configuration
@@ -30,14 +37,36 @@ type Config struct {
 	Level  LogLevel
 	Output io.Writer
 	Format string // "json" or "text"
+	// DetailLevel is the level at which Detail arguments, such as request bodies and SQL
+	// arguments, are included; records below it are written without them
+	DetailLevel LogLevel
+	// MaxValueBytes truncates longer string and raw JSON values; 0 keeps them whole
+	MaxValueBytes int
+	// RedactFields are the JSON body fields and query parameters masked in logs, and
+	// RedactArgs the kinds of SQL arguments masked, see Redactor
+	RedactFields []string
+	RedactArgs   []string
+	// SampleThereafter, when positive, samples records below WARN by message: within each
+	// SampleInterval the first SampleInitial records of a message are written, then 1 in
+	// SampleThereafter. Warnings and errors are always written.
+	SampleInitial    int
+	SampleThereafter int
+	SampleInterval   time.Duration
 }
 
 // DefaultConfig returns default logger configuration
 func DefaultConfig() *Config {
 	return &Config{
-		Level:  LevelInfo,
-		Output: os.Stdout,
-		Format: "json",
+		Level:         LevelInfo,
+		Output:        os.Stdout,
+		Format:        "json",
+		DetailLevel:   LevelInfo,
+		MaxValueBytes: 4096,
+		RedactFields:  DefaultRedactFields,
+		RedactArgs:    DefaultRedactArgs,
+
+		SampleInitial:  100,
+		SampleInterval: time.Second,
 	}
 }
 
@@ -75,6 +104,10 @@ func New(config *Config) *Logger {
 					Value: a.Value,
 				}
 			}
+			// Values are resolved by now, so lazy arguments are truncated once materialized
+			if len(groups) == 0 {
+				a.Value = limitValue(a.Value, config.MaxValueBytes, config.Format != "json")
+			}
 			return a
 		},
 	}
@@ -84,9 +117,14 @@ func New(config *Config) *Logger {
 	} else {
 		handler = slog.NewTextHandler(config.Output, opts)
 	}
+	if config.SampleThereafter > 0 {
+		handler = newSamplingHandler(handler, config.SampleInitial, config.SampleThereafter, config.SampleInterval)
+	}
 
 	return &Logger{
-		Logger: slog.New(handler),
+		Logger:      slog.New(handler),
+		detailLevel: parseLevel(config.DetailLevel),
+		redactor:    NewRedactor(config.RedactFields, config.RedactArgs),
 	}
 }
 
@@ -104,16 +142,136 @@ func NewFromEnv() *Logger {
 		config.Format = format
 	}
 
+	// Parse the level of detail arguments and the value size limit from environment
+	if level := os.Getenv("LOG_DETAIL_LEVEL"); level != "" {
+		config.DetailLevel = LogLevel(level)
+	}
+	if maxBytes, err := strconv.Atoi(os.Getenv("LOG_MAX_VALUE_BYTES")); err == nil && maxBytes >= 0 {
+		config.MaxValueBytes = maxBytes
+	}
+
+	// Parse what is masked from environment; an empty list masks nothing
+	if fields, ok := os.LookupEnv("LOG_REDACT_FIELDS"); ok {
+		config.RedactFields = splitList(fields)
+	}
+	if args, ok := os.LookupEnv("LOG_REDACT_ARGS"); ok {
+		config.RedactArgs = splitList(args)
+	}
+
+	// Parse log sampling from environment
+	if initial, err := strconv.Atoi(os.Getenv("LOG_SAMPLE_INITIAL")); err == nil && initial >= 0 {
+		config.SampleInitial = initial
+	}
+	if thereafter, err := strconv.Atoi(os.Getenv("LOG_SAMPLE_THEREAFTER")); err == nil && thereafter >= 0 {
+		config.SampleThereafter = thereafter
+	}
+	if interval, err := time.ParseDuration(os.Getenv("LOG_SAMPLE_INTERVAL")); err == nil && interval > 0 {
+		config.SampleInterval = interval
+	}
+
 	return New(config)
 }
 
-// WithRequestID adds request ID to the logger context
+// splitList splits a comma-separated list, dropping empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// lazyValue is a log argument computed when a record holding it is written
+type lazyValue struct {
+	compute func() interface{}
+	enabled func() bool // Reports whether the argument is included; nil always includes it
+}
+
+// LogValue computes the argument, or returns an empty group, which handlers leave out,
+// when it is not included
+func (v lazyValue) LogValue() slog.Value {
+	if v.enabled != nil && !v.enabled() {
+		return slog.GroupValue()
+	}
+	return slog.AnyValue(v.compute())
+}
+
+// Lazy defers computing an argument until a record holding it is written, so nothing is
+// computed for records below the logger's level, e.g. a cleaned SQL query in a debug record
+func Lazy(compute func() interface{}) slog.LogValuer {
+	return lazyValue{compute: compute}
+}
+
+// Detail defers computing an expensive argument, such as a request body or SQL arguments,
+// until a record holding it is written, and leaves it out unless the logger's detail level
+// is enabled. Long values are truncated to the configured size.
+func (l *Logger) Detail(compute func() interface{}) slog.LogValuer {
+	return lazyValue{compute: compute, enabled: l.DetailEnabled}
+}
+
+// Redactor returns what the logger masks, for callers logging request bodies, query
+// strings or SQL arguments
+func (l *Logger) Redactor() *Redactor {
+	if l.redactor == nil {
+		return &Redactor{}
+	}
+	return l.redactor
+}
+
+// DetailEnabled reports whether Detail arguments are included, so callers can skip
+// collecting them, e.g. buffering a request body, when they would be left out
+func (l *Logger) DetailEnabled() bool {
+	return l.Logger.Enabled(context.Background(), l.detailLevel)
+}
+
+// limitValue shortens string and raw JSON values longer than maxBytes, when it is
+// positive, noting how many bytes were cut. Raw JSON is embedded as is in JSON records;
+// it becomes a string when truncated, as it is no longer valid, and in text records.
+func limitValue(v slog.Value, maxBytes int, rawAsString bool) slog.Value {
+	var s string
+	switch v.Kind() {
+	case slog.KindString:
+		s = v.String()
+	case slog.KindAny:
+		raw, ok := v.Any().(json.RawMessage)
+		if !ok {
+			return v
+		}
+		s = string(raw)
+	default:
+		return v
+	}
+	if maxBytes <= 0 || len(s) <= maxBytes {
+		if rawAsString && v.Kind() == slog.KindAny {
+			return slog.StringValue(s)
+		}
+		return v
+	}
+
+	// Cut at a character boundary
+	n := maxBytes
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return slog.StringValue(fmt.Sprintf("%s...[%d bytes truncated]", s[:n], len(s)-n))
+}
+
+// WithRequestID adds request ID, and the trace and span IDs of a traced request, to the
+// logger context
 func (l *Logger) WithRequestID(ctx context.Context) *slog.Logger {
-	requestID := reqctx.GetRequestID(ctx)
-	if requestID != "" {
-		return l.Logger.With("requestId", requestID)
+	var args []interface{}
+	if requestID := reqctx.GetRequestID(ctx); requestID != "" {
+		args = append(args, "requestId", requestID)
+	}
+	if trace, ok := reqctx.GetTrace(ctx); ok {
+		args = append(args, "traceId", trace.TraceID, "spanId", trace.SpanID)
 	}
-	return l.Logger
+	if len(args) == 0 {
+		return l.Logger
+	}
+	return l.Logger.With(args...)
 }
 
 // WithFields creates a new logger with additional fields
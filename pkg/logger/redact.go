@@ -0,0 +1,162 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the values masked in log records
+const Redacted = "[REDACTED]"
+
+// Kinds of query arguments a Redactor can mask
+const (
+	RedactHash  = "hash"  // password hashes (bcrypt, argon2) and long hex digests, e.g. of tokens
+	RedactEmail = "email" // values holding an email address
+	RedactJWT   = "jwt"   // signed tokens
+)
+
+var (
+	// DefaultRedactFields are the JSON body and query parameter names masked by default
+	DefaultRedactFields = []string{"password", "token", "secret", "api_key", "private_key", "code", "email"}
+	// DefaultRedactArgs are the kinds of query arguments masked by default
+	DefaultRedactArgs = []string{RedactHash, RedactEmail, RedactJWT}
+)
+
+// argPatterns match the query arguments of each kind
+var argPatterns = map[string]*regexp.Regexp{
+	RedactHash:  regexp.MustCompile(`^(\$2[abxy]?\$\d\d\$|\$argon2(id|i|d)\$)|^[0-9a-fA-F]{40,}$`),
+	RedactEmail: regexp.MustCompile(`[^\s@]+@[^\s@]+\.[^\s@]+`),
+	RedactJWT:   regexp.MustCompile(`^eyJ[\w-]*\.[\w-]+\.[\w-]*$`),
+}
+
+// Redactor masks sensitive values before they are logged: JSON body fields and query
+// parameters by name, and SQL arguments by what they look like, since their names are
+// not known. A zero Redactor masks nothing.
+type Redactor struct {
+	fields []string         // Normalized names; a name ending with one of them is masked
+	args   []*regexp.Regexp // Patterns of the masked argument kinds
+}
+
+// NewRedactor creates a redactor masking the given fields and argument kinds; unknown
+// kinds are ignored
+func NewRedactor(fields, args []string) *Redactor {
+	r := &Redactor{}
+	for _, f := range fields {
+		if f = normalizeField(f); f != "" {
+			r.fields = append(r.fields, f)
+		}
+	}
+	for _, kind := range args {
+		if re, ok := argPatterns[strings.ToLower(strings.TrimSpace(kind))]; ok {
+			r.args = append(r.args, re)
+		}
+	}
+	return r
+}
+
+// normalizeField lowercases a name and drops separators, so password, new_password and
+// newPassword compare alike
+func normalizeField(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer("_", "", "-", "").Replace(name)
+}
+
+// Field reports whether values named name are masked, e.g. current_password for password
+func (r *Redactor) Field(name string) bool {
+	name = normalizeField(name)
+	for _, f := range r.fields {
+		if strings.HasSuffix(name, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// JSON returns body with the values of masked fields replaced, at any depth. A body that
+// is not valid JSON is returned as is.
+func (r *Redactor) JSON(body []byte) json.RawMessage {
+	if len(r.fields) == 0 {
+		return body
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+	masked, err := json.Marshal(r.value(v))
+	if err != nil {
+		return body
+	}
+	return masked
+}
+
+// value masks the fields of a decoded JSON value
+func (r *Redactor) value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if r.Field(k) {
+				v[k] = Redacted
+			} else {
+				v[k] = r.value(field)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = r.value(v[i])
+		}
+	}
+	return v
+}
+
+// Query returns a raw URL query with the values of masked parameters replaced, keeping
+// the order of the parameters
+func (r *Redactor) Query(rawQuery string) string {
+	if rawQuery == "" || len(r.fields) == 0 {
+		return rawQuery
+	}
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		key, _, _ := strings.Cut(param, "=")
+		if name, err := url.QueryUnescape(key); err == nil && r.Field(name) {
+			params[i] = key + "=" + Redacted
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// Args returns a copy of SQL arguments with those of a masked kind replaced
+func (r *Redactor) Args(args []interface{}) []interface{} {
+	if len(r.args) == 0 {
+		return args
+	}
+	masked := make([]interface{}, len(args))
+	for i, arg := range args {
+		masked[i] = arg
+		var s string
+		switch a := arg.(type) {
+		case string:
+			s = a
+		case *string:
+			if a == nil {
+				continue
+			}
+			s = *a
+		case []byte:
+			s = string(a)
+		default:
+			continue
+		}
+		for _, re := range r.args {
+			if re.MatchString(s) {
+				masked[i] = Redacted
+				break
+			}
+		}
+	}
+	return masked
+}
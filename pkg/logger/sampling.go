@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// sampler decides which records of a message are written: within each interval the first
+// initial records of a message, then every thereafter-th one. It is shared by the
+// handlers derived with WithAttrs and WithGroup, so they count together.
+type sampler struct {
+	initial    int
+	thereafter int
+	interval   time.Duration
+
+	mu          sync.Mutex
+	windowStart time.Time
+	counts      map[string]int // Records of each message seen in the current interval
+}
+
+// allow counts a record with the given key and reports whether it is written
+func (s *sampler) allow(key string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Start counting afresh each interval, which also forgets messages no longer logged
+	if now.Sub(s.windowStart) >= s.interval {
+		s.windowStart = now
+		s.counts = make(map[string]int, len(s.counts))
+	}
+	s.counts[key]++
+	n := s.counts[key]
+	if n <= s.initial {
+		return true
+	}
+	return (n-s.initial)%s.thereafter == 0
+}
+
+// samplingHandler drops records below WARN that the sampler rejects, before their
+// arguments are resolved; warnings and errors are always written
+type samplingHandler struct {
+	next    slog.Handler
+	sampler *sampler
+}
+
+// newSamplingHandler wraps next to write, per message and level, the first initial
+// records of each interval and then 1 in thereafter
+func newSamplingHandler(next slog.Handler, initial, thereafter int, interval time.Duration) slog.Handler {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return &samplingHandler{
+		next: next,
+		sampler: &sampler{
+			initial:    initial,
+			thereafter: thereafter,
+			interval:   interval,
+			counts:     make(map[string]int),
+		},
+	}
+}
+
+func (h *samplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *samplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < slog.LevelWarn && !h.sampler.allow(r.Level.String()+" "+r.Message, r.Time) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{next: h.next.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{next: h.next.WithGroup(name), sampler: h.sampler}
+}
@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/fanzru/social-media-service-go/infrastructure/config"
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+)
+
+// Mailer sends plain text emails
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// NewMailer creates an SMTP mailer, or a mailer that only logs messages when no SMTP host is configured
+func NewMailer(cfg *config.MailConfig) Mailer {
+	if cfg.SMTPHost == "" {
+		return &logMailer{}
+	}
+	return &smtpMailer{cfg: cfg}
+}
+
+// smtpMailer delivers emails through an SMTP server
+type smtpMailer struct {
+	cfg *config.MailConfig
+}
+
+// Send delivers a single email
+func (m *smtpMailer) Send(ctx context.Context, to, subject, body string) error {
+	addr := fmt.Sprintf("%s:%d", m.cfg.SMTPHost, m.cfg.SMTPPort)
+
+	var auth smtp.Auth
+	if m.cfg.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", m.cfg.SMTPUsername, m.cfg.SMTPPassword, m.cfg.SMTPHost)
+	}
+
+	msg := strings.Join([]string{
+		"From: " + m.cfg.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	if err := smtp.SendMail(addr, auth, m.cfg.From, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// logMailer writes emails to the log instead of sending them; used for local development
+type logMailer struct{}
+
+// Send logs the email
+func (m *logMailer) Send(ctx context.Context, to, subject, body string) error {
+	logger.GetGlobal().InfoWithContext(ctx, "Email not sent, SMTP is not configured",
+		"to", to,
+		"subject", subject,
+		"body", body,
+	)
+	return nil
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Connections counts the connections of an HTTP server by state. Set ConnState as the
+// server's ConnState hook and Report the counts as gauges, e.g. to see whether clients
+// reuse keep-alive connections or open new ones.
+type Connections struct {
+	mu     sync.Mutex
+	states map[net.Conn]http.ConnState // Open connections; hijacked ones, e.g. WebSockets, are left out
+	total  int64                       // Connections accepted since start
+}
+
+// NewConnections creates an empty connection counter
+func NewConnections() *Connections {
+	return &Connections{states: make(map[net.Conn]http.ConnState)}
+}
+
+// ConnState tracks a connection changing state, as http.Server.ConnState
+func (c *Connections) ConnState(conn net.Conn, state http.ConnState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch state {
+	case http.StateNew:
+		c.total++
+		c.states[conn] = state
+	case http.StateClosed, http.StateHijacked:
+		delete(c.states, conn)
+	default:
+		c.states[conn] = state
+	}
+}
+
+// Counts returns the number of open connections in each state, keyed by the state's name
+// (new, active or idle), and the number of connections accepted since start
+func (c *Connections) Counts() (map[string]int64, int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := map[string]int64{
+		http.StateNew.String():    0,
+		http.StateActive.String(): 0,
+		http.StateIdle.String():   0,
+	}
+	for _, state := range c.states {
+		counts[state.String()]++
+	}
+	return counts, c.total
+}
+
+// Open returns the number of open connections, whatever their state
+func (c *Connections) Open() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.states)
+}
+
+// Report writes the connection counts to sink every interval until ctx is done:
+// http_connections by state, and http_connections_accepted_total
+func (c *Connections) Report(ctx context.Context, sink Sink, interval time.Duration) {
+	if sink == nil || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		counts, total := c.Counts()
+		for state, count := range counts {
+			_ = sink.WriteGauge("http_connections", map[string]string{"group": "HTTP", "state": state}, float64(count))
+		}
+		_ = sink.WriteGauge("http_connections_accepted_total", map[string]string{"group": "HTTP"}, float64(total))
+	}
+}
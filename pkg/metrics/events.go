@@ -0,0 +1,24 @@
+package metrics
+
+// Business events counted by the app services, so dashboards can follow the product next
+// to the HTTP and database metrics
+const (
+	PostsCreated    = "posts_created_total"
+	CommentsCreated = "comments_created_total"
+	Registrations   = "registrations_total" // Tagged with the method, password or oauth:<provider>
+	LoginsFailed    = "logins_failed_total" // Tagged with the reason, invalid_credentials or locked
+)
+
+// CountEvent adds one to the counter of a business event on sink, which may be nil. Like
+// the connection metrics, a failed write is dropped rather than failing the operation
+// counted.
+func CountEvent(sink Sink, name string, tags map[string]string) {
+	if sink == nil {
+		return
+	}
+	eventTags := map[string]string{"group": "BUSINESS"}
+	for k, v := range tags {
+		eventTags[k] = v
+	}
+	_ = sink.WriteCounter(name, eventTags, 1)
+}
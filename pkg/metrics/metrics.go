@@ -0,0 +1,65 @@
+// Package metrics abstracts where HTTP and database metrics are sent, so InfluxDB, StatsD,
+// Prometheus or any mix of them can receive them.
+package metrics
+
+import (
+	"errors"
+	"time"
+)
+
+// Sink receives metrics. *influxdb.Client, *statsd.Client and *prometheus.Sink
+// implement it, and Multi combines them.
+type Sink interface {
+	WriteCounter(name string, tags map[string]string, value int64) error
+	WriteTiming(name string, tags map[string]string, duration time.Duration) error
+	WriteGauge(name string, tags map[string]string, value float64) error
+}
+
+// multi sends every metric to each of its sinks
+type multi []Sink
+
+// Multi returns a sink sending every metric to each of sinks, skipping nil ones. It
+// returns nil when no sink is left, so callers can skip recording metrics altogether.
+func Multi(sinks ...Sink) Sink {
+	var m multi
+	for _, s := range sinks {
+		if s != nil {
+			m = append(m, s)
+		}
+	}
+
+	switch len(m) {
+	case 0:
+		return nil
+	case 1:
+		return m[0]
+	}
+	return m
+}
+
+// WriteCounter writes a counter metric to each sink
+func (m multi) WriteCounter(name string, tags map[string]string, value int64) error {
+	var errs []error
+	for _, s := range m {
+		errs = append(errs, s.WriteCounter(name, tags, value))
+	}
+	return errors.Join(errs...)
+}
+
+// WriteTiming writes a timing metric to each sink
+func (m multi) WriteTiming(name string, tags map[string]string, duration time.Duration) error {
+	var errs []error
+	for _, s := range m {
+		errs = append(errs, s.WriteTiming(name, tags, duration))
+	}
+	return errors.Join(errs...)
+}
+
+// WriteGauge writes a gauge metric to each sink
+func (m multi) WriteGauge(name string, tags map[string]string, value float64) error {
+	var errs []error
+	for _, s := range m {
+		errs = append(errs, s.WriteGauge(name, tags, value))
+	}
+	return errors.Join(errs...)
+}
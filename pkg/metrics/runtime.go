@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"context"
+	"runtime"
+	"time"
+)
+
+// ReportRuntime writes Go runtime statistics to sink every interval until ctx is done:
+// the runtime_goroutines gauge, heap gauges runtime_heap_alloc_bytes,
+// runtime_heap_inuse_bytes, runtime_heap_sys_bytes and runtime_heap_objects, the running
+// total runtime_gc_count, and a runtime_gc_pause_ms timing for each garbage collection
+// since the last report.
+func ReportRuntime(ctx context.Context, sink Sink, interval time.Duration) {
+	if sink == nil || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	tags := map[string]string{"group": "RUNTIME"}
+	var stats runtime.MemStats
+	var lastGC uint32
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		runtime.ReadMemStats(&stats)
+		_ = sink.WriteGauge("runtime_goroutines", tags, float64(runtime.NumGoroutine()))
+		_ = sink.WriteGauge("runtime_heap_alloc_bytes", tags, float64(stats.HeapAlloc))
+		_ = sink.WriteGauge("runtime_heap_inuse_bytes", tags, float64(stats.HeapInuse))
+		_ = sink.WriteGauge("runtime_heap_sys_bytes", tags, float64(stats.HeapSys))
+		_ = sink.WriteGauge("runtime_heap_objects", tags, float64(stats.HeapObjects))
+		_ = sink.WriteGauge("runtime_gc_count", tags, float64(stats.NumGC))
+
+		// PauseNs holds the last 256 pauses, the one of collection n at (n+255)%256; older
+		// ones have been overwritten
+		first := lastGC
+		if stats.NumGC-first > uint32(len(stats.PauseNs)) {
+			first = stats.NumGC - uint32(len(stats.PauseNs))
+		}
+		for n := first + 1; n <= stats.NumGC; n++ {
+			pause := time.Duration(stats.PauseNs[(n+255)%256])
+			_ = sink.WriteTiming("runtime_gc_pause_ms", tags, pause)
+		}
+		lastGC = stats.NumGC
+	}
+}
@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/reqctx"
+)
+
+// maxAuditSnapshotBytes is the largest before or after snapshot kept; larger ones are
+// left out of the entry
+const maxAuditSnapshotBytes = 64 << 10
+
+// AuditEntry is a successful mutating API call
+type AuditEntry struct {
+	ActorID   *int64 // Authenticated caller; nil for anonymous calls such as registration
+	Method    string
+	Path      string
+	Route     string // Path with IDs replaced by {id}, e.g. /api/posts/{id}/pin
+	Entity    string // Resource changed, e.g. posts or account
+	EntityID  *int64 // ID in the path, or of the created resource
+	Status    int
+	Before    json.RawMessage // Resource as a GET returned it before the call
+	After     json.RawMessage // Data of the call's response
+	RequestID string
+	CreatedAt time.Time
+}
+
+// AuditRecorder stores audit entries. Record is called as the request completes, so it
+// should hand the entry off rather than write it in the request's time.
+type AuditRecorder interface {
+	Record(ctx context.Context, entry AuditEntry)
+}
+
+// Audit records every successful POST, PUT, PATCH and DELETE to recorder, with who made
+// it and snapshots of the resource. The before snapshot is the data a GET of the
+// resource path, the path up to its ID, returns to the caller just before the call;
+// the after snapshot is the data of the call's response. Fields masked in logs, such as
+// passwords and tokens, are masked in snapshots too. It must run after AuthMiddleware,
+// and inside IdempotencyMiddleware so replayed responses are not recorded twice.
+func Audit(recorder AuditRecorder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isMutation(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+			entry := AuditEntry{
+				Method:    r.Method,
+				Path:      r.URL.Path,
+				Route:     normalizePath(r.URL.Path),
+				Entity:    auditEntity(r.URL.Path),
+				RequestID: reqctx.GetRequestID(ctx),
+			}
+			resourcePath, entityID := auditResource(r.URL.Path)
+			entry.EntityID = entityID
+			if r.Method != http.MethodPost {
+				entry.Before = snapshot(next, r, resourcePath)
+			}
+
+			rec := &recordingResponseWriter{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+
+			if rec.status != 0 && (rec.status < 200 || rec.status >= 300) {
+				return
+			}
+			entry.Status = rec.status
+			if entry.Status == 0 {
+				entry.Status = http.StatusOK
+			}
+			entry.After = responseData(rec.body.Bytes())
+			if entry.EntityID == nil {
+				entry.EntityID = createdID(entry.After)
+			}
+			if userID, ok := GetUserID(ctx); ok && userID != 0 {
+				entry.ActorID = &userID
+			}
+			entry.CreatedAt = time.Now()
+
+			recorder.Record(ctx, entry)
+		})
+	}
+}
+
+// isMutation reports whether requests of a method change state
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// auditEntity returns the resource a path belongs to: its first segment after /api/,
+// skipping the admin and moderation prefixes
+func auditEntity(p string) string {
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(p, "/api/"), "/"), "/")
+	for _, segment := range segments {
+		if segment != "admin" && segment != "moderation" && segment != "" {
+			return segment
+		}
+	}
+	return "unknown"
+}
+
+// auditResource returns the path of the resource a mutation changes, the path up to and
+// including its first numeric segment, and that segment as the entity ID. Paths without
+// an ID, e.g. /api/account/settings, are their own resource.
+func auditResource(p string) (string, *int64) {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		if id, err := strconv.ParseInt(segment, 10, 64); err == nil {
+			return strings.Join(segments[:i+1], "/"), &id
+		}
+	}
+	return p, nil
+}
+
+// snapshot returns the data a GET of path returns to the caller of r, or nil when the
+// path cannot be read
+func snapshot(next http.Handler, r *http.Request, path string) json.RawMessage {
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, path, nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("Accept", "application/json")
+	if lang := r.Header.Get("Accept-Language"); lang != "" {
+		req.Header.Set("Accept-Language", lang)
+	}
+
+	rec := &recordingResponseWriter{ResponseWriter: discardResponseWriter{header: make(http.Header)}}
+	next.ServeHTTP(rec, req)
+	if rec.status != 0 && rec.status != http.StatusOK {
+		return nil
+	}
+	return responseData(rec.body.Bytes())
+}
+
+// responseData returns the data of a standard response body, with the fields masked in
+// logs masked, or nil when there is none or it is too large to keep
+func responseData(body []byte) json.RawMessage {
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil
+	}
+	data := envelope.Data
+	if len(data) == 0 || string(data) == "null" || len(data) > maxAuditSnapshotBytes {
+		return nil
+	}
+	return logger.GetGlobal().Redactor().JSON(data)
+}
+
+// createdID returns the id field of a created resource's data, if any
+func createdID(data json.RawMessage) *int64 {
+	var resource struct {
+		ID *int64 `json:"id"`
+	}
+	if len(data) == 0 || json.Unmarshal(data, &resource) != nil {
+		return nil
+	}
+	return resource.ID
+}
+
+// discardResponseWriter drops what is written, for internal requests whose response is
+// only recorded
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w discardResponseWriter) Header() http.Header         { return w.header }
+func (w discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w discardResponseWriter) WriteHeader(int)             {}
@@ -2,9 +2,11 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/fanzru/social-media-service-go/pkg/jwt"
 	"github.com/fanzru/social-media-service-go/pkg/logger"
@@ -12,20 +14,68 @@ import (
 	"github.com/fanzru/social-media-service-go/pkg/response"
 )
 
+// TokenDenylist reports whether an access token, its session or every token of its account
+// has been revoked before expiry
+type TokenDenylist interface {
+	IsTokenRevoked(ctx context.Context, tokenID string, sessionID, accountID int64, tokenVersion int) (bool, error)
+	// TouchSession records activity on a session
+	TouchSession(ctx context.Context, sessionID int64, ipAddress string) error
+}
+
+// APIKeyPrincipal is the account, scopes and rate limit an API key authenticates as
+type APIKeyPrincipal struct {
+	KeyID      int64
+	AccountID  int64
+	Email      string
+	Name       string
+	Role       string
+	IsVerified bool
+	Scopes     []string
+	RateLimit  int // requests per minute
+}
+
+// APIKeyAuthenticator resolves keys sent in the X-API-Key header. It returns a nil
+// principal for unknown, expired or revoked keys.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*APIKeyPrincipal, error)
+}
+
+// Security events recorded by the auth middleware
+const (
+	SecurityEventRevokedToken      = "revoked_token_use"    // A revoked access token was presented
+	SecurityEventAPIKeyScopeDenied = "api_key_scope_denied" // An API key was used beyond its scopes
+)
+
+// SecurityAuditor records security relevant events in the account's security log
+type SecurityAuditor interface {
+	RecordSecurityEvent(ctx context.Context, accountID int64, eventType, details string)
+}
+
 // AuthMiddleware handles authentication based on OpenAPI spec security requirements
 type AuthMiddleware struct {
 	jwtService *jwt.Service
+	denylist   TokenDenylist
+	apiKeys    APIKeyAuthenticator
+	auditor    SecurityAuditor
+	limiter    *rateLimiter
 	// Map of path patterns to their security requirements
 	// Key: HTTP method + path pattern (e.g., "GET /api/account/profile")
 	// Value: whether authentication is required
 	securityMap map[string]bool
+	// Routes API keys are refused on, keyed like securityMap
+	keyDenylist map[string]bool
 }
 
 // NewAuthMiddleware creates a new authentication middleware
-func NewAuthMiddleware(jwtService *jwt.Service) *AuthMiddleware {
+func NewAuthMiddleware(jwtService *jwt.Service, denylist TokenDenylist, apiKeys APIKeyAuthenticator, auditor SecurityAuditor) *AuthMiddleware {
 	return &AuthMiddleware{
 		jwtService:  jwtService,
+		denylist:    denylist,
+		apiKeys:     apiKeys,
+		auditor:     auditor,
+		limiter:     newRateLimiter(time.Minute),
 		securityMap: make(map[string]bool),
+		keyDenylist: make(map[string]bool),
 	}
 }
 
@@ -35,6 +85,14 @@ func (m *AuthMiddleware) AddSecurityRequirement(method, path string, requiresAut
 	m.securityMap[key] = requiresAuth
 }
 
+// DenyAPIKey refuses API keys on an endpoint, and on paths below it, whatever their
+// scopes. Keys carry no role, so routes for admins and routes that change credentials or
+// end the account need a bearer token.
+func (m *AuthMiddleware) DenyAPIKey(method, path string) {
+	key := fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+	m.keyDenylist[key] = true
+}
+
 // Middleware returns the authentication middleware function
 func (m *AuthMiddleware) Middleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -48,16 +106,30 @@ func (m *AuthMiddleware) Middleware() func(http.Handler) http.Handler {
 				return
 			}
 
+			// An API key is an alternative to a bearer token and is checked on every route it is sent to
+			if apiKey := r.Header.Get("X-API-Key"); apiKey != "" && m.apiKeys != nil {
+				m.serveAPIKey(w, r, next, apiKey)
+				return
+			}
+
 			// Check if this endpoint requires authentication
 			requiresAuth := m.requiresAuthFor(r.Method, r.URL.Path)
 
-			// If no auth required, proceed directly
+			// If no auth required, proceed directly. A valid bearer token is still
+			// honoured so handlers can tailor responses to the caller (e.g. post visibility).
 			if !requiresAuth {
 				logger.GetGlobal().Info("No authentication required",
 					"requestId", requestID,
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
+				if authHeader := r.Header.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+					if claims, err := m.jwtService.ValidateToken(strings.TrimPrefix(authHeader, "Bearer ")); err == nil {
+						if revoked, err := m.isRevoked(ctx, claims); err == nil && !revoked {
+							r = r.WithContext(withClaims(ctx, claims))
+						}
+					}
+				}
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -107,14 +179,48 @@ func (m *AuthMiddleware) Middleware() func(http.Handler) http.Handler {
 					"path", r.URL.Path,
 					"error", err.Error(),
 				)
-				response.Unauthorized(ctx, "Invalid token", []string{err.Error()}).Send(w, http.StatusUnauthorized)
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="invalid_token", error_description=%q`, err.Error()))
+				response.Unauthorized(ctx, tokenErrorMessage(err), []string{err.Error()}).Send(w, http.StatusUnauthorized)
+				return
+			}
+
+			// Reject tokens that were revoked on logout
+			revoked, err := m.isRevoked(ctx, claims)
+			if err != nil {
+				logger.GetGlobal().Error("Failed to check token revocation",
+					"requestId", requestID,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"error", err.Error(),
+				)
+				response.InternalServerError(ctx, "Failed to verify token", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+				return
+			}
+			if revoked {
+				logger.GetGlobal().Warn("Revoked token",
+					"requestId", requestID,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"user_id", claims.AccountID,
+				)
+				m.audit(ctx, claims.AccountID, SecurityEventRevokedToken, r.Method+" "+r.URL.Path)
+				response.Unauthorized(ctx, "Invalid token", []string{"token has been revoked"}).Send(w, http.StatusUnauthorized)
 				return
 			}
 
+			// Record session activity; failures must not block the request
+			if m.denylist != nil && claims.SessionID != 0 {
+				if err := m.denylist.TouchSession(ctx, claims.SessionID, reqctx.GetClientIP(ctx)); err != nil {
+					logger.GetGlobal().Warn("Failed to update session activity",
+						"requestId", requestID,
+						"session_id", claims.SessionID,
+						"error", err.Error(),
+					)
+				}
+			}
+
 			// Add user info to context
-			ctx = context.WithValue(ctx, "user_id", claims.AccountID)
-			ctx = context.WithValue(ctx, "user_email", claims.Email)
-			ctx = context.WithValue(ctx, "user_name", claims.Name)
+			ctx = withClaims(ctx, claims)
 
 			logger.GetGlobal().Info("Authentication successful",
 				"requestId", requestID,
@@ -130,21 +236,114 @@ func (m *AuthMiddleware) Middleware() func(http.Handler) http.Handler {
 	}
 }
 
+// serveAPIKey authenticates a request by API key, enforcing the key's scopes and rate limit
+func (m *AuthMiddleware) serveAPIKey(w http.ResponseWriter, r *http.Request, next http.Handler, apiKey string) {
+	ctx := r.Context()
+	requestID := reqctx.GetRequestID(ctx)
+
+	principal, err := m.apiKeys.AuthenticateAPIKey(ctx, apiKey)
+	if err != nil {
+		logger.GetGlobal().Error("Failed to check API key",
+			"requestId", requestID,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"error", err.Error(),
+		)
+		response.InternalServerError(ctx, "Failed to verify API key", []string{err.Error()}).Send(w, http.StatusInternalServerError)
+		return
+	}
+	if principal == nil {
+		logger.GetGlobal().Warn("Invalid API key",
+			"requestId", requestID,
+			"method", r.Method,
+			"path", r.URL.Path,
+		)
+		response.Unauthorized(ctx, "Invalid API key", []string{"API key is unknown, expired or revoked"}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	if denied, _ := matchRule(m.keyDenylist, r.Method, r.URL.Path); denied {
+		logger.GetGlobal().Warn("API key refused on route",
+			"requestId", requestID,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"api_key_id", principal.KeyID,
+		)
+		response.Forbidden(ctx, "API keys cannot be used for this request", []string{"sign in with a bearer token"}).Send(w, http.StatusForbidden)
+		return
+	}
+
+	if !scopeAllows(principal.Scopes, r.Method) {
+		logger.GetGlobal().Warn("API key scope denied",
+			"requestId", requestID,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"api_key_id", principal.KeyID,
+		)
+		m.audit(ctx, principal.AccountID, SecurityEventAPIKeyScopeDenied, fmt.Sprintf("key %d: %s %s", principal.KeyID, r.Method, r.URL.Path))
+		response.Forbidden(ctx, "API key scope does not allow this request", []string{fmt.Sprintf("%s requires the write scope", r.Method)}).Send(w, http.StatusForbidden)
+		return
+	}
+
+	ok, limit := m.limiter.Allow(principal.KeyID, principal.RateLimit, time.Now())
+	if !ok {
+		logger.GetGlobal().Warn("API key rate limit exceeded",
+			"requestId", requestID,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"api_key_id", principal.KeyID,
+		)
+		response.SendRateLimited(ctx, w, limit)
+		return
+	}
+	response.SetRateLimitHeaders(w, limit)
+
+	logger.GetGlobal().Info("API key authentication successful",
+		"requestId", requestID,
+		"method", r.Method,
+		"path", r.URL.Path,
+		"user_id", principal.AccountID,
+		"api_key_id", principal.KeyID,
+	)
+
+	next.ServeHTTP(w, r.WithContext(withAPIKey(ctx, principal)))
+}
+
+// scopeAllows reports whether the scopes permit the HTTP method; read only covers safe methods
+func scopeAllows(scopes []string, method string) bool {
+	for _, scope := range scopes {
+		if scope == "write" {
+			return true
+		}
+		if scope == "read" && (method == http.MethodGet || method == http.MethodHead) {
+			return true
+		}
+	}
+	return false
+}
+
 // requiresAuthFor determines whether auth is required for a given method and path.
+// Default: no auth required if not specified.
+func (m *AuthMiddleware) requiresAuthFor(method, path string) bool {
+	requiresAuth, _ := matchRule(m.securityMap, method, path)
+	return requiresAuth
+}
+
+// matchRule looks up the rule registered for a method and path.
 // It first tries an exact match, then falls back to prefix-based matching to support
 // dynamic path segments like "/api/comments/by-post/{postId}".
-func (m *AuthMiddleware) requiresAuthFor(method, path string) bool {
+func matchRule[V any](rules map[string]V, method, path string) (V, bool) {
 	// 1) Exact match
 	exactKey := fmt.Sprintf("%s %s", strings.ToUpper(method), path)
-	if v, ok := m.securityMap[exactKey]; ok {
-		return v
+	if v, ok := rules[exactKey]; ok {
+		return v, true
 	}
 
 	// 2) Prefix match against registered patterns
 	// Example: ruleKey "GET /api/comments/by-post" matches
 	//          request path "/api/comments/by-post/5"
 	method = strings.ToUpper(method)
-	for k, v := range m.securityMap {
+	for k, v := range rules {
 		// Expect keys in format: "METHOD /path"
 		if !strings.HasPrefix(k, method+" ") {
 			continue
@@ -152,7 +351,7 @@ func (m *AuthMiddleware) requiresAuthFor(method, path string) bool {
 		rulePath := strings.TrimPrefix(k, method+" ")
 
 		if rulePath == path {
-			return v
+			return v, true
 		}
 		// Normalize: ensure rulePath without trailing slash compares to path segments
 		if strings.HasSuffix(rulePath, "/") {
@@ -161,11 +360,72 @@ func (m *AuthMiddleware) requiresAuthFor(method, path string) bool {
 
 		// If request path starts with rulePath followed by a slash, consider it a match
 		if rulePath != "" && strings.HasPrefix(path, rulePath+"/") {
-			return v
+			return v, true
 		}
 	}
-	// Default: no auth required if not specified
-	return false
+
+	var zero V
+	return zero, false
+}
+
+// tokenErrorMessage names the reason a bearer token was rejected
+func tokenErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return "Token expired"
+	case errors.Is(err, jwt.ErrTokenNotYetValid):
+		return "Token not valid yet"
+	case errors.Is(err, jwt.ErrInvalidIssuer):
+		return "Token issuer not accepted"
+	case errors.Is(err, jwt.ErrInvalidAudience):
+		return "Token audience not accepted"
+	case errors.Is(err, jwt.ErrUnknownKey), errors.Is(err, jwt.ErrRetiredKey):
+		return "Token signing key not accepted"
+	case errors.Is(err, jwt.ErrSignatureInvalid):
+		return "Invalid token signature"
+	}
+	return "Invalid token"
+}
+
+// isRevoked consults the denylist for the token's jti, session and token version
+func (m *AuthMiddleware) isRevoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	if m.denylist == nil {
+		return false, nil
+	}
+	return m.denylist.IsTokenRevoked(ctx, claims.ID, claims.SessionID, claims.AccountID, claims.TokenVersion)
+}
+
+// audit records a security event when an auditor is configured
+func (m *AuthMiddleware) audit(ctx context.Context, accountID int64, eventType, details string) {
+	if m.auditor != nil {
+		m.auditor.RecordSecurityEvent(ctx, accountID, eventType, details)
+	}
+}
+
+// withClaims stores the authenticated user's info in the context
+func withClaims(ctx context.Context, claims *jwt.Claims) context.Context {
+	ctx = context.WithValue(ctx, "user_id", claims.AccountID)
+	ctx = context.WithValue(ctx, "user_email", claims.Email)
+	ctx = context.WithValue(ctx, "user_name", claims.Name)
+	ctx = context.WithValue(ctx, "user_role", claims.Role)
+	ctx = context.WithValue(ctx, "user_verified", claims.IsVerified)
+	ctx = context.WithValue(ctx, "token_id", claims.ID)
+	ctx = context.WithValue(ctx, "session_id", claims.SessionID)
+	if claims.ExpiresAt != nil {
+		ctx = context.WithValue(ctx, "token_expires_at", claims.ExpiresAt.Time)
+	}
+	return ctx
+}
+
+// withAPIKey stores the API key's account info in the context
+func withAPIKey(ctx context.Context, principal *APIKeyPrincipal) context.Context {
+	ctx = context.WithValue(ctx, "user_id", principal.AccountID)
+	ctx = context.WithValue(ctx, "user_email", principal.Email)
+	ctx = context.WithValue(ctx, "user_name", principal.Name)
+	ctx = context.WithValue(ctx, "user_role", principal.Role)
+	ctx = context.WithValue(ctx, "user_verified", principal.IsVerified)
+	ctx = context.WithValue(ctx, "api_key_id", principal.KeyID)
+	return ctx
 }
 
 // Helper functions to get user info from context
@@ -183,3 +443,33 @@ func GetUserName(ctx context.Context) (string, bool) {
 	name, ok := ctx.Value("user_name").(string)
 	return name, ok
 }
+
+func GetUserRole(ctx context.Context) (string, bool) {
+	role, ok := ctx.Value("user_role").(string)
+	return role, ok
+}
+
+func GetUserVerified(ctx context.Context) (bool, bool) {
+	verified, ok := ctx.Value("user_verified").(bool)
+	return verified, ok
+}
+
+func GetTokenID(ctx context.Context) (string, bool) {
+	tokenID, ok := ctx.Value("token_id").(string)
+	return tokenID, ok
+}
+
+func GetSessionID(ctx context.Context) (int64, bool) {
+	sessionID, ok := ctx.Value("session_id").(int64)
+	return sessionID, ok
+}
+
+func GetTokenExpiresAt(ctx context.Context) (time.Time, bool) {
+	expiresAt, ok := ctx.Value("token_expires_at").(time.Time)
+	return expiresAt, ok
+}
+
+func GetAPIKeyID(ctx context.Context) (int64, bool) {
+	keyID, ok := ctx.Value("api_key_id").(int64)
+	return keyID, ok
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fanzru/social-media-service-go/pkg/reqctx"
+	"github.com/fanzru/social-media-service-go/pkg/sqlwrap"
+)
+
+// DebugQueriesHeader is the request header admins set to "true" to trace the database
+// queries of a request
+const DebugQueriesHeader = "X-Debug-Queries"
+
+// DebugQueries traces the database queries of requests sent by an admin with the
+// X-Debug-Queries header: each query is logged with its arguments, whatever
+// DB_LOG_QUERIES says, and the response carries their count and timings under
+// debug.queries. The header is ignored for other callers, so it must run after
+// AuthMiddleware.
+func DebugQueries(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		enabled, _ := strconv.ParseBool(r.Header.Get(DebugQueriesHeader))
+		if !enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if role, _ := GetUserRole(r.Context()); role != "admin" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, trace := sqlwrap.WithQueryTrace(r.Context())
+		ctx = reqctx.SetDebug(ctx, func() interface{} {
+			return map[string]interface{}{"queries": trace.Summary()}
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
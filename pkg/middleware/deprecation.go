@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/reqctx"
+)
+
+// Deprecation marks an endpoint as deprecated
+type Deprecation struct {
+	Since  time.Time // When the endpoint was deprecated
+	Sunset time.Time // When the endpoint stops working; zero when no date is set
+	Link   string    // Documentation of the deprecation and the replacement, optional
+}
+
+// Warning is the message added to the responses of a deprecated endpoint
+func (d Deprecation) Warning() string {
+	if d.Sunset.IsZero() {
+		return "this endpoint is deprecated"
+	}
+	return "this endpoint is deprecated and will be removed: " + d.Sunset.UTC().Format(time.RFC3339)
+}
+
+// RouteMetadata holds metadata about endpoints that applies to their responses, such as
+// deprecation. Each response of a deprecated endpoint gets the Deprecation, Sunset and
+// Link headers and a warning in the standard response.
+type RouteMetadata struct {
+	// Map of path patterns to their deprecation
+	// Key: HTTP method + path pattern (e.g., "GET /api/posts/by-user")
+	deprecations map[string]Deprecation
+}
+
+// NewRouteMetadata creates a new route metadata middleware
+func NewRouteMetadata() *RouteMetadata {
+	return &RouteMetadata{
+		deprecations: make(map[string]Deprecation),
+	}
+}
+
+// Deprecate marks an endpoint as deprecated.
+// Paths match the same way as AddSecurityRequirement, including prefixes.
+func (m *RouteMetadata) Deprecate(method, path string, deprecation Deprecation) {
+	key := fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+	m.deprecations[key] = deprecation
+}
+
+// Middleware returns the route metadata middleware function
+func (m *RouteMetadata) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			deprecation, ok := matchRule(m.deprecations, r.Method, r.URL.Path)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// RFC 9745 and RFC 8594
+			w.Header().Set("Deprecation", "@"+strconv.FormatInt(deprecation.Since.Unix(), 10))
+			if !deprecation.Sunset.IsZero() {
+				w.Header().Set("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if deprecation.Link != "" {
+				w.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"deprecation\"", deprecation.Link))
+			}
+
+			ctx := reqctx.AddWarning(r.Context(), deprecation.Warning())
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// DeprecatedRoute is an endpoint marked as deprecated by configuration
+type DeprecatedRoute struct {
+	Method      string
+	Path        string
+	Deprecation Deprecation
+}
+
+// ParseDeprecatedRoutes parses deprecated endpoints written as "METHOD /path@deprecated-at",
+// optionally followed by "@sunset-at", with times in RFC 3339, e.g.
+// "GET /api/comments/user@2026-10-01T00:00:00Z@2027-01-01T00:00:00Z". Every endpoint
+// links to the same documentation.
+func ParseDeprecatedRoutes(specs []string, link string) ([]DeprecatedRoute, error) {
+	routes := make([]DeprecatedRoute, 0, len(specs))
+	for _, spec := range specs {
+		parts := strings.Split(spec, "@")
+		route := strings.Fields(parts[0])
+		if len(parts) < 2 || len(parts) > 3 || len(route) != 2 {
+			return nil, fmt.Errorf("invalid deprecated route %q: expected METHOD /path@deprecated-at[@sunset-at]", spec)
+		}
+
+		deprecation := Deprecation{Link: link}
+		var err error
+		if deprecation.Since, err = time.Parse(time.RFC3339, parts[1]); err != nil {
+			return nil, fmt.Errorf("invalid deprecation time of route %q: %w", parts[0], err)
+		}
+		if len(parts) == 3 {
+			if deprecation.Sunset, err = time.Parse(time.RFC3339, parts[2]); err != nil {
+				return nil, fmt.Errorf("invalid sunset time of route %q: %w", parts[0], err)
+			}
+		}
+		routes = append(routes, DeprecatedRoute{Method: route[0], Path: route[1], Deprecation: deprecation})
+	}
+	return routes, nil
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ConditionalGET answers GET and HEAD requests with 304 Not Modified when the ETag set by
+// the handler matches the If-None-Match header, dropping the body. Handlers opt in by
+// setting an ETag, e.g. with response.SetETag.
+func ConditionalGET(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch := r.Header.Get("If-None-Match")
+		if ifNoneMatch == "" || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(&etagResponseWriter{ResponseWriter: w, ifNoneMatch: ifNoneMatch}, r)
+	})
+}
+
+// etagResponseWriter turns a 200 response into 304 when its ETag matches
+type etagResponseWriter struct {
+	http.ResponseWriter
+	ifNoneMatch string
+	wroteHeader bool
+	notModified bool
+}
+
+func (rw *etagResponseWriter) WriteHeader(code int) {
+	if rw.wroteHeader {
+		return
+	}
+	rw.wroteHeader = true
+
+	etag := rw.Header().Get("ETag")
+	if code == http.StatusOK && etag != "" && etagMatches(rw.ifNoneMatch, etag) {
+		rw.notModified = true
+		rw.Header().Del("Content-Type")
+		rw.Header().Del("Content-Length")
+		rw.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *etagResponseWriter) Write(b []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+	if rw.notModified {
+		return len(b), nil
+	}
+	return rw.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (rw *etagResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// etagMatches applies the weak comparison If-None-Match uses: any listed tag equal to the
+// current one, ignoring W/ prefixes, or "*"
+func etagMatches(ifNoneMatch string, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/reqctx"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCLoggingInterceptor stores the request ID and client info in the context, as
+// reqctx.Middleware does for HTTP, and logs each call with its status code
+func GRPCLoggingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		requestID := firstMetadata(ctx, "x-request-id")
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+		ctx = reqctx.SetRequestID(ctx, requestID)
+		ctx = context.WithValue(ctx, reqctx.ClientIPKey{}, grpcClientIP(ctx))
+		ctx = context.WithValue(ctx, reqctx.UserAgentKey{}, firstMetadata(ctx, "user-agent"))
+		grpc.SetHeader(ctx, metadata.Pairs("x-request-id", requestID))
+
+		resp, err := handler(ctx, req)
+
+		duration := time.Since(start)
+		logger.GetGlobal().Info("gRPC Call",
+			"requestId", requestID,
+			"method", info.FullMethod,
+			"code", status.Code(err).String(),
+			"duration_ms", duration.Milliseconds(),
+		)
+		return resp, err
+	}
+}
+
+// GRPCInterceptor authenticates gRPC calls with the same bearer tokens and API keys as the
+// HTTP API, sent as "authorization" or "x-api-key" metadata. Calls without credentials run
+// anonymously; methods that need an account check for one themselves. The gRPC API only
+// reads, which every API key scope allows.
+func (m *AuthMiddleware) GRPCInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		requestID := reqctx.GetRequestID(ctx)
+
+		if apiKey := firstMetadata(ctx, "x-api-key"); apiKey != "" && m.apiKeys != nil {
+			principal, err := m.apiKeys.AuthenticateAPIKey(ctx, apiKey)
+			if err != nil {
+				logger.GetGlobal().Error("Failed to check API key",
+					"requestId", requestID,
+					"method", info.FullMethod,
+					"error", err.Error(),
+				)
+				return nil, status.Error(codes.Internal, "failed to verify API key")
+			}
+			if principal == nil {
+				return nil, status.Error(codes.Unauthenticated, "API key is unknown, expired or revoked")
+			}
+			if !scopeAllows(principal.Scopes, http.MethodGet) {
+				m.audit(ctx, principal.AccountID, SecurityEventAPIKeyScopeDenied, fmt.Sprintf("key %d: %s", principal.KeyID, info.FullMethod))
+				return nil, status.Error(codes.PermissionDenied, "API key scope does not allow this call")
+			}
+			if ok, _ := m.limiter.Allow(principal.KeyID, principal.RateLimit, time.Now()); !ok {
+				return nil, status.Errorf(codes.ResourceExhausted, "limit is %d requests per minute", principal.RateLimit)
+			}
+			return handler(withAPIKey(ctx, principal), req)
+		}
+
+		authHeader := firstMetadata(ctx, "authorization")
+		if authHeader == "" {
+			return handler(ctx, req)
+		}
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			return nil, status.Error(codes.Unauthenticated, "authorization must start with 'Bearer '")
+		}
+
+		claims, err := m.jwtService.ValidateToken(strings.TrimPrefix(authHeader, "Bearer "))
+		if err != nil {
+			logger.GetGlobal().Warn("Invalid token",
+				"requestId", requestID,
+				"method", info.FullMethod,
+				"error", err.Error(),
+			)
+			return nil, status.Error(codes.Unauthenticated, tokenErrorMessage(err))
+		}
+
+		revoked, err := m.isRevoked(ctx, claims)
+		if err != nil {
+			logger.GetGlobal().Error("Failed to check token revocation",
+				"requestId", requestID,
+				"method", info.FullMethod,
+				"error", err.Error(),
+			)
+			return nil, status.Error(codes.Internal, "failed to verify token")
+		}
+		if revoked {
+			m.audit(ctx, claims.AccountID, SecurityEventRevokedToken, info.FullMethod)
+			return nil, status.Error(codes.Unauthenticated, "token has been revoked")
+		}
+
+		// Record session activity; failures must not block the call
+		if m.denylist != nil && claims.SessionID != 0 {
+			if err := m.denylist.TouchSession(ctx, claims.SessionID, reqctx.GetClientIP(ctx)); err != nil {
+				logger.GetGlobal().Warn("Failed to update session activity",
+					"requestId", requestID,
+					"session_id", claims.SessionID,
+					"error", err.Error(),
+				)
+			}
+		}
+
+		return handler(withClaims(ctx, claims), req)
+	}
+}
+
+// firstMetadata returns the first value of an incoming metadata key
+func firstMetadata(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// grpcClientIP returns the caller's address, preferring the first x-forwarded-for entry
+func grpcClientIP(ctx context.Context) string {
+	if forwarded := firstMetadata(ctx, "x-forwarded-for"); forwarded != "" {
+		if ip := strings.TrimSpace(strings.Split(forwarded, ",")[0]); ip != "" {
+			return ip
+		}
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
@@ -7,17 +7,18 @@ import (
 	"strings"
 	"time"
 
-	"github.com/fanzru/social-media-service-go/pkg/influxdb"
+	"github.com/fanzru/social-media-service-go/pkg/metrics"
 )
 
-// InfluxDBMiddleware creates an InfluxDB middleware for HTTP requests
-func InfluxDBMiddleware(influxClient *influxdb.Client) func(http.Handler) http.Handler {
+// MetricsMiddleware creates a middleware sending HTTP request metrics to sink; it only
+// passes requests through when sink is nil
+func MetricsMiddleware(sink metrics.Sink) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 
 			// Create a response writer wrapper to capture status code
-			wrapper := &influxResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			wrapper := &metricsResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
 			// Process the request
 			next.ServeHTTP(wrapper, r)
@@ -30,8 +31,8 @@ func InfluxDBMiddleware(influxClient *influxdb.Client) func(http.Handler) http.H
 			entity := extractEntity(rawPath)
 			normPath := normalizePath(rawPath)
 
-			// Record metrics to InfluxDB
-			if influxClient != nil {
+			// Record metrics
+			if sink != nil {
 				tags := map[string]string{
 					"group":       "API_IN",
 					"entity":      entity,
@@ -42,22 +43,22 @@ func InfluxDBMiddleware(influxClient *influxdb.Client) func(http.Handler) http.H
 				}
 
 				// Record request count
-				_ = influxClient.WriteCounter("http_requests_total", tags, 1)
+				_ = sink.WriteCounter("http_requests_total", tags, 1)
 
 				// Record response time
-				_ = influxClient.WriteTiming("http_request_duration_ms", tags, duration)
+				_ = sink.WriteTiming("http_request_duration_ms", tags, duration)
 			}
 		})
 	}
 }
 
-// influxResponseWriter wraps http.ResponseWriter to capture status code
-type influxResponseWriter struct {
+// metricsResponseWriter wraps http.ResponseWriter to capture status code
+type metricsResponseWriter struct {
 	http.ResponseWriter
 	statusCode int
 }
 
-func (rw *influxResponseWriter) WriteHeader(code int) {
+func (rw *metricsResponseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
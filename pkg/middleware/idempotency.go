@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/reqctx"
+	"github.com/fanzru/social-media-service-go/pkg/response"
+)
+
+// IdempotencyKeyHeader is the request header clients send to make a request safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxIdempotencyKeyLength is the longest idempotency key accepted
+const maxIdempotencyKeyLength = 255
+
+var (
+	// ErrIdempotencyKeyInProgress means the first request with the key has not completed yet
+	ErrIdempotencyKeyInProgress = errors.New("idempotency key in progress")
+	// ErrIdempotencyKeyMismatch means the key was already used for a different request
+	ErrIdempotencyKeyMismatch = errors.New("idempotency key reused with a different request")
+)
+
+// IdempotentResponse is the response stored for an idempotency key
+type IdempotentResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyStore keeps the responses of requests sent with an idempotency key
+type IdempotencyStore interface {
+	// Reserve claims the key for a request fingerprint. It returns the stored response
+	// when the key already completed for the same fingerprint, or nil when the caller now
+	// holds the key. ErrIdempotencyKeyInProgress and ErrIdempotencyKeyMismatch are returned
+	// for keys held by a running request or used for a different one.
+	Reserve(ctx context.Context, accountID int64, key, fingerprint string) (*IdempotentResponse, error)
+	// Complete stores the response of a reserved key
+	Complete(ctx context.Context, accountID int64, key string, resp IdempotentResponse) error
+	// Release frees a reserved key so the request can be retried
+	Release(ctx context.Context, accountID int64, key string) error
+}
+
+// IdempotencyMiddleware replays the original response when a mutating request is retried
+// with the same Idempotency-Key header. Keys are scoped to the authenticated account, so
+// it must run after AuthMiddleware.
+type IdempotencyMiddleware struct {
+	store IdempotencyStore
+	// Set of path patterns that honor the header
+	// Key: HTTP method + path pattern (e.g., "POST /api/posts")
+	routes map[string]bool
+}
+
+// NewIdempotencyMiddleware creates a new idempotency middleware
+func NewIdempotencyMiddleware(store IdempotencyStore) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		store:  store,
+		routes: make(map[string]bool),
+	}
+}
+
+// AddRoute honors the Idempotency-Key header on an endpoint.
+// Paths match the same way as AddSecurityRequirement, including prefixes.
+func (m *IdempotencyMiddleware) AddRoute(method, path string) {
+	key := fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+	m.routes[key] = true
+}
+
+// Middleware returns the idempotency middleware function
+func (m *IdempotencyMiddleware) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if _, ok := matchRule(m.routes, r.Method, r.URL.Path); !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			userID, authenticated := GetUserID(ctx)
+			if !authenticated || userID == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				response.BadRequest(ctx, "Invalid idempotency key", []string{fmt.Sprintf("%s must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength)}).Send(w, http.StatusBadRequest)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				response.BadRequest(ctx, "Failed to read request body", []string{err.Error()}).Send(w, http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			stored, err := m.store.Reserve(ctx, userID, key, fingerprint(r, body))
+			switch {
+			case errors.Is(err, ErrIdempotencyKeyInProgress):
+				response.Conflict(ctx, "Request in progress", []string{"a request with this idempotency key is still being processed"}).Send(w, http.StatusConflict)
+				return
+			case errors.Is(err, ErrIdempotencyKeyMismatch):
+				response.UnprocessableEntity(ctx, "Idempotency key reused", []string{"the idempotency key was already used for a different request"}).Send(w, http.StatusUnprocessableEntity)
+				return
+			case err != nil:
+				logger.GetGlobal().Error("Failed to reserve idempotency key",
+					"requestId", reqctx.GetRequestID(ctx),
+					"user_id", userID,
+					"error", err.Error(),
+				)
+				response.InternalServerError(ctx, "Internal server error", []string{}).Send(w, http.StatusInternalServerError)
+				return
+			}
+
+			if stored != nil {
+				if stored.ContentType != "" {
+					w.Header().Set("Content-Type", stored.ContentType)
+				}
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(stored.StatusCode)
+				w.Write(stored.Body)
+				return
+			}
+
+			rec := &recordingResponseWriter{ResponseWriter: w}
+			completed := false
+			defer func() {
+				// Keep the key usable when the handler fails or panics
+				if !completed {
+					if err := m.store.Release(context.WithoutCancel(ctx), userID, key); err != nil {
+						logger.GetGlobal().Error("Failed to release idempotency key",
+							"requestId", reqctx.GetRequestID(ctx),
+							"user_id", userID,
+							"error", err.Error(),
+						)
+					}
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+
+			status := rec.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if status >= http.StatusInternalServerError {
+				return
+			}
+
+			err = m.store.Complete(context.WithoutCancel(ctx), userID, key, IdempotentResponse{
+				StatusCode:  status,
+				ContentType: rec.Header().Get("Content-Type"),
+				Body:        rec.body.Bytes(),
+			})
+			if err != nil {
+				logger.GetGlobal().Error("Failed to store idempotent response",
+					"requestId", reqctx.GetRequestID(ctx),
+					"user_id", userID,
+					"error", err.Error(),
+				)
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// fingerprint identifies a request by its method, URL and body, so a key reused for a
+// different request can be told apart from a retry
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recordingResponseWriter passes the response through while keeping a copy of it
+type recordingResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rw *recordingResponseWriter) WriteHeader(code int) {
+	if rw.status == 0 {
+		rw.status = code
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *recordingResponseWriter) Write(b []byte) (int, error) {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	rw.body.Write(b)
+	return rw.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (rw *recordingResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -22,38 +22,45 @@ func LoggingMiddleware() func(http.Handler) http.Handler {
 			// Get request ID from context
 			requestID := reqctx.GetRequestID(ctx)
 			
-			// Read request body
+			// Read request body, only when it would be logged
+			log := logger.GetGlobal()
 			var requestBody []byte
-			if r.Body != nil {
+			if r.Body != nil && log.DetailEnabled() {
 				requestBody, _ = io.ReadAll(r.Body)
 				r.Body = io.NopCloser(bytes.NewBuffer(requestBody))
 			}
 			
-			// Extract headers (excluding sensitive ones)
-			headers := make(map[string]string)
-			for name, values := range r.Header {
-				// Skip sensitive headers
-				if isSensitiveHeader(name) {
-					headers[name] = "[REDACTED]"
-				} else {
-					headers[name] = strings.Join(values, ", ")
+			// Extract headers (excluding sensitive ones) when the record is written
+			headers := logger.Lazy(func() interface{} {
+				headers := make(map[string]string)
+				for name, values := range r.Header {
+					// Skip sensitive headers
+					if isSensitiveHeader(name) {
+						headers[name] = "[REDACTED]"
+					} else {
+						headers[name] = strings.Join(values, ", ")
+					}
 				}
-			}
+				return headers
+			})
 			
-			// Parse request body if it's JSON
-			var parsedBody interface{}
-			if len(requestBody) > 0 && strings.Contains(r.Header.Get("Content-Type"), "application/json") {
-				json.Unmarshal(requestBody, &parsedBody)
-			}
+			// Embed request body if it's JSON, with passwords, tokens and the like masked
+			body := log.Detail(func() interface{} {
+				if len(requestBody) > 0 && strings.Contains(r.Header.Get("Content-Type"), "application/json") && json.Valid(requestBody) {
+					return log.Redactor().JSON(requestBody)
+				}
+				return nil
+			})
 			
 			// Log incoming request
-			logger.GetGlobal().Info("API Request",
+			log.Info("API Request",
 				"requestId", requestID,
+				"traceId", reqctx.GetTraceID(r.Context()),
 				"method", r.Method,
 				"path", r.URL.Path,
-				"query", r.URL.RawQuery,
+				"query", logger.Lazy(func() interface{} { return log.Redactor().Query(r.URL.RawQuery) }),
 				"headers", headers,
-				"body", parsedBody,
+				"body", body,
 				"userAgent", r.UserAgent(),
 				"remoteAddr", r.RemoteAddr,
 			)
@@ -71,8 +78,9 @@ func LoggingMiddleware() func(http.Handler) http.Handler {
 			duration := time.Since(start)
 			
 			// Log response
-			logger.GetGlobal().Info("API Response",
+			log.Info("API Response",
 				"requestId", requestID,
+				"traceId", reqctx.GetTraceID(r.Context()),
 				"method", r.Method,
 				"path", r.URL.Path,
 				"statusCode", wrapper.statusCode,
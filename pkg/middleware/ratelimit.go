@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/response"
+)
+
+// rateLimiter counts requests per key in fixed windows. State is kept in memory,
+// so each server instance enforces its own limit.
+type rateLimiter struct {
+	mu      sync.Mutex
+	window  time.Duration
+	counts  map[int64]*rateWindow
+	sweepAt time.Time
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// newRateLimiter creates a limiter with the given window length
+func newRateLimiter(window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		window: window,
+		counts: make(map[int64]*rateWindow),
+	}
+}
+
+// Allow records a request for key and reports whether it is within limit, along with
+// the state of the limit after the request.
+func (l *rateLimiter) Allow(key int64, limit int, now time.Time) (bool, response.RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop finished windows now and then so idle keys do not accumulate
+	if now.After(l.sweepAt) {
+		for k, w := range l.counts {
+			if now.Sub(w.start) >= l.window {
+				delete(l.counts, k)
+			}
+		}
+		l.sweepAt = now.Add(l.window)
+	}
+
+	w, ok := l.counts[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		w = &rateWindow{start: now}
+		l.counts[key] = w
+	}
+
+	state := response.RateLimit{
+		Limit:  limit,
+		Reset:  w.start.Add(l.window).Sub(now),
+		Window: l.window,
+	}
+	if w.count >= limit {
+		return false, state
+	}
+	w.count++
+	state.Remaining = limit - w.count
+	return true, state
+}
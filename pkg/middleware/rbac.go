@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/reqctx"
+	"github.com/fanzru/social-media-service-go/pkg/response"
+)
+
+// roleRank orders the account roles; a role satisfies every requirement ranked at or below it
+var roleRank = map[string]int{
+	"user":      1,
+	"moderator": 2,
+	"admin":     3,
+}
+
+// RoleMiddleware enforces per-route role requirements using the role claim of the
+// authenticated caller. It must run after AuthMiddleware, which puts the role in the context.
+type RoleMiddleware struct {
+	// Map of path patterns to the minimum role they require
+	// Key: HTTP method + path pattern (e.g., "POST /api/admin")
+	// Value: role name (user, moderator or admin)
+	roleMap map[string]string
+}
+
+// NewRoleMiddleware creates a new role-based access control middleware
+func NewRoleMiddleware() *RoleMiddleware {
+	return &RoleMiddleware{
+		roleMap: make(map[string]string),
+	}
+}
+
+// AddRoleRequirement requires at least the given role for a specific endpoint.
+// Paths match the same way as AddSecurityRequirement, including prefixes.
+func (m *RoleMiddleware) AddRoleRequirement(method, path, role string) {
+	if _, ok := roleRank[role]; !ok {
+		panic(fmt.Sprintf("unknown role %q for %s %s", role, method, path))
+	}
+	key := fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+	m.roleMap[key] = role
+}
+
+// Middleware returns the role-based access control middleware function
+func (m *RoleMiddleware) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			required, ok := matchRule(m.roleMap, r.Method, r.URL.Path)
+			if !ok || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userID, authenticated := GetUserID(ctx)
+			if !authenticated || userID == 0 {
+				response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+				return
+			}
+
+			role, _ := GetUserRole(ctx)
+			if roleRank[role] < roleRank[required] {
+				logger.GetGlobal().Warn("Insufficient role",
+					"requestId", reqctx.GetRequestID(ctx),
+					"method", r.Method,
+					"path", r.URL.Path,
+					"user_id", userID,
+					"role", role,
+					"required_role", required,
+				)
+				response.Forbidden(ctx, "Insufficient role", []string{fmt.Sprintf("%s role required", required)}).Send(w, http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
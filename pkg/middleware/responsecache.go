@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/cache"
+	"github.com/fanzru/social-media-service-go/pkg/eventbus"
+)
+
+// responseCacheKeyPrefix starts the cache key of every stored response
+const responseCacheKeyPrefix = "response:"
+
+// cachedResponseHeaders are the response headers stored with a cached body and replayed
+// on a hit
+var cachedResponseHeaders = []string{"Content-Type", "Content-Language", "ETag", "Cache-Control"}
+
+// cachedResponse is a successful response stored for a request
+type cachedResponse struct {
+	header http.Header
+	body   []byte
+}
+
+// ResponseCache answers anonymous GET requests from responses stored for the same path,
+// query and content negotiation headers, to absorb read-heavy traffic on public listings.
+// Responses are kept for a TTL and dropped when a post, comment or account changes, see
+// ResponseCacheKeys. Requests with credentials always reach the handler, as their
+// responses depend on the viewer, so it must run after AuthMiddleware.
+type ResponseCache struct {
+	store cache.Store
+	ttl   time.Duration
+	// Set of path patterns whose anonymous responses are cached
+	// Key: HTTP method + path pattern (e.g., "GET /api/posts")
+	routes map[string]bool
+}
+
+// NewResponseCache creates a response cache storing responses in store for ttl; a ttl of
+// 0 disables caching
+func NewResponseCache(store cache.Store, ttl time.Duration) *ResponseCache {
+	return &ResponseCache{
+		store:  store,
+		ttl:    ttl,
+		routes: make(map[string]bool),
+	}
+}
+
+// AddRoute caches the anonymous GET responses of an endpoint.
+// Paths match the same way as AddSecurityRequirement, including prefixes.
+func (m *ResponseCache) AddRoute(path string) {
+	key := fmt.Sprintf("%s %s", http.MethodGet, path)
+	m.routes[key] = true
+}
+
+// Middleware returns the response cache middleware function
+func (m *ResponseCache) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !m.cacheable(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+			key := responseCacheKey(r)
+			if v, ok := m.store.Get(ctx, key); ok {
+				cached := v.(*cachedResponse)
+				for name, values := range cached.header {
+					w.Header()[name] = values
+				}
+				w.Header().Set("X-Cache", "HIT")
+				w.WriteHeader(http.StatusOK)
+				w.Write(cached.body)
+				return
+			}
+
+			w.Header().Set("X-Cache", "MISS")
+			rec := &recordingResponseWriter{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+
+			if rec.status != 0 && rec.status != http.StatusOK {
+				return
+			}
+			header := make(http.Header, len(cachedResponseHeaders))
+			for _, name := range cachedResponseHeaders {
+				if values := rec.Header().Values(name); len(values) > 0 {
+					header[name] = append([]string(nil), values...)
+				}
+			}
+			m.store.Set(ctx, key, &cachedResponse{
+				header: header,
+				body:   rec.body.Bytes(),
+			}, m.ttl)
+		})
+	}
+}
+
+// cacheable reports whether the response to a request may be served from or stored in
+// the cache: an anonymous GET of a registered route
+func (m *ResponseCache) cacheable(r *http.Request) bool {
+	if m.ttl <= 0 || r.Method != http.MethodGet {
+		return false
+	}
+	if r.Header.Get("Authorization") != "" || r.Header.Get("X-API-Key") != "" {
+		return false
+	}
+	if _, authenticated := GetUserID(r.Context()); authenticated {
+		return false
+	}
+	_, ok := matchRule(m.routes, r.Method, r.URL.Path)
+	return ok
+}
+
+// responseCacheKey identifies a request by its cleaned path, its query with parameters
+// sorted, and the headers choosing the format and language of the response, so
+// equivalent URLs share one entry
+func responseCacheKey(r *http.Request) string {
+	return responseCacheKeyPrefix + path.Clean(r.URL.Path) + "?" + r.URL.Query().Encode() +
+		"\n" + strings.TrimSpace(r.Header.Get("Accept")) +
+		"\n" + strings.TrimSpace(r.Header.Get("Accept-Language"))
+}
+
+// ResponseCacheKeys returns the cached responses a change makes stale. Any post, comment
+// or account change can show in any listing, through counts, last comments and author
+// names, so every cached response is dropped.
+func ResponseCacheKeys(e eventbus.Event) []string {
+	switch e.Type {
+	case eventbus.PostCreated, eventbus.PostUpdated, eventbus.PostDeleted,
+		eventbus.CommentCreated, eventbus.CommentUpdated, eventbus.CommentDeleted,
+		eventbus.AccountUpdated, eventbus.AccountDeleted:
+		return []string{responseCacheKeyPrefix + "*"}
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/infrastructure/config"
+)
+
+// Supported provider names
+const (
+	ProviderGoogle = "google"
+	ProviderGitHub = "github"
+)
+
+// UserInfo is the identity returned by a provider after a successful login
+type UserInfo struct {
+	ProviderUserID string
+	Email          string
+	EmailVerified  bool
+	Name           string
+}
+
+// Provider implements the OAuth 2.0 authorization code flow for a single identity provider
+type Provider struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	RedirectURL  string
+	Scopes       []string
+
+	httpClient *http.Client
+	fetchUser  func(ctx context.Context, p *Provider, accessToken string) (*UserInfo, error)
+}
+
+// Registry holds the enabled providers keyed by name
+type Registry map[string]*Provider
+
+// Get returns the provider with the given name if it is enabled
+func (r Registry) Get(name string) (*Provider, bool) {
+	p, ok := r[strings.ToLower(name)]
+	return p, ok
+}
+
+// NewRegistry creates the providers that have a client ID configured
+func NewRegistry(cfg *config.OAuthConfig) Registry {
+	registry := Registry{}
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	redirectURL := func(name string) string {
+		return strings.TrimSuffix(cfg.RedirectBaseURL, "/") + "/api/auth/" + name + "/callback"
+	}
+
+	if cfg.GoogleClientID != "" {
+		registry[ProviderGoogle] = &Provider{
+			Name:         ProviderGoogle,
+			ClientID:     cfg.GoogleClientID,
+			ClientSecret: cfg.GoogleClientSecret,
+			AuthURL:      "https://accounts.google.com/o/oauth2/v2/auth",
+			TokenURL:     "https://oauth2.googleapis.com/token",
+			RedirectURL:  redirectURL(ProviderGoogle),
+			Scopes:       []string{"openid", "email", "profile"},
+			httpClient:   httpClient,
+			fetchUser:    fetchGoogleUser,
+		}
+	}
+
+	if cfg.GitHubClientID != "" {
+		registry[ProviderGitHub] = &Provider{
+			Name:         ProviderGitHub,
+			ClientID:     cfg.GitHubClientID,
+			ClientSecret: cfg.GitHubClientSecret,
+			AuthURL:      "https://github.com/login/oauth/authorize",
+			TokenURL:     "https://github.com/login/oauth/access_token",
+			RedirectURL:  redirectURL(ProviderGitHub),
+			Scopes:       []string{"read:user", "user:email"},
+			httpClient:   httpClient,
+			fetchUser:    fetchGitHubUser,
+		}
+	}
+
+	return registry
+}
+
+// AuthCodeURL returns the provider consent page URL carrying the given state
+func (p *Provider) AuthCodeURL(state string) string {
+	params := url.Values{}
+	params.Set("client_id", p.ClientID)
+	params.Set("redirect_uri", p.RedirectURL)
+	params.Set("response_type", "code")
+	params.Set("scope", strings.Join(p.Scopes, " "))
+	params.Set("state", state)
+	return p.AuthURL + "?" + params.Encode()
+}
+
+// Exchange trades an authorization code for an access token
+func (p *Provider) Exchange(ctx context.Context, code string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.RedirectURL)
+	form.Set("client_id", p.ClientID)
+	form.Set("client_secret", p.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := p.doJSON(req, &token); err != nil {
+		return "", fmt.Errorf("failed to exchange code: %w", err)
+	}
+	if token.Error != "" {
+		return "", fmt.Errorf("failed to exchange code: %s %s", token.Error, token.ErrorDescription)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("failed to exchange code: empty access token")
+	}
+	return token.AccessToken, nil
+}
+
+// FetchUser returns the identity of the user the access token belongs to
+func (p *Provider) FetchUser(ctx context.Context, accessToken string) (*UserInfo, error) {
+	return p.fetchUser(ctx, p, accessToken)
+}
+
+// get performs an authenticated GET request and decodes the JSON response
+func (p *Provider) get(ctx context.Context, endpoint, accessToken string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+	return p.doJSON(req, out)
+}
+
+// doJSON sends the request and decodes a successful JSON response
+func (p *Provider) doJSON(req *http.Request, out interface{}) error {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// fetchGoogleUser reads the OpenID Connect userinfo endpoint
+func fetchGoogleUser(ctx context.Context, p *Provider, accessToken string) (*UserInfo, error) {
+	var user struct {
+		Sub           string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := p.get(ctx, "https://openidconnect.googleapis.com/v1/userinfo", accessToken, &user); err != nil {
+		return nil, fmt.Errorf("failed to fetch google user: %w", err)
+	}
+
+	return &UserInfo{
+		ProviderUserID: user.Sub,
+		Email:          user.Email,
+		EmailVerified:  user.EmailVerified,
+		Name:           user.Name,
+	}, nil
+}
+
+// fetchGitHubUser reads the user profile and, since the public email may be hidden,
+// the primary verified address from the emails endpoint
+func fetchGitHubUser(ctx context.Context, p *Provider, accessToken string) (*UserInfo, error) {
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+	if err := p.get(ctx, "https://api.github.com/user", accessToken, &user); err != nil {
+		return nil, fmt.Errorf("failed to fetch github user: %w", err)
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := p.get(ctx, "https://api.github.com/user/emails", accessToken, &emails); err != nil {
+		return nil, fmt.Errorf("failed to fetch github emails: %w", err)
+	}
+
+	info := &UserInfo{
+		ProviderUserID: strconv.FormatInt(user.ID, 10),
+		Name:           user.Name,
+	}
+	if info.Name == "" {
+		info.Name = user.Login
+	}
+	for _, e := range emails {
+		if e.Primary {
+			info.Email = e.Email
+			info.EmailVerified = e.Verified
+			break
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,97 @@
+package pagination
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
+)
+
+// ErrInvalidCursor is returned for cursors this service did not issue, that were altered,
+// or that belong to another listing
+var ErrInvalidCursor = apperr.Invalid("invalid cursor")
+
+// CursorKeys are the sort keys of the last item of a page; the next page starts after them.
+// ID breaks ties between items with equal sort values, such as identical timestamps.
+type CursorKeys struct {
+	Listing   string    `json:"l"`           // Listing the cursor belongs to, e.g. "posts:top"
+	CreatedAt time.Time `json:"t"`           // created_at of the item
+	ID        int64     `json:"i"`           // id of the item
+	Count     int64     `json:"c,omitempty"` // comment_count or like_count in listings sorted by them
+	Distance  float64   `json:"d,omitempty"` // distance_km in listings sorted by distance
+}
+
+var (
+	cursorMu sync.RWMutex
+	// cursorSecret is random until SetCursorSecret is called, so cursors are never signed
+	// with a known key; such cursors stop working when the process restarts
+	cursorSecret = randomSecret()
+)
+
+// SetCursorSecret sets the key cursors are signed with. Instances serving the same
+// listings must share it, or cursors from one are rejected by the others. An empty
+// secret keeps the random per-process key.
+func SetCursorSecret(secret string) {
+	if secret == "" {
+		return
+	}
+	cursorMu.Lock()
+	defer cursorMu.Unlock()
+	cursorSecret = []byte(secret)
+}
+
+// randomSecret returns a random 32-byte key
+func randomSecret() []byte {
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return secret
+}
+
+// EncodeCursor creates an opaque cursor of the keys: their base64url-encoded JSON and its
+// HMAC-SHA256 signature, joined by a dot
+func EncodeCursor(keys CursorKeys) string {
+	payload, _ := json.Marshal(keys)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(sign(encoded))
+}
+
+// DecodeCursor verifies a cursor and returns its keys. It returns ErrInvalidCursor when
+// the signature does not match or the cursor was issued for a listing other than listing.
+func DecodeCursor(cursor string, listing string) (CursorKeys, error) {
+	encoded, signature, ok := strings.Cut(cursor, ".")
+	if !ok {
+		return CursorKeys{}, ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, sign(encoded)) {
+		return CursorKeys{}, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return CursorKeys{}, ErrInvalidCursor
+	}
+
+	var keys CursorKeys
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&keys); err != nil || keys.Listing != listing {
+		return CursorKeys{}, ErrInvalidCursor
+	}
+	return keys, nil
+}
+
+// sign computes the signature of an encoded cursor payload
+func sign(encoded string) []byte {
+	cursorMu.RLock()
+	defer cursorMu.RUnlock()
+	mac := hmac.New(sha256.New, cursorSecret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,91 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	SetCursorSecret("test-secret")
+
+	keys := CursorKeys{
+		Listing:   "posts:comments",
+		CreatedAt: time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.UTC),
+		ID:        42,
+		Count:     7,
+	}
+	got, err := DecodeCursor(EncodeCursor(keys), keys.Listing)
+	if err != nil {
+		t.Fatalf("DecodeCursor: %v", err)
+	}
+	if got.Listing != keys.Listing || got.ID != keys.ID || got.Count != keys.Count || !got.CreatedAt.Equal(keys.CreatedAt) {
+		t.Errorf("DecodeCursor = %+v, want %+v", got, keys)
+	}
+}
+
+func TestCursorTieOnCreatedAt(t *testing.T) {
+	SetCursorSecret("test-secret")
+
+	// Items with the same comment count and timestamp are told apart by their IDs alone
+	createdAt := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	first := EncodeCursor(CursorKeys{Listing: "posts:comments", CreatedAt: createdAt, ID: 10, Count: 3})
+	second := EncodeCursor(CursorKeys{Listing: "posts:comments", CreatedAt: createdAt, ID: 9, Count: 3})
+	if first == second {
+		t.Fatal("cursors of items with equal sort keys but different IDs are equal")
+	}
+
+	for cursor, wantID := range map[string]int64{first: 10, second: 9} {
+		keys, err := DecodeCursor(cursor, "posts:comments")
+		if err != nil {
+			t.Fatalf("DecodeCursor: %v", err)
+		}
+		if keys.ID != wantID || !keys.CreatedAt.Equal(createdAt) || keys.Count != 3 {
+			t.Errorf("DecodeCursor = %+v, want ID %d at %v with count 3", keys, wantID, createdAt)
+		}
+	}
+}
+
+func TestDecodeCursorRejects(t *testing.T) {
+	SetCursorSecret("test-secret")
+	valid := EncodeCursor(CursorKeys{Listing: "posts:comments", CreatedAt: time.Unix(1700000000, 0).UTC(), ID: 5, Count: 2})
+	encoded, signature, _ := strings.Cut(valid, ".")
+
+	forged := base64.RawURLEncoding.EncodeToString([]byte(`{"l":"posts:comments","t":"2024-01-01T00:00:00Z","i":1,"c":999}`))
+	unknownField := base64.RawURLEncoding.EncodeToString([]byte(`{"l":"posts:comments","t":"2024-01-01T00:00:00Z","i":1,"x":1}`))
+	sig := func(payload string) string { return base64.RawURLEncoding.EncodeToString(sign(payload)) }
+
+	tests := map[string]struct {
+		cursor  string
+		listing string
+	}{
+		"empty":                 {"", "posts:comments"},
+		"no signature":          {encoded, "posts:comments"},
+		"tampered signature":    {encoded + "." + strings.Repeat("A", len(signature)), "posts:comments"},
+		"malformed signature":   {encoded + ".!!!", "posts:comments"},
+		"tampered payload":      {forged + "." + signature, "posts:comments"},
+		"other listing":         {valid, "posts:likes"},
+		"unknown field":         {unknownField + "." + sig(unknownField), "posts:comments"},
+		"payload not base64url": {"%%%." + sig("%%%"), "posts:comments"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.cursor, tt.listing); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("DecodeCursor(%q) error = %v, want ErrInvalidCursor", tt.cursor, err)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejectsOtherSecret(t *testing.T) {
+	SetCursorSecret("old-secret")
+	cursor := EncodeCursor(CursorKeys{Listing: "posts:comments", ID: 1})
+
+	SetCursorSecret("new-secret")
+	defer SetCursorSecret("test-secret")
+	if _, err := DecodeCursor(cursor, "posts:comments"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("DecodeCursor of a cursor signed with another secret error = %v, want ErrInvalidCursor", err)
+	}
+}
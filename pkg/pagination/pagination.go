@@ -0,0 +1,74 @@
+// Package pagination supports page-numbered listings with total counts, an alternative to
+// cursor pagination for back-office tools that jump between pages.
+package pagination
+
+import (
+	"fmt"
+)
+
+// Page size limits
+const (
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+)
+
+// Params selects a page; pages are numbered from 1
+type Params struct {
+	Page    int
+	PerPage int
+}
+
+// New validates the page and per_page parameters, defaulting the ones not given
+func New(page *int, perPage *int) (Params, error) {
+	p := Params{Page: 1, PerPage: DefaultPerPage}
+	if page != nil {
+		if *page < 1 {
+			return Params{}, fmt.Errorf("invalid page: must be at least 1")
+		}
+		p.Page = *page
+	}
+	if perPage != nil {
+		if *perPage < 1 || *perPage > MaxPerPage {
+			return Params{}, fmt.Errorf("invalid per_page: must be between 1 and %d", MaxPerPage)
+		}
+		p.PerPage = *perPage
+	}
+	return p, nil
+}
+
+// Offset returns how many items come before the page
+func (p Params) Offset() int {
+	return (p.Page - 1) * p.PerPage
+}
+
+// Info describes a page of a listing
+type Info struct {
+	Page       int   `json:"page"`
+	PerPage    int   `json:"per_page"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// Cursor describes a page of a cursor listing. NextCursor and HasMore repeat the cursor
+// and has_more fields of the listing so clients can read all paging state in one place.
+type Cursor struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+	TotalCount *int64 `json:"total_count,omitempty"` // Only when asked for, since counting costs a query
+}
+
+// NewCursor describes a page of a cursor listing of at most limit items
+func NewCursor(limit int, nextCursor string, hasMore bool) Cursor {
+	return Cursor{Limit: limit, NextCursor: nextCursor, HasMore: hasMore}
+}
+
+// NewInfo describes the page p of a listing with total items
+func NewInfo(p Params, total int64) Info {
+	return Info{
+		Page:       p.Page,
+		PerPage:    p.PerPage,
+		Total:      total,
+		TotalPages: int((total + int64(p.PerPage) - 1) / int64(p.PerPage)),
+	}
+}
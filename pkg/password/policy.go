@@ -0,0 +1,133 @@
+package password
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/fanzru/social-media-service-go/infrastructure/config"
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+)
+
+// PolicyError lists every rule a password breaks
+type PolicyError struct {
+	Violations []string
+}
+
+func (e *PolicyError) Error() string {
+	return "password does not meet the policy: " + strings.Join(e.Violations, "; ")
+}
+
+// Policy checks new passwords against length and character class rules and,
+// optionally, against known breaches using the Pwned Passwords k-anonymity API
+type Policy struct {
+	cfg    *config.PasswordConfig
+	client *http.Client
+}
+
+// NewPolicy creates a password policy from configuration
+func NewPolicy(cfg *config.PasswordConfig) *Policy {
+	return &Policy{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.BreachTimeout},
+	}
+}
+
+// Validate returns a *PolicyError when the password breaks a rule. A failing breach
+// lookup is logged and does not block the password.
+func (p *Policy) Validate(ctx context.Context, password string) error {
+	var violations []string
+
+	length := len([]rune(password))
+	if length < p.cfg.MinLength {
+		violations = append(violations, fmt.Sprintf("password must be at least %d characters", p.cfg.MinLength))
+	}
+	if p.cfg.MaxLength > 0 && len(password) > p.cfg.MaxLength {
+		violations = append(violations, fmt.Sprintf("password must be at most %d bytes", p.cfg.MaxLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.cfg.RequireUpper && !hasUpper {
+		violations = append(violations, "password must contain an uppercase letter")
+	}
+	if p.cfg.RequireLower && !hasLower {
+		violations = append(violations, "password must contain a lowercase letter")
+	}
+	if p.cfg.RequireDigit && !hasDigit {
+		violations = append(violations, "password must contain a digit")
+	}
+	if p.cfg.RequireSymbol && !hasSymbol {
+		violations = append(violations, "password must contain a symbol")
+	}
+
+	// Only spend a network call on passwords that pass the local rules
+	if len(violations) == 0 && p.cfg.CheckBreached {
+		breached, err := p.isBreached(ctx, password)
+		if err != nil {
+			logger.GetGlobal().Warn("Breached password check failed", "error", err.Error())
+		} else if breached {
+			violations = append(violations, "password has appeared in a data breach, choose another one")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// isBreached looks the password up by the first five characters of its SHA-1, so
+// neither the password nor its full hash leaves the service
+func (p *Policy) isBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.BreachTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(p.cfg.BreachAPIURL, "/")+"/"+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	// Padding hides the real number of matches from observers of the response size
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("breach API returned status %d", resp.StatusCode)
+	}
+
+	// Each line is "<hash suffix>:<count>"; padding entries have a count of 0
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		candidate, count, ok := strings.Cut(line, ":")
+		if ok && candidate == suffix && count != "0" {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
@@ -1,7 +1,12 @@
 package prometheus
 
 import (
+	"crypto/subtle"
+	"fmt"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -90,19 +95,24 @@ func (m *Metrics) HTTPMiddleware(next http.Handler) http.Handler {
 		method := r.Method
 		endpoint := normalizeEndpoint(r.URL.Path)
 		status := wrapped.statusCode
+		if status == http.StatusNotFound {
+			// Unknown paths, e.g. from scanners, would each add a series
+			endpoint = "not_found"
+		}
 		statusStr := getStatusString(status)
 
 		// Record metrics
 		m.HTTPRequestsTotal.WithLabelValues(method, endpoint, statusStr).Inc()
 		m.HTTPRequestDuration.WithLabelValues(method, endpoint).Observe(duration.Seconds())
 
-		// Record response size if available
+		// Record response size, as declared or else as written
+		size := wrapped.written
 		if contentLength := wrapped.Header().Get("Content-Length"); contentLength != "" {
-			// Parse content length and record
-			if size, err := parseContentLength(contentLength); err == nil {
-				m.ResponseSize.WithLabelValues(method, endpoint).Observe(float64(size))
+			if declared, err := parseContentLength(contentLength); err == nil {
+				size = declared
 			}
 		}
+		m.ResponseSize.WithLabelValues(method, endpoint).Observe(float64(size))
 	})
 }
 
@@ -122,10 +132,31 @@ func (m *Metrics) Handler() http.Handler {
 	return promhttp.Handler()
 }
 
-// responseWriter wraps http.ResponseWriter to capture status code
+// BasicAuth requires the given credentials to reach next, e.g. to keep the metrics
+// handler private. An empty username leaves next open.
+func BasicAuth(next http.Handler, username, password string) http.Handler {
+	if username == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		// Compare both in constant time so neither leaks through timing
+		userOK := subtle.ConstantTimeCompare([]byte(user), []byte(username)) == 1
+		passOK := subtle.ConstantTimeCompare([]byte(pass), []byte(password)) == 1
+		if !ok || !userOK || !passOK {
+			w.Header().Set("WWW-Authenticate", `Basic realm="metrics", charset="UTF-8"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// responseWriter wraps http.ResponseWriter to capture status code and body size
 type responseWriter struct {
 	http.ResponseWriter
 	statusCode int
+	written    int64
 }
 
 func (rw *responseWriter) WriteHeader(code int) {
@@ -133,20 +164,35 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
-// normalizeEndpoint normalizes the endpoint for metrics
-func normalizeEndpoint(path string) string {
-	// Remove leading slash
-	if len(path) > 0 && path[0] == '/' {
-		path = path[1:]
-	}
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	n, err := rw.ResponseWriter.Write(b)
+	rw.written += int64(n)
+	return n, err
+}
 
-	// Replace numeric IDs with placeholder
-	// This is a simple implementation - you might want to use regex for more complex cases
+// Unwrap lets http.ResponseController reach the underlying writer
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// uuidSegment matches a path segment holding a UUID
+var uuidSegment = regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// normalizeEndpoint normalizes the endpoint for metrics: the leading slash is removed and
+// segments holding numeric IDs or UUIDs become {id}, so each route is one series
+func normalizeEndpoint(path string) string {
+	path = strings.Trim(path, "/")
 	if path == "" {
 		return "root"
 	}
 
-	return path
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if _, err := strconv.ParseUint(segment, 10, 64); err == nil || uuidSegment.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
 }
 
 // getStatusString converts status code to string category
@@ -167,6 +213,12 @@ func getStatusString(status int) string {
 
 // parseContentLength parses content length string to int64
 func parseContentLength(contentLength string) (int64, error) {
-	// Simple implementation - you might want to use strconv.ParseInt for more robust parsing
-	return 0, nil // Placeholder implementation
+	size, err := strconv.ParseInt(strings.TrimSpace(contentLength), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if size < 0 {
+		return 0, fmt.Errorf("negative content length %d", size)
+	}
+	return size, nil
 }
@@ -0,0 +1,139 @@
+package prometheus
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// durationBuckets are the histogram buckets of timings, in milliseconds
+var durationBuckets = []float64{1, 2.5, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// Sink exposes the metrics written through metrics.Sink, e.g. by MetricsMiddleware and
+// sqlwrap, at the Prometheus endpoint next to those of Metrics. Counters become
+// counters, timings histograms in milliseconds and gauges gauges, named after the metric
+// with the namespace prepended and labelled with its tags. A metric keeps the tag names
+// it was first written with; writes with other tags are rejected.
+type Sink struct {
+	namespace  string
+	registerer prometheus.Registerer
+
+	mu         sync.Mutex
+	counters   map[string]*prometheus.CounterVec
+	histograms map[string]*prometheus.HistogramVec
+	gauges     map[string]*prometheus.GaugeVec
+	labels     map[string][]string // Tag names of each metric, sorted
+}
+
+// NewSink creates a sink registering its metrics with the default registerer, the one
+// Handler serves
+func NewSink(namespace string) *Sink {
+	return &Sink{
+		namespace:  namespace,
+		registerer: prometheus.DefaultRegisterer,
+		counters:   make(map[string]*prometheus.CounterVec),
+		histograms: make(map[string]*prometheus.HistogramVec),
+		gauges:     make(map[string]*prometheus.GaugeVec),
+		labels:     make(map[string][]string),
+	}
+}
+
+// WriteCounter adds value to a counter
+func (s *Sink) WriteCounter(name string, tags map[string]string, value int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	labels, err := s.labelNames(name, tags)
+	if err != nil {
+		return err
+	}
+	vec, ok := s.counters[name]
+	if !ok {
+		vec = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: s.namespace,
+			Name:      name,
+			Help:      fmt.Sprintf("Counter %s written through the metrics sink", name),
+		}, labels)
+		if err := s.registerer.Register(vec); err != nil {
+			return fmt.Errorf("register counter %s: %w", name, err)
+		}
+		s.counters[name] = vec
+	}
+	vec.With(tags).Add(float64(value))
+	return nil
+}
+
+// WriteTiming observes a duration, in milliseconds
+func (s *Sink) WriteTiming(name string, tags map[string]string, duration time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	labels, err := s.labelNames(name, tags)
+	if err != nil {
+		return err
+	}
+	vec, ok := s.histograms[name]
+	if !ok {
+		vec = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: s.namespace,
+			Name:      name,
+			Help:      fmt.Sprintf("Timing %s written through the metrics sink, in milliseconds", name),
+			Buckets:   durationBuckets,
+		}, labels)
+		if err := s.registerer.Register(vec); err != nil {
+			return fmt.Errorf("register timing %s: %w", name, err)
+		}
+		s.histograms[name] = vec
+	}
+	vec.With(tags).Observe(float64(duration) / float64(time.Millisecond))
+	return nil
+}
+
+// WriteGauge sets a gauge
+func (s *Sink) WriteGauge(name string, tags map[string]string, value float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	labels, err := s.labelNames(name, tags)
+	if err != nil {
+		return err
+	}
+	vec, ok := s.gauges[name]
+	if !ok {
+		vec = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: s.namespace,
+			Name:      name,
+			Help:      fmt.Sprintf("Gauge %s written through the metrics sink", name),
+		}, labels)
+		if err := s.registerer.Register(vec); err != nil {
+			return fmt.Errorf("register gauge %s: %w", name, err)
+		}
+		s.gauges[name] = vec
+	}
+	vec.With(tags).Set(value)
+	return nil
+}
+
+// labelNames returns the sorted tag names of a metric, remembering them on first use and
+// failing when they differ from those. The caller holds s.mu.
+func (s *Sink) labelNames(name string, tags map[string]string) ([]string, error) {
+	names := make([]string, 0, len(tags))
+	for k := range tags {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	known, ok := s.labels[name]
+	if !ok {
+		s.labels[name] = names
+		return names, nil
+	}
+	if strings.Join(known, ",") != strings.Join(names, ",") {
+		return nil, fmt.Errorf("metric %s has tags %v, not %v", name, known, names)
+	}
+	return known, nil
+}
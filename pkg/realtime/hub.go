@@ -0,0 +1,314 @@
+// Package realtime pushes events to clients connected over WebSocket or Server-Sent
+// Events. WebSocket clients subscribe to the posts they are viewing to receive new
+// comments, and signed-in clients of either kind receive notifications meant for their
+// account.
+package realtime
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"golang.org/x/net/websocket"
+)
+
+// Event types sent to clients
+const (
+	EventCommentCreated = "comment.created" // A comment was added to a subscribed post
+	EventNotification   = "notification"    // Something happened that concerns the account
+	EventPostMedia      = "post.media"      // The image of one of the account's posts finished processing
+	EventSubscribed     = "subscribed"      // Reply to a subscribe message
+	EventUnsubscribed   = "unsubscribed"    // Reply to an unsubscribe message
+	EventError          = "error"           // A client message could not be handled
+	EventPing           = "ping"            // Keepalive; clients need not answer
+)
+
+// Notification kinds
+const (
+	NotificationComment = "comment" // Someone commented on the account's post
+	NotificationMessage = "message" // Someone sent the account a direct message
+)
+
+// Connection limits
+const (
+	maxSubscriptions = 50
+	maxMessageBytes  = 4096
+	sendBuffer       = 64
+	pingInterval     = 30 * time.Second
+	writeTimeout     = 10 * time.Second
+)
+
+// Event is a message pushed to a client
+type Event struct {
+	ID      int64       `json:"id,omitempty"` // Set on account events, which can be replayed
+	Type    string      `json:"type"`
+	PostID  int64       `json:"post_id,omitempty"`
+	Message string      `json:"message,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+
+	at time.Time // When an account event was published
+}
+
+// Notification is the data of a notification event
+type Notification struct {
+	Kind           string `json:"kind"`
+	ActorID        int64  `json:"actor_id"`
+	PostID         int64  `json:"post_id,omitempty"`
+	CommentID      int64  `json:"comment_id,omitempty"`
+	ConversationID int64  `json:"conversation_id,omitempty"`
+	MessageID      int64  `json:"message_id,omitempty"`
+}
+
+// PostMedia is the data of a post media event
+type PostMedia struct {
+	PostID      int64  `json:"post_id"`
+	MediaStatus string `json:"media_status"` // ready or failed
+	ImageURL    string `json:"image_url,omitempty"`
+}
+
+// Publisher delivers events to the connected clients interested in them. Publishing
+// never blocks; services may hold a nil Publisher when real-time updates are off.
+type Publisher interface {
+	PublishToPost(postID int64, eventType string, data interface{})
+	PublishToAccount(accountID int64, eventType string, data interface{})
+}
+
+// PostAccessFunc reports whether an account (0 for anonymous) may see a post
+type PostAccessFunc func(ctx context.Context, postID, viewerID int64) (bool, error)
+
+// clientMessage is a message sent by a client
+type clientMessage struct {
+	Action string `json:"action"` // "subscribe" or "unsubscribe"
+	PostID int64  `json:"post_id"`
+}
+
+// client is one WebSocket connection
+type client struct {
+	conn      *websocket.Conn
+	accountID int64
+	send      chan Event
+	posts     map[int64]bool // Only touched by the connection's reader
+	closeOnce sync.Once
+}
+
+// Hub tracks the connected clients and what they subscribed to
+type Hub struct {
+	canView PostAccessFunc
+
+	mu        sync.RWMutex
+	posts     map[int64]map[*client]bool
+	accounts  map[int64]map[*client]bool
+	streams   map[int64]map[*stream]bool
+	history   map[int64][]Event // Recent account events, oldest first
+	nextID    int64
+	lastSweep time.Time
+}
+
+// NewHub creates a hub; canView decides who may subscribe to a post
+func NewHub(canView PostAccessFunc) *Hub {
+	return &Hub{
+		canView:  canView,
+		posts:    map[int64]map[*client]bool{},
+		accounts: map[int64]map[*client]bool{},
+		streams:  map[int64]map[*stream]bool{},
+		history:  map[int64][]Event{},
+		// Start from the clock so IDs keep increasing across restarts and a client
+		// resuming with an ID from before never skips newer events
+		nextID:    time.Now().UnixMilli(),
+		lastSweep: time.Now(),
+	}
+}
+
+// PublishToPost sends an event to the clients subscribed to a post
+func (h *Hub) PublishToPost(postID int64, eventType string, data interface{}) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for c := range h.posts[postID] {
+		c.push(Event{Type: eventType, PostID: postID, Data: data})
+	}
+}
+
+// PublishToAccount sends an event to every connection of an account and keeps it for
+// event streams that reconnect later
+func (h *Hub) PublishToAccount(accountID int64, eventType string, data interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.nextID++
+	e := Event{ID: h.nextID, Type: eventType, Data: data}
+	h.remember(accountID, e)
+
+	for c := range h.accounts[accountID] {
+		c.push(e)
+	}
+	for s := range h.streams[accountID] {
+		s.push(e)
+	}
+}
+
+// Handler upgrades requests to WebSocket connections. It must be mounted behind the auth
+// middleware, without wrappers that hide http.Hijacker, so the caller's account is known.
+func (h *Hub) Handler() http.Handler {
+	// Origins are not checked: the account comes from the Authorization header, which
+	// browsers never attach to cross-site WebSocket handshakes on their own
+	return websocket.Server{Handler: h.serve}
+}
+
+// serve runs one connection: a writer goroutine drains the send queue while the reader
+// handles subscription messages until the client goes away
+func (h *Hub) serve(conn *websocket.Conn) {
+	ctx := conn.Request().Context()
+	accountID, _ := middleware.GetUserID(ctx)
+
+	conn.MaxPayloadBytes = maxMessageBytes
+	// The connection outlives the server's request timeouts; the writer sets its own deadlines
+	conn.SetDeadline(time.Time{})
+	c := &client{
+		conn:      conn,
+		accountID: accountID,
+		send:      make(chan Event, sendBuffer),
+		posts:     map[int64]bool{},
+	}
+
+	h.register(c)
+	defer h.unregister(c)
+	go c.writeLoop()
+
+	logger.GetGlobal().Info("WebSocket connected", "user_id", accountID)
+	for {
+		var msg clientMessage
+		if err := websocket.JSON.Receive(conn, &msg); err != nil {
+			logger.GetGlobal().Info("WebSocket disconnected", "user_id", accountID)
+			return
+		}
+		h.handle(ctx, c, msg)
+	}
+}
+
+// handle applies a subscription message and acknowledges it
+func (h *Hub) handle(ctx context.Context, c *client, msg clientMessage) {
+	switch msg.Action {
+	case "subscribe":
+		if c.posts[msg.PostID] {
+			c.push(Event{Type: EventSubscribed, PostID: msg.PostID})
+			return
+		}
+		if len(c.posts) >= maxSubscriptions {
+			c.push(Event{Type: EventError, PostID: msg.PostID, Message: "too many subscriptions"})
+			return
+		}
+		allowed, err := h.canView(ctx, msg.PostID, c.accountID)
+		if err != nil {
+			logger.GetGlobal().Error("Failed to check post access", "post_id", msg.PostID, "error", err.Error())
+			c.push(Event{Type: EventError, PostID: msg.PostID, Message: "failed to subscribe"})
+			return
+		}
+		if !allowed {
+			c.push(Event{Type: EventError, PostID: msg.PostID, Message: "post not found"})
+			return
+		}
+
+		h.mu.Lock()
+		if h.posts[msg.PostID] == nil {
+			h.posts[msg.PostID] = map[*client]bool{}
+		}
+		h.posts[msg.PostID][c] = true
+		h.mu.Unlock()
+		c.posts[msg.PostID] = true
+		c.push(Event{Type: EventSubscribed, PostID: msg.PostID})
+	case "unsubscribe":
+		h.mu.Lock()
+		h.removeFromPost(msg.PostID, c)
+		h.mu.Unlock()
+		delete(c.posts, msg.PostID)
+		c.push(Event{Type: EventUnsubscribed, PostID: msg.PostID})
+	default:
+		c.push(Event{Type: EventError, Message: "action must be subscribe or unsubscribe"})
+	}
+}
+
+func (h *Hub) register(c *client) {
+	if c.accountID == 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.accounts[c.accountID] == nil {
+		h.accounts[c.accountID] = map[*client]bool{}
+	}
+	h.accounts[c.accountID][c] = true
+}
+
+// unregister drops every subscription of the client and stops its writer
+func (h *Hub) unregister(c *client) {
+	h.mu.Lock()
+	for postID := range c.posts {
+		h.removeFromPost(postID, c)
+	}
+	if clients := h.accounts[c.accountID]; clients != nil {
+		delete(clients, c)
+		if len(clients) == 0 {
+			delete(h.accounts, c.accountID)
+		}
+	}
+	close(c.send)
+	h.mu.Unlock()
+
+	c.close()
+}
+
+// removeFromPost must be called with h.mu held
+func (h *Hub) removeFromPost(postID int64, c *client) {
+	if clients := h.posts[postID]; clients != nil {
+		delete(clients, c)
+		if len(clients) == 0 {
+			delete(h.posts, postID)
+		}
+	}
+}
+
+// push queues an event without blocking; a client too slow to keep up is disconnected.
+// Callers either hold h.mu or run on the connection's reader, so send is still open.
+func (c *client) push(e Event) {
+	select {
+	case c.send <- e:
+	default:
+		logger.GetGlobal().Warn("WebSocket client too slow, disconnecting", "user_id", c.accountID)
+		go c.close()
+	}
+}
+
+// writeLoop sends queued events and keepalive pings until the queue is closed or a write fails
+func (c *client) writeLoop() {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		var e Event
+		select {
+		case queued, ok := <-c.send:
+			if !ok {
+				return
+			}
+			e = queued
+		case <-ticker.C:
+			e = Event{Type: EventPing}
+		}
+
+		c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if err := websocket.JSON.Send(c.conn, e); err != nil {
+			c.close()
+			return
+		}
+	}
+}
+
+// close ends the connection, which makes the reader return and unregister the client
+func (c *client) close() {
+	c.closeOnce.Do(func() {
+		c.conn.Close()
+	})
+}
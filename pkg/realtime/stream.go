@@ -0,0 +1,208 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/middleware"
+	"github.com/fanzru/social-media-service-go/pkg/response"
+)
+
+// Event stream limits
+const (
+	historySize       = 100              // Account events kept for replay
+	historyTTL        = 10 * time.Minute // How long an event can be replayed
+	keepAliveInterval = 15 * time.Second // Comment lines that stop proxies closing idle streams
+	retryMillis       = 5000             // Reconnect delay suggested to EventSource clients
+)
+
+// stream is one Server-Sent Events connection
+type stream struct {
+	accountID int64
+	send      chan Event
+	dropped   chan struct{} // Closed when the client falls behind
+	dropOnce  sync.Once
+}
+
+// StreamHandler serves the caller's account events as Server-Sent Events. Clients that
+// reconnect with a Last-Event-ID header first receive the events they missed, as long as
+// they are still in the recent history. It must be mounted behind the auth middleware
+// with a rule that requires a signed-in account.
+func (h *Hub) StreamHandler() http.Handler {
+	return http.HandlerFunc(h.serveStream)
+}
+
+func (h *Hub) serveStream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	accountID, ok := middleware.GetUserID(ctx)
+	if !ok {
+		response.Unauthorized(ctx, "User not authenticated", []string{}).Send(w, http.StatusUnauthorized)
+		return
+	}
+
+	var lastEventID int64
+	if header := r.Header.Get("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseInt(header, 10, 64)
+		if err != nil || id < 0 {
+			response.BadRequest(ctx, "Invalid Last-Event-ID", []string{"Last-Event-ID must be an event ID from this stream"}).Send(w, http.StatusBadRequest)
+			return
+		}
+		lastEventID = id
+	}
+
+	rc := http.NewResponseController(w)
+	// The stream outlives the server's write timeout, so each write gets its own deadline
+	if err := rc.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		logger.GetGlobal().Warn("Event stream write deadline cannot be set", "error", err.Error())
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", retryMillis)
+	if err := rc.Flush(); err != nil {
+		logger.GetGlobal().Error("Event stream cannot be flushed", "error", err.Error())
+		return
+	}
+
+	s := &stream{
+		accountID: accountID,
+		send:      make(chan Event, sendBuffer),
+		dropped:   make(chan struct{}),
+	}
+	missed := h.registerStream(s, lastEventID)
+	defer h.unregisterStream(s)
+
+	logger.GetGlobal().Info("Event stream connected", "user_id", accountID, "replayed", len(missed))
+	for _, e := range missed {
+		if err := writeEvent(w, e); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case e := <-s.send:
+			rc.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		case <-ticker.C:
+			rc.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case <-s.dropped:
+			logger.GetGlobal().Warn("Event stream client too slow, disconnecting", "user_id", accountID)
+			return
+		case <-ctx.Done():
+			logger.GetGlobal().Info("Event stream disconnected", "user_id", accountID)
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes one event in the text/event-stream format; the data line holds the
+// event's data as JSON
+func writeEvent(w http.ResponseWriter, e Event) error {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
+
+// registerStream adds the stream and returns the kept events newer than lastEventID. Both
+// happen under the lock so no event is missed or sent twice in between.
+func (h *Hub) registerStream(s *stream, lastEventID int64) []Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.streams[s.accountID] == nil {
+		h.streams[s.accountID] = map[*stream]bool{}
+	}
+	h.streams[s.accountID][s] = true
+
+	if lastEventID == 0 {
+		return nil
+	}
+	var missed []Event
+	for _, e := range expire(h.history[s.accountID], time.Now()) {
+		if e.ID > lastEventID {
+			missed = append(missed, e)
+		}
+	}
+	return missed
+}
+
+func (h *Hub) unregisterStream(s *stream) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if streams := h.streams[s.accountID]; streams != nil {
+		delete(streams, s)
+		if len(streams) == 0 {
+			delete(h.streams, s.accountID)
+		}
+	}
+}
+
+// push queues an event without blocking; a stream too slow to keep up is dropped
+func (s *stream) push(e Event) {
+	select {
+	case s.send <- e:
+	default:
+		s.dropOnce.Do(func() {
+			close(s.dropped)
+		})
+	}
+}
+
+// remember keeps an account event for replay, dropping the oldest past historySize. Once
+// a minute it also forgets the history of accounts with nothing recent, so the map only
+// holds accounts active within historyTTL. It must be called with h.mu held.
+func (h *Hub) remember(accountID int64, e Event) {
+	now := time.Now()
+	e.at = now
+	events := append(h.history[accountID], e)
+	if len(events) > historySize {
+		events = events[len(events)-historySize:]
+	}
+	h.history[accountID] = expire(events, now)
+
+	if now.Sub(h.lastSweep) < time.Minute {
+		return
+	}
+	h.lastSweep = now
+	for id, events := range h.history {
+		if kept := expire(events, now); len(kept) > 0 {
+			h.history[id] = kept
+		} else {
+			delete(h.history, id)
+		}
+	}
+}
+
+// expire drops the events published more than historyTTL ago
+func expire(events []Event, now time.Time) []Event {
+	for i, e := range events {
+		if now.Sub(e.at) < historyTTL {
+			return events[i:]
+		}
+	}
+	return nil
+}
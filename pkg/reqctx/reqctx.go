@@ -2,14 +2,38 @@ package reqctx
 
 import (
 	"context"
+	"net"
 	"net/http"
+	"strings"
 
+	"github.com/fanzru/social-media-service-go/pkg/i18n"
 	"github.com/google/uuid"
 )
 
 // RequestIDKey is the key used to store request ID in context
 type RequestIDKey struct{}
 
+// ClientIPKey is the key used to store the client IP address in context
+type ClientIPKey struct{}
+
+// UserAgentKey is the key used to store the client user agent in context
+type UserAgentKey struct{}
+
+// AcceptKey is the key used to store the request's Accept header in context
+type AcceptKey struct{}
+
+// RequestPathKey is the key used to store the request path in context
+type RequestPathKey struct{}
+
+// LocaleKey is the key used to store the locale selected by Accept-Language in context
+type LocaleKey struct{}
+
+// WarningsKey is the key used to store warnings for the response in context
+type WarningsKey struct{}
+
+// DebugKey is the key used to store the debug information of the response in context
+type DebugKey struct{}
+
 // GetRequestID extracts request ID from context
 func GetRequestID(ctx context.Context) string {
 	if requestID, ok := ctx.Value(RequestIDKey{}).(string); ok {
@@ -23,6 +47,93 @@ func SetRequestID(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, RequestIDKey{}, requestID)
 }
 
+// GetClientIP extracts the client IP address from context
+func GetClientIP(ctx context.Context) string {
+	if ip, ok := ctx.Value(ClientIPKey{}).(string); ok {
+		return ip
+	}
+	return ""
+}
+
+// GetUserAgent extracts the client user agent from context
+func GetUserAgent(ctx context.Context) string {
+	if userAgent, ok := ctx.Value(UserAgentKey{}).(string); ok {
+		return userAgent
+	}
+	return ""
+}
+
+// GetAccept extracts the request's Accept header from context
+func GetAccept(ctx context.Context) string {
+	if accept, ok := ctx.Value(AcceptKey{}).(string); ok {
+		return accept
+	}
+	return ""
+}
+
+// GetRequestPath extracts the request path from context
+func GetRequestPath(ctx context.Context) string {
+	if path, ok := ctx.Value(RequestPathKey{}).(string); ok {
+		return path
+	}
+	return ""
+}
+
+// GetLocale extracts the locale selected for the response from context
+func GetLocale(ctx context.Context) string {
+	if locale, ok := ctx.Value(LocaleKey{}).(string); ok {
+		return locale
+	}
+	return ""
+}
+
+// AddWarning adds a warning to be sent in the response, e.g. that the endpoint is deprecated
+func AddWarning(ctx context.Context, warning string) context.Context {
+	existing := GetWarnings(ctx)
+	warnings := make([]string, 0, len(existing)+1)
+	warnings = append(append(warnings, existing...), warning)
+	return context.WithValue(ctx, WarningsKey{}, warnings)
+}
+
+// GetWarnings extracts the warnings for the response from context
+func GetWarnings(ctx context.Context) []string {
+	if warnings, ok := ctx.Value(WarningsKey{}).([]string); ok {
+		return warnings
+	}
+	return nil
+}
+
+// SetDebug makes the response carry the value debug returns when the response is sent,
+// e.g. timings collected while the request was handled
+func SetDebug(ctx context.Context, debug func() interface{}) context.Context {
+	return context.WithValue(ctx, DebugKey{}, debug)
+}
+
+// GetDebug returns the debug information for the response, or nil when none was requested
+func GetDebug(ctx context.Context) interface{} {
+	if debug, ok := ctx.Value(DebugKey{}).(func() interface{}); ok {
+		return debug()
+	}
+	return nil
+}
+
+// ExtractClientIP returns the originating client IP, preferring the first
+// X-Forwarded-For entry set by a reverse proxy
+func ExtractClientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		if ip := strings.TrimSpace(strings.Split(forwarded, ",")[0]); ip != "" {
+			return ip
+		}
+	}
+	if ip := r.Header.Get("X-Real-Ip"); ip != "" {
+		return ip
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
 // ExtractRequestIDFromHeader extracts request ID from HTTP header
 func ExtractRequestIDFromHeader(r *http.Request) string {
 	// Try X-Request-Id header first
@@ -39,11 +150,18 @@ func ExtractRequestIDFromHeader(r *http.Request) string {
 	return generateRequestID()
 }
 
-// Middleware creates a middleware that extracts request ID and adds it to context
+// Middleware creates a middleware that extracts request ID, trace context and client info
+// and adds them to context
 func Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		requestID := ExtractRequestIDFromHeader(r)
 		ctx := SetRequestID(r.Context(), requestID)
+		ctx = SetTrace(ctx, ExtractTrace(r, requestID))
+		ctx = context.WithValue(ctx, ClientIPKey{}, ExtractClientIP(r))
+		ctx = context.WithValue(ctx, UserAgentKey{}, r.UserAgent())
+		ctx = context.WithValue(ctx, AcceptKey{}, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, RequestPathKey{}, r.URL.Path)
+		ctx = context.WithValue(ctx, LocaleKey{}, i18n.Negotiate(r.Header.Get("Accept-Language")))
 
 		// Add request ID to response header for tracing
 		w.Header().Set("X-Request-Id", requestID)
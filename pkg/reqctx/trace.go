@@ -0,0 +1,140 @@
+package reqctx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// W3C Trace Context headers, see https://www.w3.org/TR/trace-context/
+const (
+	TraceparentHeader = "traceparent"
+	TracestateHeader  = "tracestate"
+)
+
+// TraceKey is the key used to store the trace context of the request in context
+type TraceKey struct{}
+
+// Trace is the W3C trace context of a request: the trace it belongs to and the span this
+// service handles it in
+type Trace struct {
+	TraceID  string // 32 lowercase hex digits
+	ParentID string // Span of the caller; empty when the trace starts here
+	SpanID   string // Span of this service, 16 lowercase hex digits
+	Flags    string // Trace flags, e.g. 01 when the caller samples the trace
+	State    string // Vendor data of the tracestate header, passed on as is
+}
+
+// Traceparent returns the traceparent header calls made while handling the request send,
+// naming this service's span as their parent
+func (t Trace) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-%s", t.TraceID, t.SpanID, t.Flags)
+}
+
+// GetTrace extracts the trace context of the request from context
+func GetTrace(ctx context.Context) (Trace, bool) {
+	trace, ok := ctx.Value(TraceKey{}).(Trace)
+	return trace, ok
+}
+
+// SetTrace sets the trace context in context
+func SetTrace(ctx context.Context, trace Trace) context.Context {
+	return context.WithValue(ctx, TraceKey{}, trace)
+}
+
+// GetTraceID extracts the trace ID of the request from context
+func GetTraceID(ctx context.Context) string {
+	trace, _ := GetTrace(ctx)
+	return trace.TraceID
+}
+
+// ExtractTrace returns the trace context of a request with a new span of this service. A
+// valid traceparent header continues the caller's trace; otherwise a trace is started,
+// with the request ID as its ID when that is a UUID, so the two can be matched.
+func ExtractTrace(r *http.Request, requestID string) Trace {
+	trace := Trace{SpanID: randomHex(8), Flags: "00"}
+	if traceID, parentID, flags, ok := parseTraceparent(r.Header.Get(TraceparentHeader)); ok {
+		trace.TraceID, trace.ParentID, trace.Flags = traceID, parentID, flags
+		trace.State = r.Header.Get(TracestateHeader)
+		return trace
+	}
+
+	if id := strings.ToLower(strings.ReplaceAll(requestID, "-", "")); isTraceHex(id, 32) {
+		trace.TraceID = id
+	} else {
+		trace.TraceID = randomHex(16)
+	}
+	return trace
+}
+
+// parseTraceparent splits a traceparent header into its trace ID, parent span ID and
+// flags, reporting whether it is valid. Versions other than 00 are read as 00, as the
+// specification asks.
+func parseTraceparent(header string) (traceID, parentID, flags string, ok bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 {
+		return "", "", "", false
+	}
+	version, traceID, parentID, flags := parts[0], parts[1], parts[2], parts[3]
+	if !isTraceHex(version, 2) || version == "ff" || (version == "00" && len(parts) != 4) {
+		return "", "", "", false
+	}
+	if !isTraceHex(traceID, 32) || !isTraceHex(parentID, 16) || !isTraceHex(flags, 2) {
+		return "", "", "", false
+	}
+	return traceID, parentID, flags, true
+}
+
+// isTraceHex reports whether s is n lowercase hex digits, not all zero
+func isTraceHex(s string, n int) bool {
+	if len(s) != n || (n > 2 && strings.Trim(s, "0") == "") {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// randomHex returns n random bytes as hex
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// Transport returns a RoundTripper that sends the trace context of each request's context
+// on with it, so calls to other services, e.g. S3 and InfluxDB, join the trace. base is
+// http.DefaultTransport when nil.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &traceTransport{base: base}
+}
+
+// traceTransport adds the traceparent and tracestate headers to requests made in a traced
+// context
+type traceTransport struct {
+	base http.RoundTripper
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	trace, ok := GetTrace(req.Context())
+	if !ok || req.Header.Get(TraceparentHeader) != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the request it is given
+	req = req.Clone(req.Context())
+	req.Header.Set(TraceparentHeader, trace.Traceparent())
+	if trace.State != "" {
+		req.Header.Set(TracestateHeader, trace.State)
+	}
+	return t.base.RoundTrip(req)
+}
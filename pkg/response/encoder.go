@@ -0,0 +1,152 @@
+package response
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fanzru/social-media-service-go/pkg/msgpack"
+	"github.com/fanzru/social-media-service-go/pkg/reqctx"
+	"google.golang.org/protobuf/proto"
+)
+
+// Media types of the built-in encoders
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeMsgPack  = "application/msgpack"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// Encoder renders responses in one media type. Encode returns errors.ErrUnsupported for
+// responses it cannot represent, and the next acceptable encoder is tried.
+type Encoder interface {
+	ContentType() string
+	Encode(resp *Response) ([]byte, error)
+}
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = map[string]Encoder{
+		ContentTypeJSON:     jsonEncoder{},
+		ContentTypeMsgPack:  msgpackEncoder{},
+		ContentTypeProtobuf: protobufEncoder{},
+	}
+)
+
+// RegisterEncoder adds an encoder, or replaces the one of the same media type
+func RegisterEncoder(e Encoder) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+	encoders[e.ContentType()] = e
+}
+
+// SendNegotiated sends the response in the media type the request's Accept header
+// prefers among the registered encoders, falling back to JSON. Errors are sent as with Send.
+func (rb *ResponseBuilder) SendNegotiated(w http.ResponseWriter, r *http.Request, statusCode int) {
+	addVary(w.Header(), "Accept")
+	rb.localize(w)
+	if rb.sendProblem(w, statusCode) {
+		return
+	}
+	rb.response.Debug = reqctx.GetDebug(rb.ctx)
+	for _, e := range acceptedEncoders(r.Header.Get("Accept")) {
+		body, err := e.Encode(rb.response)
+		if errors.Is(err, errors.ErrUnsupported) {
+			continue
+		}
+		if err != nil {
+			break
+		}
+		w.Header().Set("Content-Type", e.ContentType())
+		w.WriteHeader(statusCode)
+		w.Write(body)
+		return
+	}
+	rb.Send(w, statusCode)
+}
+
+// acceptedEncoders returns the registered encoders named in an Accept header, most
+// preferred first; wildcards are left to the JSON fallback
+func acceptedEncoders(accept string) []Encoder {
+	type candidate struct {
+		encoder Encoder
+		q       float64
+	}
+
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+
+	var candidates []candidate
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		e, ok := encoders[mediaType]
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{encoder: e, q: q})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	list := make([]Encoder, len(candidates))
+	for i, c := range candidates {
+		list[i] = c.encoder
+	}
+	return list
+}
+
+// Prefers reports whether the media type is the one the request's Accept header prefers
+// among the registered encoders, e.g. to convert data to a protobuf message only for
+// clients that asked for protobuf first
+func Prefers(r *http.Request, contentType string) bool {
+	accepted := acceptedEncoders(r.Header.Get("Accept"))
+	return len(accepted) > 0 && accepted[0].ContentType() == contentType
+}
+
+type jsonEncoder struct{}
+
+func (jsonEncoder) ContentType() string { return ContentTypeJSON }
+
+func (jsonEncoder) Encode(resp *Response) ([]byte, error) {
+	return marshalJSON(resp)
+}
+
+// msgpackEncoder encodes the whole response, with the same field names as JSON
+type msgpackEncoder struct{}
+
+func (msgpackEncoder) ContentType() string { return ContentTypeMsgPack }
+
+func (msgpackEncoder) Encode(resp *Response) ([]byte, error) {
+	return msgpack.Marshal(resp)
+}
+
+// protobufEncoder encodes responses whose data is a protobuf message, such as the
+// messages generated for the gRPC API. Only the data is sent; the request ID stays
+// available in the X-Request-Id header.
+type protobufEncoder struct{}
+
+func (protobufEncoder) ContentType() string { return ContentTypeProtobuf }
+
+func (protobufEncoder) Encode(resp *Response) ([]byte, error) {
+	msg, ok := resp.Data.(proto.Message)
+	if !ok {
+		return nil, errors.ErrUnsupported
+	}
+	return proto.Marshal(msg)
+}
@@ -0,0 +1,59 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
+)
+
+// errorKinds maps the kinds of domain errors to the status and code they are sent with
+var errorKinds = []struct {
+	kind   error
+	status int
+	code   string
+}{
+	{apperr.ErrInvalid, http.StatusBadRequest, "BAD_REQUEST"},
+	{apperr.ErrUnauthorized, http.StatusUnauthorized, "UNAUTHORIZED"},
+	{apperr.ErrForbidden, http.StatusForbidden, "FORBIDDEN"},
+	{apperr.ErrNotFound, http.StatusNotFound, "NOT_FOUND"},
+	{apperr.ErrConflict, http.StatusConflict, "CONFLICT"},
+	{apperr.ErrUnavailable, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE"},
+}
+
+// Error builds the response for an error returned by a service, with its status code.
+// Domain errors get the status and code of their kind and their text, capitalized, as
+// message; any other error is an internal server error with the fallback message.
+func Error(ctx context.Context, err error, fallback string) (*ResponseBuilder, int) {
+	var domainErr *apperr.Error
+	if errors.As(err, &domainErr) {
+		for _, k := range errorKinds {
+			if errors.Is(domainErr, k.kind) {
+				return New(ctx).
+					WithCode(k.code).
+					WithMessage(capitalize(domainErr.Error())).
+					WithErrors([]string{err.Error()}), k.status
+			}
+		}
+	}
+
+	return InternalServerError(ctx, fallback, []string{err.Error()}), http.StatusInternalServerError
+}
+
+// SendError sends the response Error builds for a service error
+func SendError(ctx context.Context, w http.ResponseWriter, err error, fallback string) {
+	rb, status := Error(ctx, err, fallback)
+	rb.Send(w, status)
+}
+
+// capitalize upper-cases the first letter of an error text for use as message
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,37 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SetETag tags a response with a weak entity tag built from the values its representation
+// depends on, such as the resource's ID and updated_at, so clients can revalidate it with
+// If-None-Match. middleware.ConditionalGET answers matching requests with 304 Not Modified.
+// The response is marked private since it may differ between viewers.
+func SetETag(w http.ResponseWriter, parts ...interface{}) {
+	w.Header().Set("ETag", ETag(parts...))
+	w.Header().Set("Cache-Control", "private, no-cache")
+}
+
+// ETag builds a weak entity tag from the given values
+func ETag(parts ...interface{}) string {
+	h := sha256.New()
+	for _, part := range parts {
+		switch v := part.(type) {
+		case time.Time:
+			fmt.Fprintf(h, "%d|", v.UnixNano())
+		case *time.Time:
+			if v != nil {
+				fmt.Fprintf(h, "%d", v.UnixNano())
+			}
+			fmt.Fprint(h, "|")
+		default:
+			fmt.Fprintf(h, "%v|", v)
+		}
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
@@ -0,0 +1,99 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Fields is a sparse fieldset: the JSON fields of list items a client asked for with a
+// fields parameter. A nil Fields keeps every field.
+type Fields map[string]bool
+
+// ParseFields checks the requested field names against the JSON fields of model, a struct
+// value. The id field is always kept so clients can tell items apart. Nothing requested
+// gives a nil Fields.
+func ParseFields(requested []string, model interface{}) (Fields, error) {
+	if len(requested) == 0 {
+		return nil, nil
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(model))
+	fields := Fields{"id": true}
+	for _, name := range requested {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !known[name] {
+			names := make([]string, 0, len(known))
+			for n := range known {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("invalid fields: unknown field %q, expected some of %s", name, strings.Join(names, ", "))
+		}
+		fields[name] = true
+	}
+	return fields, nil
+}
+
+// Select returns data with every object in its listKey array reduced to the fields, e.g.
+// the posts of a post list. Other properties of data, such as the cursor, are kept.
+func (f Fields) Select(data interface{}, listKey string) (interface{}, error) {
+	if f == nil {
+		return data, nil
+	}
+
+	encoded, err := marshalJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode numbers as json.Number so 64-bit IDs survive the round trip
+	var object map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+
+	items, _ := object[listKey].([]interface{})
+	for _, item := range items {
+		if fieldsOf, ok := item.(map[string]interface{}); ok {
+			for name := range fieldsOf {
+				if !f[name] {
+					delete(fieldsOf, name)
+				}
+			}
+		}
+	}
+	return object, nil
+}
+
+// jsonFieldNames lists the names a struct type is encoded with
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
@@ -0,0 +1,89 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// JSONEncodingStd is the JSON encoding of the standard library, used unless another is chosen
+const JSONEncodingStd = "std"
+
+// JSONCodec marshals response bodies to JSON. Faster libraries are compiled in behind a
+// build tag, register themselves with RegisterJSONCodec and are chosen with
+// SetJSONEncoding. They must produce the same JSON as encoding/json, HTML escaping and
+// sorted map keys included, so clients and entity tags see no difference.
+type JSONCodec interface {
+	Name() string
+	Marshal(v interface{}) ([]byte, error)
+}
+
+var (
+	jsonMu     sync.RWMutex
+	jsonCodecs = map[string]JSONCodec{JSONEncodingStd: stdJSON{}}
+	jsonCodec  = jsonCodecs[JSONEncodingStd]
+)
+
+// RegisterJSONCodec makes a codec available to SetJSONEncoding, or replaces the one of
+// the same name
+func RegisterJSONCodec(c JSONCodec) {
+	jsonMu.Lock()
+	defer jsonMu.Unlock()
+	jsonCodecs[c.Name()] = c
+}
+
+// SetJSONEncoding chooses the codec response bodies are marshalled with. It fails for
+// codecs that were not compiled in.
+func SetJSONEncoding(name string) error {
+	jsonMu.Lock()
+	defer jsonMu.Unlock()
+
+	c, ok := jsonCodecs[name]
+	if !ok {
+		names := make([]string, 0, len(jsonCodecs))
+		for n := range jsonCodecs {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown JSON encoding %q, expected one of %s", name, strings.Join(names, ", "))
+	}
+	jsonCodec = c
+	return nil
+}
+
+// marshalJSON marshals v with the chosen codec, ending it with a newline like
+// json.Encoder does
+func marshalJSON(v interface{}) ([]byte, error) {
+	jsonMu.RLock()
+	c := jsonCodec
+	jsonMu.RUnlock()
+
+	body, err := c.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(body, '\n'), nil
+}
+
+// writeJSON writes v as the body of a response with the given content type and status.
+// A value that cannot be marshalled leaves the body empty.
+func writeJSON(w http.ResponseWriter, contentType string, statusCode int, v interface{}) {
+	body, err := marshalJSON(v)
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+	if err == nil {
+		w.Write(body)
+	}
+}
+
+// stdJSON is the JSONCodec of encoding/json
+type stdJSON struct{}
+
+func (stdJSON) Name() string { return JSONEncodingStd }
+
+func (stdJSON) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
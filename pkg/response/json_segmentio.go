@@ -0,0 +1,25 @@
+//go:build segmentio
+
+package response
+
+import (
+	segmentio "github.com/segmentio/encoding/json"
+)
+
+// JSONEncodingSegmentio is the JSON encoding of github.com/segmentio/encoding, a drop-in
+// replacement for encoding/json that marshals several times faster. It is compiled in
+// with the segmentio build tag.
+const JSONEncodingSegmentio = "segmentio"
+
+func init() {
+	RegisterJSONCodec(segmentioJSON{})
+}
+
+// segmentioJSON is the JSONCodec of github.com/segmentio/encoding
+type segmentioJSON struct{}
+
+func (segmentioJSON) Name() string { return JSONEncodingSegmentio }
+
+func (segmentioJSON) Marshal(v interface{}) ([]byte, error) {
+	return segmentio.Marshal(v)
+}
@@ -0,0 +1,28 @@
+package response
+
+import (
+	"net/http"
+
+	"github.com/fanzru/social-media-service-go/pkg/i18n"
+	"github.com/fanzru/social-media-service-go/pkg/reqctx"
+)
+
+// localize translates the message, errors and warnings into the locale reqctx selected
+// from the Accept-Language header. Texts without a translation are sent in English.
+func (rb *ResponseBuilder) localize(w http.ResponseWriter) {
+	if rb.localized {
+		return
+	}
+	rb.localized = true
+
+	locale := reqctx.GetLocale(rb.ctx)
+	if locale == "" {
+		return
+	}
+	addVary(w.Header(), "Accept-Language")
+	w.Header().Set("Content-Language", locale)
+
+	rb.response.Message = i18n.Translate(locale, rb.response.Message)
+	rb.response.Errors = i18n.TranslateAll(locale, rb.response.Errors)
+	rb.response.Warnings = i18n.TranslateAll(locale, rb.response.Warnings)
+}
@@ -0,0 +1,122 @@
+package response
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fanzru/social-media-service-go/pkg/reqctx"
+)
+
+// ContentTypeProblemJSON is the media type of RFC 7807 problem details
+const ContentTypeProblemJSON = "application/problem+json"
+
+// Error formats
+const (
+	ErrorFormatEnvelope = "envelope" // Errors use the standard response, problems only when the Accept header asks for one
+	ErrorFormatProblem  = "problem"  // Every error is rendered as problem details
+)
+
+// Problem is an error rendered as RFC 7807 problem details. Code, Errors, Warnings and
+// RequestID are extension members carrying the same values as the standard response.
+type Problem struct {
+	Type      string   `json:"type"`
+	Title     string   `json:"title"`
+	Status    int      `json:"status"`
+	Detail    string   `json:"detail,omitempty"`
+	Instance  string   `json:"instance,omitempty"`
+	Code      string   `json:"code"`
+	Errors    []string `json:"errors,omitempty"`
+	Warnings  []string `json:"warnings,omitempty"`
+	RequestID string   `json:"requestId"`
+}
+
+var (
+	problemMu          sync.RWMutex
+	errorFormat        = ErrorFormatEnvelope
+	problemTypeBaseURL string
+)
+
+// SetErrorFormat chooses how error responses are rendered. Problem types are typeBaseURL
+// followed by the kebab-cased response code, e.g. ".../not-found", or about:blank when
+// typeBaseURL is empty.
+func SetErrorFormat(format string, typeBaseURL string) error {
+	if format != ErrorFormatEnvelope && format != ErrorFormatProblem {
+		return fmt.Errorf("unknown error format %q, expected %s or %s", format, ErrorFormatEnvelope, ErrorFormatProblem)
+	}
+
+	problemMu.Lock()
+	defer problemMu.Unlock()
+	errorFormat = format
+	problemTypeBaseURL = strings.TrimSuffix(typeBaseURL, "/")
+	return nil
+}
+
+// sendProblem sends an error response as problem details when problems are the
+// configured format or the client asked for them, and reports whether it did
+func (rb *ResponseBuilder) sendProblem(w http.ResponseWriter, statusCode int) bool {
+	if statusCode < http.StatusBadRequest {
+		return false
+	}
+
+	problemMu.RLock()
+	format, typeBaseURL := errorFormat, problemTypeBaseURL
+	problemMu.RUnlock()
+
+	if format == ErrorFormatEnvelope {
+		addVary(w.Header(), "Accept")
+		if !acceptsProblem(reqctx.GetAccept(rb.ctx)) {
+			return false
+		}
+	}
+
+	problem := Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(statusCode),
+		Status:    statusCode,
+		Detail:    rb.response.Message,
+		Instance:  reqctx.GetRequestPath(rb.ctx),
+		Code:      rb.response.Code,
+		Errors:    rb.response.Errors,
+		Warnings:  rb.response.Warnings,
+		RequestID: rb.response.RequestID,
+	}
+	if typeBaseURL != "" && rb.response.Code != "" {
+		problem.Type = typeBaseURL + "/" + strings.ReplaceAll(strings.ToLower(rb.response.Code), "_", "-")
+	}
+
+	writeJSON(w, ContentTypeProblemJSON, statusCode, problem)
+	return true
+}
+
+// acceptsProblem reports whether an Accept header lists problem details
+func acceptsProblem(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mediaType != ContentTypeProblemJSON {
+			continue
+		}
+		if value, ok := params["q"]; ok {
+			if q, err := strconv.ParseFloat(value, 64); err == nil && q <= 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// addVary adds a header name to the Vary header unless it is already listed
+func addVary(h http.Header, name string) {
+	for _, value := range h.Values("Vary") {
+		for _, listed := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(listed), name) {
+				return
+			}
+		}
+	}
+	h.Add("Vary", name)
+}
@@ -0,0 +1,61 @@
+package response
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimit is the state of a client's rate limit after a request
+type RateLimit struct {
+	Limit     int           // Requests allowed per window
+	Remaining int           // Requests left in the current window
+	Reset     time.Duration // Time until the current window ends
+	Window    time.Duration // Length of a window
+}
+
+// RateLimitData is the data of a rate limited response, so clients can back off
+// without parsing headers
+type RateLimitData struct {
+	Limit         int `json:"limit"`
+	Remaining     int `json:"remaining"`
+	ResetSeconds  int `json:"reset_seconds"`
+	WindowSeconds int `json:"window_seconds"`
+	RetryAfter    int `json:"retry_after"`
+}
+
+// SetRateLimitHeaders adds the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset
+// headers describing a client's rate limit to a response
+func SetRateLimitHeaders(w http.ResponseWriter, rl RateLimit) {
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(rl.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(rl.Remaining))
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(seconds(rl.Reset)))
+}
+
+// SendRateLimited sends a 429 Too Many Requests response with the rate limit headers,
+// Retry-After and the limit state as data
+func SendRateLimited(ctx context.Context, w http.ResponseWriter, rl RateLimit) {
+	retryAfter := seconds(rl.Reset)
+	SetRateLimitHeaders(w, rl)
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+
+	TooManyRequests(ctx, "Rate limit exceeded", []string{fmt.Sprintf("limit is %d requests per %d seconds", rl.Limit, seconds(rl.Window))}).
+		WithData(RateLimitData{
+			Limit:         rl.Limit,
+			Remaining:     rl.Remaining,
+			ResetSeconds:  retryAfter,
+			WindowSeconds: seconds(rl.Window),
+			RetryAfter:    retryAfter,
+		}).
+		Send(w, http.StatusTooManyRequests)
+}
+
+// seconds rounds a duration up to whole seconds
+func seconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int((d + time.Second - 1) / time.Second)
+}
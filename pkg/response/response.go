@@ -2,7 +2,6 @@ package response
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 	"time"
 
@@ -14,15 +13,18 @@ type Response struct {
 	Code       string      `json:"code"`
 	Message    string      `json:"message"`
 	Errors     []string    `json:"errors,omitempty"`
+	Warnings   []string    `json:"warnings,omitempty"`
 	ServerTime string      `json:"serverTime"`
 	RequestID  string      `json:"requestId"`
 	Data       interface{} `json:"data,omitempty"`
+	Debug      interface{} `json:"debug,omitempty"` // Diagnostics requested for the request, such as query timings
 }
 
 // ResponseBuilder helps build standardized responses
 type ResponseBuilder struct {
-	response *Response
-	ctx      context.Context
+	response  *Response
+	ctx       context.Context
+	localized bool
 }
 
 // New creates a new response builder
@@ -31,6 +33,7 @@ func New(ctx context.Context) *ResponseBuilder {
 		response: &Response{
 			ServerTime: time.Now().Format(time.RFC3339),
 			RequestID:  reqctx.GetRequestID(ctx),
+			Warnings:   reqctx.GetWarnings(ctx),
 		},
 		ctx: ctx,
 	}
@@ -66,11 +69,15 @@ func (rb *ResponseBuilder) WithRequestID(requestID string) *ResponseBuilder {
 	return rb
 }
 
-// Send sends the response with the specified status code
+// Send sends the response with the specified status code. Error responses are sent as
+// problem details when configured or requested, see SetErrorFormat.
 func (rb *ResponseBuilder) Send(w http.ResponseWriter, statusCode int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(rb.response)
+	rb.localize(w)
+	if rb.sendProblem(w, statusCode) {
+		return
+	}
+	rb.response.Debug = reqctx.GetDebug(rb.ctx)
+	writeJSON(w, ContentTypeJSON, statusCode, rb.response)
 }
 
 // Success creates a success response
@@ -121,6 +128,30 @@ func Conflict(ctx context.Context, message string, errors []string) *ResponseBui
 		WithErrors(errors)
 }
 
+// TooManyRequests creates a rate limited response
+func TooManyRequests(ctx context.Context, message string, errors []string) *ResponseBuilder {
+	return New(ctx).
+		WithCode("TOO_MANY_REQUESTS").
+		WithMessage(message).
+		WithErrors(errors)
+}
+
+// Locked creates a locked resource response
+func Locked(ctx context.Context, message string, errors []string) *ResponseBuilder {
+	return New(ctx).
+		WithCode("LOCKED").
+		WithMessage(message).
+		WithErrors(errors)
+}
+
+// UnprocessableEntity creates an unprocessable entity response
+func UnprocessableEntity(ctx context.Context, message string, errors []string) *ResponseBuilder {
+	return New(ctx).
+		WithCode("UNPROCESSABLE_ENTITY").
+		WithMessage(message).
+		WithErrors(errors)
+}
+
 // ValidationError creates a validation error response
 func ValidationError(ctx context.Context, message string, errors []string) *ResponseBuilder {
 	return New(ctx).
@@ -1,16 +1,20 @@
 package s3
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/fanzru/social-media-service-go/infrastructure/config"
 	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/reqctx"
 )
 
 // Client wraps AWS S3 client with simplified interface
@@ -32,9 +36,11 @@ func NewClient(cfg *config.StorageConfig) (*Client, error) {
 		return nil, fmt.Errorf("S3 bucket is required: S3_BUCKET must be set")
 	}
 
-	// Create AWS config manually to avoid shared config issues
+	// Create AWS config manually to avoid shared config issues. Requests carry the trace
+	// context of the request they are made for.
 	awsConfig := aws.Config{
-		Region: cfg.S3Region,
+		Region:     cfg.S3Region,
+		HTTPClient: &http.Client{Transport: reqctx.Transport(nil)},
 		Credentials: credentials.NewStaticCredentialsProvider(
 			cfg.S3AccessKeyID,
 			cfg.S3SecretAccessKey,
@@ -75,6 +81,82 @@ func (c *Client) Upload(ctx context.Context, key string, data io.Reader, content
 	return nil
 }
 
+// partSize is the size of the parts UploadStream sends; S3 requires at least 5 MiB for
+// every part but the last
+const partSize = 8 << 20
+
+// UploadStream uploads data of unknown size to S3 holding at most one part in memory.
+// Data that fits in one part is sent with a single request, larger data as a multipart
+// upload, which is aborted if a part fails.
+func (c *Client) UploadStream(ctx context.Context, key string, data io.Reader, contentType string) error {
+	buf := make([]byte, partSize)
+	n, err := io.ReadFull(data, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return c.Upload(ctx, key, bytes.NewReader(buf[:n]), contentType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read upload: %w", err)
+	}
+
+	created, err := c.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(c.bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start multipart upload to S3: %w", err)
+	}
+
+	parts, err := c.uploadParts(ctx, key, created.UploadId, data, buf, n)
+	if err == nil {
+		_, err = c.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:          aws.String(c.bucket),
+			Key:             aws.String(key),
+			UploadId:        created.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+		})
+	}
+	if err != nil {
+		if _, abortErr := c.client.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(c.bucket),
+			Key:      aws.String(key),
+			UploadId: created.UploadId,
+		}); abortErr != nil {
+			c.logger.Error("Failed to abort multipart upload", "key", key, "error", abortErr.Error())
+		}
+		return fmt.Errorf("failed to upload to S3: %w", err)
+	}
+
+	c.logger.Info("File uploaded to S3", "key", key, "bucket", c.bucket, "parts", len(parts))
+	return nil
+}
+
+// uploadParts sends the n bytes already read into buf as the first part and the rest of
+// data as the following parts, reusing buf
+func (c *Client) uploadParts(ctx context.Context, key string, uploadID *string, data io.Reader, buf []byte, n int) ([]types.CompletedPart, error) {
+	var parts []types.CompletedPart
+	for number := int32(1); n > 0; number++ {
+		uploaded, err := c.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:        aws.String(c.bucket),
+			Key:           aws.String(key),
+			UploadId:      uploadID,
+			PartNumber:    aws.Int32(number),
+			Body:          bytes.NewReader(buf[:n]),
+			ContentLength: aws.Int64(int64(n)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("part %d: %w", number, err)
+		}
+		parts = append(parts, types.CompletedPart{ETag: uploaded.ETag, PartNumber: aws.Int32(number)})
+
+		n, err = io.ReadFull(data, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("failed to read upload: %w", err)
+		}
+	}
+	return parts, nil
+}
+
 // Delete deletes an object from S3
 func (c *Client) Delete(ctx context.Context, key string) error {
 	_, err := c.client.DeleteObject(ctx, &s3.DeleteObjectInput{
@@ -94,6 +176,18 @@ func (c *Client) GetURL(key string) string {
 	return fmt.Sprintf("%s/%s", c.baseURL, key)
 }
 
+// HeadBucket checks the bucket exists and the credentials may access it, without
+// reading any objects
+func (c *Client) HeadBucket(ctx context.Context) error {
+	_, err := c.client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(c.bucket),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to access bucket %s: %w", c.bucket, err)
+	}
+	return nil
+}
+
 // Exists checks if an object exists in S3
 func (c *Client) Exists(ctx context.Context, key string) (bool, error) {
 	_, err := c.client.HeadObject(ctx, &s3.HeadObjectInput{
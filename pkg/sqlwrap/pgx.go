@@ -0,0 +1,74 @@
+package sqlwrap
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/stdlib" // Registers DriverName with database/sql
+)
+
+// DriverName is the database/sql driver repositories run on: pgx through its stdlib
+// adapter, which caches prepared statements per connection
+const DriverName = "pgx"
+
+// codeUniqueViolation is the PostgreSQL error code of a unique constraint violation
+const codeUniqueViolation = "23505"
+
+// Open opens a PostgreSQL database with the pgx driver. dsn is a URL or a keyword/value
+// connection string, as accepted by lib/pq before.
+func Open(dsn string) (*sql.DB, error) {
+	return sql.Open(DriverName, dsn)
+}
+
+// Array adapts a slice for database/sql like lib/pq's Array did: a slice such as []int64
+// is passed through, as pgx encodes slices as PostgreSQL arrays, and a pointer to a slice
+// becomes a scanner filling it from an array column.
+func Array(v interface{}) interface{} {
+	switch v.(type) {
+	case *[]int64, *[]string, *[]int32, *[]bool, *[]float64:
+		// A Map caches scan plans and is not safe for concurrent use
+		return pgtype.NewMap().SQLScanner(v)
+	}
+	return v
+}
+
+// IsUniqueViolation reports whether err was caused by a unique constraint, e.g. when a
+// concurrent request inserted the same key first
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == codeUniqueViolation
+}
+
+// WithPgxConn runs fn on a pgx connection of db, a *sql.DB or *DB, for what database/sql
+// cannot do, such as COPY. The connection goes back to the pool when fn returns, so fn
+// must not keep it.
+func WithPgxConn(ctx context.Context, db interface{}, fn func(conn *pgx.Conn) error) error {
+	var sqlDB *sql.DB
+	switch d := db.(type) {
+	case *DB:
+		sqlDB = d.DB
+	case *sql.DB:
+		sqlDB = d
+	default:
+		return fmt.Errorf("unsupported database %T", db)
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn interface{}) error {
+		c, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("database driver is not pgx: %T", driverConn)
+		}
+		return fn(c.Conn())
+	})
+}
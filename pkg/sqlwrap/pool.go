@@ -0,0 +1,38 @@
+package sqlwrap
+
+import (
+	"context"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/metrics"
+)
+
+// ReportPoolStats writes the connection pool statistics of db to sink every interval until
+// ctx is done: db_pool_connections by state (open, in_use, idle), db_pool_max_open, and
+// the running totals db_pool_wait_count and db_pool_wait_duration_ms of queries that
+// waited for a free connection. Waits growing while in_use sits at max_open mean the pool
+// is exhausted.
+func (db *DB) ReportPoolStats(ctx context.Context, sink metrics.Sink, interval time.Duration) {
+	if sink == nil || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stats := db.Stats()
+		tags := map[string]string{"group": "DATABASE"}
+		for state, count := range map[string]int{"open": stats.OpenConnections, "in_use": stats.InUse, "idle": stats.Idle} {
+			_ = sink.WriteGauge("db_pool_connections", map[string]string{"group": "DATABASE", "state": state}, float64(count))
+		}
+		_ = sink.WriteGauge("db_pool_max_open", tags, float64(stats.MaxOpenConnections))
+		_ = sink.WriteGauge("db_pool_wait_count", tags, float64(stats.WaitCount))
+		_ = sink.WriteGauge("db_pool_wait_duration_ms", tags, milliseconds(stats.WaitDuration))
+	}
+}
@@ -3,59 +3,107 @@ package sqlwrap
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
 	"regexp"
 	"strings"
 	"time"
 
-	"github.com/fanzru/social-media-service-go/pkg/influxdb"
 	"github.com/fanzru/social-media-service-go/pkg/logger"
+	"github.com/fanzru/social-media-service-go/pkg/metrics"
+	"github.com/fanzru/social-media-service-go/pkg/reqctx"
 )
 
 // DB wraps sql.DB to add automatic query logging with execution time and metrics
 type DB struct {
 	*sql.DB
-	logger       *logger.Logger
-	influxClient *influxdb.Client
+	*instrumentation
 }
 
 // Tx wraps sql.Tx to add automatic query logging with execution time and metrics
 type Tx struct {
 	*sql.Tx
-	logger       *logger.Logger
-	influxClient *influxdb.Client
+	*instrumentation
 }
 
 // Stmt wraps sql.Stmt to add automatic query logging with execution time and metrics
 type Stmt struct {
 	*sql.Stmt
-	logger       *logger.Logger
-	influxClient *influxdb.Client
-	query        string
+	*instrumentation
+	query string
+	kind  string // Prefix of the operations in logs, e.g. Prepared Statement
+}
+
+// QueryObserver is told of each query a DB runs, by operation and table, e.g. to record
+// it in metrics that are not sent through a metrics.Sink
+type QueryObserver func(operation, table string, duration time.Duration, err error)
+
+// instrumentation logs queries and records their metrics. A DB shares it with the
+// transactions and statements it starts, so they are instrumented alike.
+type instrumentation struct {
+	logger        *logger.Logger
+	metrics       metrics.Sink
+	observer      QueryObserver
+	quiet         bool          // Logs only traced and slow queries, see NewQuietDB
+	slowThreshold time.Duration // Queries taking this long are logged as slow; 0 disables
+	tagRequestID  bool          // Tag query metrics with the request ID, see TagRequestID
 }
 
 // NewDB creates a new DB wrapper around sql.DB with logging
 func NewDB(db *sql.DB) *DB {
 	return &DB{
-		DB:     db,
-		logger: logger.GetGlobal(),
+		DB:              db,
+		instrumentation: &instrumentation{logger: logger.GetGlobal()},
+	}
+}
+
+// NewDBWithMetrics creates a new DB wrapper around sql.DB with logging and metrics sent to
+// sink, which may be nil
+func NewDBWithMetrics(db *sql.DB, sink metrics.Sink) *DB {
+	return &DB{
+		DB:              db,
+		instrumentation: &instrumentation{logger: logger.GetGlobal(), metrics: sink},
 	}
 }
 
-// NewDBWithInfluxDB creates a new DB wrapper around sql.DB with logging and InfluxDB metrics
-func NewDBWithInfluxDB(db *sql.DB, influxClient *influxdb.Client) *DB {
+// NewQuietDB creates a DB wrapper around sql.DB that neither logs queries nor records
+// metrics, except that queries of requests traced with WithQueryTrace, and slow queries
+// when SlowQueries is set, are logged
+func NewQuietDB(db *sql.DB) *DB {
 	return &DB{
-		DB:           db,
-		logger:       logger.GetGlobal(),
-		influxClient: influxClient,
+		DB:              db,
+		instrumentation: &instrumentation{logger: logger.GetGlobal(), quiet: true},
 	}
 }
 
+// Observe tells observer of each query db runs, in transactions and prepared statements
+// too, the same queries whose metrics are sent to the sink, whether or not db logs queries
+// or has a sink
+func (db *DB) Observe(observer QueryObserver) {
+	db.observer = observer
+}
+
+// SlowQueries logs queries taking threshold or longer as warnings, with the request they
+// were run for, even when db does not log queries otherwise. 0 disables it.
+func (db *DB) SlowQueries(threshold time.Duration) {
+	db.slowThreshold = threshold
+}
+
+// TagRequestID tags query metrics with the ID of the request each query was run for,
+// empty for queries run outside a request. Every request adds new series, so this is
+// meant for short investigations rather than to be left on.
+func (db *DB) TagRequestID(enabled bool) {
+	db.tagRequestID = enabled
+}
+
+// whitespace matches the runs of whitespace cleanQuery collapses
+var whitespace = regexp.MustCompile(`\s+`)
+
 // cleanQuery removes extra whitespace and makes query more readable
 func cleanQuery(query string) string {
 	// Replace multiple whitespace characters with single space
-	re := regexp.MustCompile(`\s+`)
-	cleaned := re.ReplaceAllString(query, " ")
+	cleaned := whitespace.ReplaceAllString(query, " ")
 
 	// Trim leading and trailing spaces
 	cleaned = strings.TrimSpace(cleaned)
@@ -63,6 +111,18 @@ func cleanQuery(query string) string {
 	return cleaned
 }
 
+// logQuery defers cleaning a query until a record holding it is written
+func logQuery(query string) slog.LogValuer {
+	return logger.Lazy(func() interface{} { return cleanQuery(query) })
+}
+
+// logArgs defers formatting query arguments until a record holding them is written, and
+// leaves them out unless the logger's detail level is enabled. Arguments looking like
+// password hashes, emails or tokens are masked.
+func logArgs(l *logger.Logger, args []interface{}) slog.LogValuer {
+	return l.Detail(func() interface{} { return l.Redactor().Args(args) })
+}
+
 // getOperationFromQuery extracts the operation type from SQL query
 func getOperationFromQuery(query string) string {
 	query = strings.ToUpper(strings.TrimSpace(query))
@@ -109,51 +169,65 @@ func getTableFromQuery(query string) string {
 	return "unknown"
 }
 
-// recordMetrics records database metrics if InfluxDB client is available
-func (db *DB) recordMetrics(operation, table string, duration time.Duration, err error) {
-	if db.influxClient == nil {
-		db.logger.Debug("InfluxDB client is nil, skipping database metrics")
-		return
+// queryStatus returns the code query metrics are tagged with: SUCCESS, TIMEOUT when the
+// context's deadline passed, CANCELED when it was canceled, or FAILED
+func queryStatus(err error) string {
+	switch {
+	case err == nil:
+		return "SUCCESS"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "TIMEOUT"
+	case errors.Is(err, context.Canceled):
+		return "CANCELED"
+	default:
+		return "FAILED"
 	}
+}
 
-	db.logger.Debug("Recording database metrics", "operation", operation, "table", table, "duration_ms", duration.Milliseconds())
-
-	status := "SUCCESS"
-	if err != nil {
-		status = "FAILED"
+// queryDone is called when a query of any kind has run: it traces the query for requests
+// asking for it, records its metrics and logs it. kind names the operation in logs, e.g.
+// Transaction Query. err is the query's error; a context that ended while the query
+// ran counts as its error, as drivers may report that as a generic failure.
+func (in *instrumentation) queryDone(ctx context.Context, kind, query string, args []interface{}, start time.Time, err error) {
+	duration := time.Since(start)
+	if ctxErr := ctx.Err(); err != nil && ctxErr != nil && !errors.Is(err, ctxErr) {
+		err = fmt.Errorf("%w: %w", ctxErr, err)
 	}
 
-	tags := map[string]string{
-		"group":     "DATABASE",
-		"entity":    fmt.Sprintf("%s %s", operation, table),
-		"operation": operation,
-		"table":     table,
-		"code":      status,
-	}
+	// Trace the query for requests asking for it
+	traceQuery(ctx, in.logger, query, args, duration, err)
 
-	// Record query count
-	if writeErr := db.influxClient.WriteCounter("db_queries_total", tags, 1); writeErr != nil {
-		db.logger.Error("Failed to write database metrics to InfluxDB", "error", writeErr.Error())
+	in.recordMetrics(ctx, getOperationFromQuery(query), getTableFromQuery(query), duration, err)
+
+	attrs := []interface{}{
+		"query", logQuery(query),
+		"args", logArgs(in.logger, args),
+		"exec_time_ms", duration.Milliseconds(),
+		"exec_time_ns", duration.Nanoseconds(),
+	}
+	if err != nil {
+		attrs = append(attrs, "error", err.Error())
 	}
 
-	// Record query duration
-	if writeErr := db.influxClient.WriteTiming("db_query_duration_ms", tags, duration); writeErr != nil {
-		db.logger.Error("Failed to write database timing to InfluxDB", "error", writeErr.Error())
+	switch {
+	case in.slowThreshold > 0 && duration >= in.slowThreshold:
+		in.logger.WarnWithContext(ctx, "Database "+kind+" slow", append(attrs, "slow_query_threshold_ms", in.slowThreshold.Milliseconds())...)
+	case in.quiet:
+	case err != nil:
+		in.logger.ErrorWithContext(ctx, "Database "+kind+" failed", attrs...)
+	default:
+		in.logger.InfoWithContext(ctx, "Database "+kind+" executed", attrs...)
 	}
 }
 
-// recordTxMetrics records transaction metrics if InfluxDB client is available
-func (tx *Tx) recordTxMetrics(operation, table string, duration time.Duration, err error) {
-	if tx.influxClient == nil {
-		tx.logger.Debug("InfluxDB client is nil, skipping transaction metrics")
-		return
+// recordMetrics records database metrics if a metrics sink is set
+func (in *instrumentation) recordMetrics(ctx context.Context, operation, table string, duration time.Duration, err error) {
+	if in.observer != nil {
+		in.observer(operation, table, duration, err)
 	}
 
-	tx.logger.Debug("Recording transaction metrics", "operation", operation, "table", table, "duration_ms", duration.Milliseconds())
-
-	status := "SUCCESS"
-	if err != nil {
-		status = "FAILED"
+	if in.metrics == nil {
+		return
 	}
 
 	tags := map[string]string{
@@ -161,205 +235,89 @@ func (tx *Tx) recordTxMetrics(operation, table string, duration time.Duration, e
 		"entity":    fmt.Sprintf("%s %s", operation, table),
 		"operation": operation,
 		"table":     table,
-		"code":      status,
+		"code":      queryStatus(err),
+	}
+	if in.tagRequestID {
+		tags["request_id"] = reqctx.GetRequestID(ctx)
 	}
 
 	// Record query count
-	if writeErr := tx.influxClient.WriteCounter("db_queries_total", tags, 1); writeErr != nil {
-		tx.logger.Error("Failed to write database metrics to InfluxDB", "error", writeErr.Error())
+	if writeErr := in.metrics.WriteCounter("db_queries_total", tags, 1); writeErr != nil {
+		in.logger.Error("Failed to write database metrics", "error", writeErr.Error())
 	}
 
 	// Record query duration
-	if writeErr := tx.influxClient.WriteTiming("db_query_duration_ms", tags, duration); writeErr != nil {
-		tx.logger.Error("Failed to write database timing to InfluxDB", "error", writeErr.Error())
+	if writeErr := in.metrics.WriteTiming("db_query_duration_ms", tags, duration); writeErr != nil {
+		in.logger.Error("Failed to write database timing", "error", writeErr.Error())
 	}
 }
 
-// QueryRow executes a query that returns a single row
-func (db *DB) QueryRow(query string, args ...interface{}) *sql.Row {
-	start := time.Now()
-	operation := getOperationFromQuery(query)
-	table := getTableFromQuery(query)
+// recordTransaction records the duration of a transaction step, e.g. COMMIT
+func (in *instrumentation) recordTransaction(entity string, start time.Time, err error) {
+	if in.metrics == nil {
+		return
+	}
 
-	row := db.DB.QueryRow(query, args...)
-	duration := time.Since(start)
+	tags := map[string]string{
+		"group":      "DB",
+		"entity":     entity,
+		"error_code": queryStatus(err),
+	}
 
-	// Record metrics
-	db.recordMetrics(operation, table, duration, nil)
+	_ = in.metrics.WriteTiming("db_transaction_duration_ms", tags, time.Since(start))
+}
 
-	db.logger.Info("Database QueryRow executed",
-		"query", cleanQuery(query),
-		"args", args,
-		"exec_time_ms", duration.Milliseconds(),
-		"exec_time_ns", duration.Nanoseconds(),
-	)
+// rowErr returns the error a row will report on Scan, leaving out sql.ErrNoRows, which
+// is an answer rather than a failure
+func rowErr(row *sql.Row) error {
+	if err := row.Err(); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return nil
+}
 
-	return row
+// QueryRow executes a query that returns a single row
+func (db *DB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return db.QueryRowContext(context.Background(), query, args...)
 }
 
 // QueryRowContext executes a query that returns a single row with context
 func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
 	start := time.Now()
-	operation := getOperationFromQuery(query)
-	table := getTableFromQuery(query)
-
 	row := db.DB.QueryRowContext(ctx, query, args...)
-	duration := time.Since(start)
-
-	// Record metrics
-	db.recordMetrics(operation, table, duration, nil)
-
-	db.logger.Info("Database QueryRowContext executed",
-		"query", cleanQuery(query),
-		"args", args,
-		"exec_time_ms", duration.Milliseconds(),
-		"exec_time_ns", duration.Nanoseconds(),
-	)
-
+	db.queryDone(ctx, "QueryRow", query, args, start, rowErr(row))
 	return row
 }
 
 // Query executes a query that returns rows
 func (db *DB) Query(query string, args ...interface{}) (*sql.Rows, error) {
-	start := time.Now()
-	operation := getOperationFromQuery(query)
-	table := getTableFromQuery(query)
-
-	rows, err := db.DB.Query(query, args...)
-	duration := time.Since(start)
-
-	// Record metrics
-	db.recordMetrics(operation, table, duration, err)
-
-	if err != nil {
-		db.logger.Error("Database Query failed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-			"error", err.Error(),
-		)
-	} else {
-		db.logger.Info("Database Query executed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-		)
-	}
-
-	return rows, err
+	return db.QueryContext(context.Background(), query, args...)
 }
 
 // QueryContext executes a query that returns rows with context
 func (db *DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
 	start := time.Now()
-	operation := getOperationFromQuery(query)
-	table := getTableFromQuery(query)
-
 	rows, err := db.DB.QueryContext(ctx, query, args...)
-	duration := time.Since(start)
-
-	// Record metrics
-	db.recordMetrics(operation, table, duration, err)
-
-	if err != nil {
-		db.logger.Error("Database QueryContext failed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-			"error", err.Error(),
-		)
-	} else {
-		db.logger.Info("Database QueryContext executed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-		)
-	}
-
+	db.queryDone(ctx, "Query", query, args, start, err)
 	return rows, err
 }
 
 // Exec executes a query without returning any rows
 func (db *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
-	start := time.Now()
-	operation := getOperationFromQuery(query)
-	table := getTableFromQuery(query)
-
-	result, err := db.DB.Exec(query, args...)
-	duration := time.Since(start)
-
-	// Record metrics
-	db.recordMetrics(operation, table, duration, err)
-
-	if err != nil {
-		db.logger.Error("Database Exec failed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-			"error", err.Error(),
-		)
-	} else {
-		db.logger.Info("Database Exec executed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-		)
-	}
-
-	return result, err
+	return db.ExecContext(context.Background(), query, args...)
 }
 
 // ExecContext executes a query without returning any rows with context
 func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
 	start := time.Now()
-	operation := getOperationFromQuery(query)
-	table := getTableFromQuery(query)
-
 	result, err := db.DB.ExecContext(ctx, query, args...)
-	duration := time.Since(start)
-
-	// Record metrics
-	db.recordMetrics(operation, table, duration, err)
-
-	if err != nil {
-		db.logger.Error("Database ExecContext failed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-			"error", err.Error(),
-		)
-	} else {
-		db.logger.Info("Database ExecContext executed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-		)
-	}
-
+	db.queryDone(ctx, "Exec", query, args, start, err)
 	return result, err
 }
 
 // Prepare creates a prepared statement
 func (db *DB) Prepare(query string) (*Stmt, error) {
-	stmt, err := db.DB.Prepare(query)
-	if err != nil {
-		return nil, err
-	}
-
-	return &Stmt{
-		Stmt:         stmt,
-		logger:       db.logger,
-		influxClient: db.influxClient,
-		query:        query,
-	}, nil
+	return db.PrepareContext(context.Background(), query)
 }
 
 // PrepareContext creates a prepared statement with context
@@ -370,232 +328,111 @@ func (db *DB) PrepareContext(ctx context.Context, query string) (*Stmt, error) {
 	}
 
 	return &Stmt{
-		Stmt:         stmt,
-		logger:       db.logger,
-		influxClient: db.influxClient,
-		query:        query,
+		Stmt:            stmt,
+		instrumentation: db.instrumentation,
+		query:           query,
+		kind:            "Prepared Statement",
 	}, nil
 }
 
-// Begin starts a transaction
-func (db *DB) Begin() (*Tx, error) {
-	start := time.Now()
-
-	tx, err := db.DB.Begin()
-	duration := time.Since(start)
+// PrepareAll prepares queries on db, a *sql.DB or *DB, keyed by their SQL, so repositories
+// holding either can reuse statements instead of having every query parsed again. Queries
+// that fail to prepare, e.g. while the database is unreachable, are logged and left out;
+// callers run those unprepared.
+func PrepareAll(ctx context.Context, db interface{}, queries ...string) map[string]*Stmt {
+	var wrapped *DB
+	switch d := db.(type) {
+	case *DB:
+		wrapped = d
+	case *sql.DB:
+		wrapped = NewDB(d)
+	default:
+		return nil
+	}
 
-	// Record transaction metrics
-	if db.influxClient != nil {
-		status := "SUCCESS"
+	stmts := make(map[string]*Stmt, len(queries))
+	for _, query := range queries {
+		stmt, err := wrapped.PrepareContext(ctx, query)
 		if err != nil {
-			status = "FAILED"
-		}
-
-		tags := map[string]string{
-			"group":      "DB",
-			"entity":     "BEGIN",
-			"error_code": status,
+			wrapped.logger.Warn("Failed to prepare statement, it runs unprepared",
+				"query", logQuery(query),
+				"error", err.Error(),
+			)
+			continue
 		}
-
-		_ = db.influxClient.WriteTiming("db_transaction_duration_ms", tags, duration)
+		stmts[query] = stmt
 	}
+	return stmts
+}
 
+// Begin starts a transaction
+func (db *DB) Begin() (*Tx, error) {
+	start := time.Now()
+	tx, err := db.DB.Begin()
+	db.recordTransaction("BEGIN", start, err)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Tx{
-		Tx:           tx,
-		logger:       db.logger,
-		influxClient: db.influxClient,
-	}, nil
+	return &Tx{Tx: tx, instrumentation: db.instrumentation}, nil
 }
 
 // BeginTx starts a transaction with context and options
 func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
 	start := time.Now()
-
 	tx, err := db.DB.BeginTx(ctx, opts)
-	duration := time.Since(start)
-
-	// Record transaction metrics
-	if db.influxClient != nil {
-		status := "SUCCESS"
-		if err != nil {
-			status = "FAILED"
-		}
-
-		tags := map[string]string{
-			"group":      "DB",
-			"entity":     "BEGIN_TX",
-			"error_code": status,
-		}
-
-		_ = db.influxClient.WriteTiming("db_transaction_duration_ms", tags, duration)
-	}
-
+	db.recordTransaction("BEGIN_TX", start, err)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Tx{
-		Tx:           tx,
-		logger:       db.logger,
-		influxClient: db.influxClient,
-	}, nil
+	return &Tx{Tx: tx, instrumentation: db.instrumentation}, nil
 }
 
 // Tx methods
 
 // QueryRow executes a query that returns a single row within transaction
 func (tx *Tx) QueryRow(query string, args ...interface{}) *sql.Row {
-	start := time.Now()
-	row := tx.Tx.QueryRow(query, args...)
-	duration := time.Since(start)
-
-	tx.logger.Info("Database Transaction QueryRow executed",
-		"query", cleanQuery(query),
-		"args", args,
-		"exec_time_ms", duration.Milliseconds(),
-		"exec_time_ns", duration.Nanoseconds(),
-	)
-
-	return row
+	return tx.QueryRowContext(context.Background(), query, args...)
 }
 
 // QueryRowContext executes a query that returns a single row within transaction with context
 func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
 	start := time.Now()
 	row := tx.Tx.QueryRowContext(ctx, query, args...)
-	duration := time.Since(start)
-
-	tx.logger.Info("Database Transaction QueryRowContext executed",
-		"query", cleanQuery(query),
-		"args", args,
-		"exec_time_ms", duration.Milliseconds(),
-		"exec_time_ns", duration.Nanoseconds(),
-	)
-
+	tx.queryDone(ctx, "Transaction QueryRow", query, args, start, rowErr(row))
 	return row
 }
 
 // Query executes a query that returns rows within transaction
 func (tx *Tx) Query(query string, args ...interface{}) (*sql.Rows, error) {
-	start := time.Now()
-	rows, err := tx.Tx.Query(query, args...)
-	duration := time.Since(start)
-
-	if err != nil {
-		tx.logger.Error("Database Transaction Query failed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-			"error", err.Error(),
-		)
-	} else {
-		tx.logger.Info("Database Transaction Query executed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-		)
-	}
-
-	return rows, err
+	return tx.QueryContext(context.Background(), query, args...)
 }
 
 // QueryContext executes a query that returns rows within transaction with context
 func (tx *Tx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
 	start := time.Now()
 	rows, err := tx.Tx.QueryContext(ctx, query, args...)
-	duration := time.Since(start)
-
-	if err != nil {
-		tx.logger.Error("Database Transaction QueryContext failed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-			"error", err.Error(),
-		)
-	} else {
-		tx.logger.Info("Database Transaction QueryContext executed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-		)
-	}
-
+	tx.queryDone(ctx, "Transaction Query", query, args, start, err)
 	return rows, err
 }
 
 // Exec executes a query without returning any rows within transaction
 func (tx *Tx) Exec(query string, args ...interface{}) (sql.Result, error) {
-	start := time.Now()
-	result, err := tx.Tx.Exec(query, args...)
-	duration := time.Since(start)
-
-	if err != nil {
-		tx.logger.Error("Database Transaction Exec failed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-			"error", err.Error(),
-		)
-	} else {
-		tx.logger.Info("Database Transaction Exec executed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-		)
-	}
-
-	return result, err
+	return tx.ExecContext(context.Background(), query, args...)
 }
 
 // ExecContext executes a query without returning any rows within transaction with context
 func (tx *Tx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
 	start := time.Now()
 	result, err := tx.Tx.ExecContext(ctx, query, args...)
-	duration := time.Since(start)
-
-	if err != nil {
-		tx.logger.Error("Database Transaction ExecContext failed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-			"error", err.Error(),
-		)
-	} else {
-		tx.logger.Info("Database Transaction ExecContext executed",
-			"query", cleanQuery(query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-		)
-	}
-
+	tx.queryDone(ctx, "Transaction Exec", query, args, start, err)
 	return result, err
 }
 
 // Prepare creates a prepared statement within transaction
 func (tx *Tx) Prepare(query string) (*Stmt, error) {
-	stmt, err := tx.Tx.Prepare(query)
-	if err != nil {
-		return nil, err
-	}
-
-	return &Stmt{
-		Stmt:         stmt,
-		logger:       tx.logger,
-		influxClient: tx.influxClient,
-		query:        query,
-	}, nil
+	return tx.PrepareContext(context.Background(), query)
 }
 
 // PrepareContext creates a prepared statement within transaction with context
@@ -606,62 +443,26 @@ func (tx *Tx) PrepareContext(ctx context.Context, query string) (*Stmt, error) {
 	}
 
 	return &Stmt{
-		Stmt:         stmt,
-		logger:       tx.logger,
-		influxClient: tx.influxClient,
-		query:        query,
+		Stmt:            stmt,
+		instrumentation: tx.instrumentation,
+		query:           query,
+		kind:            "Transaction Prepared Statement",
 	}, nil
 }
 
 // Commit commits the transaction
 func (tx *Tx) Commit() error {
 	start := time.Now()
-
 	err := tx.Tx.Commit()
-	duration := time.Since(start)
-
-	// Record transaction metrics
-	if tx.influxClient != nil {
-		status := "SUCCESS"
-		if err != nil {
-			status = "FAILED"
-		}
-
-		tags := map[string]string{
-			"group":      "DB",
-			"entity":     "COMMIT",
-			"error_code": status,
-		}
-
-		_ = tx.influxClient.WriteTiming("db_transaction_duration_ms", tags, duration)
-	}
-
+	tx.recordTransaction("COMMIT", start, err)
 	return err
 }
 
 // Rollback rolls back the transaction
 func (tx *Tx) Rollback() error {
 	start := time.Now()
-
 	err := tx.Tx.Rollback()
-	duration := time.Since(start)
-
-	// Record transaction metrics
-	if tx.influxClient != nil {
-		status := "SUCCESS"
-		if err != nil {
-			status = "FAILED"
-		}
-
-		tags := map[string]string{
-			"group":      "DB",
-			"entity":     "ROLLBACK",
-			"error_code": status,
-		}
-
-		_ = tx.influxClient.WriteTiming("db_transaction_duration_ms", tags, duration)
-	}
-
+	tx.recordTransaction("ROLLBACK", start, err)
 	return err
 }
 
@@ -669,137 +470,40 @@ func (tx *Tx) Rollback() error {
 
 // QueryRow executes a prepared statement that returns a single row
 func (stmt *Stmt) QueryRow(args ...interface{}) *sql.Row {
-	start := time.Now()
-	row := stmt.Stmt.QueryRow(args...)
-	duration := time.Since(start)
-
-	stmt.logger.Info("Database Prepared Statement QueryRow executed",
-		"query", cleanQuery(stmt.query),
-		"args", args,
-		"exec_time_ms", duration.Milliseconds(),
-		"exec_time_ns", duration.Nanoseconds(),
-	)
-
-	return row
+	return stmt.QueryRowContext(context.Background(), args...)
 }
 
 // QueryRowContext executes a prepared statement that returns a single row with context
 func (stmt *Stmt) QueryRowContext(ctx context.Context, args ...interface{}) *sql.Row {
 	start := time.Now()
 	row := stmt.Stmt.QueryRowContext(ctx, args...)
-	duration := time.Since(start)
-
-	stmt.logger.Info("Database Prepared Statement QueryRowContext executed",
-		"query", cleanQuery(stmt.query),
-		"args", args,
-		"exec_time_ms", duration.Milliseconds(),
-		"exec_time_ns", duration.Nanoseconds(),
-	)
-
+	stmt.queryDone(ctx, stmt.kind+" QueryRow", stmt.query, args, start, rowErr(row))
 	return row
 }
 
 // Query executes a prepared statement that returns rows
 func (stmt *Stmt) Query(args ...interface{}) (*sql.Rows, error) {
-	start := time.Now()
-	rows, err := stmt.Stmt.Query(args...)
-	duration := time.Since(start)
-
-	if err != nil {
-		stmt.logger.Error("Database Prepared Statement Query failed",
-			"query", cleanQuery(stmt.query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-			"error", err.Error(),
-		)
-	} else {
-		stmt.logger.Info("Database Prepared Statement Query executed",
-			"query", cleanQuery(stmt.query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-		)
-	}
-
-	return rows, err
+	return stmt.QueryContext(context.Background(), args...)
 }
 
 // QueryContext executes a prepared statement that returns rows with context
 func (stmt *Stmt) QueryContext(ctx context.Context, args ...interface{}) (*sql.Rows, error) {
 	start := time.Now()
 	rows, err := stmt.Stmt.QueryContext(ctx, args...)
-	duration := time.Since(start)
-
-	if err != nil {
-		stmt.logger.Error("Database Prepared Statement QueryContext failed",
-			"query", cleanQuery(stmt.query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-			"error", err.Error(),
-		)
-	} else {
-		stmt.logger.Info("Database Prepared Statement QueryContext executed",
-			"query", cleanQuery(stmt.query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-		)
-	}
-
+	stmt.queryDone(ctx, stmt.kind+" Query", stmt.query, args, start, err)
 	return rows, err
 }
 
 // Exec executes a prepared statement without returning any rows
 func (stmt *Stmt) Exec(args ...interface{}) (sql.Result, error) {
-	start := time.Now()
-	result, err := stmt.Stmt.Exec(args...)
-	duration := time.Since(start)
-
-	if err != nil {
-		stmt.logger.Error("Database Prepared Statement Exec failed",
-			"query", cleanQuery(stmt.query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-			"error", err.Error(),
-		)
-	} else {
-		stmt.logger.Info("Database Prepared Statement Exec executed",
-			"query", cleanQuery(stmt.query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-		)
-	}
-
-	return result, err
+	return stmt.ExecContext(context.Background(), args...)
 }
 
 // ExecContext executes a prepared statement without returning any rows with context
 func (stmt *Stmt) ExecContext(ctx context.Context, args ...interface{}) (sql.Result, error) {
 	start := time.Now()
 	result, err := stmt.Stmt.ExecContext(ctx, args...)
-	duration := time.Since(start)
-
-	if err != nil {
-		stmt.logger.Error("Database Prepared Statement ExecContext failed",
-			"query", cleanQuery(stmt.query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-			"error", err.Error(),
-		)
-	} else {
-		stmt.logger.Info("Database Prepared Statement ExecContext executed",
-			"query", cleanQuery(stmt.query),
-			"args", args,
-			"exec_time_ms", duration.Milliseconds(),
-			"exec_time_ns", duration.Nanoseconds(),
-		)
-	}
-
+	stmt.queryDone(ctx, stmt.kind+" Exec", stmt.query, args, start, err)
 	return result, err
 }
 
@@ -0,0 +1,102 @@
+package sqlwrap
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/logger"
+)
+
+// maxTracedQueries is how many queries a trace lists; later ones are only counted
+const maxTracedQueries = 100
+
+// queryTraceKey is the context key of a request's QueryTrace
+type queryTraceKey struct{}
+
+// QueryTrace collects the queries run for one request, so their timings can be returned
+// to the caller. It is safe for concurrent use, as a request may run queries in parallel.
+type QueryTrace struct {
+	mu      sync.Mutex
+	count   int
+	total   time.Duration
+	queries []TracedQuery
+}
+
+// TracedQuery is a query run while tracing
+type TracedQuery struct {
+	Query      string  `json:"query"`
+	DurationMs float64 `json:"duration_ms"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// QueryTraceSummary sums up the queries of a trace
+type QueryTraceSummary struct {
+	Count        int           `json:"count"`
+	TotalMs      float64       `json:"total_ms"`
+	Queries      []TracedQuery `json:"queries"`
+	OmittedCount int           `json:"omitted_count,omitempty"` // Queries run beyond the listed ones
+}
+
+// WithQueryTrace starts tracing the queries run with the returned context through a DB,
+// Tx or Stmt of this package. Traced queries are logged with their arguments whatever the
+// logging configuration, so only trusted callers should be traced.
+func WithQueryTrace(ctx context.Context) (context.Context, *QueryTrace) {
+	trace := &QueryTrace{}
+	return context.WithValue(ctx, queryTraceKey{}, trace), trace
+}
+
+// Summary returns the queries traced so far
+func (t *QueryTrace) Summary() QueryTraceSummary {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return QueryTraceSummary{
+		Count:        t.count,
+		TotalMs:      milliseconds(t.total),
+		Queries:      append([]TracedQuery{}, t.queries...),
+		OmittedCount: t.count - len(t.queries),
+	}
+}
+
+// add records a query
+func (t *QueryTrace) add(query string, duration time.Duration, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.count++
+	t.total += duration
+	if len(t.queries) >= maxTracedQueries {
+		return
+	}
+	traced := TracedQuery{Query: cleanQuery(query), DurationMs: milliseconds(duration)}
+	if err != nil {
+		traced.Error = err.Error()
+	}
+	t.queries = append(t.queries, traced)
+}
+
+// traceQuery records a query in the trace of ctx, if any, and logs it with its arguments,
+// sensitive ones masked
+func traceQuery(ctx context.Context, l *logger.Logger, query string, args []interface{}, duration time.Duration, err error) {
+	trace, ok := ctx.Value(queryTraceKey{}).(*QueryTrace)
+	if !ok {
+		return
+	}
+	trace.add(query, duration, err)
+
+	logArgs := []interface{}{
+		"query", logQuery(query),
+		"args", l.Redactor().Args(args),
+		"exec_time_ms", duration.Milliseconds(),
+	}
+	if err != nil {
+		logArgs = append(logArgs, "error", err.Error())
+	}
+	l.InfoWithContext(ctx, "Database query traced", logArgs...)
+}
+
+// milliseconds converts a duration to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
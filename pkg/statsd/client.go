@@ -2,26 +2,34 @@ package statsd
 
 import (
 	"fmt"
+	"math/rand/v2"
 	"net"
 	"time"
 )
 
 // Client represents a StatsD client
 type Client struct {
-	conn   net.Conn
-	prefix string
+	conn       net.Conn
+	prefix     string
+	sampleRate float64 // Share of counters and timings sent, in (0, 1]
 }
 
-// NewClient creates a new StatsD client
-func NewClient(addr, prefix string) (*Client, error) {
+// NewClient creates a new StatsD client sending the given share of counters and timings;
+// StatsD scales sampled counters back up by the rate sent along with them
+func NewClient(addr, prefix string, sampleRate float64) (*Client, error) {
 	conn, err := net.Dial("udp", addr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to StatsD: %w", err)
 	}
 
+	if sampleRate <= 0 || sampleRate > 1 {
+		sampleRate = 1
+	}
+
 	return &Client{
-		conn:   conn,
-		prefix: prefix,
+		conn:       conn,
+		prefix:     prefix,
+		sampleRate: sampleRate,
 	}, nil
 }
 
@@ -32,16 +40,17 @@ func (c *Client) Close() error {
 
 // Incr increments a counter metric
 func (c *Client) Incr(name string, tags map[string]string) error {
-	metric := c.buildMetric(name, tags)
-	_, err := fmt.Fprintf(c.conn, "%s:1|c\n", metric)
-	return err
+	return c.Count(name, 1, tags)
+}
+
+// Count adds value to a counter metric
+func (c *Client) Count(name string, value int64, tags map[string]string) error {
+	return c.sampled(c.buildMetric(name, tags), fmt.Sprintf("%d", value), "c")
 }
 
 // Timing records a timing metric
 func (c *Client) Timing(name string, duration time.Duration, tags map[string]string) error {
-	metric := c.buildMetric(name, tags)
-	_, err := fmt.Fprintf(c.conn, "%s:%d|ms\n", metric, duration.Milliseconds())
-	return err
+	return c.sampled(c.buildMetric(name, tags), fmt.Sprintf("%d", duration.Milliseconds()), "ms")
 }
 
 // Gauge sets a gauge metric
@@ -51,6 +60,34 @@ func (c *Client) Gauge(name string, value float64, tags map[string]string) error
 	return err
 }
 
+// WriteCounter adds value to a counter metric, as metrics.Sink
+func (c *Client) WriteCounter(name string, tags map[string]string, value int64) error {
+	return c.Count(name, value, tags)
+}
+
+// WriteTiming records a timing metric, as metrics.Sink
+func (c *Client) WriteTiming(name string, tags map[string]string, duration time.Duration) error {
+	return c.Timing(name, duration, tags)
+}
+
+// WriteGauge sets a gauge metric, as metrics.Sink
+func (c *Client) WriteGauge(name string, tags map[string]string, value float64) error {
+	return c.Gauge(name, value, tags)
+}
+
+// sampled sends a metric with the client's sample rate, skipping it when it is not sampled
+func (c *Client) sampled(metric, value, metricType string) error {
+	if c.sampleRate >= 1 {
+		_, err := fmt.Fprintf(c.conn, "%s:%s|%s\n", metric, value, metricType)
+		return err
+	}
+	if rand.Float64() >= c.sampleRate {
+		return nil
+	}
+	_, err := fmt.Fprintf(c.conn, "%s:%s|%s|@%g\n", metric, value, metricType, c.sampleRate)
+	return err
+}
+
 // buildMetric constructs the metric name with tags
 func (c *Client) buildMetric(name string, tags map[string]string) string {
 	metric := name
@@ -17,22 +17,33 @@ import (
 
 	"github.com/disintegration/imaging"
 	"github.com/fanzru/social-media-service-go/infrastructure/config"
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
 	"github.com/fanzru/social-media-service-go/pkg/logger"
 	"github.com/fanzru/social-media-service-go/pkg/s3"
 )
 
+// MaxUploadMemory is how much of a multipart form handlers parse into memory; larger
+// uploads spill to temporary files, which images are streamed and decoded from
+const MaxUploadMemory = 1 << 20
+
+// ErrInvalidImage is returned for uploads that are too large, of a type not allowed, or
+// that cannot be decoded as an image
+var ErrInvalidImage = apperr.Invalid("invalid image")
+
 // ImageStorageService handles image upload and processing
 type ImageStorageService struct {
 	config   *config.StorageConfig
 	s3Client *s3.Client
 	logger   *logger.Logger
+	workers  *workerPool // Decodes, resizes and encodes images
 }
 
 // NewImageStorageService creates a new image storage service
 func NewImageStorageService(cfg *config.StorageConfig) *ImageStorageService {
 	service := &ImageStorageService{
-		config: cfg,
-		logger: logger.GetGlobal(),
+		config:  cfg,
+		logger:  logger.GetGlobal(),
+		workers: newWorkerPool(cfg.ImageWorkers, cfg.ImageQueueSize, cfg.ImageJobTimeout),
 	}
 
 	// Always initialize S3 client
@@ -47,17 +58,43 @@ func NewImageStorageService(cfg *config.StorageConfig) *ImageStorageService {
 	return service
 }
 
-// ProcessAndUploadImage processes and uploads an image directly to S3
+// PendingImage is an original uploaded to S3 whose processed copy is yet to be made
+type PendingImage struct {
+	OriginalPath string // Key of the original, in its original format
+	Path         string // Key the processed image will be stored at
+	URL          string // URL the processed image will be served from
+}
+
+// ProcessAndUploadImage processes and uploads an image directly to S3. The file is never
+// read into memory whole: the original is streamed to S3 and the processed copy decoded
+// from the file again.
 func (s *ImageStorageService) ProcessAndUploadImage(file multipart.File, header *multipart.FileHeader) (string, string, error) {
-	// Validate file
-	if err := s.validateFile(header); err != nil {
-		return "", "", fmt.Errorf("file validation failed: %w", err)
+	pending, err := s.UploadOriginal(file, header)
+	if err != nil {
+		return "", "", err
 	}
 
-	// Read file content
-	fileContent, err := io.ReadAll(file)
+	// Process image (resize and convert to JPG)
+	processedImage, err := s.workers.do(func() ([]byte, error) { return s.processImage(io.NewSectionReader(file, 0, header.Size)) })
 	if err != nil {
-		return "", "", fmt.Errorf("failed to read file: %w", err)
+		return "", "", fmt.Errorf("image processing failed: %w", err)
+	}
+
+	// Upload processed image directly to S3
+	imagePath, imageURL, err := s.uploadToS3(processedImage, pending.Path)
+	if err != nil {
+		return "", "", fmt.Errorf("image upload failed: %w", err)
+	}
+
+	return imagePath, imageURL, nil
+}
+
+// UploadOriginal validates an image and streams it to S3 as uploaded, returning where its
+// processed copy will be stored; ProcessOriginal makes that copy later
+func (s *ImageStorageService) UploadOriginal(file multipart.File, header *multipart.FileHeader) (*PendingImage, error) {
+	// Validate file
+	if err := s.validateFile(header); err != nil {
+		return nil, fmt.Errorf("file validation failed: %w", err)
 	}
 
 	// Generate a stable timestamp-based base name
@@ -70,32 +107,72 @@ func (s *ImageStorageService) ProcessAndUploadImage(file multipart.File, header
 	}
 	originalKey := fmt.Sprintf("post_%d_orig%s", timestamp, originalExt)
 	contentType := contentTypeFromExt(originalExt)
-	if err := s.s3Client.Upload(context.Background(), originalKey, bytes.NewReader(fileContent), contentType); err != nil {
-		return "", "", fmt.Errorf("original image upload failed: %w", err)
-	}
-	// Process image (resize and convert to JPG)
-	processedImage, err := s.processImage(fileContent)
-	if err != nil {
-		return "", "", fmt.Errorf("image processing failed: %w", err)
+	if err := s.s3Client.UploadStream(context.Background(), originalKey, io.NewSectionReader(file, 0, header.Size), contentType); err != nil {
+		return nil, fmt.Errorf("original image upload failed: %w", err)
 	}
 
 	// Generate processed filename (always .jpg)
 	processedKey := fmt.Sprintf("post_%d.jpg", timestamp)
 
-	// Upload processed image directly to S3
-	imagePath, imageURL, err := s.uploadToS3(processedImage, processedKey)
+	return &PendingImage{
+		OriginalPath: originalKey,
+		Path:         processedKey,
+		URL:          s.s3Client.GetURL(processedKey),
+	}, nil
+}
+
+// ProcessOriginal downloads an original stored by UploadOriginal, resizes and converts it
+// on the worker pool, waiting for a free worker as long as ctx allows, and uploads the
+// result to path
+func (s *ImageStorageService) ProcessOriginal(ctx context.Context, originalPath string, path string) error {
+	original, err := s.s3Client.GetObject(ctx, originalPath)
 	if err != nil {
-		return "", "", fmt.Errorf("image upload failed: %w", err)
+		return fmt.Errorf("original image download failed: %w", err)
 	}
+	defer original.Close()
 
-	return imagePath, imageURL, nil
+	processedImage, err := s.workers.wait(ctx, func() ([]byte, error) { return s.processImage(original) })
+	if err != nil {
+		return fmt.Errorf("image processing failed: %w", err)
+	}
+
+	if err := s.s3Client.Upload(ctx, path, bytes.NewReader(processedImage), "image/jpeg"); err != nil {
+		return fmt.Errorf("image upload failed: %w", err)
+	}
+
+	s.logger.Info("Image processed", "original", originalPath, "path", path)
+	return nil
+}
+
+// ProcessAndUploadAvatar crops an avatar to a square, resizes it and uploads it to S3
+func (s *ImageStorageService) ProcessAndUploadAvatar(file multipart.File, header *multipart.FileHeader) (string, string, error) {
+	// Validate file
+	if err := s.validateFile(header); err != nil {
+		return "", "", fmt.Errorf("file validation failed: %w", err)
+	}
+
+	// Process avatar (square crop, resize and convert to JPG)
+	processedImage, err := s.workers.do(func() ([]byte, error) { return s.processAvatar(io.NewSectionReader(file, 0, header.Size)) })
+	if err != nil {
+		return "", "", fmt.Errorf("avatar processing failed: %w", err)
+	}
+
+	avatarKey := fmt.Sprintf("avatar_%d.jpg", time.Now().UnixNano())
+
+	// Upload processed avatar directly to S3
+	avatarPath, avatarURL, err := s.uploadToS3(processedImage, avatarKey)
+	if err != nil {
+		return "", "", fmt.Errorf("avatar upload failed: %w", err)
+	}
+
+	return avatarPath, avatarURL, nil
 }
 
 // validateFile validates the uploaded file
 func (s *ImageStorageService) validateFile(header *multipart.FileHeader) error {
 	// Check file size
 	if header.Size > s.config.MaxSize {
-		return fmt.Errorf("file size exceeds maximum allowed size of %d bytes", s.config.MaxSize)
+		return fmt.Errorf("%w: file size exceeds maximum allowed size of %d bytes", ErrInvalidImage, s.config.MaxSize)
 	}
 
 	// Check file extension
@@ -106,15 +183,15 @@ func (s *ImageStorageService) validateFile(header *multipart.FileHeader) error {
 		}
 	}
 
-	return fmt.Errorf("file extension %s is not allowed. Allowed extensions: %v", ext, s.config.AllowedExts)
+	return fmt.Errorf("%w: file extension %s is not allowed. Allowed extensions: %v", ErrInvalidImage, ext, s.config.AllowedExts)
 }
 
 // processImage processes the image (resize and convert to JPG)
-func (s *ImageStorageService) processImage(imageData []byte) ([]byte, error) {
+func (s *ImageStorageService) processImage(imageData io.Reader) ([]byte, error) {
 	// Decode image
-	img, err := imaging.Decode(bytes.NewReader(imageData))
+	img, err := imaging.Decode(imageData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode image: %w", err)
+		return nil, fmt.Errorf("%w: failed to decode image: %v", ErrInvalidImage, err)
 	}
 
 	// Resize image
@@ -130,6 +207,27 @@ func (s *ImageStorageService) processImage(imageData []byte) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// processAvatar center-crops the image to a square of the configured avatar size and encodes it as JPG
+func (s *ImageStorageService) processAvatar(imageData io.Reader) ([]byte, error) {
+	// Decode image
+	img, err := imaging.Decode(imageData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode image: %v", ErrInvalidImage, err)
+	}
+
+	// Crop to a centered square and resize in one step
+	avatarImg := imaging.Fill(img, s.config.AvatarSize, s.config.AvatarSize, imaging.Center, imaging.Lanczos)
+
+	// Encode as JPEG
+	var buf bytes.Buffer
+	err = imaging.Encode(&buf, avatarImg, imaging.JPEG, imaging.JPEGQuality(s.config.AvatarQuality))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
 // generateFilename generates a unique filename
 func (s *ImageStorageService) generateFilename(originalFilename string) string {
 	ext := filepath.Ext(originalFilename)
@@ -192,6 +290,12 @@ func (s *ImageStorageService) deleteFromS3(imagePath string) error {
 	return nil
 }
 
+// Ping checks the S3 bucket images are stored in can be reached with the configured
+// credentials
+func (s *ImageStorageService) Ping(ctx context.Context) error {
+	return s.s3Client.HeadBucket(ctx)
+}
+
 // GenerateImageURL generates the public URL for an image from S3
 func (s *ImageStorageService) GenerateImageURL(filename string) string {
 	return s.s3Client.GetURL(filename)
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fanzru/social-media-service-go/pkg/apperr"
+)
+
+// Errors of image processing jobs the pool could not run
+var (
+	ErrImageQueueFull = apperr.Unavailable("too many images are being processed, try again later")
+	ErrImageTimeout   = apperr.Unavailable("image processing timed out, try again later")
+)
+
+// imageResult is the outcome of an image processing job
+type imageResult struct {
+	data []byte
+	err  error
+}
+
+// imageJob is an image processing job waiting for a worker
+type imageJob struct {
+	ctx     context.Context // Done when the submitter stopped waiting
+	process func() ([]byte, error)
+	result  chan imageResult // Buffered, so a worker never blocks on a submitter that left
+}
+
+// workerPool runs image processing jobs on a fixed number of workers, so bursts of uploads
+// queue up instead of decoding every image at once
+type workerPool struct {
+	jobs    chan imageJob
+	timeout time.Duration
+}
+
+// newWorkerPool starts workers processing jobs from a queue of queueSize; each submitter
+// waits at most timeout, queueing included, for its job
+func newWorkerPool(workers int, queueSize int, timeout time.Duration) *workerPool {
+	if workers <= 0 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	p := &workerPool{
+		jobs:    make(chan imageJob, queueSize),
+		timeout: timeout,
+	}
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// work runs queued jobs, skipping those whose submitter has stopped waiting
+func (p *workerPool) work() {
+	for job := range p.jobs {
+		if job.ctx.Err() != nil {
+			continue
+		}
+		data, err := job.process()
+		job.result <- imageResult{data: data, err: err}
+	}
+}
+
+// do runs process on a worker and returns its result. It returns ErrImageQueueFull right
+// away when the queue is full, and ErrImageTimeout when the job does not finish in time; a
+// job still queued then is skipped, one already running finishes and is discarded.
+func (p *workerPool) do(process func() ([]byte, error)) ([]byte, error) {
+	ctx := context.Background()
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	job := imageJob{ctx: ctx, process: process, result: make(chan imageResult, 1)}
+	select {
+	case p.jobs <- job:
+	default:
+		return nil, ErrImageQueueFull
+	}
+
+	data, err := p.await(job)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, ErrImageTimeout
+	}
+	return data, err
+}
+
+// wait runs process on a worker like do, but for background jobs: it waits for room in
+// the queue instead of failing, and is bounded by ctx only
+func (p *workerPool) wait(ctx context.Context, process func() ([]byte, error)) ([]byte, error) {
+	job := imageJob{ctx: ctx, process: process, result: make(chan imageResult, 1)}
+	select {
+	case p.jobs <- job:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	return p.await(job)
+}
+
+// await waits for the result of a queued job until its context is done
+func (p *workerPool) await(job imageJob) ([]byte, error) {
+	select {
+	case r := <-job.result:
+		return r.data, r.err
+	case <-job.ctx.Done():
+		return nil, job.ctx.Err()
+	}
+}
@@ -0,0 +1,26 @@
+#!/bin/bash
+
+# Generate the Go client of each API into client/<name>. Unused schemas are kept so the
+# client has typed models (Post, Comment, ...) to decode the data of responses into.
+set -e
+
+for file in ./api/http/*.yaml; do
+    f=$(basename $file .yaml)
+    echo "Generating client for $f..."
+
+    mkdir -p ./client/$f
+
+    config=$(mktemp)
+    cat > "$config" <<CONFIG
+package: $f
+generate:
+  models: true
+  client: true
+output: ./client/$f/client.gen.go
+output-options:
+  skip-prune: true
+CONFIG
+
+    oapi-codegen -config "$config" api/http/$f.yaml
+    rm -f "$config"
+done
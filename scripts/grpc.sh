@@ -0,0 +1,17 @@
+#!/bin/bash
+
+# Generate gRPC code for each service definition
+for file in ./api/grpc/*.proto; do
+    f=$(basename $file .proto)
+    echo "Generating gRPC code for $f..."
+
+    # Create directory if it doesn't exist
+    mkdir -p ./internal/app/$f/port/gengrpc
+
+    # Generate messages and the service stubs
+    protoc -I api/grpc \
+        --go_out=./internal/app/$f/port/gengrpc --go_opt=paths=source_relative \
+        --go-grpc_out=./internal/app/$f/port/gengrpc --go-grpc_opt=paths=source_relative \
+        $f.proto
+
+done